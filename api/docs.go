@@ -19,6 +19,157 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/audit_events/": {
+            "get": {
+                "description": "List the requests that created, updated, deleted, uploaded or canceled content of the organization, successful or not, newest first. Events record the body of the request, not the state of the objects before and after it. Events are kept for a year by default.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit_events"
+                ],
+                "summary": "List Audit Events",
+                "operationId": "listAuditEvents",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Starting point for retrieving a subset of results. Determines how many items to skip from the beginning of the result set. Default value:` + "`" + `0` + "`" + `.",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to include in response. Use it to control the number of items, particularly when dealing with large datasets. Default value: ` + "`" + `100` + "`" + `.",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort the response data based on specific parameters. Sort criteria can include ` + "`" + `created_at` + "`" + `, ` + "`" + `username` + "`" + `, and ` + "`" + `status_code` + "`" + `.",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "List the events about the repository, snapshot, template, task or other object with this UUID.",
+                        "name": "object_uuid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "List the events of this user.",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "List the events at or after this time, in RFC 3339 format, such as ` + "`" + `2024-01-02T15:04:05Z` + "`" + `.",
+                        "name": "start_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "List the events before this time, in RFC 3339 format.",
+                        "name": "end_time",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.AuditEventCollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/audit_events/export/": {
+            "get": {
+                "description": "Export the audit events matching the filters as CSV, newest first. At most 10000 events are exported, narrow the time range to export older events.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "audit_events"
+                ],
+                "summary": "Export Audit Events",
+                "operationId": "exportAuditEvents",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export the events about the repository, snapshot, template, task or other object with this UUID.",
+                        "name": "object_uuid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Export the events of this user.",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Export the events at or after this time, in RFC 3339 format, such as ` + "`" + `2024-01-02T15:04:05Z` + "`" + `.",
+                        "name": "start_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Export the events before this time, in RFC 3339 format.",
+                        "name": "end_time",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/coverage_reports/": {
             "post": {
                 "description": "Upload a manifest file and start coverage analysis.",
@@ -112,9 +263,120 @@ const docTemplate = `{
                 }
             }
         },
+        "/coverage_reports/{uuid}/compare/{other_uuid}": {
+            "get": {
+                "description": "Return the changes from a completed coverage report to another one, such as the report of the manifest uploaded in the next sprint: packages newly covered or uncovered, version changes, and the change of the counts of each ecosystem.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "coverage_reports"
+                ],
+                "summary": "Compare coverage reports",
+                "operationId": "compareCoverageReports",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Coverage report UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of the coverage report to compare to",
+                        "name": "other_uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.CoverageReportComparisonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/coverage_reports/{uuid}/export": {
+            "get": {
+                "description": "Download a completed coverage report with all of its packages. The csv format lists one package per row, with its match status, advisories and the catalog timestamp. The json format also contains the report and its ecosystem summaries. The cyclonedx format is a CycloneDX 1.5 BOM whose components are annotated with their coverage status, listing the advisories affecting them as vulnerabilities (VEX).",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.cyclonedx+json"
+                ],
+                "tags": [
+                    "coverage_reports"
+                ],
+                "summary": "Export coverage report",
+                "operationId": "exportCoverageReport",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Coverage report UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format of the export: csv, json or cyclonedx. Default: json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.CoverageReportExportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/coverage_reports/{uuid}/packages": {
             "get": {
-                "description": "Return paginated packages for a completed coverage report.",
+                "description": "Return paginated packages for a completed coverage report. Advisories are matched from their fixed versions only: a covered version is considered affected when an advisory is fixed in a later version and no fix exists at or below it on its own minor line. Advisories do not record the version introducing the vulnerability, so versions released before it may be reported as affected.",
                 "produces": [
                     "application/json"
                 ],
@@ -149,6 +411,12 @@ const docTemplate = `{
                         "name": "covered",
                         "in": "query"
                     },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by known advisories affecting the package version (true = covered and vulnerable, false = not vulnerable). Approximated from the fixed versions of the advisories.",
+                        "name": "vulnerable",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "Starting point for pagination. Default: 0",
@@ -190,9 +458,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/environments/names": {
+        "/coverage_reports/{uuid}/refresh/": {
             "post": {
-                "description": "This enables users to search for environments in a given list of repositories.",
+                "description": "Match the packages of a completed coverage report against the current catalog, without uploading the manifest again. The result is a new pending coverage report linked to the refreshed one, completed by the task of its analysis_task_uuid. Set scheduled_refresh to re-evaluate the new report periodically and be notified when its coverage improves.",
                 "consumes": [
                     "application/json"
                 ],
@@ -200,29 +468,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "environments"
+                    "coverage_reports"
                 ],
-                "summary": "Search environments",
-                "operationId": "searchEnvironments",
+                "summary": "Refresh coverage report",
+                "operationId": "refreshCoverageReport",
                 "parameters": [
                     {
-                        "description": "request body",
+                        "type": "string",
+                        "description": "Coverage report UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Refresh options",
                         "name": "body",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.ContentUnitSearchRequest"
+                            "$ref": "#/definitions/api.CoverageReportRefreshRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/api.SearchEnvironmentResponse"
-                            }
+                            "$ref": "#/definitions/api.CoverageReportResponse"
                         }
                     },
                     "400": {
@@ -231,24 +502,80 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/errors.ErrorResponse"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
-                    "415": {
-                        "description": "Unsupported Media Type",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/errors.ErrorResponse"
                         }
-                    },
+                    }
+                }
+            }
+        },
+        "/environments/names": {
+            "post": {
+                "description": "This enables users to search for environments in a given list of repositories.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "environments"
+                ],
+                "summary": "Search environments",
+                "operationId": "searchEnvironments",
+                "parameters": [
+                    {
+                        "description": "request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.ContentUnitSearchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.SearchEnvironmentResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -604,6 +931,62 @@ const docTemplate = `{
                 }
             }
         },
+        "/permissions/": {
+            "get": {
+                "description": "Get the permissions of the user for each resource and verb of the service, as checked by RBAC, Kessel or the local policy file. Permissions on single repositories and templates can be requested as well, they include the permissions granted on the whole organization.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "permissions"
+                ],
+                "summary": "List the permissions of the user",
+                "operationId": "listPermissions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "A comma separated list of repository UUIDs to check permissions on.",
+                        "name": "repository_uuids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "A comma separated list of template UUIDs to check permissions on.",
+                        "name": "template_uuids",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.PermissionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/popular_repositories/": {
             "get": {
                 "description": "This operation enables retrieving a paginated list of repository suggestions that are commonly used.",
@@ -940,6 +1323,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1017,6 +1406,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1230,6 +1625,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1828,6 +2229,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.AddUploadsRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key identifying the request. Retrying a request with the same key returns the task it already enqueued.",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -2024,6 +2431,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.RepositoryIntrospectRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key identifying the request. Retrying a request with the same key returns the task it already enqueued.",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -2763,6 +3176,12 @@ const docTemplate = `{
                         "name": "uuid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key identifying the request. Retrying a request with the same key returns the task it already enqueued.",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -3358,11 +3777,697 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/snapshots/package_groups/names": {
+            }
+        },
+        "/snapshots/package_groups/names": {
+            "post": {
+                "description": "This enables users to search for package groups in a given list of snapshots.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packagegroups"
+                ],
+                "summary": "Search package groups within snapshots",
+                "operationId": "searchSnapshotPackageGroups",
+                "parameters": [
+                    {
+                        "description": "request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.SnapshotSearchRpmRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.SearchPackageGroupResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/rpms/names": {
+            "post": {
+                "description": "This enables users to search for RPMs (Red Hat Package Manager) in a given list of snapshots.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rpms"
+                ],
+                "summary": "Search RPMs within snapshots",
+                "operationId": "searchSnapshotRpms",
+                "parameters": [
+                    {
+                        "description": "request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.SnapshotSearchRpmRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.SearchRpmResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/{snapshot_uuid}/config.repo": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "repositories"
+                ],
+                "summary": "Get configuration file of a repository",
+                "operationId": "getRepoConfigurationFile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identifier of the snapshot",
+                        "name": "snapshot_uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/{uuid}/errata": {
+            "get": {
+                "description": "List errata in a repository snapshot.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rpms"
+                ],
+                "summary": "List Snapshot Errata",
+                "operationId": "listSnapshotErrata",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Snapshot ID.",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to include in response. Use it to control the number of items, particularly when dealing with large datasets. Default value: ` + "`" + `100` + "`" + `.",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Starting point for retrieving a subset of results. Determines how many items to skip from the beginning of the result set. Default value:` + "`" + `0` + "`" + `.",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Term to filter and retrieve items that match the specified search criteria. Search term can include name.",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "A comma separated list of types to control api response. Type can include ` + "`" + `security` + "`" + `, ` + "`" + `enhancement` + "`" + `, ` + "`" + `bugfix` + "`" + `, and ` + "`" + `other` + "`" + `.",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "A comma separated list of severities to control api response. Severity can include ` + "`" + `Important` + "`" + `, ` + "`" + `Critical` + "`" + `, ` + "`" + `Moderate` + "`" + `, ` + "`" + `Low` + "`" + `, and ` + "`" + `Unknown` + "`" + `.",
+                        "name": "severity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort the response based on specific parameters. Sort criteria can include ` + "`" + `issued_date` + "`" + `, ` + "`" + `updated_date` + "`" + `, ` + "`" + `type` + "`" + `, and ` + "`" + `severity` + "`" + `.",
+                        "name": "sort_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SnapshotErrataCollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots/{uuid}/rpms": {
+            "get": {
+                "description": "List RPMs in a repository snapshot.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rpms"
+                ],
+                "summary": "List Snapshot RPMs",
+                "operationId": "listSnapshotRpms",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Snapshot ID.",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to include in response. Use it to control the number of items, particularly when dealing with large datasets. Default value: ` + "`" + `100` + "`" + `.",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Starting point for retrieving a subset of results. Determines how many items to skip from the beginning of the result set. Default value:` + "`" + `0` + "`" + `.",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Term to filter and retrieve items that match the specified search criteria. Search term can include name.",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SnapshotRpmCollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/": {
+            "get": {
+                "description": "Get the list of tasks.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "List Tasks",
+                "operationId": "listTasks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Starting point for retrieving a subset of results. Determines how many items to skip from the beginning of the result set. Default value:` + "`" + `0` + "`" + `.",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to include in response. Use it to control the number of items, particularly when dealing with large datasets. Default value: ` + "`" + `100` + "`" + `.",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "A comma separated list of statuses to control response. Statuses can include ` + "`" + `running` + "`" + `, ` + "`" + `completed` + "`" + `, ` + "`" + `failed` + "`" + `.",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter results based on a specific task types. Helps to narrow down the results to a specific type. Task types can be ` + "`" + `snapshot` + "`" + ` or ` + "`" + `introspect` + "`" + `. ",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "A unique identifier of a repository to filter the results.",
+                        "name": "repository_uuid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "A unique identifier of a template to filter the results.",
+                        "name": "template_uuid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "A flag to exclude tasks for the red hat org from the query.",
+                        "name": "exclude_red_hat_org",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TaskInfoCollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{uuid}": {
+            "get": {
+                "description": "Get information about a specific task.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Get Task",
+                "operationId": "getTask",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID.",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TaskInfoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{uuid}/graph": {
+            "get": {
+                "description": "Get a task along with the tasks it is waiting on (upstream) and the tasks waiting on it (downstream). Use it to find which task a pending task is blocked by.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Get Task Graph",
+                "operationId": "getTaskGraph",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID.",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TaskGraphResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/": {
+            "get": {
+                "description": "This operation enables users to retrieve a list of templates.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "List Templates",
+                "operationId": "listTemplates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Term to filter and retrieve items that match the specified search criteria. Search term can include name.",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Starting point for retrieving a subset of results. Determines how many items to skip from the beginning of the result set. Default value:` + "`" + `0` + "`" + `.",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to include in response. Use it to control the number of items, particularly when dealing with large datasets. Default value: ` + "`" + `100` + "`" + `.",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter templates by version. Supports comma-separated lists (e.g. '8,9').",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter templates by architecture.",
+                        "name": "arch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter templates by name.",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter templates by associated repositories using a comma separated list of repository UUIDs",
+                        "name": "repository_uuids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter templates by associated snapshots using a comma separated list of snapshot UUIDs",
+                        "name": "snapshot_uuids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter templates by extended release type. Valid values: eus, e4s, eeus. Supports comma-separated lists (e.g., 'eus,e4s'). Use 'none' to filter templates without extended release.",
+                        "name": "extended_release",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter templates by extended release version (e.g., 9.4). Supports comma-separated lists (e.g., '9.4,9.6'). Use 'none' to filter templates without extended release versions.",
+                        "name": "extended_release_version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "When true, major versions in the version filter only match templates without an extended release version. Specific minor versions in the version filter are still matched.",
+                        "name": "restrict_to_major",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort the response data based on specific parameters. Sort criteria can include ` + "`" + `name` + "`" + `, ` + "`" + `arch` + "`" + `, and ` + "`" + `version` + "`" + `.",
+                        "name": "sort_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TemplateCollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "This enables users to search for package groups in a given list of snapshots.",
+                "description": "This operation enables creating templates based on user preferences.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3370,10 +4475,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "packagegroups"
+                    "templates"
                 ],
-                "summary": "Search package groups within snapshots",
-                "operationId": "searchSnapshotPackageGroups",
+                "summary": "Create Template",
+                "operationId": "createTemplate",
                 "parameters": [
                     {
                         "description": "request body",
@@ -3381,17 +4486,20 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.SnapshotSearchRpmRequest"
+                            "$ref": "#/definitions/api.TemplateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/api.SearchPackageGroupResponse"
+                            "$ref": "#/definitions/api.TemplateResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "resource URL"
                             }
                         }
                     },
@@ -3407,6 +4515,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -3428,39 +4542,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/snapshots/rpms/names": {
-            "post": {
-                "description": "This enables users to search for RPMs (Red Hat Package Manager) in a given list of snapshots.",
+        "/templates/{template_uuid}/config.repo": {
+            "get": {
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "rpms"
+                    "templates"
                 ],
-                "summary": "Search RPMs within snapshots",
-                "operationId": "searchSnapshotRpms",
+                "summary": "Get configuration file for all repositories in a template",
+                "operationId": "getTemplateRepoConfigurationFile",
                 "parameters": [
                     {
-                        "description": "request body",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.SnapshotSearchRpmRequest"
-                        }
+                        "type": "string",
+                        "description": "Identifier of the template",
+                        "name": "template_uuid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/api.SearchRpmResponse"
-                            }
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -3481,12 +4589,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
-                    "415": {
-                        "description": "Unsupported Media Type",
-                        "schema": {
-                            "$ref": "#/definitions/errors.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3496,24 +4598,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/snapshots/{snapshot_uuid}/config.repo": {
+        "/templates/{uuid}": {
             "get": {
+                "description": "Get template information.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "repositories"
+                    "templates"
                 ],
-                "summary": "Get configuration file of a repository",
-                "operationId": "getRepoConfigurationFile",
+                "summary": "Get Template",
+                "operationId": "getTemplate",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Identifier of the snapshot",
-                        "name": "snapshot_uuid",
+                        "description": "Template ID.",
+                        "name": "uuid",
                         "in": "path",
                         "required": true
                     }
@@ -3522,7 +4625,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.TemplateResponse"
                         }
                     },
                     "400": {
@@ -3550,11 +4653,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/snapshots/{uuid}/errata": {
-            "get": {
-                "description": "List errata in a repository snapshot.",
+            },
+            "put": {
+                "description": "This operation enables updating all attributes of a template",
                 "consumes": [
                     "application/json"
                 ],
@@ -3562,60 +4663,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "rpms"
+                    "templates"
                 ],
-                "summary": "List Snapshot Errata",
-                "operationId": "listSnapshotErrata",
+                "summary": "Fully update all attributes of a Template",
+                "operationId": "fullUpdateTemplate",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Snapshot ID.",
+                        "description": "Template ID.",
                         "name": "uuid",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Number of items to include in response. Use it to control the number of items, particularly when dealing with large datasets. Default value: ` + "`" + `100` + "`" + `.",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Starting point for retrieving a subset of results. Determines how many items to skip from the beginning of the result set. Default value:` + "`" + `0` + "`" + `.",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Term to filter and retrieve items that match the specified search criteria. Search term can include name.",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "A comma separated list of types to control api response. Type can include ` + "`" + `security` + "`" + `, ` + "`" + `enhancement` + "`" + `, ` + "`" + `bugfix` + "`" + `, and ` + "`" + `other` + "`" + `.",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "A comma separated list of severities to control api response. Severity can include ` + "`" + `Important` + "`" + `, ` + "`" + `Critical` + "`" + `, ` + "`" + `Moderate` + "`" + `, ` + "`" + `Low` + "`" + `, and ` + "`" + `Unknown` + "`" + `.",
-                        "name": "severity",
-                        "in": "query"
+                        "description": "request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.TemplateUpdateRequest"
+                        }
                     },
                     {
                         "type": "string",
-                        "description": "Sort the response based on specific parameters. Sort criteria can include ` + "`" + `issued_date` + "`" + `, ` + "`" + `updated_date` + "`" + `, ` + "`" + `type` + "`" + `, and ` + "`" + `severity` + "`" + `.",
-                        "name": "sort_by",
-                        "in": "query"
+                        "description": "Key identifying the request. Retrying a request with the same key does not enqueue another template content update.",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/api.SnapshotErrataCollectionResponse"
+                            "$ref": "#/definitions/api.TemplateResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "resource URL"
+                            }
                         }
                     },
                     "400": {
@@ -3636,6 +4722,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3643,55 +4735,26 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/snapshots/{uuid}/rpms": {
-            "get": {
-                "description": "List RPMs in a repository snapshot.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "This enables deleting a specific template.",
                 "tags": [
-                    "rpms"
+                    "templates"
                 ],
-                "summary": "List Snapshot RPMs",
-                "operationId": "listSnapshotRpms",
+                "summary": "Delete a template",
+                "operationId": "deleteTemplate",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Snapshot ID.",
+                        "description": "Template ID.",
                         "name": "uuid",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of items to include in response. Use it to control the number of items, particularly when dealing with large datasets. Default value: ` + "`" + `100` + "`" + `.",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Starting point for retrieving a subset of results. Determines how many items to skip from the beginning of the result set. Default value:` + "`" + `0` + "`" + `.",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Term to filter and retrieve items that match the specified search criteria. Search term can include name.",
-                        "name": "search",
-                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/api.SnapshotRpmCollectionResponse"
-                        }
+                    "204": {
+                        "description": "Template was successfully deleted"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -3718,11 +4781,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/tasks/": {
-            "get": {
-                "description": "Get the list of tasks.",
+            },
+            "patch": {
+                "description": "This operation enables updating some subset of attributes of a template",
                 "consumes": [
                     "application/json"
                 ],
@@ -3730,59 +4791,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "templates"
                 ],
-                "summary": "List Tasks",
-                "operationId": "listTasks",
+                "summary": "Update some attributes of a Template",
+                "operationId": "partialUpdateTemplate",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Starting point for retrieving a subset of results. Determines how many items to skip from the beginning of the result set. Default value:` + "`" + `0` + "`" + `.",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of items to include in response. Use it to control the number of items, particularly when dealing with large datasets. Default value: ` + "`" + `100` + "`" + `.",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "A comma separated list of statuses to control response. Statuses can include ` + "`" + `running` + "`" + `, ` + "`" + `completed` + "`" + `, ` + "`" + `failed` + "`" + `.",
-                        "name": "status",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Filter results based on a specific task types. Helps to narrow down the results to a specific type. Task types can be ` + "`" + `snapshot` + "`" + ` or ` + "`" + `introspect` + "`" + `. ",
-                        "name": "type",
-                        "in": "query"
+                        "description": "Template ID.",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "A unique identifier of a repository to filter the results.",
-                        "name": "repository_uuid",
-                        "in": "query"
+                        "description": "request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.TemplateUpdateRequest"
+                        }
                     },
                     {
                         "type": "string",
-                        "description": "A unique identifier of a template to filter the results.",
-                        "name": "template_uuid",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "A flag to exclude tasks for the red hat org from the query.",
-                        "name": "exclude_red_hat_org",
-                        "in": "query"
+                        "description": "Key identifying the request. Retrying a request with the same key does not enqueue another template content update.",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/api.TaskInfoCollectionResponse"
+                            "$ref": "#/definitions/api.TemplateResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "resource URL"
+                            }
                         }
                     },
                     "400": {
@@ -3803,6 +4850,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3812,24 +4865,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{uuid}": {
+        "/templates/{uuid}/advisories/ids": {
             "get": {
-                "description": "Get information about a specific task.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "This operation enables users to retrieve a template's advisory IDs.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "templates"
                 ],
-                "summary": "Get Task",
-                "operationId": "getTask",
+                "summary": "Fetch template Advisory IDs",
+                "operationId": "fetchTemplateAdvisoryIDs",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Task ID.",
+                        "description": "Template ID",
                         "name": "uuid",
                         "in": "path",
                         "required": true
@@ -3839,7 +4889,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TaskInfoResponse"
+                            "$ref": "#/definitions/api.TemplateAdvisoryIDsResponse"
                         }
                     },
                     "400": {
@@ -3869,9 +4919,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/templates/": {
+        "/templates/{uuid}/errata": {
             "get": {
-                "description": "This operation enables users to retrieve a list of templates.",
+                "description": "List errata in a content template.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3881,20 +4931,15 @@ const docTemplate = `{
                 "tags": [
                     "templates"
                 ],
-                "summary": "List Templates",
-                "operationId": "listTemplates",
+                "summary": "List Template Errata",
+                "operationId": "listTemplateErrata",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Term to filter and retrieve items that match the specified search criteria. Search term can include name.",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Starting point for retrieving a subset of results. Determines how many items to skip from the beginning of the result set. Default value:` + "`" + `0` + "`" + `.",
-                        "name": "offset",
-                        "in": "query"
+                        "description": "Template ID.",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
@@ -3903,56 +4948,32 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Filter templates by version. Supports comma-separated lists (e.g. '8,9').",
-                        "name": "version",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter templates by architecture.",
-                        "name": "arch",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter templates by name.",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter templates by associated repositories using a comma separated list of repository UUIDs",
-                        "name": "repository_uuids",
+                        "type": "integer",
+                        "description": "Starting point for retrieving a subset of results. Determines how many items to skip from the beginning of the result set. Default value:` + "`" + `0` + "`" + `.",
+                        "name": "offset",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter templates by associated snapshots using a comma separated list of snapshot UUIDs",
-                        "name": "snapshot_uuids",
+                        "description": "Term to filter and retrieve items that match the specified search criteria. Search term can include name.",
+                        "name": "search",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter templates by extended release type. Valid values: eus, e4s, eeus. Supports comma-separated lists (e.g., 'eus,e4s'). Use 'none' to filter templates without extended release.",
-                        "name": "extended_release",
+                        "description": "A comma separated list of types to control api response. Type can include ` + "`" + `security` + "`" + `, ` + "`" + `enhancement` + "`" + `, ` + "`" + `bugfix` + "`" + `, and ` + "`" + `other` + "`" + `.",
+                        "name": "type",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter templates by extended release version (e.g., 9.4). Supports comma-separated lists (e.g., '9.4,9.6'). Use 'none' to filter templates without extended release versions.",
-                        "name": "extended_release_version",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "When true, major versions in the version filter only match templates without an extended release version. Specific minor versions in the version filter are still matched.",
-                        "name": "restrict_to_major",
+                        "description": "A comma separated list of severities to control api response. Severity can include ` + "`" + `Important` + "`" + `, ` + "`" + `Critical` + "`" + `, ` + "`" + `Moderate` + "`" + `, ` + "`" + `Low` + "`" + `, and ` + "`" + `Unknown` + "`" + `.",
+                        "name": "severity",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Sort the response data based on specific parameters. Sort criteria can include ` + "`" + `name` + "`" + `, ` + "`" + `arch` + "`" + `, and ` + "`" + `version` + "`" + `.",
+                        "description": "Sort the response based on specific parameters. Sort criteria can include ` + "`" + `issued_date` + "`" + `, ` + "`" + `updated_date` + "`" + `, ` + "`" + `type` + "`" + `, and ` + "`" + `severity` + "`" + `.",
                         "name": "sort_by",
                         "in": "query"
                     }
@@ -3961,7 +4982,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TemplateCollectionResponse"
+                            "$ref": "#/definitions/api.SnapshotErrataCollectionResponse"
                         }
                     },
                     "400": {
@@ -3989,9 +5010,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "This operation enables creating templates based on user preferences.",
+            }
+        },
+        "/templates/{uuid}/rpms": {
+            "get": {
+                "description": "List RPMs in a content template.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3999,32 +5022,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "templates"
+                    "rpms"
                 ],
-                "summary": "Create Template",
-                "operationId": "createTemplate",
+                "summary": "List Template RPMs",
+                "operationId": "listTemplateRpms",
                 "parameters": [
                     {
-                        "description": "request body",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.TemplateRequest"
-                        }
+                        "type": "string",
+                        "description": "Template ID.",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to include in response. Use it to control the number of items, particularly when dealing with large datasets. Default value: ` + "`" + `100` + "`" + `.",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Starting point for retrieving a subset of results. Determines how many items to skip from the beginning of the result set. Default value:` + "`" + `0` + "`" + `.",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Term to filter and retrieve items that match the specified search criteria. Search term can include name.",
+                        "name": "search",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TemplateResponse"
-                        },
-                        "headers": {
-                            "Location": {
-                                "type": "string",
-                                "description": "resource URL"
-                            }
+                            "$ref": "#/definitions/api.SnapshotRpmCollectionResponse"
                         }
                     },
                     "400": {
@@ -4045,12 +5078,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
-                    "415": {
-                        "description": "Unsupported Media Type",
-                        "schema": {
-                            "$ref": "#/definitions/errors.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4060,33 +5087,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/templates/{template_uuid}/config.repo": {
+        "/templates/{uuid}/snapshots/": {
             "get": {
+                "description": "List snapshots for a template.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "templates"
+                    "snapshots"
                 ],
-                "summary": "Get configuration file for all repositories in a template",
-                "operationId": "getTemplateRepoConfigurationFile",
+                "summary": "List snapshots for a template",
+                "operationId": "listSnapshotsForTemplate",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Identifier of the template",
-                        "name": "template_uuid",
+                        "description": "Template ID.",
+                        "name": "uuid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search through snapshots by repository name.",
+                        "name": "repository_search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort the response data based on specific snapshot parameters. Sort criteria can include ` + "`" + `repository_name` + "`" + ` or ` + "`" + `created_at` + "`" + `.",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Starting point for retrieving a subset of results. Determines how many items to skip from the beginning of the result set. Default value:` + "`" + `0` + "`" + `.",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to include in response. Use it to control the number of items, particularly when dealing with large datasets. Default value: ` + "`" + `100` + "`" + `.",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.SnapshotCollectionResponse"
                         }
                     },
                     "400": {
@@ -4116,9 +5168,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/templates/{uuid}": {
+        "/user_preferences/": {
             "get": {
-                "description": "Get template information.",
+                "description": "List preference labels and values for the authenticated user.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4126,40 +5178,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "templates"
-                ],
-                "summary": "Get Template",
-                "operationId": "getTemplate",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Template ID.",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
-                    }
+                    "user_preferences"
                 ],
+                "summary": "List user preferences",
+                "operationId": "listUserPreferences",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TemplateResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/errors.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/errors.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.UserPreferenceResponse"
+                            }
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/errors.ErrorResponse"
                         }
@@ -4171,9 +5205,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/user_preferences/{label}": {
             "put": {
-                "description": "This operation enables updating all attributes of a template",
+                "description": "Create or update a user preference for the given label. The request body is a JSON string value (for example ` + "`" + `\"true\"` + "`" + `).",
                 "consumes": [
                     "application/json"
                 ],
@@ -4181,39 +5217,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "templates"
+                    "user_preferences"
                 ],
-                "summary": "Fully update all attributes of a Template",
-                "operationId": "fullUpdateTemplate",
+                "summary": "Set a user preference",
+                "operationId": "setUserPreference",
                 "parameters": [
                     {
+                        "enum": [
+                            "lightwell-notification-enabled",
+                            "lightwell-notification-minimum"
+                        ],
                         "type": "string",
-                        "description": "Template ID.",
-                        "name": "uuid",
+                        "description": "Preference label",
+                        "name": "label",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "request body",
+                        "description": "Preference value as a JSON string",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.TemplateUpdateRequest"
+                            "type": "string"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TemplateResponse"
-                        },
-                        "headers": {
-                            "Location": {
-                                "type": "string",
-                                "description": "resource URL"
-                            }
+                            "$ref": "#/definitions/api.UserPreferenceResponse"
                         }
                     },
                     "400": {
@@ -4222,24 +5256,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/errors.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/errors.ErrorResponse"
-                        }
-                    },
-                    "415": {
-                        "description": "Unsupported Media Type",
-                        "schema": {
-                            "$ref": "#/definitions/errors.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4247,26 +5263,48 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "This enables deleting a specific template.",
+            }
+        },
+        "/webhooks/": {
+            "get": {
+                "description": "List the webhooks of the organization.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "templates"
+                    "webhooks"
                 ],
-                "summary": "Delete a template",
-                "operationId": "deleteTemplate",
+                "summary": "List Webhooks",
+                "operationId": "listWebhooks",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Starting point for retrieving a subset of results. Determines how many items to skip from the beginning of the result set. Default value:` + "`" + `0` + "`" + `.",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to include in response. Use it to control the number of items, particularly when dealing with large datasets. Default value: ` + "`" + `100` + "`" + `.",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Template ID.",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
+                        "description": "Sort the response data based on specific parameters. Sort criteria can include ` + "`" + `name` + "`" + `, ` + "`" + `url` + "`" + `, and ` + "`" + `created_at` + "`" + `.",
+                        "name": "sort_by",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Template was successfully deleted"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.WebhookCollectionResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -4280,12 +5318,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/errors.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4294,8 +5326,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
-                "description": "This operation enables updating some subset of attributes of a template",
+            "post": {
+                "description": "Register an endpoint that receives the subscribed repository and template events. Each event is posted as JSON, signed with the secret using HMAC-SHA256.\nThe ` + "`" + `X-Content-Sources-Signature` + "`" + ` header holds ` + "`" + `sha256=` + "`" + ` followed by the hex encoded HMAC of the ` + "`" + `X-Content-Sources-Timestamp` + "`" + ` header, a period and the body. Failed deliveries are retried with an exponential backoff.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4303,25 +5335,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "templates"
+                    "webhooks"
                 ],
-                "summary": "Update some attributes of a Template",
-                "operationId": "partialUpdateTemplate",
+                "summary": "Create Webhook",
+                "operationId": "createWebhook",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Template ID.",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "description": "request body",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.TemplateUpdateRequest"
+                            "$ref": "#/definitions/api.WebhookRequest"
                         }
                     }
                 ],
@@ -4329,13 +5354,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/api.TemplateResponse"
-                        },
-                        "headers": {
-                            "Location": {
-                                "type": "string",
-                                "description": "resource URL"
-                            }
+                            "$ref": "#/definitions/api.WebhookResponse"
                         }
                     },
                     "400": {
@@ -4350,12 +5369,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/errors.ErrorResponse"
-                        }
-                    },
                     "415": {
                         "description": "Unsupported Media Type",
                         "schema": {
@@ -4371,21 +5384,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/templates/{uuid}/advisories/ids": {
+        "/webhooks/{uuid}": {
             "get": {
-                "description": "This operation enables users to retrieve a template's advisory IDs.",
+                "description": "Get a webhook. Its secret is never returned.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "templates"
+                    "webhooks"
                 ],
-                "summary": "Fetch template Advisory IDs",
-                "operationId": "fetchTemplateAdvisoryIDs",
+                "summary": "Get Webhook",
+                "operationId": "getWebhook",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Template ID",
+                        "description": "Webhook ID.",
                         "name": "uuid",
                         "in": "path",
                         "required": true
@@ -4395,7 +5411,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TemplateAdvisoryIDsResponse"
+                            "$ref": "#/definitions/api.WebhookResponse"
                         }
                     },
                     "400": {
@@ -4423,73 +5439,26 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/templates/{uuid}/errata": {
-            "get": {
-                "description": "List errata in a content template.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Delete a webhook along with its delivery log.",
                 "tags": [
-                    "templates"
+                    "webhooks"
                 ],
-                "summary": "List Template Errata",
-                "operationId": "listTemplateErrata",
+                "summary": "Delete Webhook",
+                "operationId": "deleteWebhook",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Template ID.",
+                        "description": "Webhook ID.",
                         "name": "uuid",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of items to include in response. Use it to control the number of items, particularly when dealing with large datasets. Default value: ` + "`" + `100` + "`" + `.",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Starting point for retrieving a subset of results. Determines how many items to skip from the beginning of the result set. Default value:` + "`" + `0` + "`" + `.",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Term to filter and retrieve items that match the specified search criteria. Search term can include name.",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "A comma separated list of types to control api response. Type can include ` + "`" + `security` + "`" + `, ` + "`" + `enhancement` + "`" + `, ` + "`" + `bugfix` + "`" + `, and ` + "`" + `other` + "`" + `.",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "A comma separated list of severities to control api response. Severity can include ` + "`" + `Important` + "`" + `, ` + "`" + `Critical` + "`" + `, ` + "`" + `Moderate` + "`" + `, ` + "`" + `Low` + "`" + `, and ` + "`" + `Unknown` + "`" + `.",
-                        "name": "severity",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort the response based on specific parameters. Sort criteria can include ` + "`" + `issued_date` + "`" + `, ` + "`" + `updated_date` + "`" + `, ` + "`" + `type` + "`" + `, and ` + "`" + `severity` + "`" + `.",
-                        "name": "sort_by",
-                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/api.SnapshotErrataCollectionResponse"
-                        }
+                    "204": {
+                        "description": "Webhook was successfully deleted"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -4516,11 +5485,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/templates/{uuid}/rpms": {
-            "get": {
-                "description": "List RPMs in a content template.",
+            },
+            "patch": {
+                "description": "Update some attributes of a webhook.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4528,42 +5495,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "rpms"
+                    "webhooks"
                 ],
-                "summary": "List Template RPMs",
-                "operationId": "listTemplateRpms",
+                "summary": "Update Webhook",
+                "operationId": "updateWebhook",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Template ID.",
+                        "description": "Webhook ID.",
                         "name": "uuid",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Number of items to include in response. Use it to control the number of items, particularly when dealing with large datasets. Default value: ` + "`" + `100` + "`" + `.",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Starting point for retrieving a subset of results. Determines how many items to skip from the beginning of the result set. Default value:` + "`" + `0` + "`" + `.",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Term to filter and retrieve items that match the specified search criteria. Search term can include name.",
-                        "name": "search",
-                        "in": "query"
+                        "description": "request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.WebhookUpdateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.SnapshotRpmCollectionResponse"
+                            "$ref": "#/definitions/api.WebhookResponse"
                         }
                     },
                     "400": {
@@ -4584,6 +5542,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4593,9 +5557,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/templates/{uuid}/snapshots/": {
+        "/webhooks/{uuid}/deliveries/": {
             "get": {
-                "description": "List snapshots for a template.",
+                "description": "List the events sent, or waiting to be sent, to a webhook, newest first.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4603,29 +5567,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "snapshots"
+                    "webhooks"
                 ],
-                "summary": "List snapshots for a template",
-                "operationId": "listSnapshotsForTemplate",
+                "summary": "List Webhook Deliveries",
+                "operationId": "listWebhookDeliveries",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Template ID.",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search through snapshots by repository name.",
-                        "name": "repository_search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort the response data based on specific snapshot parameters. Sort criteria can include ` + "`" + `repository_name` + "`" + ` or ` + "`" + `created_at` + "`" + `.",
-                        "name": "sort_by",
-                        "in": "query"
+                        "description": "Webhook ID.",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
@@ -4644,7 +5596,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.SnapshotCollectionResponse"
+                            "$ref": "#/definitions/api.WebhookDeliveryCollectionResponse"
                         }
                     },
                     "400": {
@@ -4674,9 +5626,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/user_preferences/": {
-            "get": {
-                "description": "List preference labels and values for the authenticated user.",
+        "/webhooks/{uuid}/test/": {
+            "post": {
+                "description": "Send a ` + "`" + `webhook-test` + "`" + ` event to the webhook right away, and return the outcome. Test events are not retried.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4684,18 +5636,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user_preferences"
+                    "webhooks"
+                ],
+                "summary": "Send a test event to a Webhook",
+                "operationId": "testWebhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID.",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List user preferences",
-                "operationId": "listUserPreferences",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/api.UserPreferenceResponse"
-                            }
+                            "$ref": "#/definitions/api.WebhookDeliveryResponse"
                         }
                     },
                     "400": {
@@ -4704,60 +5662,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/errors.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/user_preferences/{label}": {
-            "put": {
-                "description": "Create or update a user preference for the given label. The request body is a JSON string value (for example ` + "`" + `\"true\"` + "`" + `).",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "user_preferences"
-                ],
-                "summary": "Set a user preference",
-                "operationId": "setUserPreference",
-                "parameters": [
-                    {
-                        "enum": [
-                            "lightwell-notification-enabled",
-                            "lightwell-notification-minimum"
-                        ],
-                        "type": "string",
-                        "description": "Preference label",
-                        "name": "label",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Preference value as a JSON string",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/api.UserPreferenceResponse"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/errors.ErrorResponse"
                         }
@@ -4805,6 +5717,90 @@ const docTemplate = `{
                 }
             }
         },
+        "api.AuditEventCollectionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Requested Data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.AuditEventResponse"
+                    }
+                },
+                "links": {
+                    "description": "Links to other pages of results",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.Links"
+                        }
+                    ]
+                },
+                "meta": {
+                    "description": "Metadata about the request",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.ResponseMetadata"
+                        }
+                    ]
+                }
+            }
+        },
+        "api.AuditEventResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "description": "Account ID of the user",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "Datetime the request was handled",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error returned, if the request failed",
+                    "type": "string"
+                },
+                "method": {
+                    "description": "HTTP method of the request",
+                    "type": "string"
+                },
+                "object_uuids": {
+                    "description": "UUIDs of the objects the request is about",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "path": {
+                    "description": "Path of the request",
+                    "type": "string"
+                },
+                "request": {
+                    "description": "JSON body of the request, with secrets redacted",
+                    "type": "object"
+                },
+                "request_id": {
+                    "description": "ID of the request",
+                    "type": "string"
+                },
+                "route": {
+                    "description": "Route of the request, such as /repositories/:uuid",
+                    "type": "string"
+                },
+                "status_code": {
+                    "description": "HTTP status of the response",
+                    "type": "integer"
+                },
+                "username": {
+                    "description": "User that sent the request",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string",
+                    "readOnly": true
+                }
+            }
+        },
         "api.BulkRemoveRpmsRequest": {
             "type": "object",
             "properties": {
@@ -4859,6 +5855,67 @@ const docTemplate = `{
                 }
             }
         },
+        "api.CoverageReportComparisonResponse": {
+            "type": "object",
+            "properties": {
+                "ecosystem_deltas": {
+                    "description": "Per-ecosystem change of the counts, from the report to the other report",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.EcosystemCoverageSummary"
+                    }
+                },
+                "newly_covered": {
+                    "description": "Packages of the other report that are covered, and were not covered or not in the report",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.CoverageReportPackageResponse"
+                    }
+                },
+                "newly_uncovered": {
+                    "description": "Packages of the other report that are not covered, and were covered or not in the report",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.CoverageReportPackageResponse"
+                    }
+                },
+                "other_uuid": {
+                    "description": "UUID of the coverage report compared to",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "UUID of the coverage report",
+                    "type": "string"
+                },
+                "version_changes": {
+                    "description": "Packages whose version differs between the reports",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.CoverageReportVersionChange"
+                    }
+                }
+            }
+        },
+        "api.CoverageReportExportResponse": {
+            "type": "object",
+            "properties": {
+                "packages": {
+                    "description": "Packages of the report",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.CoverageReportPackageResponse"
+                    }
+                },
+                "report": {
+                    "description": "Coverage report",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.CoverageReportResponse"
+                        }
+                    ]
+                }
+            }
+        },
         "api.CoverageReportPackageCollectionResponse": {
             "type": "object",
             "properties": {
@@ -4890,6 +5947,13 @@ const docTemplate = `{
         "api.CoverageReportPackageResponse": {
             "type": "object",
             "properties": {
+                "advisory_ids": {
+                    "description": "Known advisories of the catalog affecting the package version, approximated from their fixed versions",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "covered": {
                     "description": "Whether the package is covered (true = exact or partial match)",
                     "type": "boolean"
@@ -4898,13 +5962,53 @@ const docTemplate = `{
                     "description": "Ecosystem of the package",
                     "type": "string"
                 },
+                "fixed_versions": {
+                    "description": "Versions fixing the advisories",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "highest_severity": {
+                    "description": "Highest severity of the advisories: critical, important, moderate or low",
+                    "type": "string"
+                },
+                "match_status": {
+                    "description": "Match of the package in the catalog: exact, partial or none",
+                    "type": "string"
+                },
                 "name": {
                     "description": "Package name from the manifest",
                     "type": "string"
                 },
+                "namespace": {
+                    "description": "Package namespace, such as the group ID of Maven packages or the scope of npm packages",
+                    "type": "string"
+                },
+                "suggested_versions": {
+                    "description": "Catalog versions to upgrade to, for packages whose version is not in the catalog",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.CoverageSuggestedVersions"
+                        }
+                    ]
+                },
                 "version": {
                     "description": "Package version from the manifest",
                     "type": "string"
+                },
+                "version_constraint": {
+                    "description": "Version specifier from the manifest for packages without a pinned version, such as \u003e=2.31,\u003c3",
+                    "type": "string"
+                }
+            }
+        },
+        "api.CoverageReportRefreshRequest": {
+            "type": "object",
+            "properties": {
+                "scheduled_refresh": {
+                    "description": "Whether to re-evaluate the new report periodically, notifying its owner when coverage improves. Defaults to the setting of the refreshed report.",
+                    "type": "boolean"
                 }
             }
         },
@@ -4919,6 +6023,10 @@ const docTemplate = `{
                     "description": "UUID of the coverage analysis task",
                     "type": "string"
                 },
+                "catalog_snapshot_at": {
+                    "description": "Timestamp of the catalog the packages were matched against",
+                    "type": "string"
+                },
                 "completed_at": {
                     "description": "Timestamp when coverage analysis finished",
                     "type": "string"
@@ -4946,19 +6054,84 @@ const docTemplate = `{
                     "description": "Number of packages with name found but not version",
                     "type": "integer"
                 },
-                "status": {
-                    "description": "Coverage analysis task status",
+                "scheduled_refresh": {
+                    "description": "Whether the report is re-evaluated periodically, notifying its owner when coverage improves",
+                    "type": "boolean"
+                },
+                "source_report_uuid": {
+                    "description": "UUID of the report this report re-evaluates against a newer catalog",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Coverage analysis task status",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "Total packages parsed from the manifest",
+                    "type": "integer"
+                },
+                "unmatched": {
+                    "description": "Number of packages with name not found",
+                    "type": "integer"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "description": "Manifest entries that could not be resolved, such as dependencies with an unresolved version",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "api.CoverageReportVersionChange": {
+            "type": "object",
+            "properties": {
+                "covered": {
+                    "description": "Whether the package is covered in the coverage report",
+                    "type": "boolean"
+                },
+                "ecosystem": {
+                    "description": "Ecosystem of the package",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Package name",
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "Package namespace",
+                    "type": "string"
+                },
+                "other_covered": {
+                    "description": "Whether the package is covered in the coverage report compared to",
+                    "type": "boolean"
+                },
+                "other_version": {
+                    "description": "Version in the coverage report compared to",
                     "type": "string"
                 },
-                "total": {
-                    "description": "Total packages parsed from the manifest",
-                    "type": "integer"
+                "version": {
+                    "description": "Version in the coverage report",
+                    "type": "string"
+                }
+            }
+        },
+        "api.CoverageSuggestedVersions": {
+            "type": "object",
+            "properties": {
+                "latest": {
+                    "description": "Highest catalog version",
+                    "type": "string"
                 },
-                "unmatched": {
-                    "description": "Number of packages with name not found",
-                    "type": "integer"
+                "nearest_patch": {
+                    "description": "Lowest catalog version higher than the package version, with the same major and minor",
+                    "type": "string"
                 },
-                "uuid": {
+                "same_minor": {
+                    "description": "Highest catalog version with the same major and minor as the package version",
                     "type": "string"
                 }
             }
@@ -5009,6 +6182,23 @@ const docTemplate = `{
                 }
             }
         },
+        "api.ErrataCounts": {
+            "type": "object",
+            "properties": {
+                "bugfix": {
+                    "description": "Number of bug fix errata",
+                    "type": "integer"
+                },
+                "enhancement": {
+                    "description": "Number of enhancement errata",
+                    "type": "integer"
+                },
+                "security": {
+                    "description": "Number of security errata",
+                    "type": "integer"
+                }
+            }
+        },
         "api.ExtendedReleaseArchitecture": {
             "type": "object",
             "properties": {
@@ -5466,6 +6656,22 @@ const docTemplate = `{
                 }
             }
         },
+        "api.ObjectPermissions": {
+            "type": "object",
+            "properties": {
+                "permissions": {
+                    "description": "Permissions of the user on the object, by resource",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/api.ResourcePermissions"
+                    }
+                },
+                "uuid": {
+                    "description": "UUID of the repository or template",
+                    "type": "string"
+                }
+            }
+        },
         "api.PackageItem": {
             "type": "object",
             "properties": {
@@ -5550,6 +6756,36 @@ const docTemplate = `{
                 }
             }
         },
+        "api.PermissionsResponse": {
+            "type": "object",
+            "properties": {
+                "backend": {
+                    "description": "Service checking permissions: rbac, kessel or file, empty when permissions are not checked",
+                    "type": "string"
+                },
+                "permissions": {
+                    "description": "Permissions of the user on each resource of the organization",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/api.ResourcePermissions"
+                    }
+                },
+                "repositories": {
+                    "description": "Permissions of the user on the requested repositories",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.ObjectPermissions"
+                    }
+                },
+                "templates": {
+                    "description": "Permissions of the user on the requested templates",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.ObjectPermissions"
+                    }
+                }
+            }
+        },
         "api.PopularRepositoriesCollectionResponse": {
             "type": "object",
             "properties": {
@@ -6259,6 +7495,10 @@ const docTemplate = `{
                 "url": {
                     "description": "URL of the remote yum repository",
                     "type": "string"
+                },
+                "workspace_id": {
+                    "description": "Workspace to create the repository in, defaults to the default workspace of the organization. Requires per object permissions",
+                    "type": "string"
                 }
             }
         },
@@ -6597,6 +7837,12 @@ const docTemplate = `{
                 }
             }
         },
+        "api.ResourcePermissions": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "boolean"
+            }
+        },
         "api.ResponseMetadata": {
             "type": "object",
             "properties": {
@@ -7088,6 +8334,33 @@ const docTemplate = `{
                 }
             }
         },
+        "api.TaskGraphResponse": {
+            "type": "object",
+            "properties": {
+                "downstream": {
+                    "description": "Tasks that depend on the requested task, directly or through other tasks",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.TaskInfoResponse"
+                    }
+                },
+                "task": {
+                    "description": "The requested task",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.TaskInfoResponse"
+                        }
+                    ]
+                },
+                "upstream": {
+                    "description": "Tasks the requested task depends on, directly or through other tasks",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.TaskInfoResponse"
+                    }
+                }
+            }
+        },
         "api.TaskInfoCollectionResponse": {
             "type": "object",
             "properties": {
@@ -7261,6 +8534,10 @@ const docTemplate = `{
                 "version": {
                     "description": "Version of the template",
                     "type": "string"
+                },
+                "workspace_id": {
+                    "description": "Workspace to create the template in, defaults to the default workspace of the organization. Requires per object permissions",
+                    "type": "string"
                 }
             }
         },
@@ -7271,6 +8548,20 @@ const docTemplate = `{
                     "description": "Architecture of the template",
                     "type": "string"
                 },
+                "available_errata_counted_at": {
+                    "description": "Datetime the available errata were last counted",
+                    "type": "string",
+                    "readOnly": true
+                },
+                "available_errata_counts": {
+                    "description": "Errata in the latest snapshots of the repositories that the template does not include, counted periodically for templates not using the latest snapshots",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.ErrataCounts"
+                        }
+                    ],
+                    "readOnly": true
+                },
                 "created_at": {
                     "description": "Datetime template was created",
                     "type": "string"
@@ -7514,6 +8805,216 @@ const docTemplate = `{
                 }
             }
         },
+        "api.WebhookCollectionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Requested Data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.WebhookResponse"
+                    }
+                },
+                "links": {
+                    "description": "Links to other pages of results",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.Links"
+                        }
+                    ]
+                },
+                "meta": {
+                    "description": "Metadata about the request",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.ResponseMetadata"
+                        }
+                    ]
+                }
+            }
+        },
+        "api.WebhookDeliveryCollectionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Requested Data",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.WebhookDeliveryResponse"
+                    }
+                },
+                "links": {
+                    "description": "Links to other pages of results",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.Links"
+                        }
+                    ]
+                },
+                "meta": {
+                    "description": "Metadata about the request",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.ResponseMetadata"
+                        }
+                    ]
+                }
+            }
+        },
+        "api.WebhookDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "Number of delivery attempts",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "Datetime the event was recorded",
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "description": "Datetime the delivery succeeded",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error of the last attempt",
+                    "type": "string"
+                },
+                "event_type": {
+                    "description": "Type of the delivered event",
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "description": "Datetime of the next attempt of a pending delivery",
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "Body posted to the webhook",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "response_code": {
+                    "description": "HTTP status returned by the last attempt",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Status of the delivery: pending, succeeded or failed",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string",
+                    "readOnly": true
+                },
+                "webhook_uuid": {
+                    "description": "UUID of the webhook",
+                    "type": "string"
+                }
+            }
+        },
+        "api.WebhookRequest": {
+            "type": "object",
+            "required": [
+                "event_types",
+                "name",
+                "secret",
+                "url"
+            ],
+            "properties": {
+                "enabled": {
+                    "description": "Whether events are delivered to the webhook, defaults to true",
+                    "type": "boolean"
+                },
+                "event_types": {
+                    "description": "Event types the webhook subscribes to",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "Name of the webhook",
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "Secret used to sign the payloads with HMAC-SHA256, at least 16 characters long",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "URL events are posted to, it must use https",
+                    "type": "string"
+                }
+            }
+        },
+        "api.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Datetime the webhook was created",
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "User that created the webhook",
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "Whether events are delivered to the webhook",
+                    "type": "boolean"
+                },
+                "event_types": {
+                    "description": "Event types the webhook subscribes to",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "Name of the webhook",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Datetime the webhook was last updated",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "URL events are posted to",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string",
+                    "readOnly": true
+                }
+            }
+        },
+        "api.WebhookUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Whether events are delivered to the webhook",
+                    "type": "boolean"
+                },
+                "event_types": {
+                    "description": "Event types the webhook subscribes to",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "Name of the webhook",
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "Secret used to sign the payloads with HMAC-SHA256, at least 16 characters long",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "URL events are posted to, it must use https",
+                    "type": "string"
+                }
+            }
+        },
         "config.DistributionArch": {
             "type": "object",
             "properties": {
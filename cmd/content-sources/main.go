@@ -16,16 +16,20 @@ import (
 	"github.com/content-services/content-sources-backend/pkg/dao"
 	"github.com/content-services/content-sources-backend/pkg/db"
 	ce "github.com/content-services/content-sources-backend/pkg/errors"
+	"github.com/content-services/content-sources-backend/pkg/event"
 	"github.com/content-services/content-sources-backend/pkg/external_repos"
 	"github.com/content-services/content-sources-backend/pkg/handler"
 	m "github.com/content-services/content-sources-backend/pkg/instrumentation"
 	custom_collector "github.com/content-services/content-sources-backend/pkg/instrumentation/custom"
+	"github.com/content-services/content-sources-backend/pkg/outbox"
+	"github.com/content-services/content-sources-backend/pkg/rbac"
 	"github.com/content-services/content-sources-backend/pkg/router"
 	"github.com/content-services/content-sources-backend/pkg/tasks"
 	"github.com/content-services/content-sources-backend/pkg/tasks/queue"
 	"github.com/content-services/content-sources-backend/pkg/tasks/worker"
 	mocks_rbac "github.com/content-services/content-sources-backend/pkg/test/mocks/rbac"
 	"github.com/content-services/content-sources-backend/pkg/utils"
+	"github.com/content-services/content-sources-backend/pkg/webhooks"
 	"github.com/labstack/echo/v4"
 	echo_middleware "github.com/labstack/echo/v4/middleware"
 	"github.com/prometheus/client_golang/prometheus"
@@ -48,6 +52,13 @@ func main() {
 		log.Fatal().Err(err).Msg("Failed to connect to database.")
 	}
 	defer db.Close()
+	event.SetWebhookDispatcher(dao.GetWebhookDao(db.DB))
+	event.SetOutbox(dao.GetOutboxDao(db.DB))
+
+	err = rbac.SetupObjectReporter()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to set up kessel object reporter")
+	}
 
 	err = config.ConfigureTang()
 	if err != nil {
@@ -79,6 +90,8 @@ func main() {
 
 	if argsContain(args, "consumer") {
 		kafkaConsumer(ctx, &wg, metrics)
+		webhookDeliverer(ctx, &wg)
+		outboxRelay(ctx, &wg)
 	}
 
 	if argsContain(args, "instrumentation") {
@@ -131,12 +144,31 @@ func kafkaConsumer(ctx context.Context, wg *sync.WaitGroup, metrics *m.Metrics)
 		wrk.RegisterHandler(config.UpdateLatestSnapshotTask, tasks.UpdateLatestSnapshotHandler)
 		wrk.RegisterHandler(config.BulkRemoveRpmsTask, tasks.BulkRemoveRpmsHandler)
 		wrk.RegisterHandler(config.UpdateSnapshotPublishedTask, tasks.UpdateSnapshotPublishedHandler)
+		wrk.RegisterHandler(config.RefreshCoverageReportTask, tasks.RefreshCoverageReportHandler)
 		go wrk.StartWorkerPool(ctx)
 		<-ctx.Done()
 		wrk.Stop()
 	}()
 }
 
+// Sends the webhook deliveries recorded by the api and the task workers
+func webhookDeliverer(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		webhooks.NewDeliverer(dao.GetWebhookDao(db.DB)).Run(ctx)
+	}()
+}
+
+// Sends the events recorded in the outbox along with the changes they describe
+func outboxRelay(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		outbox.NewRelay(dao.GetOutboxDao(db.DB)).Run(ctx)
+	}()
+}
+
 func apiServer(ctx context.Context, wg *sync.WaitGroup, allRoutes bool, metrics *m.Metrics) {
 	wg.Add(2) // api server & shutdown monitor
 
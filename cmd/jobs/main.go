@@ -29,6 +29,9 @@ func loadJobs() map[string]jobFunc {
 		"remove-custom-epel-repos":        jobs.RemoveCustomEpelRepos,
 		"cancel-tasks":                    jobs.CancelTasks,
 		"send-template-update-events":     jobs.SendTemplateUpdateEvents,
+		"send-template-errata-digests":    jobs.SendTemplateErrataDigests,
+		"refresh-coverage-reports":        jobs.RefreshCoverageReports,
+		"report-workspace-objects":        jobs.ReportWorkspaceObjects,
 	}
 }
 
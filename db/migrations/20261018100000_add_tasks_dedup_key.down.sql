@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_tasks_dedup_key;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS dedup_key;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS dedup_key VARCHAR(255) DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_dedup_key ON tasks (org_id, type, dedup_key) WHERE dedup_key IS NOT NULL;
+
+COMMIT;
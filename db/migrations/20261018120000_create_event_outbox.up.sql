@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS event_outbox (
+    id BIGSERIAL NOT NULL PRIMARY KEY,
+    org_id VARCHAR(255) NOT NULL,
+    channel VARCHAR(64) NOT NULL,
+    event_type VARCHAR(255) NOT NULL,
+    object_uuid VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS event_outbox_pending_idx ON event_outbox(channel, object_uuid, id) WHERE status = 'pending';
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE templates
+    DROP COLUMN IF EXISTS available_errata_counts,
+    DROP COLUMN IF EXISTS available_errata_counted_at;
+
+COMMIT;
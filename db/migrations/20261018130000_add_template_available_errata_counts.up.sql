@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE templates
+    ADD COLUMN IF NOT EXISTS available_errata_counts JSONB DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS available_errata_counted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS audit_events (
+    uuid UUID NOT NULL PRIMARY KEY,
+    org_id VARCHAR(255) NOT NULL,
+    account_id VARCHAR(255),
+    username VARCHAR(255),
+    request_id VARCHAR(255),
+    method VARCHAR(16) NOT NULL,
+    route TEXT NOT NULL,
+    path TEXT NOT NULL,
+    object_uuids TEXT[] NOT NULL DEFAULT '{}',
+    request JSONB,
+    status_code INTEGER NOT NULL,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS audit_events_org_id_created_at_idx ON audit_events(org_id, created_at);
+CREATE INDEX IF NOT EXISTS audit_events_object_uuids_idx ON audit_events USING GIN (object_uuids);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE coverage_reports DROP COLUMN IF EXISTS warnings;
+
+COMMIT;
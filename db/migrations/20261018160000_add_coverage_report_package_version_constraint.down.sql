@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE coverage_report_packages DROP COLUMN IF EXISTS version_constraint;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE coverage_report_packages ADD COLUMN IF NOT EXISTS version_constraint VARCHAR(255) DEFAULT NULL;
+
+COMMIT;
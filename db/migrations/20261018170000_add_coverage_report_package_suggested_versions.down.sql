@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE coverage_report_packages
+    DROP COLUMN IF EXISTS nearest_patch_version,
+    DROP COLUMN IF EXISTS same_minor_version,
+    DROP COLUMN IF EXISTS latest_version;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE coverage_report_packages
+    ADD COLUMN IF NOT EXISTS nearest_patch_version VARCHAR(255) DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS same_minor_version VARCHAR(255) DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS latest_version VARCHAR(255) DEFAULT NULL;
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_coverage_reports_scheduled_refresh;
+DROP INDEX IF EXISTS idx_coverage_reports_source_report_uuid;
+
+ALTER TABLE coverage_reports
+    DROP CONSTRAINT IF EXISTS fk_coverage_reports_source_report;
+
+ALTER TABLE coverage_reports
+    DROP COLUMN IF EXISTS source_report_uuid,
+    DROP COLUMN IF EXISTS scheduled_refresh;
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+ALTER TABLE coverage_reports
+    ADD COLUMN IF NOT EXISTS source_report_uuid UUID DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS scheduled_refresh BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE ONLY coverage_reports
+    DROP CONSTRAINT IF EXISTS fk_coverage_reports_source_report,
+    ADD CONSTRAINT fk_coverage_reports_source_report
+        FOREIGN KEY (source_report_uuid) REFERENCES coverage_reports(uuid) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_coverage_reports_source_report_uuid ON coverage_reports (source_report_uuid);
+CREATE INDEX IF NOT EXISTS idx_coverage_reports_scheduled_refresh ON coverage_reports (scheduled_refresh) WHERE scheduled_refresh;
+
+COMMIT;
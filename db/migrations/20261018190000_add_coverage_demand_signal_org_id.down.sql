@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_coverage_demand_signals_created_at;
+
+ALTER TABLE coverage_demand_signals DROP COLUMN IF EXISTS org_id;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE coverage_demand_signals ADD COLUMN IF NOT EXISTS org_id VARCHAR(255) DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_coverage_demand_signals_created_at ON coverage_demand_signals (created_at);
+
+COMMIT;
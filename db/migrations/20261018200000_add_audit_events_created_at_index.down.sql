@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP INDEX IF EXISTS audit_events_created_at_idx;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+-- The cleanup deletes the old audit events of every organization
+CREATE INDEX IF NOT EXISTS audit_events_created_at_idx ON audit_events(created_at);
+
+COMMIT;
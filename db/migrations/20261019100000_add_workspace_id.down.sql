@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE repository_configurations DROP COLUMN IF EXISTS workspace_id;
+
+ALTER TABLE templates DROP COLUMN IF EXISTS workspace_id;
+
+COMMIT;
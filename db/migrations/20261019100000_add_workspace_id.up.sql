@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE repository_configurations ADD COLUMN IF NOT EXISTS workspace_id VARCHAR(255) DEFAULT NULL;
+
+ALTER TABLE templates ADD COLUMN IF NOT EXISTS workspace_id VARCHAR(255) DEFAULT NULL;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE coverage_reports
+    DROP COLUMN IF EXISTS automatic;
+
+COMMIT;
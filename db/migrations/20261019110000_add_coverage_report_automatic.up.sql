@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE coverage_reports
+    ADD COLUMN IF NOT EXISTS automatic BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
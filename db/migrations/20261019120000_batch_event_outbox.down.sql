@@ -0,0 +1,14 @@
+BEGIN;
+
+DROP INDEX IF EXISTS event_outbox_finished_idx;
+DROP INDEX IF EXISTS event_outbox_pending_idx;
+DELETE FROM event_outbox WHERE status = 'sent';
+
+ALTER TABLE event_outbox ADD COLUMN IF NOT EXISTS object_uuid VARCHAR(255) NOT NULL DEFAULT '';
+UPDATE event_outbox SET object_uuid = COALESCE(object_uuids[1], ''), payload = COALESCE(payload->0, payload);
+ALTER TABLE event_outbox ALTER COLUMN object_uuid DROP DEFAULT;
+ALTER TABLE event_outbox DROP COLUMN IF EXISTS object_uuids;
+
+CREATE INDEX IF NOT EXISTS event_outbox_pending_idx ON event_outbox(channel, object_uuid, id) WHERE status = 'pending';
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+-- An outbox event holds the payloads of all the objects of one change, in a json array
+ALTER TABLE event_outbox ADD COLUMN IF NOT EXISTS object_uuids TEXT[] NOT NULL DEFAULT '{}';
+UPDATE event_outbox SET object_uuids = ARRAY[object_uuid], payload = jsonb_build_array(payload);
+
+DROP INDEX IF EXISTS event_outbox_pending_idx;
+ALTER TABLE event_outbox DROP COLUMN IF EXISTS object_uuid;
+
+CREATE INDEX IF NOT EXISTS event_outbox_pending_idx ON event_outbox USING GIN (object_uuids) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS event_outbox_finished_idx ON event_outbox(created_at) WHERE status <> 'pending';
+
+COMMIT;
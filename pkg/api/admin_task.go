@@ -28,6 +28,39 @@ type AdminTaskInfoCollectionResponse struct {
 	Links Links                   `json:"links"` // Links to other pages of results
 }
 
+// AdminDeadLetterGroupResponse summarizes failed tasks that will not be retried automatically, grouped by type and error
+type AdminDeadLetterGroupResponse struct {
+	Typename       string `json:"typename"`        // Type of the tasks
+	ErrorSignature string `json:"error_signature"` // Identifies the error shared by the tasks, use it to filter bulk actions
+	Error          string `json:"error"`           // Error of the tasks, with UUIDs and numbers replaced by placeholders
+	Count          int64  `json:"count"`           // Number of tasks in the group
+	OrgCount       int64  `json:"org_count"`       // Number of organizations with tasks in the group
+	FirstFailedAt  string `json:"first_failed_at"` // Timestamp the oldest task in the group failed at
+	LastFailedAt   string `json:"last_failed_at"`  // Timestamp the newest task in the group failed at
+}
+
+type AdminDeadLetterGroupCollectionResponse struct {
+	Data  []AdminDeadLetterGroupResponse `json:"data"`  // Requested Data
+	Meta  ResponseMetadata               `json:"meta"`  // Metadata about the request
+	Links Links                          `json:"links"` // Links to other pages of results
+}
+
+// AdminDeadLetterRequest selects the dead-lettered tasks a bulk action applies to. At least one filter is required.
+type AdminDeadLetterRequest struct {
+	OrgId          string   `json:"org_id"`          // Only act on tasks of this organization
+	Typenames      []string `json:"typenames"`       // Only act on tasks of these types
+	ErrorSignature string   `json:"error_signature"` // Only act on tasks with this error signature
+	TaskUUIDs      []string `json:"task_uuids"`      // Only act on these tasks
+	ResetRetries   bool     `json:"reset_retries"`   // Reset the retry count of retried tasks, so they may be retried automatically again
+}
+
+// AdminDeadLetterActionResponse reports the tasks a bulk action was applied to
+type AdminDeadLetterActionResponse struct {
+	Action    string   `json:"action"`     // Action applied to the tasks (retry, cancel or purge)
+	Count     int      `json:"count"`      // Number of tasks the action was applied to
+	TaskUUIDs []string `json:"task_uuids"` // UUIDs of the tasks the action was applied to
+}
+
 type PulpResponse struct {
 	Sync         *PulpTaskResponse `json:"sync,omitempty"`
 	Distribution *PulpTaskResponse `json:"distribution,omitempty"`
@@ -105,6 +138,11 @@ func (a *AdminTaskInfoCollectionResponse) SetMetadata(meta ResponseMetadata, lin
 	a.Links = links
 }
 
+func (a *AdminDeadLetterGroupCollectionResponse) SetMetadata(meta ResponseMetadata, links Links) {
+	a.Meta = meta
+	a.Links = links
+}
+
 func zestProgressReportToApi(zestProgressReport *zest.ProgressReportResponse, apiProgressReport *pulpProgressReportResponse) {
 	apiProgressReport.Message = zestProgressReport.Message
 	apiProgressReport.Code = zestProgressReport.Code
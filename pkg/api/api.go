@@ -8,6 +8,7 @@ import (
 )
 
 const IdentityHeader = "x-rh-identity"
+const IdempotencyKeyHeader = "Idempotency-Key"
 const ApiVersion = "1.0"
 const ApiVersionMajor = "1"
 
@@ -23,21 +24,22 @@ type PaginationData struct {
 }
 
 type FilterData struct {
-	Search                 string `query:"search" json:"search" `                                    // Search string based query to optionally filter on
-	Arch                   string `query:"arch" json:"arch" `                                        // Comma separated list of architecture to optionally filter on (e.g. 'x86_64,s390x' would return Repositories with x86_64 or s390x only)
-	Version                string `query:"version" json:"version"`                                   // Comma separated list of versions to optionally filter on  (e.g. '7,8' would return Repositories with versions 7 or 8 only)
-	AvailableForArch       string `query:"available_for_arch" json:"available_for_arch"`             // Filter by compatible arch (e.g. 'x86_64' would return Repositories with the 'x86_64' arch and Repositories where arch is not set)
-	AvailableForVersion    string `query:"available_for_version" json:"available_for_version"`       // Filter by compatible version (e.g. 7 would return Repositories with the version 7 or where version is not set)
-	Name                   string `query:"name" json:"name"`                                         // Filter repositories by name using an exact match.
-	URL                    string `query:"url" json:"url"`                                           // Comma separated list of urls to optionally filter on.
-	UUID                   string `query:"uuid" json:"uuid"`                                         // Comma separated list of uuids to optionally filter on.
-	Status                 string `query:"status" json:"status"`                                     // Comma separated list of statuses to optionally filter on.
-	Origin                 string `query:"origin" json:"origin"`                                     // Comma separated list of origins to filter on (e.g. external, red_hat, upload)
-	ContentType            string `query:"content_type" json:"content_type"`                         // Filter repositories by content type (e.g. rpm)
-	ExtendedRelease        string `query:"extended_release" json:"extended_release"`                 // Comma separated list of extended release types to filter on (eus, e4s)
-	ExtendedReleaseVersion string `query:"extended_release_version" json:"extended_release_version"` // Comma separated list of extended release versions to filter on (9.4, 9.6, etc.). Use 'none' to filter repositories without extended release versions.
-	Partner                string `query:"partner" json:"partner"`                                   // Filter repositories by partner flag (true or false)
-	FeatureName            string `query:"feature_name" json:"feature_name"`                         // Comma separated list of feature names to filter on
+	Search                 string   `query:"search" json:"search" `                                    // Search string based query to optionally filter on
+	Arch                   string   `query:"arch" json:"arch" `                                        // Comma separated list of architecture to optionally filter on (e.g. 'x86_64,s390x' would return Repositories with x86_64 or s390x only)
+	Version                string   `query:"version" json:"version"`                                   // Comma separated list of versions to optionally filter on  (e.g. '7,8' would return Repositories with versions 7 or 8 only)
+	AvailableForArch       string   `query:"available_for_arch" json:"available_for_arch"`             // Filter by compatible arch (e.g. 'x86_64' would return Repositories with the 'x86_64' arch and Repositories where arch is not set)
+	AvailableForVersion    string   `query:"available_for_version" json:"available_for_version"`       // Filter by compatible version (e.g. 7 would return Repositories with the version 7 or where version is not set)
+	Name                   string   `query:"name" json:"name"`                                         // Filter repositories by name using an exact match.
+	URL                    string   `query:"url" json:"url"`                                           // Comma separated list of urls to optionally filter on.
+	UUID                   string   `query:"uuid" json:"uuid"`                                         // Comma separated list of uuids to optionally filter on.
+	Status                 string   `query:"status" json:"status"`                                     // Comma separated list of statuses to optionally filter on.
+	Origin                 string   `query:"origin" json:"origin"`                                     // Comma separated list of origins to filter on (e.g. external, red_hat, upload)
+	ContentType            string   `query:"content_type" json:"content_type"`                         // Filter repositories by content type (e.g. rpm)
+	ExtendedRelease        string   `query:"extended_release" json:"extended_release"`                 // Comma separated list of extended release types to filter on (eus, e4s)
+	ExtendedReleaseVersion string   `query:"extended_release_version" json:"extended_release_version"` // Comma separated list of extended release versions to filter on (9.4, 9.6, etc.). Use 'none' to filter repositories without extended release versions.
+	Partner                string   `query:"partner" json:"partner"`                                   // Filter repositories by partner flag (true or false)
+	FeatureName            string   `query:"feature_name" json:"feature_name"`                         // Comma separated list of feature names to filter on
+	AllowedUUIDs           []string `query:"-" json:"-" swaggerignore:"true"`                          // Repositories of the organization the user is restricted to, nil if not restricted
 }
 
 type ResponseMetadata struct {
@@ -64,6 +66,12 @@ type AdminTaskFilterData struct {
 	Typename  string `json:"type"`
 }
 
+type AdminDeadLetterFilterData struct {
+	OrgId          string `json:"org_id"`
+	Typename       string `json:"type"` // Comma separated list of task types to optionally filter on.
+	ErrorSignature string `json:"error_signature"`
+}
+
 type FeatureStatus struct {
 	OrgID       string   `json:"org_id"`
 	FeatureList []string `json:"feature_list"`
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEventExportLimit is the maximum number of audit events returned by an export
+const AuditEventExportLimit = 10000
+
+type AuditEventResponse struct {
+	UUID        string          `json:"uuid" readonly:"true"`
+	Username    string          `json:"username"`                               // User that sent the request
+	AccountID   string          `json:"account_id"`                             // Account ID of the user
+	RequestID   string          `json:"request_id"`                             // ID of the request
+	Method      string          `json:"method"`                                 // HTTP method of the request
+	Route       string          `json:"route"`                                  // Route of the request, such as /repositories/:uuid
+	Path        string          `json:"path"`                                   // Path of the request
+	ObjectUUIDs []string        `json:"object_uuids"`                           // UUIDs of the objects the request is about
+	Request     json.RawMessage `json:"request,omitempty" swaggertype:"object"` // JSON body of the request, with secrets redacted
+	StatusCode  int             `json:"status_code"`                            // HTTP status of the response
+	Error       string          `json:"error,omitempty"`                        // Error returned, if the request failed
+	CreatedAt   time.Time       `json:"created_at"`                             // Datetime the request was handled
+}
+
+type AuditEventCollectionResponse struct {
+	Data  []AuditEventResponse `json:"data"`  // Requested Data
+	Meta  ResponseMetadata     `json:"meta"`  // Metadata about the request
+	Links Links                `json:"links"` // Links to other pages of results
+}
+
+func (r *AuditEventCollectionResponse) SetMetadata(meta ResponseMetadata, links Links) {
+	r.Meta = meta
+	r.Links = links
+}
+
+type AuditEventFilterData struct {
+	ObjectUUID string     `json:"object_uuid"` // List events about this object
+	Username   string     `json:"username"`    // List events of this user
+	StartTime  *time.Time `json:"start_time"`  // List events at or after this time
+	EndTime    *time.Time `json:"end_time"`    // List events before this time
+}
@@ -0,0 +1,56 @@
+package api
+
+import "time"
+
+// CoverageDemandExportLimit is the maximum number of packages returned by a demand signal export
+const CoverageDemandExportLimit = 10000
+
+const (
+	CoverageDemandIntervalDay   = "day"
+	CoverageDemandIntervalWeek  = "week"
+	CoverageDemandIntervalMonth = "month"
+)
+
+// CoverageDemandSummaryResponse aggregates the demand signals of a package missing from the catalog, or whose
+// requested versions are missing
+type CoverageDemandSummaryResponse struct {
+	Ecosystem      string                    `json:"ecosystem"`           // Ecosystem of the package
+	Namespace      string                    `json:"namespace,omitempty"` // Namespace of the package, such as the group ID of Maven packages
+	Name           string                    `json:"name"`                // Name of the package
+	MinVersion     string                    `json:"min_version"`         // Lowest version requested
+	MaxVersion     string                    `json:"max_version"`         // Highest version requested
+	VersionCount   int                       `json:"version_count"`       // Number of distinct versions requested
+	SignalCount    int                       `json:"signal_count"`        // Number of demand signals
+	OrgCount       int                       `json:"org_count"`           // Number of distinct organizations requesting the package
+	UnmatchedCount int                       `json:"unmatched_count"`     // Number of signals for which the package is not in the catalog
+	PartialCount   int                       `json:"partial_count"`       // Number of signals for which the package is in the catalog, but not the version
+	FirstSeenAt    time.Time                 `json:"first_seen_at"`       // Datetime of the first demand signal
+	LastSeenAt     time.Time                 `json:"last_seen_at"`        // Datetime of the last demand signal
+	Trend          []CoverageDemandTrendItem `json:"trend"`               // Demand signals of each period, oldest first
+}
+
+// CoverageDemandTrendItem represents the demand signals of a package over a period
+type CoverageDemandTrendItem struct {
+	PeriodStart time.Time `json:"period_start"` // Start of the period
+	SignalCount int       `json:"signal_count"` // Number of demand signals in the period
+	OrgCount    int       `json:"org_count"`    // Number of distinct organizations requesting the package in the period
+}
+
+type CoverageDemandSummaryCollectionResponse struct {
+	Data  []CoverageDemandSummaryResponse `json:"data"`  // Requested Data
+	Meta  ResponseMetadata                `json:"meta"`  // Metadata about the request
+	Links Links                           `json:"links"` // Links to other pages of results
+}
+
+func (r *CoverageDemandSummaryCollectionResponse) SetMetadata(meta ResponseMetadata, links Links) {
+	r.Meta = meta
+	r.Links = links
+}
+
+type CoverageDemandFilterData struct {
+	Ecosystem string     `json:"ecosystem"`  // Aggregate the signals of this ecosystem
+	Search    string     `json:"search"`     // Aggregate the signals of packages whose name or namespace contains this term
+	StartTime *time.Time `json:"start_time"` // Aggregate the signals recorded at or after this time
+	EndTime   *time.Time `json:"end_time"`   // Aggregate the signals recorded before this time
+	Interval  string     `json:"interval"`   // Period of the trend: day, week or month
+}
@@ -16,6 +16,15 @@ type CoverageReportResponse struct {
 	EcosystemCoverageSummary []EcosystemCoverageSummary `json:"ecosystem_coverage_summary"`    // Per-ecosystem breakdown
 	AnalysisTaskError        string                     `json:"analysis_task_error,omitempty"` // Error if coverage analysis task failed
 	AnalysisTaskUUID         string                     `json:"analysis_task_uuid"`            // UUID of the coverage analysis task
+	Warnings                 []string                   `json:"warnings"`                      // Manifest entries that could not be resolved, such as dependencies with an unresolved version
+	SourceReportUUID         string                     `json:"source_report_uuid,omitempty"`  // UUID of the report this report re-evaluates against a newer catalog
+	ScheduledRefresh         bool                       `json:"scheduled_refresh"`             // Whether the report is re-evaluated periodically, notifying its owner when coverage improves
+	CatalogSnapshotAt        *time.Time                 `json:"catalog_snapshot_at"`           // Timestamp of the catalog the packages were matched against
+}
+
+// CoverageReportRefreshRequest represents the options of a coverage report refresh
+type CoverageReportRefreshRequest struct {
+	ScheduledRefresh *bool `json:"scheduled_refresh"` // Whether to re-evaluate the new report periodically, notifying its owner when coverage improves. Defaults to the setting of the refreshed report.
 }
 
 // EcosystemCoverageSummary represents the ecosystem breakdown in a coverage report
@@ -29,10 +38,24 @@ type EcosystemCoverageSummary struct {
 
 // CoverageReportPackageResponse represents a package in a coverage report
 type CoverageReportPackageResponse struct {
-	Name      string `json:"name"`      // Package name from the manifest
-	Version   string `json:"version"`   // Package version from the manifest
-	Ecosystem string `json:"ecosystem"` // Ecosystem of the package
-	Covered   bool   `json:"covered"`   // Whether the package is covered (true = exact or partial match)
+	Name              string                     `json:"name"`                         // Package name from the manifest
+	Namespace         string                     `json:"namespace,omitempty"`          // Package namespace, such as the group ID of Maven packages or the scope of npm packages
+	Version           string                     `json:"version"`                      // Package version from the manifest
+	VersionConstraint string                     `json:"version_constraint,omitempty"` // Version specifier from the manifest for packages without a pinned version, such as >=2.31,<3
+	Ecosystem         string                     `json:"ecosystem"`                    // Ecosystem of the package
+	Covered           bool                       `json:"covered"`                      // Whether the package is covered (true = exact or partial match)
+	MatchStatus       string                     `json:"match_status"`                 // Match of the package in the catalog: exact, partial or none
+	SuggestedVersions *CoverageSuggestedVersions `json:"suggested_versions,omitempty"` // Catalog versions to upgrade to, for packages whose version is not in the catalog
+	AdvisoryIDs       []string                   `json:"advisory_ids,omitempty"`       // Known advisories of the catalog affecting the package version, approximated from their fixed versions
+	HighestSeverity   string                     `json:"highest_severity,omitempty"`   // Highest severity of the advisories: critical, important, moderate or low
+	FixedVersions     []string                   `json:"fixed_versions,omitempty"`     // Versions fixing the advisories
+}
+
+// CoverageSuggestedVersions represents the catalog versions nearest to the version of a partially matched package
+type CoverageSuggestedVersions struct {
+	NearestPatch string `json:"nearest_patch,omitempty"` // Lowest catalog version higher than the package version, with the same major and minor
+	SameMinor    string `json:"same_minor,omitempty"`    // Highest catalog version with the same major and minor as the package version
+	Latest       string `json:"latest,omitempty"`        // Highest catalog version
 }
 
 // CoverageReportPackageCollectionResponse represents the paginated response for packages in a coverage report
@@ -47,11 +70,46 @@ func (r *CoverageReportPackageCollectionResponse) SetMetadata(meta ResponseMetad
 	r.Links = links
 }
 
+// CoverageReportComparisonResponse represents the changes from a coverage report to another report, such as the
+// report of the manifest uploaded in the next sprint
+type CoverageReportComparisonResponse struct {
+	UUID            string                          `json:"uuid"`             // UUID of the coverage report
+	OtherUUID       string                          `json:"other_uuid"`       // UUID of the coverage report compared to
+	NewlyCovered    []CoverageReportPackageResponse `json:"newly_covered"`    // Packages of the other report that are covered, and were not covered or not in the report
+	NewlyUncovered  []CoverageReportPackageResponse `json:"newly_uncovered"`  // Packages of the other report that are not covered, and were covered or not in the report
+	VersionChanges  []CoverageReportVersionChange   `json:"version_changes"`  // Packages whose version differs between the reports
+	EcosystemDeltas []EcosystemCoverageSummary      `json:"ecosystem_deltas"` // Per-ecosystem change of the counts, from the report to the other report
+}
+
+// CoverageReportVersionChange represents a package whose version differs between two coverage reports
+type CoverageReportVersionChange struct {
+	Name         string `json:"name"`                // Package name
+	Namespace    string `json:"namespace,omitempty"` // Package namespace
+	Ecosystem    string `json:"ecosystem"`           // Ecosystem of the package
+	Version      string `json:"version"`             // Version in the coverage report
+	OtherVersion string `json:"other_version"`       // Version in the coverage report compared to
+	Covered      bool   `json:"covered"`             // Whether the package is covered in the coverage report
+	OtherCovered bool   `json:"other_covered"`       // Whether the package is covered in the coverage report compared to
+}
+
 // ListCoverageReportPackagesRequest represents the request for listing packages in a coverage report
 type ListCoverageReportPackagesRequest struct {
-	Covered   *bool  `query:"covered"`   // Optional filter for coverage status (true = covered, false = not covered)
-	Ecosystem string `query:"ecosystem"` // Optional filter for ecosystem
-	Search    string `query:"search"`    // Optional filter for package name
+	Covered    *bool  `query:"covered"`    // Optional filter for coverage status (true = covered, false = not covered)
+	Ecosystem  string `query:"ecosystem"`  // Optional filter for ecosystem
+	Search     string `query:"search"`     // Optional filter for package name
+	Vulnerable *bool  `query:"vulnerable"` // Optional filter for covered packages affected by a known advisory at their version, approximated from its fixed versions
+}
+
+const (
+	CoverageReportExportFormatCSV       = "csv"
+	CoverageReportExportFormatJSON      = "json"
+	CoverageReportExportFormatCycloneDX = "cyclonedx"
+)
+
+// CoverageReportExportResponse represents a completed coverage report along with all of its packages
+type CoverageReportExportResponse struct {
+	Report   CoverageReportResponse          `json:"report"`   // Coverage report
+	Packages []CoverageReportPackageResponse `json:"packages"` // Packages of the report
 }
 
 // CreateCoverageReportRequest represents the request for creating a coverage report
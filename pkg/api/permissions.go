@@ -0,0 +1,19 @@
+package api
+
+// PermissionsLimit is the maximum number of repositories and templates to check permissions on in a single request
+const PermissionsLimit = 100
+
+// ResourcePermissions maps each verb of a resource, such as read or write, to whether the user is allowed to perform it
+type ResourcePermissions map[string]bool
+
+type PermissionsResponse struct {
+	Backend      string                         `json:"backend"`                // Service checking permissions: rbac, kessel or file, empty when permissions are not checked
+	Permissions  map[string]ResourcePermissions `json:"permissions"`            // Permissions of the user on each resource of the organization
+	Repositories []ObjectPermissions            `json:"repositories,omitempty"` // Permissions of the user on the requested repositories
+	Templates    []ObjectPermissions            `json:"templates,omitempty"`    // Permissions of the user on the requested templates
+}
+
+type ObjectPermissions struct {
+	UUID        string                         `json:"uuid"`        // UUID of the repository or template
+	Permissions map[string]ResourcePermissions `json:"permissions"` // Permissions of the user on the object, by resource
+}
@@ -64,6 +64,7 @@ type RepositoryRequest struct {
 	Snapshot               *bool     `json:"snapshot"`                                          // Enable snapshotting and hosting of this repository
 	ExtendedRelease        *string   `json:"-" swaggerignore:"true"`                            // Extended release type (eus, e4s)
 	ExtendedReleaseVersion *string   `json:"-" swaggerignore:"true"`                            // Extended release version (9.4, 9.6, etc.)
+	WorkspaceID            *string   `json:"workspace_id"`                                      // Workspace to create the repository in, defaults to the default workspace of the organization. Requires per object permissions
 }
 
 type RepositoryUpdateRequest struct {
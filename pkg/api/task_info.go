@@ -16,6 +16,13 @@ type TaskInfoResponse struct {
 	Dependents   []string `json:"dependents,omitempty"`   // UUIDs of child tasks
 }
 
+// TaskGraphResponse holds a task along with the tasks it waits on and the tasks waiting on it
+type TaskGraphResponse struct {
+	Task       TaskInfoResponse   `json:"task"`       // The requested task
+	Upstream   []TaskInfoResponse `json:"upstream"`   // Tasks the requested task depends on, directly or through other tasks
+	Downstream []TaskInfoResponse `json:"downstream"` // Tasks that depend on the requested task, directly or through other tasks
+}
+
 type TaskInfoCollectionResponse struct {
 	Data  []TaskInfoResponse `json:"data"`  // Requested Data
 	Meta  ResponseMetadata   `json:"meta"`  // Metadata about the request
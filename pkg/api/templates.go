@@ -21,32 +21,46 @@ type TemplateRequest struct {
 	OrgID                  *string        `json:"org_id" readonly:"true" swaggerignore:"true"`     // Organization ID of the owner
 	User                   *string        `json:"created_by" readonly:"true" swaggerignore:"true"` // User creating the template
 	UseLatest              *bool          `json:"use_latest"`                                      // Use latest snapshot for all repositories in the template
+	WorkspaceID            *string        `json:"workspace_id"`                                    // Workspace to create the template in, defaults to the default workspace of the organization. Requires per object permissions
 }
 
 type TemplateResponse struct {
-	UUID                    string             `json:"uuid" readonly:"true"`
-	Name                    string             `json:"name"`                                              // Name of the template
-	OrgID                   string             `json:"org_id"`                                            // Organization ID of the owner
-	Description             string             `json:"description"`                                       // Description of the template
-	Arch                    string             `json:"arch"`                                              // Architecture of the template
-	Version                 string             `json:"version"`                                           // Version of the template
-	ExtendedRelease         string             `json:"extended_release,omitempty"`                        // Extended release type (eus, e4s)
-	ExtendedReleaseVersion  string             `json:"extended_release_version,omitempty"`                // Extended release version (9.4, 9.6, etc.)
-	Date                    time.Time          `json:"date"`                                              // Latest date to include snapshots for
-	RepositoryUUIDS         []string           `json:"repository_uuids"`                                  // Repositories added to the template
-	Snapshots               []SnapshotResponse `json:"snapshots,omitempty" readonly:"true"`               // The list of snapshots in use by the template
-	ToBeDeletedSnapshots    []SnapshotResponse `json:"to_be_deleted_snapshots,omitempty" readonly:"true"` // List of snapshots used by this template which are going to be deleted soon
-	RHSMEnvironmentID       string             `json:"rhsm_environment_id"`                               // Environment ID used by subscription-manager and candlepin
-	CreatedBy               string             `json:"created_by"`                                        // User that created the template
-	LastUpdatedBy           string             `json:"last_updated_by"`                                   // User that most recently updated the template
-	CreatedAt               time.Time          `json:"created_at"`                                        // Datetime template was created
-	UpdatedAt               time.Time          `json:"updated_at"`                                        // Datetime template was last updated
-	DeletedAt               gorm.DeletedAt     `json:"-" swaggerignore:"true"`                            // Datetime template was deleted
-	UseLatest               bool               `json:"use_latest"`                                        // Use latest snapshot for all repositories in the template
-	LastUpdateSnapshotError string             `json:"last_update_snapshot_error"`                        // Error of last update_latest_snapshot task that updated the template
-	LastUpdateTaskUUID      string             `json:"last_update_task_uuid,omitempty"`                   // UUID of the last update_template_content task that updated the template
-	LastUpdateTask          *TaskInfoResponse  `json:"last_update_task,omitempty"`                        // Response of last update_template_content task that updated the template
-	RHSMEnvironmentCreated  bool               `json:"rhsm_environment_created" readonly:"true"`          // Whether the candlepin environment is created and systems can be added
+	UUID                     string             `json:"uuid" readonly:"true"`
+	Name                     string             `json:"name"`                                                  // Name of the template
+	OrgID                    string             `json:"org_id"`                                                // Organization ID of the owner
+	Description              string             `json:"description"`                                           // Description of the template
+	Arch                     string             `json:"arch"`                                                  // Architecture of the template
+	Version                  string             `json:"version"`                                               // Version of the template
+	ExtendedRelease          string             `json:"extended_release,omitempty"`                            // Extended release type (eus, e4s)
+	ExtendedReleaseVersion   string             `json:"extended_release_version,omitempty"`                    // Extended release version (9.4, 9.6, etc.)
+	Date                     time.Time          `json:"date"`                                                  // Latest date to include snapshots for
+	RepositoryUUIDS          []string           `json:"repository_uuids"`                                      // Repositories added to the template
+	Snapshots                []SnapshotResponse `json:"snapshots,omitempty" readonly:"true"`                   // The list of snapshots in use by the template
+	ToBeDeletedSnapshots     []SnapshotResponse `json:"to_be_deleted_snapshots,omitempty" readonly:"true"`     // List of snapshots used by this template which are going to be deleted soon
+	RHSMEnvironmentID        string             `json:"rhsm_environment_id"`                                   // Environment ID used by subscription-manager and candlepin
+	CreatedBy                string             `json:"created_by"`                                            // User that created the template
+	LastUpdatedBy            string             `json:"last_updated_by"`                                       // User that most recently updated the template
+	CreatedAt                time.Time          `json:"created_at"`                                            // Datetime template was created
+	UpdatedAt                time.Time          `json:"updated_at"`                                            // Datetime template was last updated
+	DeletedAt                gorm.DeletedAt     `json:"-" swaggerignore:"true"`                                // Datetime template was deleted
+	UseLatest                bool               `json:"use_latest"`                                            // Use latest snapshot for all repositories in the template
+	LastUpdateSnapshotError  string             `json:"last_update_snapshot_error"`                            // Error of last update_latest_snapshot task that updated the template
+	LastUpdateTaskUUID       string             `json:"last_update_task_uuid,omitempty"`                       // UUID of the last update_template_content task that updated the template
+	LastUpdateTask           *TaskInfoResponse  `json:"last_update_task,omitempty"`                            // Response of last update_template_content task that updated the template
+	RHSMEnvironmentCreated   bool               `json:"rhsm_environment_created" readonly:"true"`              // Whether the candlepin environment is created and systems can be added
+	AvailableErrataCounts    *ErrataCounts      `json:"available_errata_counts,omitempty" readonly:"true"`     // Errata in the latest snapshots of the repositories that the template does not include, counted periodically for templates not using the latest snapshots
+	AvailableErrataCountedAt *time.Time         `json:"available_errata_counted_at,omitempty" readonly:"true"` // Datetime the available errata were last counted
+}
+
+// ErrataCounts are the numbers of errata of each type
+type ErrataCounts struct {
+	Security    int64 `json:"security"`    // Number of security errata
+	Bugfix      int64 `json:"bugfix"`      // Number of bug fix errata
+	Enhancement int64 `json:"enhancement"` // Number of enhancement errata
+}
+
+func (c ErrataCounts) Total() int64 {
+	return c.Security + c.Bugfix + c.Enhancement
 }
 
 // We use a separate struct because version and arch cannot be updated
@@ -84,6 +98,7 @@ type TemplateFilterData struct {
 	RepositoryUUIDs        []string `json:"repository_uuids"`         // List templates that contain one or more of these Repositories
 	SnapshotUUIDs          []string `json:"snapshot_uuids"`           // List templates that contain one or more of these Snapshots
 	UseLatest              bool     `json:"use_latest"`               // List templates that have use_latest set to true
+	AllowedUUIDs           []string `json:"-" swaggerignore:"true"`   // Templates the user is restricted to, nil if not restricted
 }
 
 // Provides defaults if not provided during PUT request
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WebhookRequest struct {
+	Name       *string  `json:"name" validate:"required"`                        // Name of the webhook
+	URL        *string  `json:"url" validate:"required"`                         // URL events are posted to, it must use https
+	Secret     *string  `json:"secret" validate:"required"`                      // Secret used to sign the payloads with HMAC-SHA256, at least 16 characters long
+	EventTypes []string `json:"event_types" validate:"required"`                 // Event types the webhook subscribes to
+	Enabled    *bool    `json:"enabled"`                                         // Whether events are delivered to the webhook, defaults to true
+	OrgID      *string  `json:"org_id" readonly:"true" swaggerignore:"true"`     // Organization ID of the owner
+	User       *string  `json:"created_by" readonly:"true" swaggerignore:"true"` // User creating the webhook
+}
+
+type WebhookUpdateRequest struct {
+	Name       *string  `json:"name"`        // Name of the webhook
+	URL        *string  `json:"url"`         // URL events are posted to, it must use https
+	Secret     *string  `json:"secret"`      // Secret used to sign the payloads with HMAC-SHA256, at least 16 characters long
+	EventTypes []string `json:"event_types"` // Event types the webhook subscribes to
+	Enabled    *bool    `json:"enabled"`     // Whether events are delivered to the webhook
+}
+
+type WebhookResponse struct {
+	UUID       string    `json:"uuid" readonly:"true"`
+	Name       string    `json:"name"`        // Name of the webhook
+	URL        string    `json:"url"`         // URL events are posted to
+	EventTypes []string  `json:"event_types"` // Event types the webhook subscribes to
+	Enabled    bool      `json:"enabled"`     // Whether events are delivered to the webhook
+	CreatedBy  string    `json:"created_by"`  // User that created the webhook
+	CreatedAt  time.Time `json:"created_at"`  // Datetime the webhook was created
+	UpdatedAt  time.Time `json:"updated_at"`  // Datetime the webhook was last updated
+}
+
+type WebhookCollectionResponse struct {
+	Data  []WebhookResponse `json:"data"`  // Requested Data
+	Meta  ResponseMetadata  `json:"meta"`  // Metadata about the request
+	Links Links             `json:"links"` // Links to other pages of results
+}
+
+func (r *WebhookCollectionResponse) SetMetadata(meta ResponseMetadata, links Links) {
+	r.Meta = meta
+	r.Links = links
+}
+
+type WebhookDeliveryResponse struct {
+	UUID          string          `json:"uuid" readonly:"true"`
+	WebhookUUID   string          `json:"webhook_uuid"`              // UUID of the webhook
+	EventType     string          `json:"event_type"`                // Type of the delivered event
+	Payload       json.RawMessage `json:"payload"`                   // Body posted to the webhook
+	Status        string          `json:"status"`                    // Status of the delivery: pending, succeeded or failed
+	Attempts      int             `json:"attempts"`                  // Number of delivery attempts
+	ResponseCode  *int            `json:"response_code,omitempty"`   // HTTP status returned by the last attempt
+	Error         string          `json:"error,omitempty"`           // Error of the last attempt
+	NextAttemptAt *time.Time      `json:"next_attempt_at,omitempty"` // Datetime of the next attempt of a pending delivery
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty"`    // Datetime the delivery succeeded
+	CreatedAt     time.Time       `json:"created_at"`                // Datetime the event was recorded
+}
+
+type WebhookDeliveryCollectionResponse struct {
+	Data  []WebhookDeliveryResponse `json:"data"`  // Requested Data
+	Meta  ResponseMetadata          `json:"meta"`  // Metadata about the request
+	Links Links                     `json:"links"` // Links to other pages of results
+}
+
+func (r *WebhookDeliveryCollectionResponse) SetMetadata(meta ResponseMetadata, links Links) {
+	r.Meta = meta
+	r.Links = links
+}
+
+// WebhookPayload is the body posted to webhooks
+type WebhookPayload struct {
+	ID        string    `json:"id"`         // UUID of the delivery, the same for every attempt
+	EventType string    `json:"event_type"` // Type of the event
+	OrgID     string    `json:"org_id"`     // Organization ID the event belongs to
+	Timestamp time.Time `json:"timestamp"`  // Datetime the event happened
+	Data      any       `json:"data"`       // The repository, snapshot or template the event is about
+}
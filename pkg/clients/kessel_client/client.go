@@ -3,6 +3,7 @@ package kessel_client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -16,12 +17,38 @@ import (
 	"github.com/redhatinsights/platform-go-middlewares/v2/identity"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
+// ReporterType is the reporter of the resources registered by content sources
+const ReporterType = "content_sources"
+
+// Resource types registered by content sources
+const (
+	ResourceTypeRepository = "repository"
+	ResourceTypeTemplate   = "template"
+)
+
+// listResourcesPageSize is the number of resources requested per page when listing resources
+const listResourcesPageSize = 1000
+
 type KesselClient interface {
 	GetDefaultWorkspaceID(ctx context.Context, orgID string) (string, int, error)
 	CheckRead(ctx context.Context, workspaceID string, permission string) (bool, error)
 	CheckWrite(ctx context.Context, workspaceID string, permission string) (bool, error)
+	CheckResourceRead(ctx context.Context, resourceType string, resourceID string, permission string) (bool, error)
+	CheckResourceWrite(ctx context.Context, resourceType string, resourceID string, permission string) (bool, error)
+	ListResources(ctx context.Context, resourceType string, permission string) ([]string, error)
+	ReportResource(ctx context.Context, resource Resource) error
+	DeleteResource(ctx context.Context, resourceType string, resourceID string) error
+}
+
+// Resource is a repository or template registered in a workspace, permissions on it are inherited from the workspace
+type Resource struct {
+	Type        string
+	ID          string
+	WorkspaceID string
+	APIHref     string
 }
 
 type kesselClientImpl struct {
@@ -116,10 +143,34 @@ func (k *kesselClientImpl) GetDefaultWorkspaceID(ctx context.Context, orgID stri
 
 // CheckRead checks if the user with the given workspaceID has the given permission
 func (k *kesselClientImpl) CheckRead(ctx context.Context, workspaceID string, permission string) (bool, error) {
+	log.Debug().Msgf("[Kessel] CheckRead: workspaceID %v", workspaceID)
+	return k.check(ctx, workspaceReference(workspaceID), permission)
+}
+
+// CheckWrite checks if the user with the given workspaceID has the given permission
+func (k *kesselClientImpl) CheckWrite(ctx context.Context, workspaceID string, permission string) (bool, error) {
+	log.Debug().Msgf("[Kessel] CheckWrite: workspaceID %v", workspaceID)
+	return k.checkForUpdate(ctx, workspaceReference(workspaceID), permission)
+}
+
+// CheckResourceRead checks if the user has the given permission on a single resource
+func (k *kesselClientImpl) CheckResourceRead(ctx context.Context, resourceType string, resourceID string, permission string) (bool, error) {
+	log.Debug().Msgf("[Kessel] CheckResourceRead: %v %v", resourceType, resourceID)
+	return k.check(ctx, resourceReference(resourceType, resourceID), permission)
+}
+
+// CheckResourceWrite checks if the user has the given permission on a single resource, with a fully up-to-date view of
+// the permissions
+func (k *kesselClientImpl) CheckResourceWrite(ctx context.Context, resourceType string, resourceID string, permission string) (bool, error) {
+	log.Debug().Msgf("[Kessel] CheckResourceWrite: %v %v", resourceType, resourceID)
+	return k.checkForUpdate(ctx, resourceReference(resourceType, resourceID), permission)
+}
+
+func (k *kesselClientImpl) check(ctx context.Context, object *v1beta2.ResourceReference, permission string) (bool, error) {
 	reqCtx, cancel := context.WithTimeout(ctx, k.timeout)
 	defer cancel()
 
-	object, subject, err := k.buildRequestObjects(ctx, workspaceID)
+	subject, err := k.buildSubject(ctx)
 	if err != nil {
 		return false, fmt.Errorf("error building reference objects: %w", err)
 	}
@@ -136,22 +187,20 @@ func (k *kesselClientImpl) CheckRead(ctx context.Context, workspaceID string, pe
 		Subject:  subject,
 	}
 
-	log.Debug().Msgf("[Kessel] CheckRead: workspaceID %v", workspaceID)
 	resp, err := inventoryClient.Check(reqCtx, req)
 	if err != nil {
 		return false, fmt.Errorf("kessel permission check failed: %w", err)
 	}
-	log.Debug().Msgf("[Kessel] CheckRead resp: %v", resp.String())
+	log.Debug().Msgf("[Kessel] Check resp: %v", resp.String())
 
 	return resp.GetAllowed() == v1beta2.Allowed_ALLOWED_TRUE, nil
 }
 
-// CheckWrite checks if the user with the given workspaceID has the given permission
-func (k *kesselClientImpl) CheckWrite(ctx context.Context, workspaceID string, permission string) (bool, error) {
+func (k *kesselClientImpl) checkForUpdate(ctx context.Context, object *v1beta2.ResourceReference, permission string) (bool, error) {
 	reqCtx, cancel := context.WithTimeout(ctx, k.timeout)
 	defer cancel()
 
-	object, subject, err := k.buildRequestObjects(ctx, workspaceID)
+	subject, err := k.buildSubject(ctx)
 	if err != nil {
 		return false, fmt.Errorf("error building reference objects: %w", err)
 	}
@@ -168,35 +217,160 @@ func (k *kesselClientImpl) CheckWrite(ctx context.Context, workspaceID string, p
 		Subject:  subject,
 	}
 
-	log.Debug().Msgf("[Kessel] CheckWrite: workspaceID %v", workspaceID)
 	resp, err := inventoryClient.CheckForUpdate(reqCtx, req)
 	if err != nil {
 		return false, fmt.Errorf("kessel permission check failed: %w", err)
 	}
-	log.Debug().Msgf("[Kessel] CheckWrite resp: %v", resp.String())
+	log.Debug().Msgf("[Kessel] CheckForUpdate resp: %v", resp.String())
 
 	return resp.GetAllowed() == v1beta2.Allowed_ALLOWED_TRUE, nil
 }
 
-// buildCheckRequest returns the objects need to make a CheckRequest or CheckForUpdateRequest call
-func (k *kesselClientImpl) buildRequestObjects(ctx context.Context, workspaceID string) (object *v1beta2.ResourceReference, subject *v1beta2.SubjectReference, err error) {
-	id := identity.GetIdentity(ctx)
+// ListResources returns the IDs of the resources of the given type the user has the given permission on
+func (k *kesselClientImpl) ListResources(ctx context.Context, resourceType string, permission string) ([]string, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, k.timeout)
+	defer cancel()
+
+	subject, err := k.buildSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error building reference objects: %w", err)
+	}
+
+	inventoryClient, conn, err := k.buildGRPCConnection()
+	if err != nil {
+		return nil, fmt.Errorf("error building inventory client: %w", err)
+	}
+	defer conn.Close()
+
+	reporterType := ReporterType
+	ids := []string{}
+	var continuationToken *string
+	for {
+		stream, err := inventoryClient.StreamedListObjects(reqCtx, &v1beta2.StreamedListObjectsRequest{
+			ObjectType: &v1beta2.RepresentationType{ResourceType: resourceType, ReporterType: &reporterType},
+			Relation:   permission,
+			Subject:    subject,
+			Pagination: &v1beta2.RequestPagination{Limit: listResourcesPageSize, ContinuationToken: continuationToken},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("kessel list objects failed: %w", err)
+		}
+
+		count := 0
+		continuationToken = nil
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("kessel list objects failed: %w", err)
+			}
+			ids = append(ids, resp.GetObject().GetResourceId())
+			count++
+			if token := resp.GetPagination().GetContinuationToken(); token != "" {
+				continuationToken = &token
+			}
+		}
+		if count < listResourcesPageSize || continuationToken == nil {
+			break
+		}
+	}
+	log.Debug().Msgf("[Kessel] ListResources: %v %v resources", len(ids), resourceType)
+	return ids, nil
+}
 
-	// Create the authorization check request
-	object = &v1beta2.ResourceReference{
+// ReportResource registers the resource in its workspace, or moves it to it if it is already registered
+func (k *kesselClientImpl) ReportResource(ctx context.Context, resource Resource) error {
+	reqCtx, cancel := context.WithTimeout(ctx, k.timeout)
+	defer cancel()
+
+	common, err := structpb.NewStruct(map[string]any{"workspace_id": resource.WorkspaceID})
+	if err != nil {
+		return fmt.Errorf("error building resource representation: %w", err)
+	}
+	reporter, err := structpb.NewStruct(map[string]any{})
+	if err != nil {
+		return fmt.Errorf("error building resource representation: %w", err)
+	}
+
+	inventoryClient, conn, err := k.buildGRPCConnection()
+	if err != nil {
+		return fmt.Errorf("error building inventory client: %w", err)
+	}
+	defer conn.Close()
+
+	_, err = inventoryClient.ReportResource(reqCtx, &v1beta2.ReportResourceRequest{
+		Type:               resource.Type,
+		ReporterType:       ReporterType,
+		ReporterInstanceId: ReporterType,
+		Representations: &v1beta2.ResourceRepresentations{
+			Metadata: &v1beta2.RepresentationMetadata{
+				LocalResourceId: resource.ID,
+				ApiHref:         resource.APIHref,
+			},
+			Common:   common,
+			Reporter: reporter,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("kessel report resource failed: %w", err)
+	}
+	log.Debug().Msgf("[Kessel] ReportResource: %v %v in workspace %v", resource.Type, resource.ID, resource.WorkspaceID)
+	return nil
+}
+
+// DeleteResource unregisters the resource
+func (k *kesselClientImpl) DeleteResource(ctx context.Context, resourceType string, resourceID string) error {
+	reqCtx, cancel := context.WithTimeout(ctx, k.timeout)
+	defer cancel()
+
+	inventoryClient, conn, err := k.buildGRPCConnection()
+	if err != nil {
+		return fmt.Errorf("error building inventory client: %w", err)
+	}
+	defer conn.Close()
+
+	_, err = inventoryClient.DeleteResource(reqCtx, &v1beta2.DeleteResourceRequest{
+		Reference: resourceReference(resourceType, resourceID),
+	})
+	if err != nil {
+		return fmt.Errorf("kessel delete resource failed: %w", err)
+	}
+	log.Debug().Msgf("[Kessel] DeleteResource: %v %v", resourceType, resourceID)
+	return nil
+}
+
+func workspaceReference(workspaceID string) *v1beta2.ResourceReference {
+	return &v1beta2.ResourceReference{
 		ResourceType: "workspace",
 		ResourceId:   workspaceID,
 		Reporter: &v1beta2.ReporterReference{
 			Type: "rbac",
 		},
 	}
+}
+
+func resourceReference(resourceType string, resourceID string) *v1beta2.ResourceReference {
+	return &v1beta2.ResourceReference{
+		ResourceType: resourceType,
+		ResourceId:   resourceID,
+		Reporter: &v1beta2.ReporterReference{
+			Type: ReporterType,
+		},
+	}
+}
+
+// buildSubject returns the user or service account of the request, as the subject of a check or list request
+func (k *kesselClientImpl) buildSubject(ctx context.Context) (*v1beta2.SubjectReference, error) {
+	id := identity.GetIdentity(ctx)
 
 	userID, err := extractUserID(id)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error extracting user ID: %w", err)
+		return nil, fmt.Errorf("error extracting user ID: %w", err)
 	}
 
-	subject = &v1beta2.SubjectReference{
+	return &v1beta2.SubjectReference{
 		Resource: &v1beta2.ResourceReference{
 			ResourceType: "principal",
 			ResourceId:   fmt.Sprintf("redhat/%s", userID),
@@ -204,9 +378,7 @@ func (k *kesselClientImpl) buildRequestObjects(ctx context.Context, workspaceID
 				Type: "rbac",
 			},
 		},
-	}
-
-	return object, subject, nil
+	}, nil
 }
 
 func (k *kesselClientImpl) buildGRPCConnection() (v1beta2.KesselInventoryServiceClient, *grpc.ClientConn, error) {
@@ -39,6 +39,8 @@ type Configuration struct {
 	NotificationsProducer *NotificationsKafkaProducer
 	TemplateEventClient   cloudevents.Client `mapstructure:"template_event_client"`
 	Tasking               Tasking            `mapstructure:"tasking"`
+	Webhooks              Webhooks           `mapstructure:"webhooks"`
+	Outbox                Outbox             `mapstructure:"outbox"`
 	Features              FeatureSet         `mapstructure:"features"`
 }
 
@@ -46,6 +48,7 @@ type Clients struct {
 	RbacEnabled    bool           `mapstructure:"rbac_enabled"`
 	RbacBaseUrl    string         `mapstructure:"rbac_base_url"`
 	RbacTimeout    int            `mapstructure:"rbac_timeout"`
+	RbacPolicyFile string         `mapstructure:"rbac_policy_file"` // Policy file to read permissions from, instead of the RBAC service
 	Kessel         Kessel         `mapstructure:"kessel"`
 	Pulp           Pulp           `mapstructure:"pulp"`
 	Lightwell      Lightwell      `mapstructure:"lightwell"`
@@ -54,6 +57,11 @@ type Clients struct {
 	FeatureService FeatureService `mapstructure:"feature_service"`
 	PulpLogParser  PulpLogParser  `mapstructure:"pulp_log_parser"`
 	Roadmap        Roadmap        `mapstructure:"roadmap"`
+
+	// RbacWriteFallback allows deleting and publishing snapshots, deleting repositories, removing content and reading
+	// the audit log with the write permission on repositories, for roles that do not grant their permissions yet.
+	// Temporary migration switch, to be removed after 2027-01-31 once the rbac-config roles grant the new permissions.
+	RbacWriteFallback bool `mapstructure:"rbac_write_fallback"`
 }
 
 type Mocks struct {
@@ -77,6 +85,7 @@ type FeatureSet struct {
 	Snapshots                Feature
 	AdminTasks               Feature `mapstructure:"admin_tasks"`
 	Kessel                   Feature `mapstructure:"kessel"`
+	KesselObjectPermissions  Feature `mapstructure:"kessel_object_permissions"` // Check permissions on single repositories and templates, requires kessel
 	ExtendedReleaseRepos     Feature `mapstructure:"extended_release_repos"`
 	Lightwell                Feature `mapstructure:"lightwell"`
 	LightwellNotifications   Feature `mapstructure:"lightwell_notifications"`
@@ -184,6 +193,40 @@ type Tasking struct {
 	WorkerCount         int           `mapstructure:"worker_count"`
 	RetryWaitUpperBound time.Duration `mapstructure:"retry_wait_upper_bound"`
 	PoolLimit           int           `mapstructure:"pool_limit"`
+	// WorkerPools dedicate workers to groups of task types. Task types not listed
+	// in any pool are processed by WorkerCount shared workers.
+	WorkerPools []TaskWorkerPool `mapstructure:"worker_pools"`
+}
+
+type TaskWorkerPool struct {
+	Name        string   `mapstructure:"name"`
+	Types       []string `mapstructure:"types"`
+	WorkerCount int      `mapstructure:"worker_count"`
+}
+
+type Webhooks struct {
+	// AllowHTTP permits webhook urls without TLS, only meant for development
+	AllowHTTP bool `mapstructure:"allow_http"`
+	// AllowPrivateNetworks permits webhook urls on loopback, private and link-local addresses, only meant for development
+	AllowPrivateNetworks bool `mapstructure:"allow_private_networks"`
+	// SecretKey is a base64 encoded 32 byte key encrypting the webhook secrets stored in the database.
+	// Secrets are stored as is when it is empty.
+	SecretKey    string        `mapstructure:"secret_key"`
+	Timeout      time.Duration `mapstructure:"timeout"`
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	MaxAttempts  int           `mapstructure:"max_attempts"`
+	// RetryWait is doubled after every failed attempt
+	RetryWait time.Duration `mapstructure:"retry_wait"`
+}
+
+// Outbox configures the relay sending the events recorded in the outbox
+type Outbox struct {
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	MaxAttempts  int           `mapstructure:"max_attempts"`
+	// RetryWait is doubled after every failed attempt
+	RetryWait time.Duration `mapstructure:"retry_wait"`
+	// RetainDaysLimit is how long sent and failed events are kept before the cleanup deletes them
+	RetainDaysLimit int `mapstructure:"retain_days_limit"`
 }
 
 type Database struct {
@@ -255,12 +298,15 @@ type Options struct {
 	// If this is encountered (and clowder is used), it will prepend the envName from clowder
 	ExternalURL                  string   `mapstructure:"external_url"`
 	SnapshotRetainDaysLimit      int      `mapstructure:"snapshot_retain_days_limit"`
+	AuditEventRetainDaysLimit    int      `mapstructure:"audit_event_retain_days_limit"`
 	FeatureFilter                []string `mapstructure:"feature_filter"` // Used to control which repos are imported based on feature name
 	EntitleAll                   bool     `mapstructure:"entitle_all"`    // Used in ephemeral to allow access to all layered repos
 	InternalUser                 string   `mapstructure:"internal_user"`
 	LoadLightwellDemo            bool     `mapstructure:"load_lightwell_demo"`
 	SeedLightwell                bool     `mapstructure:"seed_lightwell"`
 	SeedLightwellCoverageReports bool     `mapstructure:"seed_lightwell_coverage_reports"`
+	// Number of consecutive failed snapshots of a repository that triggers a notification, 0 disables it
+	SnapshotFailureNotificationThreshold int `mapstructure:"snapshot_failure_notification_threshold"`
 }
 
 type Metrics struct {
@@ -334,10 +380,12 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("options.feature_filter", featureFilter)
 	v.SetDefault("options.external_url", "http://pulp.content:8000")
 	v.SetDefault("options.snapshot_retain_days_limit", 365)
+	v.SetDefault("options.audit_event_retain_days_limit", 365)
 	v.SetDefault("options.internal_user", "")
 	v.SetDefault("options.load_lightwell_demo", true)
 	v.SetDefault("options.seed_lightwell", false)
 	v.SetDefault("options.seed_lightwell_coverage_reports", false)
+	v.SetDefault("options.snapshot_failure_notification_threshold", 3)
 	v.SetDefault("logging.level", "info")
 	v.SetDefault("logging.metrics_level", "error")
 	v.SetDefault("logging.db_level", "")
@@ -349,6 +397,8 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("clients.rbac_enabled", true)
 	v.SetDefault("clients.rbac_base_url", "http://rbac-service:8000/api/rbac/v1")
 	v.SetDefault("clients.rbac_timeout", 30)
+	v.SetDefault("clients.rbac_policy_file", "")
+	v.SetDefault("clients.rbac_write_fallback", true)
 	v.SetDefault("clients.kessel.server", "")
 	v.SetDefault("clients.kessel.auth.enabled", false)
 	v.SetDefault("clients.kessel.auth.client_id", "")
@@ -437,6 +487,19 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("tasking.pgx_logging", true)
 	v.SetDefault("tasking.retry_wait_upper_bound", time.Hour*12)
 	v.SetDefault("tasking.pool_limit", 20)
+	v.SetDefault("tasking.worker_pools", nil)
+
+	v.SetDefault("webhooks.allow_http", false)
+	v.SetDefault("webhooks.allow_private_networks", false)
+	v.SetDefault("webhooks.secret_key", "")
+	v.SetDefault("webhooks.timeout", 10*time.Second)
+	v.SetDefault("webhooks.poll_interval", 10*time.Second)
+	v.SetDefault("webhooks.max_attempts", 6)
+	v.SetDefault("webhooks.retry_wait", time.Minute)
+	v.SetDefault("outbox.poll_interval", 5*time.Second)
+	v.SetDefault("outbox.max_attempts", 10)
+	v.SetDefault("outbox.retry_wait", 30*time.Second)
+	v.SetDefault("outbox.retain_days_limit", 14)
 
 	v.SetDefault("features.snapshots.enabled", false)
 	v.SetDefault("features.snapshots.accounts", nil)
@@ -451,6 +514,10 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("features.kessel.accounts", nil)
 	v.SetDefault("features.kessel.organizations", nil)
 	v.SetDefault("features.kessel.users", nil)
+	v.SetDefault("features.kessel_object_permissions.enabled", false)
+	v.SetDefault("features.kessel_object_permissions.accounts", nil)
+	v.SetDefault("features.kessel_object_permissions.organizations", nil)
+	v.SetDefault("features.kessel_object_permissions.users", nil)
 	v.SetDefault("features.lightwell.enabled", false)
 	v.SetDefault("features.lightwell_notifications.enabled", false)
 	v.SetDefault("features.admin_partner_repositories.enabled", false)
@@ -12,6 +12,7 @@ const (
 	UpdateLatestSnapshotTask      = "update-latest-snapshot"      // Task to update templates to use the latest snapshot of a repository
 	BulkRemoveRpmsTask            = "bulk-remove-rpms"            // Task to remove RPMs from an upload repository in pulp
 	UpdateSnapshotPublishedTask   = "update-snapshot-published"   // Task to update the content guard on a snapshot distribution for publish/unpublish
+	RefreshCoverageReportTask     = "refresh-coverage-report"     // Task to match the packages of a coverage report against the current catalog
 )
 
 const (
@@ -34,6 +35,7 @@ var TaskTypes = []string{
 	UpdateLatestSnapshotTask,
 	BulkRemoveRpmsTask,
 	UpdateSnapshotPublishedTask,
+	RefreshCoverageReportTask,
 }
 
 var RequeueableTasks = []string{DeleteTemplatesTask, DeleteRepositorySnapshotsTask, UpdateTemplateContentTask, DeleteSnapshotsTask}
@@ -54,6 +56,7 @@ var TasksToCleanup = []string{
 	UpdateLatestSnapshotTask,
 	AddUploadsTask,
 	BulkRemoveRpmsTask,
+	RefreshCoverageReportTask,
 }
 
 // TasksToCleanupIfCompleted tasks that will get deleted if older than 10 days, only if status is completed
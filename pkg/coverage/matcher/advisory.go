@@ -0,0 +1,50 @@
+package matcher
+
+import (
+	"slices"
+	"strings"
+)
+
+// AdvisoryPackageName returns the lowercase name of a package in OSV advisories, such as org.springframework:spring-core
+// for Java or @types/node for npm
+func AdvisoryPackageName(pkg Package) string {
+	switch pkg.Ecosystem {
+	case EcosystemJava:
+		return strings.ToLower(pkg.Namespace) + ":" + strings.ToLower(pkg.Name)
+	case EcosystemNpm:
+		return strings.TrimPrefix(normalizeNpmName(pkg.Namespace, pkg.Name), EcosystemNpm+":")
+	default:
+		return strings.ToLower(pkg.Name)
+	}
+}
+
+// IsVulnerable reports whether a version is affected by an advisory fixed in the given versions. Fixes are backported
+// per minor line, so a version is affected when a fixed version is higher than it, unless its own line has a fix at or
+// below it. Advisories without a fixed version affect every version, and packages without a version none.
+// This is an approximation: advisories only record their fixed versions, not the version introducing the
+// vulnerability, so versions released before it are reported as affected.
+func IsVulnerable(ecosystem string, version string, fixedVersions []string) bool {
+	if version == "" {
+		return false
+	}
+	if len(fixedVersions) == 0 {
+		return true
+	}
+
+	fixedLater := false
+	for _, fixed := range fixedVersions {
+		if CompareVersions(ecosystem, version, fixed) < 0 {
+			fixedLater = true
+		} else if minorLine(fixed) == minorLine(version) {
+			return false
+		}
+	}
+	return fixedLater
+}
+
+// SortVersions sorts versions in ascending order per the rules of the ecosystem, removing duplicates
+func SortVersions(ecosystem string, versions []string) []string {
+	sorted := slices.Clone(versions)
+	slices.SortFunc(sorted, func(a, b string) int { return CompareVersions(ecosystem, a, b) })
+	return slices.CompactFunc(sorted, func(a, b string) bool { return CompareVersions(ecosystem, a, b) == 0 })
+}
@@ -0,0 +1,40 @@
+package matcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdvisoryPackageName(t *testing.T) {
+	assert.Equal(t, "org.springframework:spring-core", AdvisoryPackageName(Package{Ecosystem: EcosystemJava, Namespace: "org.springframework", Name: "spring-core"}))
+	assert.Equal(t, "@types/node", AdvisoryPackageName(Package{Ecosystem: EcosystemNpm, Namespace: "@types", Name: "node"}))
+	assert.Equal(t, "lodash", AdvisoryPackageName(Package{Ecosystem: EcosystemNpm, Name: "lodash"}))
+	assert.Equal(t, "django", AdvisoryPackageName(Package{Ecosystem: EcosystemPython, Name: "Django"}))
+}
+
+func TestIsVulnerable(t *testing.T) {
+	fixed := []string{"2.12.2", "2.17.1"}
+	tests := []struct {
+		version  string
+		fixed    []string
+		expected bool
+	}{
+		{version: "2.12.1", fixed: fixed, expected: true},
+		{version: "2.12.2", fixed: fixed, expected: false},
+		{version: "2.12.4", fixed: fixed, expected: false},
+		{version: "2.13.0", fixed: fixed, expected: true},
+		{version: "2.17.0", fixed: fixed, expected: true},
+		{version: "2.17.1", fixed: fixed, expected: false},
+		{version: "2.18.0", fixed: fixed, expected: false},
+		{version: "1.0.0", fixed: nil, expected: true},
+		{version: "", fixed: fixed, expected: false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, IsVulnerable(EcosystemJava, tt.version, tt.fixed), tt.version)
+	}
+}
+
+func TestSortVersions(t *testing.T) {
+	assert.Equal(t, []string{"1.9.0", "1.10.0", "2.0.0"}, SortVersions(EcosystemNpm, []string{"2.0.0", "1.10.0", "1.9.0", "2.0.0"}))
+}
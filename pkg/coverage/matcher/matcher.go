@@ -14,18 +14,29 @@ const (
 const (
 	EcosystemJava   = "Java"
 	EcosystemPython = "Python"
+	EcosystemNpm    = "npm"
 )
 
 type Package struct {
-	Ecosystem string
-	Name      string
-	Version   string
-	Namespace string
+	Ecosystem  string
+	Name       string
+	Version    string
+	Namespace  string
+	Constraint string // Version specifier of dependencies without a pinned version, matched by name only
 }
 
 type MatchResult struct {
 	Package
 	MatchStatus string
+	Suggestions VersionSuggestions // Catalog versions to move to, for partial matches
+}
+
+// VersionSuggestions are the catalog versions nearest to the version of a partially matched package. Only Latest is
+// set for packages without a version.
+type VersionSuggestions struct {
+	NearestPatch string // Lowest catalog version higher than the package version, with the same major and minor
+	SameMinor    string // Highest catalog version with the same major and minor as the package version
+	Latest       string // Highest catalog version
 }
 
 type MatchSummary struct {
@@ -47,17 +58,20 @@ type EcosystemSummary struct {
 
 // MatchCatalog compares manifest packages against a catalog and returns per-package match results and an aggregate summary.
 func MatchCatalog(catalog, parsedPackages []Package, snapshotAt time.Time) ([]MatchResult, MatchSummary) {
-	catalogedNames, catalogedNameVersions := buildIndex(catalog)
+	catalogedVersions, catalogedNameVersions := buildIndex(catalog)
 
 	results := make([]MatchResult, len(parsedPackages))
 	ecosystemSummaries := map[string]*EcosystemSummary{}
 
 	for i, pkg := range parsedPackages {
-		status := matchPackage(pkg, catalogedNames, catalogedNameVersions)
+		status := matchPackage(pkg, catalogedVersions, catalogedNameVersions)
 		results[i] = MatchResult{
 			Package:     pkg,
 			MatchStatus: status,
 		}
+		if status == MatchStatusPartial {
+			results[i].Suggestions = suggestVersions(pkg, catalogedVersions[normalizeKey(pkg)])
+		}
 
 		entry, exists := ecosystemSummaries[pkg.Ecosystem]
 		if !exists {
@@ -90,7 +104,7 @@ func MatchCatalog(catalog, parsedPackages []Package, snapshotAt time.Time) ([]Ma
 	return results, summary
 }
 
-func matchPackage(pkg Package, catalogedNames, catalogedNameVersions map[string]struct{}) string {
+func matchPackage(pkg Package, catalogedVersions map[string][]string, catalogedNameVersions map[string]struct{}) string {
 	nameKey := normalizeKey(pkg)
 
 	if pkg.Version != "" {
@@ -100,26 +114,54 @@ func matchPackage(pkg Package, catalogedNames, catalogedNameVersions map[string]
 		}
 	}
 
-	if _, found := catalogedNames[nameKey]; found {
+	if _, found := catalogedVersions[nameKey]; found {
 		return MatchStatusPartial
 	}
 
 	return MatchStatusNone
 }
 
-func buildIndex(catalog []Package) (catalogedNames, catalogedNameVersions map[string]struct{}) {
-	catalogedNames = make(map[string]struct{}, len(catalog))
+// buildIndex returns the versions of the cataloged packages by name, and the set of cataloged names and versions
+func buildIndex(catalog []Package) (catalogedVersions map[string][]string, catalogedNameVersions map[string]struct{}) {
+	catalogedVersions = make(map[string][]string, len(catalog))
 	catalogedNameVersions = make(map[string]struct{}, len(catalog))
 
 	for _, pkg := range catalog {
 		key := normalizeKey(pkg)
-		catalogedNames[key] = struct{}{}
+		if _, found := catalogedVersions[key]; !found {
+			catalogedVersions[key] = []string{}
+		}
 		if pkg.Version != "" {
+			if _, found := catalogedNameVersions[key+":"+pkg.Version]; !found {
+				catalogedVersions[key] = append(catalogedVersions[key], pkg.Version)
+			}
 			catalogedNameVersions[key+":"+pkg.Version] = struct{}{}
 		}
 	}
 
-	return catalogedNames, catalogedNameVersions
+	return catalogedVersions, catalogedNameVersions
+}
+
+// suggestVersions returns the cataloged versions nearest to the version of the package, ordered per its ecosystem
+func suggestVersions(pkg Package, versions []string) VersionSuggestions {
+	var suggestions VersionSuggestions
+	compare := func(a, b string) int { return CompareVersions(pkg.Ecosystem, a, b) }
+
+	for _, version := range versions {
+		if suggestions.Latest == "" || compare(version, suggestions.Latest) > 0 {
+			suggestions.Latest = version
+		}
+		if pkg.Version == "" || minorLine(version) != minorLine(pkg.Version) {
+			continue
+		}
+		if suggestions.SameMinor == "" || compare(version, suggestions.SameMinor) > 0 {
+			suggestions.SameMinor = version
+		}
+		if compare(version, pkg.Version) > 0 && (suggestions.NearestPatch == "" || compare(version, suggestions.NearestPatch) < 0) {
+			suggestions.NearestPatch = version
+		}
+	}
+	return suggestions
 }
 
 func normalizeKey(pkg Package) string {
@@ -128,11 +170,23 @@ func normalizeKey(pkg Package) string {
 		return normalizePythonName(pkg.Name)
 	case EcosystemJava:
 		return strings.ToLower(pkg.Namespace) + ":" + strings.ToLower(pkg.Name)
+	case EcosystemNpm:
+		return normalizeNpmName(pkg.Namespace, pkg.Name)
 	default:
 		return strings.ToLower(pkg.Ecosystem) + ":" + strings.ToLower(pkg.Name)
 	}
 }
 
+// normalizeNpmName returns the lowercase full name of an npm package, such as @babel/core. The catalog lists
+// unscoped packages under the "-" scope.
+func normalizeNpmName(scope, name string) string {
+	name = strings.ToLower(name)
+	if scope == "" || scope == "-" {
+		return EcosystemNpm + ":" + name
+	}
+	return EcosystemNpm + ":@" + strings.TrimPrefix(strings.ToLower(scope), "@") + "/" + name
+}
+
 // normalizePythonName applies PEP 503 normalization: lowercase, replace [-_.] with -.
 func normalizePythonName(name string) string {
 	var b strings.Builder
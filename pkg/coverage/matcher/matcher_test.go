@@ -20,25 +20,31 @@ var javaCatalog = []Package{
 	{Ecosystem: EcosystemJava, Name: "spring-core", Version: "6.1.0", Namespace: "org.springframework"},
 }
 
+var npmCatalog = []Package{
+	{Ecosystem: EcosystemNpm, Name: "react", Version: "18.2.0", Namespace: "-"},
+	{Ecosystem: EcosystemNpm, Name: "core", Version: "7.24.0", Namespace: "@babel"},
+}
+
 func TestMatchCatalog_EcosystemSummary(t *testing.T) {
-	catalog := append(pythonCatalog, javaCatalog...)
+	catalog := append(append(pythonCatalog, javaCatalog...), npmCatalog...)
 	manifest := []Package{
 		{Ecosystem: EcosystemPython, Name: "flask", Version: "3.0.3"},
 		{Ecosystem: EcosystemPython, Name: "numpy", Version: "2.0.0"},
 		{Ecosystem: EcosystemPython, Name: "custom-lib", Version: "0.1.0"},
 		{Ecosystem: EcosystemJava, Name: "spring-core", Version: "6.1.0", Namespace: "org.springframework"},
 		{Ecosystem: EcosystemJava, Name: "guava", Version: "32.0", Namespace: "com.google.guava"},
+		{Ecosystem: EcosystemNpm, Name: "react", Version: "18.2.0"},
 	}
 
 	_, summary := MatchCatalog(catalog, manifest, snapshotAt)
 
-	assert.Equal(t, 5, summary.Total)
-	assert.Equal(t, 2, summary.ExactMatches)
+	assert.Equal(t, 6, summary.Total)
+	assert.Equal(t, 3, summary.ExactMatches)
 	assert.Equal(t, 1, summary.PartialMatches)
 	assert.Equal(t, 2, summary.Unmatched)
 	assert.Equal(t, snapshotAt, summary.CatalogSnapshotAt)
 
-	assert.Len(t, summary.EcosystemCoverageSummary, 2)
+	assert.Len(t, summary.EcosystemCoverageSummary, 3)
 	summaryMap := map[string]EcosystemSummary{}
 	for _, e := range summary.EcosystemCoverageSummary {
 		summaryMap[e.Ecosystem] = e
@@ -55,6 +61,10 @@ func TestMatchCatalog_EcosystemSummary(t *testing.T) {
 	assert.Equal(t, 1, java.ExactMatches)
 	assert.Equal(t, 0, java.PartialMatches)
 	assert.Equal(t, 1, java.Unmatched)
+
+	npm := summaryMap[EcosystemNpm]
+	assert.Equal(t, 1, npm.Total)
+	assert.Equal(t, 1, npm.ExactMatches)
 }
 
 func TestMatchCatalog_ExactMatch(t *testing.T) {
@@ -101,6 +111,20 @@ func TestMatchCatalog_PartialMatch_NoVersion(t *testing.T) {
 	assert.Equal(t, 1, summary.PartialMatches)
 }
 
+func TestMatchCatalog_PythonConstraint(t *testing.T) {
+	manifest := []Package{
+		{Ecosystem: EcosystemPython, Name: "Ruamel.Yaml", Constraint: ">=0.18,<0.19"},
+		{Ecosystem: EcosystemPython, Name: "custom-lib", Constraint: ">=1.0"},
+	}
+
+	results, summary := MatchCatalog(pythonCatalog, manifest, snapshotAt)
+
+	assert.Equal(t, MatchStatusPartial, results[0].MatchStatus)
+	assert.Equal(t, ">=0.18,<0.19", results[0].Constraint)
+	assert.Equal(t, MatchStatusNone, results[1].MatchStatus)
+	assert.Equal(t, 1, summary.PartialMatches)
+}
+
 func TestMatchCatalog_JavaMatch(t *testing.T) {
 	manifest := []Package{
 		{Ecosystem: EcosystemJava, Name: "spring-core", Version: "6.1.0", Namespace: "org.springframework"},
@@ -118,6 +142,60 @@ func TestMatchCatalog_JavaMatch(t *testing.T) {
 	assert.Equal(t, MatchStatusNone, results[2].MatchStatus)
 }
 
+func TestMatchCatalog_NpmMatch(t *testing.T) {
+	manifest := []Package{
+		{Ecosystem: EcosystemNpm, Name: "core", Version: "7.24.0", Namespace: "@babel"},
+		{Ecosystem: EcosystemNpm, Name: "React", Version: "17.0.2"},
+		{Ecosystem: EcosystemNpm, Name: "core", Version: "7.24.0"},
+		{Ecosystem: EcosystemNpm, Name: "core", Version: "7.24.0", Namespace: "@angular"},
+	}
+
+	results, _ := MatchCatalog(npmCatalog, manifest, snapshotAt)
+
+	assert.Equal(t, MatchStatusExact, results[0].MatchStatus)
+	assert.Equal(t, MatchStatusPartial, results[1].MatchStatus)
+	assert.Equal(t, MatchStatusNone, results[2].MatchStatus)
+	assert.Equal(t, MatchStatusNone, results[3].MatchStatus)
+}
+
+func TestMatchCatalog_Suggestions(t *testing.T) {
+	catalog := []Package{
+		{Ecosystem: EcosystemJava, Name: "spring-core", Version: "5.3.31", Namespace: "org.springframework"},
+		{Ecosystem: EcosystemJava, Name: "spring-core", Version: "5.3.9", Namespace: "org.springframework"},
+		{Ecosystem: EcosystemJava, Name: "spring-core", Version: "5.3.27", Namespace: "org.springframework"},
+		{Ecosystem: EcosystemJava, Name: "spring-core", Version: "6.1.0-RC1", Namespace: "org.springframework"},
+		{Ecosystem: EcosystemJava, Name: "spring-core", Version: "6.0.13", Namespace: "org.springframework"},
+	}
+	manifest := []Package{
+		{Ecosystem: EcosystemJava, Name: "spring-core", Version: "5.3.20", Namespace: "org.springframework"},
+		{Ecosystem: EcosystemJava, Name: "spring-core", Version: "5.2.0", Namespace: "org.springframework"},
+		{Ecosystem: EcosystemJava, Name: "spring-core", Namespace: "org.springframework"},
+		{Ecosystem: EcosystemJava, Name: "spring-core", Version: "5.3.27", Namespace: "org.springframework"},
+	}
+
+	results, _ := MatchCatalog(catalog, manifest, snapshotAt)
+
+	assert.Equal(t, VersionSuggestions{NearestPatch: "5.3.27", SameMinor: "5.3.31", Latest: "6.1.0-RC1"}, results[0].Suggestions)
+	assert.Equal(t, VersionSuggestions{Latest: "6.1.0-RC1"}, results[1].Suggestions)
+	assert.Equal(t, VersionSuggestions{Latest: "6.1.0-RC1"}, results[2].Suggestions)
+	assert.Equal(t, MatchStatusExact, results[3].MatchStatus)
+	assert.Equal(t, VersionSuggestions{}, results[3].Suggestions)
+}
+
+func TestMatchCatalog_SuggestionsPEP440(t *testing.T) {
+	catalog := []Package{
+		{Ecosystem: EcosystemPython, Name: "django", Version: "4.2.11"},
+		{Ecosystem: EcosystemPython, Name: "django", Version: "4.2.2"},
+		{Ecosystem: EcosystemPython, Name: "django", Version: "5.0rc1"},
+		{Ecosystem: EcosystemPython, Name: "django", Version: "4.2.post1"},
+	}
+	manifest := []Package{{Ecosystem: EcosystemPython, Name: "Django", Version: "4.2"}}
+
+	results, _ := MatchCatalog(catalog, manifest, snapshotAt)
+
+	assert.Equal(t, VersionSuggestions{NearestPatch: "4.2.post1", SameMinor: "4.2.11", Latest: "5.0rc1"}, results[0].Suggestions)
+}
+
 func TestNormalizePythonName(t *testing.T) {
 	assert.Equal(t, "flask", normalizePythonName("Flask"))
 	assert.Equal(t, "ruamel-yaml", normalizePythonName("ruamel.yaml"))
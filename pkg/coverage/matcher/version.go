@@ -0,0 +1,469 @@
+package matcher
+
+import (
+	"cmp"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// CompareVersions orders two versions of a package per the rules of its ecosystem: Maven ComparableVersion for Java,
+// PEP 440 for Python and semantic versioning for npm
+func CompareVersions(ecosystem string, a string, b string) int {
+	switch ecosystem {
+	case EcosystemJava:
+		return compareMavenVersions(a, b)
+	case EcosystemPython:
+		pa, okA := parsePEP440(a)
+		pb, okB := parsePEP440(b)
+		if okA && okB {
+			return comparePEP440(pa, pb)
+		}
+	case EcosystemNpm:
+		return compareSemver(a, b)
+	}
+	return compareGenericVersions(a, b)
+}
+
+// minorLine returns the epoch, major and minor components of a version, such as 1.2 for 1.2.3 or 1.2-beta
+func minorLine(version string) string {
+	version = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(version)), "v")
+	epoch, release, found := strings.Cut(version, "!")
+	if !found {
+		epoch, release = "", version
+	}
+
+	components := []string{"0", "0"}
+	for i, segment := range strings.SplitN(release, ".", 3) {
+		if i >= len(components) {
+			break
+		}
+		digits := strings.TrimLeft(leadingDigits(segment), "0")
+		if digits != "" {
+			components[i] = digits
+		}
+		if len(leadingDigits(segment)) < len(segment) {
+			break
+		}
+	}
+
+	line := components[0] + "." + components[1]
+	if epoch != "" {
+		line = epoch + "!" + line
+	}
+	return line
+}
+
+func leadingDigits(s string) string {
+	if end := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' }); end >= 0 {
+		return s[:end]
+	}
+	return s
+}
+
+// compareNumeric compares two strings of digits of any length
+func compareNumeric(a string, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		return cmp.Compare(len(a), len(b))
+	}
+	return strings.Compare(a, b)
+}
+
+func isNumeric(s string) bool {
+	return s != "" && strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' }) < 0
+}
+
+// Maven ComparableVersion
+
+type mavenItemKind int
+
+const (
+	mavenInt mavenItemKind = iota
+	mavenString
+	mavenList
+)
+
+type mavenItem struct {
+	kind  mavenItemKind
+	value string
+	items []*mavenItem
+}
+
+// mavenQualifiers are the well known qualifiers in ascending order, the empty qualifier being the release
+var mavenQualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
+
+var mavenQualifierAliases = map[string]string{"ga": "", "final": "", "release": "", "cr": "rc"}
+
+func newMavenString(value string, followedByDigit bool) *mavenItem {
+	if followedByDigit && len(value) == 1 {
+		switch value {
+		case "a":
+			value = "alpha"
+		case "b":
+			value = "beta"
+		case "m":
+			value = "milestone"
+		}
+	}
+	if alias, ok := mavenQualifierAliases[value]; ok {
+		value = alias
+	}
+	return &mavenItem{kind: mavenString, value: value}
+}
+
+func (item *mavenItem) isNull() bool {
+	switch item.kind {
+	case mavenInt:
+		return strings.TrimLeft(item.value, "0") == ""
+	case mavenString:
+		return item.value == ""
+	default:
+		return len(item.items) == 0
+	}
+}
+
+// normalize removes the trailing null items of the list, such as the zeros of 1.0.0
+func (item *mavenItem) normalize() {
+	for i := len(item.items) - 1; i >= 0; i-- {
+		last := item.items[i]
+		if last.kind == mavenList {
+			last.normalize()
+		}
+		if last.isNull() {
+			item.items = slices.Delete(item.items, i, i+1)
+		} else if last.kind != mavenList {
+			break
+		}
+	}
+}
+
+// parseMavenVersion splits a version into items, a dash or a change between digits and letters starting a sub list
+func parseMavenVersion(version string) *mavenItem {
+	root := &mavenItem{kind: mavenList}
+	list := root
+	version = strings.ToLower(version)
+	isDigit := false
+	start := 0
+
+	addItem := func(end int) {
+		if end == start {
+			list.items = append(list.items, &mavenItem{kind: mavenInt, value: "0"})
+		} else if isDigit {
+			list.items = append(list.items, &mavenItem{kind: mavenInt, value: version[start:end]})
+		} else {
+			list.items = append(list.items, newMavenString(version[start:end], false))
+		}
+	}
+	startList := func() {
+		sub := &mavenItem{kind: mavenList}
+		list.items = append(list.items, sub)
+		list = sub
+	}
+
+	for i := 0; i < len(version); i++ {
+		c := version[i]
+		switch {
+		case c == '.':
+			addItem(i)
+			start = i + 1
+		case c == '-':
+			addItem(i)
+			start = i + 1
+			startList()
+		case c >= '0' && c <= '9':
+			if !isDigit && i > start {
+				list.items = append(list.items, newMavenString(version[start:i], true))
+				start = i
+				startList()
+			}
+			isDigit = true
+		default:
+			if isDigit && i > start {
+				addItem(i)
+				start = i
+				startList()
+			}
+			isDigit = false
+		}
+	}
+	if len(version) > start {
+		addItem(len(version))
+	}
+	root.normalize()
+	return root
+}
+
+func mavenQualifierKey(qualifier string) string {
+	if idx := slices.Index(mavenQualifiers, qualifier); idx >= 0 {
+		return strconv.Itoa(idx)
+	}
+	return strconv.Itoa(len(mavenQualifiers)) + "-" + qualifier
+}
+
+// compareMavenItems compares two items, b being nil when the other version has fewer items
+func compareMavenItems(a *mavenItem, b *mavenItem) int {
+	switch a.kind {
+	case mavenInt:
+		if b == nil {
+			if a.isNull() {
+				return 0
+			}
+			return 1
+		}
+		if b.kind == mavenInt {
+			return compareNumeric(a.value, b.value)
+		}
+		return 1
+	case mavenString:
+		if b == nil {
+			return strings.Compare(mavenQualifierKey(a.value), mavenQualifierKey(""))
+		}
+		if b.kind == mavenString {
+			return strings.Compare(mavenQualifierKey(a.value), mavenQualifierKey(b.value))
+		}
+		return -1
+	default:
+		if b == nil {
+			if len(a.items) == 0 {
+				return 0
+			}
+			return compareMavenItems(a.items[0], nil)
+		}
+		switch b.kind {
+		case mavenInt:
+			return -1
+		case mavenString:
+			return 1
+		}
+		for i := 0; i < max(len(a.items), len(b.items)); i++ {
+			var result int
+			switch {
+			case i >= len(a.items):
+				result = -compareMavenItems(b.items[i], nil)
+			case i >= len(b.items):
+				result = compareMavenItems(a.items[i], nil)
+			default:
+				result = compareMavenItems(a.items[i], b.items[i])
+			}
+			if result != 0 {
+				return result
+			}
+		}
+		return 0
+	}
+}
+
+func compareMavenVersions(a string, b string) int {
+	return compareMavenItems(parseMavenVersion(a), parseMavenVersion(b))
+}
+
+// PEP 440
+
+var pep440Pattern = regexp.MustCompile(`^v?(?:(\d+)!)?(\d+(?:\.\d+)*)` +
+	`(?:[-_.]?(a|b|c|rc|alpha|beta|pre|preview)[-_.]?(\d*))?` +
+	`(?:-(\d+)|[-_.]?(post|rev|r)[-_.]?(\d*))?` +
+	`(?:[-_.]?(dev)[-_.]?(\d*))?` +
+	`(?:\+[a-z0-9]+(?:[-_.][a-z0-9]+)*)?$`)
+
+type pep440Version struct {
+	epoch   int
+	release []int
+	pre     *[2]int // phase (0 alpha, 1 beta, 2 rc) and number
+	post    *int
+	dev     *int
+}
+
+func parsePEP440(version string) (pep440Version, bool) {
+	match := pep440Pattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(version)))
+	if match == nil {
+		return pep440Version{}, false
+	}
+	number := func(s string) int {
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+
+	v := pep440Version{epoch: number(match[1])}
+	for _, segment := range strings.Split(match[2], ".") {
+		v.release = append(v.release, number(segment))
+	}
+	for len(v.release) > 1 && v.release[len(v.release)-1] == 0 {
+		v.release = v.release[:len(v.release)-1]
+	}
+	if match[3] != "" {
+		phase := 2
+		switch match[3] {
+		case "a", "alpha":
+			phase = 0
+		case "b", "beta":
+			phase = 1
+		}
+		v.pre = &[2]int{phase, number(match[4])}
+	}
+	if match[5] != "" {
+		post := number(match[5])
+		v.post = &post
+	} else if match[6] != "" {
+		post := number(match[7])
+		v.post = &post
+	}
+	if match[8] != "" {
+		dev := number(match[9])
+		v.dev = &dev
+	}
+	return v, true
+}
+
+// comparePEP440 orders versions as 1.0.dev0 < 1.0a1.dev0 < 1.0a1 < 1.0 < 1.0.post1.dev0 < 1.0.post1
+func comparePEP440(a pep440Version, b pep440Version) int {
+	if result := cmp.Compare(a.epoch, b.epoch); result != 0 {
+		return result
+	}
+	if result := slices.Compare(a.release, b.release); result != 0 {
+		return result
+	}
+	// A development release without a pre-release comes before the pre-releases, a release after them
+	preKey := func(v pep440Version) [2]int {
+		switch {
+		case v.pre == nil && v.post == nil && v.dev != nil:
+			return [2]int{-1, 0}
+		case v.pre == nil:
+			return [2]int{3, 0}
+		default:
+			return *v.pre
+		}
+	}
+	preA, preB := preKey(a), preKey(b)
+	if result := slices.Compare(preA[:], preB[:]); result != 0 {
+		return result
+	}
+	postKey := func(v pep440Version) int {
+		if v.post == nil {
+			return -1
+		}
+		return *v.post
+	}
+	if result := cmp.Compare(postKey(a), postKey(b)); result != 0 {
+		return result
+	}
+	devKey := func(v pep440Version) int {
+		if v.dev == nil {
+			return math.MaxInt
+		}
+		return *v.dev
+	}
+	return cmp.Compare(devKey(a), devKey(b))
+}
+
+// Semantic versioning
+
+// compareSemver orders versions as 1.0.0-alpha < 1.0.0-alpha.1 < 1.0.0-beta < 1.0.0, ignoring build metadata
+func compareSemver(a string, b string) int {
+	split := func(version string) ([]string, []string) {
+		version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+		version, _, _ = strings.Cut(version, "+")
+		core, pre, _ := strings.Cut(version, "-")
+		var preIdentifiers []string
+		if pre != "" {
+			preIdentifiers = strings.Split(pre, ".")
+		}
+		return strings.Split(core, "."), preIdentifiers
+	}
+	coreA, preA := split(a)
+	coreB, preB := split(b)
+
+	for i := 0; i < max(len(coreA), len(coreB)); i++ {
+		partA, partB := "0", "0"
+		if i < len(coreA) {
+			partA = coreA[i]
+		}
+		if i < len(coreB) {
+			partB = coreB[i]
+		}
+		if result := compareIdentifiers(partA, partB); result != 0 {
+			return result
+		}
+	}
+
+	switch {
+	case len(preA) == 0 && len(preB) == 0:
+		return 0
+	case len(preA) == 0:
+		return 1
+	case len(preB) == 0:
+		return -1
+	}
+	for i := 0; i < min(len(preA), len(preB)); i++ {
+		if result := compareIdentifiers(preA[i], preB[i]); result != 0 {
+			return result
+		}
+	}
+	return cmp.Compare(len(preA), len(preB))
+}
+
+// compareIdentifiers compares numeric identifiers numerically, and before alphanumeric identifiers
+func compareIdentifiers(a string, b string) int {
+	numericA, numericB := isNumeric(a), isNumeric(b)
+	switch {
+	case numericA && numericB:
+		return compareNumeric(a, b)
+	case numericA:
+		return -1
+	case numericB:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// Other ecosystems
+
+// compareGenericVersions compares the numeric and alphabetic runs of two versions in turn, a number coming after
+// letters such as 1.0.1 after 1.0.beta
+func compareGenericVersions(a string, b string) int {
+	runsA, runsB := versionRuns(a), versionRuns(b)
+	for i := 0; i < min(len(runsA), len(runsB)); i++ {
+		numericA, numericB := isNumeric(runsA[i]), isNumeric(runsB[i])
+		var result int
+		switch {
+		case numericA && numericB:
+			result = compareNumeric(runsA[i], runsB[i])
+		case numericA:
+			result = 1
+		case numericB:
+			result = -1
+		default:
+			result = strings.Compare(strings.ToLower(runsA[i]), strings.ToLower(runsB[i]))
+		}
+		if result != 0 {
+			return result
+		}
+	}
+	return cmp.Compare(len(runsA), len(runsB))
+}
+
+// versionRuns splits a version into runs of digits and runs of letters, such as 1, 0, rc, 1 for 1.0-rc1
+func versionRuns(version string) []string {
+	var runs []string
+	var current strings.Builder
+	currentIsDigit := false
+	for _, r := range version {
+		isDigit := r >= '0' && r <= '9'
+		isLetter := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+		if current.Len() > 0 && (!(isDigit || isLetter) || isDigit != currentIsDigit) {
+			runs = append(runs, current.String())
+			current.Reset()
+		}
+		if isDigit || isLetter {
+			current.WriteRune(r)
+			currentIsDigit = isDigit
+		}
+	}
+	if current.Len() > 0 {
+		runs = append(runs, current.String())
+	}
+	return runs
+}
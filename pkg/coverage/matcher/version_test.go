@@ -0,0 +1,67 @@
+package matcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertAscending checks that each version is ordered before the following ones
+func assertAscending(t *testing.T, ecosystem string, versions []string) {
+	for i := range versions {
+		for j := range versions {
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			assert.Equal(t, expected, CompareVersions(ecosystem, versions[i], versions[j]), "%s %s", versions[i], versions[j])
+		}
+	}
+}
+
+func TestCompareVersions_Maven(t *testing.T) {
+	assertAscending(t, EcosystemJava, []string{
+		"1-alpha1", "1-alpha2", "1-beta1", "1-milestone1", "1-rc1", "1-SNAPSHOT", "1", "1-sp1", "1-abc", "1.0.1", "1.1",
+		"1.2", "1.10", "2.0.0-M1", "2.0.0", "10",
+	})
+	assert.Equal(t, 0, CompareVersions(EcosystemJava, "1.0.0", "1"))
+	assert.Equal(t, 0, CompareVersions(EcosystemJava, "1.0-ga", "1.0.final"))
+	assert.Equal(t, 0, CompareVersions(EcosystemJava, "1.0a1", "1.0-alpha-1"))
+	assert.Equal(t, 0, CompareVersions(EcosystemJava, "1.0-CR1", "1.0-RC1"))
+	assert.Equal(t, -1, CompareVersions(EcosystemJava, "5.3.20", "5.3.31"))
+	assert.Equal(t, 1, CompareVersions(EcosystemJava, "6.1.0", "6.1.0-RC1"))
+}
+
+func TestCompareVersions_PEP440(t *testing.T) {
+	assertAscending(t, EcosystemPython, []string{
+		"1.0.dev0", "1.0a1.dev0", "1.0a1", "1.0b2", "1.0rc1", "1.0", "1.0.post1.dev0", "1.0.post1", "1.0.1", "1.1",
+		"1.10", "1!0.1",
+	})
+	assert.Equal(t, 0, CompareVersions(EcosystemPython, "1.0", "1.0.0"))
+	assert.Equal(t, 0, CompareVersions(EcosystemPython, "1.0-alpha1", "1.0a1"))
+	assert.Equal(t, 0, CompareVersions(EcosystemPython, "1.0-1", "1.0.post1"))
+}
+
+func TestCompareVersions_Semver(t *testing.T) {
+	assertAscending(t, EcosystemNpm, []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11",
+		"1.0.0-rc.1", "1.0.0", "1.0.1", "1.2.0", "1.10.0", "2.0.0",
+	})
+	assert.Equal(t, 0, CompareVersions(EcosystemNpm, "1.0.0+build.1", "1.0.0"))
+}
+
+func TestCompareVersions_Generic(t *testing.T) {
+	assertAscending(t, "Unknown", []string{"1.0", "1.0.beta", "1.0.1", "1.2", "1.10"})
+}
+
+func TestMinorLine(t *testing.T) {
+	assert.Equal(t, "1.2", minorLine("1.2.3"))
+	assert.Equal(t, "1.2", minorLine("1.2"))
+	assert.Equal(t, "1.0", minorLine("1"))
+	assert.Equal(t, "1.2", minorLine("1.2-beta.1"))
+	assert.Equal(t, "1.2", minorLine("v1.2.3"))
+	assert.Equal(t, "1.0", minorLine("1.0a1"))
+	assert.Equal(t, "2!1.2", minorLine("2!1.2.3"))
+}
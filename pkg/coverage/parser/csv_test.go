@@ -44,7 +44,7 @@ CVE-2024-22262,pkg:maven/org.springframework/spring-web@6.1.5,Spring Web,6.1.5
 
 func TestParseCSV_SkipsUnsupportedEcosystems(t *testing.T) {
 	data := []byte(`vulnerability_id,packageurl,component_name
-CVE-001,pkg:npm/express@4.18.2,Express
+CVE-001,pkg:cargo/serde@1.0.197,Serde
 CVE-002,pkg:pypi/flask@3.0.3,Flask
 `)
 
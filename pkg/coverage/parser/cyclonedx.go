@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+)
+
+// cycloneDXComponent is a component of a CycloneDX BOM, in JSON or XML. Components may contain other components.
+type cycloneDXComponent struct {
+	Group      string               `json:"group" xml:"group"`
+	Name       string               `json:"name" xml:"name"`
+	Version    string               `json:"version" xml:"version"`
+	PURL       string               `json:"purl" xml:"purl"`
+	Components []cycloneDXComponent `json:"components" xml:"components>component"`
+}
+
+// cycloneDXBOM holds the components of a CycloneDX BOM. The component the BOM describes, in its metadata, is not
+// one of its dependencies and is ignored.
+type cycloneDXBOM struct {
+	Components []cycloneDXComponent `json:"components" xml:"components>component"`
+}
+
+func parseCycloneDXJSON(data []byte) ([]Package, error) {
+	var bom cycloneDXBOM
+	if err := json.Unmarshal(data, &bom); err != nil {
+		return nil, fmt.Errorf("CycloneDX JSON read error: %w", err)
+	}
+	return cycloneDXPackages(bom.Components), nil
+}
+
+func parseCycloneDXXML(data []byte) ([]Package, error) {
+	var bom cycloneDXBOM
+	if err := xml.Unmarshal(data, &bom); err != nil {
+		return nil, fmt.Errorf("CycloneDX XML read error: %w", err)
+	}
+	return cycloneDXPackages(bom.Components), nil
+}
+
+func cycloneDXPackages(components []cycloneDXComponent) []Package {
+	var packages []Package
+	for _, component := range components {
+		if pkg := componentPackage(component.PURL, component.Group, component.Name, component.Version); pkg != nil {
+			packages = append(packages, *pkg)
+		}
+		packages = append(packages, cycloneDXPackages(component.Components)...)
+	}
+	return packages
+}
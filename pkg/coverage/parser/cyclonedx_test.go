@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cycloneDXJSON = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "metadata": {"component": {"type": "application", "name": "my-app", "version": "1.0.0"}},
+  "components": [
+    {"type": "library", "group": "org.springframework", "name": "spring-core", "version": "5.3.20",
+     "purl": "pkg:maven/org.springframework/spring-core@5.3.20",
+     "components": [{"type": "library", "name": "flask", "version": "3.0.3", "purl": "pkg:pypi/flask@3.0.3"}]},
+    {"type": "library", "name": "react", "version": "18.2.0", "purl": "pkg:npm/react@18.2.0"},
+    {"type": "library", "group": "com.example", "name": "internal-lib", "version": "2.0"}
+  ]
+}`
+
+const cycloneDXXML = `<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.5" version="1">
+  <metadata>
+    <component type="application"><name>my-app</name><version>1.0.0</version></component>
+  </metadata>
+  <components>
+    <component type="library">
+      <group>org.springframework</group>
+      <name>spring-core</name>
+      <version>5.3.20</version>
+      <purl>pkg:maven/org.springframework/spring-core@5.3.20</purl>
+      <components>
+        <component type="library">
+          <name>flask</name>
+          <version>3.0.3</version>
+          <purl>pkg:pypi/flask@3.0.3</purl>
+        </component>
+      </components>
+    </component>
+    <component type="library">
+      <name>react</name>
+      <version>18.2.0</version>
+      <purl>pkg:npm/react@18.2.0</purl>
+    </component>
+    <component type="library">
+      <group>com.example</group>
+      <name>internal-lib</name>
+      <version>2.0</version>
+    </component>
+  </components>
+</bom>`
+
+var cycloneDXExpected = []Package{
+	{Ecosystem: EcosystemJava, Name: "spring-core", Version: "5.3.20", Namespace: "org.springframework"},
+	{Ecosystem: EcosystemPython, Name: "flask", Version: "3.0.3"},
+	{Ecosystem: EcosystemNpm, Name: "react", Version: "18.2.0"},
+	{Ecosystem: EcosystemUnknown, Name: "internal-lib", Version: "2.0", Namespace: "com.example"},
+}
+
+func TestParseCycloneDXJSON(t *testing.T) {
+	pkgs, err := parseCycloneDXJSON([]byte(cycloneDXJSON))
+	require.NoError(t, err)
+	assert.Equal(t, cycloneDXExpected, pkgs)
+}
+
+func TestParseCycloneDXXML(t *testing.T) {
+	pkgs, err := parseCycloneDXXML([]byte(cycloneDXXML))
+	require.NoError(t, err)
+	assert.Equal(t, cycloneDXExpected, pkgs)
+}
+
+func TestParseCycloneDX_Invalid(t *testing.T) {
+	_, err := parseCycloneDXJSON([]byte(`{"components": "nope"}`))
+	assert.Error(t, err)
+
+	_, err = parseCycloneDXXML([]byte(`<bom><components>`))
+	assert.Error(t, err)
+}
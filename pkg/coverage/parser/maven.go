@@ -0,0 +1,271 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// pomPropertyPattern matches the property references of a pom.xml file, such as ${spring.version}
+var pomPropertyPattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// pomMaxInterpolations bounds the resolution of properties referencing other properties
+const pomMaxInterpolations = 10
+
+type pomDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Scope      string `xml:"scope"`
+}
+
+type pomProperties map[string]string
+
+func (p *pomProperties) UnmarshalXML(d *xml.Decoder, _ xml.StartElement) error {
+	*p = pomProperties{}
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			var value string
+			if err := d.DecodeElement(&value, &t); err != nil {
+				return err
+			}
+			(*p)[t.Name.Local] = strings.TrimSpace(value)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+type pomProject struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Parent     struct {
+		GroupID string `xml:"groupId"`
+		Version string `xml:"version"`
+	} `xml:"parent"`
+	Properties           pomProperties   `xml:"properties"`
+	DependencyManagement []pomDependency `xml:"dependencyManagement>dependencies>dependency"`
+	Dependencies         []pomDependency `xml:"dependencies>dependency"`
+}
+
+// mavenPackage returns the package of a Maven artifact, the group ID being its namespace
+func mavenPackage(groupID string, artifactID string, version string) Package {
+	return Package{
+		Ecosystem: EcosystemJava,
+		Name:      artifactID,
+		Version:   version,
+		Namespace: groupID,
+	}
+}
+
+// parsePom reads the dependencies of a pom.xml file. Versions referencing properties, or managed in the
+// dependencyManagement section, are resolved within the file. Dependencies whose version is managed by a parent
+// or an imported BOM cannot be resolved and are returned as warnings.
+func parsePom(data []byte) ([]Package, []string, error) {
+	var project pomProject
+	if err := xml.Unmarshal(data, &project); err != nil {
+		return nil, nil, fmt.Errorf("pom.xml read error: %w", err)
+	}
+
+	properties := map[string]string{}
+	for key, value := range project.Properties {
+		properties[key] = value
+	}
+	groupID := project.GroupID
+	if groupID == "" {
+		groupID = project.Parent.GroupID
+	}
+	version := project.Version
+	if version == "" {
+		version = project.Parent.Version
+	}
+	for _, prefix := range []string{"project.", "pom.", ""} {
+		properties[prefix+"groupId"] = groupID
+		properties[prefix+"artifactId"] = project.ArtifactID
+		properties[prefix+"version"] = version
+	}
+	properties["project.parent.groupId"] = project.Parent.GroupID
+	properties["project.parent.version"] = project.Parent.Version
+
+	managed := map[string]string{}
+	for _, dependency := range project.DependencyManagement {
+		key := interpolatePom(dependency.GroupID, properties) + ":" + interpolatePom(dependency.ArtifactID, properties)
+		managed[key] = interpolatePom(dependency.Version, properties)
+	}
+
+	var packages []Package
+	var warnings []string
+	for _, dependency := range project.Dependencies {
+		depGroupID := interpolatePom(dependency.GroupID, properties)
+		depArtifactID := interpolatePom(dependency.ArtifactID, properties)
+		coordinates := depGroupID + ":" + depArtifactID
+		if depGroupID == "" || depArtifactID == "" || strings.Contains(coordinates, "${") {
+			warnings = append(warnings, fmt.Sprintf("pom.xml dependency %s: group ID or artifact ID could not be resolved", coordinates))
+			continue
+		}
+
+		depVersion := interpolatePom(dependency.Version, properties)
+		if depVersion == "" {
+			depVersion = managed[coordinates]
+		}
+		switch {
+		case depVersion == "":
+			warnings = append(warnings, fmt.Sprintf("pom.xml dependency %s: version is not managed in this file", coordinates))
+		case strings.Contains(depVersion, "${"):
+			warnings = append(warnings, fmt.Sprintf("pom.xml dependency %s: version %s could not be resolved", coordinates, depVersion))
+		default:
+			packages = append(packages, mavenPackage(depGroupID, depArtifactID, depVersion))
+		}
+	}
+	return packages, warnings, nil
+}
+
+// interpolatePom replaces the property references of a value, leaving unknown properties unresolved
+func interpolatePom(value string, properties map[string]string) string {
+	value = strings.TrimSpace(value)
+	for range pomMaxInterpolations {
+		if !strings.Contains(value, "${") {
+			break
+		}
+		resolved := pomPropertyPattern.ReplaceAllStringFunc(value, func(reference string) string {
+			if property, ok := properties[reference[2:len(reference)-1]]; ok && property != "" {
+				return property
+			}
+			return reference
+		})
+		if resolved == value {
+			break
+		}
+		value = resolved
+	}
+	return value
+}
+
+// parseGradleLockfile reads a gradle.lockfile, listing one group:artifact:version per line followed by the
+// configurations using it, such as com.google.guava:guava:31.1-jre=compileClasspath,runtimeClasspath
+func parseGradleLockfile(data []byte) ([]Package, []string, error) {
+	var packages []Package
+	var warnings []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		coordinates, _, _ := strings.Cut(line, "=")
+		// Configurations without dependencies are listed as empty=annotationProcessor
+		if coordinates == "empty" {
+			continue
+		}
+		parts := strings.Split(coordinates, ":")
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			warnings = append(warnings, fmt.Sprintf("gradle.lockfile entry %q could not be parsed", coordinates))
+			continue
+		}
+		packages = append(packages, mavenPackage(parts[0], parts[1], parts[2]))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("gradle.lockfile read error: %w", err)
+	}
+	return packages, warnings, nil
+}
+
+// parseMavenDependencyTree reads the output of mvn dependency:tree, such as
+//
+//	[INFO] com.example:my-app:jar:1.0.0
+//	[INFO] +- org.springframework:spring-core:jar:5.3.20:compile
+//	[INFO] |  \- org.springframework:spring-jcl:jar:5.3.20:compile
+//
+// The first artifact is the project itself and is skipped, as are the lines of the build log.
+func parseMavenDependencyTree(data []byte) ([]Package, []string, error) {
+	var packages []Package
+	var warnings []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		line, depth, ok := mavenDependencyTreeLine(scanner.Text())
+		if !ok || depth == 0 {
+			continue
+		}
+		// Remove annotations, such as (optional) or (version managed from 1.0)
+		coordinates, _, _ := strings.Cut(line, " ")
+		parts := strings.Split(coordinates, ":")
+		var version string
+		switch len(parts) {
+		case 5:
+			// group:artifact:type:version:scope
+			version = parts[3]
+		case 6:
+			// group:artifact:type:classifier:version:scope
+			version = parts[4]
+		default:
+			warnings = append(warnings, fmt.Sprintf("dependency tree entry %q could not be parsed", coordinates))
+			continue
+		}
+		if parts[0] == "" || parts[1] == "" || version == "" {
+			warnings = append(warnings, fmt.Sprintf("dependency tree entry %q could not be parsed", coordinates))
+			continue
+		}
+		packages = append(packages, mavenPackage(parts[0], parts[1], version))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("dependency tree read error: %w", err)
+	}
+	return packages, warnings, nil
+}
+
+// mavenDependencyTreeLine returns the artifact of a dependency tree line and its depth, the depth of the project
+// being 0. Returns false for lines that are not part of the tree.
+func mavenDependencyTreeLine(raw string) (string, int, bool) {
+	line := strings.TrimRight(raw, " \r")
+	line = strings.TrimPrefix(line, "[INFO]")
+	line = strings.TrimPrefix(line, " ")
+	if line == "" {
+		return "", 0, false
+	}
+
+	prefixEnd := strings.IndexFunc(line, func(r rune) bool {
+		return !strings.ContainsRune("|+-\\ ", r)
+	})
+	if prefixEnd < 0 {
+		return "", 0, false
+	}
+	prefix, artifact := line[:prefixEnd], line[prefixEnd:]
+	if prefix != "" && !strings.HasSuffix(prefix, "+- ") && !strings.HasSuffix(prefix, "\\- ") {
+		return "", 0, false
+	}
+	// Lines of the tree are group:artifact:type:version, with the scope for dependencies
+	if strings.Count(strings.Fields(artifact)[0], ":") < 3 {
+		return "", 0, false
+	}
+	return artifact, len(prefix) / 3, true
+}
+
+// isMavenDependencyTree recognizes the output of mvn dependency:tree, starting with the project artifact followed
+// by its dependencies
+func isMavenDependencyTree(data []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	foundProject := false
+	for scanner.Scan() {
+		_, depth, ok := mavenDependencyTreeLine(scanner.Text())
+		if !ok {
+			continue
+		}
+		if depth == 0 {
+			foundProject = true
+		} else {
+			return foundProject
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pomXML = `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <modelVersion>4.0.0</modelVersion>
+  <parent>
+    <groupId>org.springframework.boot</groupId>
+    <artifactId>spring-boot-starter-parent</artifactId>
+    <version>3.2.0</version>
+  </parent>
+  <groupId>com.example</groupId>
+  <artifactId>my-app</artifactId>
+  <version>1.0.0</version>
+  <properties>
+    <spring.version>6.1.0</spring.version>
+    <jackson.version>2.16.0</jackson.version>
+    <jackson.databind.version>${jackson.version}</jackson.databind.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>com.fasterxml.jackson.core</groupId>
+        <artifactId>jackson-databind</artifactId>
+        <version>${jackson.databind.version}</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+  <dependencies>
+    <dependency>
+      <groupId>org.springframework</groupId>
+      <artifactId>spring-core</artifactId>
+      <version>${spring.version}</version>
+    </dependency>
+    <dependency>
+      <groupId>com.fasterxml.jackson.core</groupId>
+      <artifactId>jackson-databind</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>${project.groupId}</groupId>
+      <artifactId>my-lib</artifactId>
+      <version>${project.version}</version>
+    </dependency>
+    <dependency>
+      <groupId>org.springframework.boot</groupId>
+      <artifactId>spring-boot-starter-web</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>org.slf4j</groupId>
+      <artifactId>slf4j-api</artifactId>
+      <version>${slf4j.version}</version>
+    </dependency>
+  </dependencies>
+</project>`
+
+func TestParsePom(t *testing.T) {
+	pkgs, warnings, err := parsePom([]byte(pomXML))
+	require.NoError(t, err)
+	assert.Equal(t, []Package{
+		{Ecosystem: EcosystemJava, Name: "spring-core", Version: "6.1.0", Namespace: "org.springframework"},
+		{Ecosystem: EcosystemJava, Name: "jackson-databind", Version: "2.16.0", Namespace: "com.fasterxml.jackson.core"},
+		{Ecosystem: EcosystemJava, Name: "my-lib", Version: "1.0.0", Namespace: "com.example"},
+	}, pkgs)
+	assert.Equal(t, []string{
+		"pom.xml dependency org.springframework.boot:spring-boot-starter-web: version is not managed in this file",
+		"pom.xml dependency org.slf4j:slf4j-api: version ${slf4j.version} could not be resolved",
+	}, warnings)
+}
+
+func TestParsePom_ParentVersion(t *testing.T) {
+	data := `<project>
+  <parent><groupId>com.example</groupId><artifactId>parent</artifactId><version>2.0.0</version></parent>
+  <artifactId>child</artifactId>
+  <dependencies>
+    <dependency><groupId>${project.groupId}</groupId><artifactId>sibling</artifactId><version>${project.version}</version></dependency>
+  </dependencies>
+</project>`
+	pkgs, warnings, err := parsePom([]byte(data))
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, []Package{
+		{Ecosystem: EcosystemJava, Name: "sibling", Version: "2.0.0", Namespace: "com.example"},
+	}, pkgs)
+}
+
+func TestParsePom_Invalid(t *testing.T) {
+	_, _, err := parsePom([]byte("<project>"))
+	assert.Error(t, err)
+}
+
+func TestInterpolatePom_Cycle(t *testing.T) {
+	properties := map[string]string{"a": "${b}", "b": "${a}"}
+	assert.Contains(t, interpolatePom("${a}", properties), "${")
+}
+
+func TestParseGradleLockfile(t *testing.T) {
+	data := `# This is a Gradle generated file for dependency locking.
+# Manual edits can break the build and are not advised.
+# This file is expected to be part of source control.
+com.google.guava:guava:32.1.3-jre=compileClasspath,runtimeClasspath
+org.slf4j:slf4j-api:2.0.9=runtimeClasspath
+org.broken:entry=runtimeClasspath
+empty=annotationProcessor
+`
+	pkgs, warnings, err := parseGradleLockfile([]byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, []Package{
+		{Ecosystem: EcosystemJava, Name: "guava", Version: "32.1.3-jre", Namespace: "com.google.guava"},
+		{Ecosystem: EcosystemJava, Name: "slf4j-api", Version: "2.0.9", Namespace: "org.slf4j"},
+	}, pkgs)
+	assert.Equal(t, []string{`gradle.lockfile entry "org.broken:entry" could not be parsed`}, warnings)
+}
+
+const mavenDependencyTree = `[INFO] Scanning for projects...
+[INFO]
+[INFO] --- maven-dependency-plugin:3.6.1:tree (default-cli) @ my-app ---
+[INFO] com.example:my-app:jar:1.0.0
+[INFO] +- org.springframework:spring-core:jar:6.1.0:compile
+[INFO] |  \- org.springframework:spring-jcl:jar:6.1.0:compile
+[INFO] +- io.netty:netty-transport-native-epoll:jar:linux-x86_64:4.1.100.Final:runtime (optional)
+[INFO] \- junit:junit:jar:4.13.2:test
+[INFO]    \- org.hamcrest:hamcrest-core:jar:1.3:test
+[INFO] ------------------------------------------------------------------------
+[INFO] BUILD SUCCESS
+`
+
+func TestParseMavenDependencyTree(t *testing.T) {
+	pkgs, warnings, err := parseMavenDependencyTree([]byte(mavenDependencyTree))
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, []Package{
+		{Ecosystem: EcosystemJava, Name: "spring-core", Version: "6.1.0", Namespace: "org.springframework"},
+		{Ecosystem: EcosystemJava, Name: "spring-jcl", Version: "6.1.0", Namespace: "org.springframework"},
+		{Ecosystem: EcosystemJava, Name: "netty-transport-native-epoll", Version: "4.1.100.Final", Namespace: "io.netty"},
+		{Ecosystem: EcosystemJava, Name: "junit", Version: "4.13.2", Namespace: "junit"},
+		{Ecosystem: EcosystemJava, Name: "hamcrest-core", Version: "1.3", Namespace: "org.hamcrest"},
+	}, pkgs)
+}
+
+func TestParseMavenDependencyTree_PlainOutput(t *testing.T) {
+	data := `com.example:my-app:jar:1.0.0
++- org.springframework:spring-core:jar:6.1.0:compile
+\- com.example:broken:jar:1.0.0:compile:extra:parts
+`
+	pkgs, warnings, err := parseMavenDependencyTree([]byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, []Package{
+		{Ecosystem: EcosystemJava, Name: "spring-core", Version: "6.1.0", Namespace: "org.springframework"},
+	}, pkgs)
+	assert.Len(t, warnings, 1)
+}
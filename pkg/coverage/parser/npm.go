@@ -0,0 +1,214 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// npmPackage returns the package of an npm package name, such as lodash or @babel/core, the scope being its namespace
+func npmPackage(fullName string, version string) *Package {
+	var scope, name string
+	if strings.HasPrefix(fullName, "@") {
+		var found bool
+		scope, name, found = strings.Cut(fullName, "/")
+		if !found {
+			return nil
+		}
+	} else {
+		name = fullName
+	}
+	if name == "" || strings.Contains(name, "/") {
+		return nil
+	}
+	return &Package{
+		Ecosystem: EcosystemNpm,
+		Name:      name,
+		Version:   version,
+		Namespace: scope,
+	}
+}
+
+// splitNpmDescriptor splits a descriptor such as @babel/core@^7.0.0 into the package name and the version or range.
+// The name of an alias such as foo@npm:bar@^1.0.0 is the name of the aliased package, bar.
+func splitNpmDescriptor(descriptor string) (string, string) {
+	name, versionRange, _ := cutNpmName(descriptor)
+	if aliased, ok := strings.CutPrefix(versionRange, "npm:"); ok {
+		if aliasedName, aliasedRange, found := cutNpmName(aliased); found {
+			return aliasedName, aliasedRange
+		}
+	}
+	return name, versionRange
+}
+
+// cutNpmName cuts a descriptor at the first @ following the package name, the scope of a scoped package starting
+// with an @ as well
+func cutNpmName(descriptor string) (string, string, bool) {
+	start := 0
+	if strings.HasPrefix(descriptor, "@") {
+		start = 1
+	}
+	idx := strings.Index(descriptor[start:], "@")
+	if idx < 0 {
+		return descriptor, "", false
+	}
+	return descriptor[:start+idx], descriptor[start+idx+1:], true
+}
+
+type packageLockDependency struct {
+	Version      string                           `json:"version"`
+	Dependencies map[string]packageLockDependency `json:"dependencies"`
+}
+
+// parsePackageLock reads a package-lock.json or npm-shrinkwrap.json file. Lockfile versions 2 and 3 list the installed
+// packages by path, such as node_modules/a/node_modules/@scope/b, lockfile version 1 nests the dependencies.
+func parsePackageLock(data []byte) ([]Package, error) {
+	var lock struct {
+		Packages map[string]struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+			Link    bool   `json:"link"`
+		} `json:"packages"`
+		Dependencies map[string]packageLockDependency `json:"dependencies"`
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("package-lock.json read error: %w", err)
+	}
+
+	var packages []Package
+	if len(lock.Packages) > 0 {
+		for _, path := range slices.Sorted(maps.Keys(lock.Packages)) {
+			entry := lock.Packages[path]
+			idx := strings.LastIndex(path, "node_modules/")
+			if idx < 0 || entry.Link {
+				continue
+			}
+			name := path[idx+len("node_modules/"):]
+			if entry.Name != "" {
+				name = entry.Name
+			}
+			if pkg := npmPackage(name, entry.Version); pkg != nil {
+				packages = append(packages, *pkg)
+			}
+		}
+	} else {
+		packages = packageLockDependencies(lock.Dependencies)
+	}
+	return packages, nil
+}
+
+func packageLockDependencies(dependencies map[string]packageLockDependency) []Package {
+	var packages []Package
+	for _, name := range slices.Sorted(maps.Keys(dependencies)) {
+		dependency := dependencies[name]
+		if pkg := npmPackage(name, dependency.Version); pkg != nil {
+			packages = append(packages, *pkg)
+		}
+		packages = append(packages, packageLockDependencies(dependency.Dependencies)...)
+	}
+	return packages
+}
+
+// parseYarnLock reads a yarn.lock file, of yarn 1 or of later versions. Each entry starts with an unindented line
+// listing the descriptors it resolves, such as "lodash@^4.17.0, lodash@^4.17.21":, followed by its indented fields.
+func parseYarnLock(data []byte) ([]Package, error) {
+	var packages []Package
+	var name string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+
+		if !strings.HasPrefix(line, " ") {
+			name = ""
+			descriptors := strings.TrimSuffix(strings.TrimSpace(line), ":")
+			first, _, _ := strings.Cut(descriptors, ",")
+			first = strings.Trim(strings.TrimSpace(first), `"`)
+			descriptorName, versionRange := splitNpmDescriptor(first)
+			// Skip the metadata of the lockfile, the workspaces of the project, and the patched packages, whose
+			// unpatched package has its own entry
+			if first == "__metadata" || strings.HasPrefix(versionRange, "workspace:") || strings.HasPrefix(versionRange, "patch:") {
+				continue
+			}
+			name = descriptorName
+			continue
+		}
+
+		field := strings.TrimSpace(line)
+		if name == "" || !strings.HasPrefix(field, "version") {
+			continue
+		}
+		// version "1.2.3" in yarn 1, version: 1.2.3 in later versions
+		version := strings.TrimPrefix(field, "version")
+		version = strings.Trim(strings.TrimSpace(strings.TrimPrefix(version, ":")), `"`)
+		if pkg := npmPackage(name, version); pkg != nil {
+			packages = append(packages, *pkg)
+		}
+		name = ""
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("yarn.lock read error: %w", err)
+	}
+	return packages, nil
+}
+
+// parsePnpmLock reads the packages section of a pnpm-lock.yaml file. Its keys are /name/version in lockfile
+// version 5, /name@version in version 6 and name@version in version 9, followed by the peer dependencies, such as
+// '/@babel/core@7.0.0(supports-color@8.1.1)' or /react-dom/18.2.0_react@18.2.0.
+func parsePnpmLock(data []byte) ([]Package, error) {
+	var packages []Package
+	inPackages := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") {
+			inPackages = strings.TrimSpace(line) == "packages:"
+			continue
+		}
+		// Keys of the packages section are indented by two spaces, their fields by more
+		if !inPackages || strings.HasPrefix(line, "   ") || !strings.HasSuffix(line, ":") {
+			continue
+		}
+
+		key := strings.Trim(strings.TrimSuffix(strings.TrimSpace(line), ":"), `'"`)
+		key = strings.TrimPrefix(key, "/")
+		if idx := strings.Index(key, "("); idx >= 0 {
+			key = key[:idx]
+		}
+
+		// The name ends at the first @ or /, after the scope for scoped packages
+		nameEnd := 0
+		if strings.HasPrefix(key, "@") {
+			nameEnd = strings.Index(key, "/") + 1
+			if nameEnd == 0 {
+				continue
+			}
+		}
+		idx := strings.IndexAny(key[nameEnd:], "@/")
+		if idx <= 0 {
+			continue
+		}
+		name, version := key[:nameEnd+idx], key[nameEnd+idx+1:]
+		if key[nameEnd+idx] == '/' {
+			version, _, _ = strings.Cut(version, "_")
+		}
+		if pkg := npmPackage(name, version); pkg != nil {
+			packages = append(packages, *pkg)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("pnpm-lock.yaml read error: %w", err)
+	}
+	return packages, nil
+}
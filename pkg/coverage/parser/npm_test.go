@@ -0,0 +1,215 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const packageLockV3 = `{
+  "name": "my-app",
+  "version": "1.0.0",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "my-app", "version": "1.0.0", "dependencies": {"react": "^18.2.0"}},
+    "node_modules/@babel/core": {"version": "7.24.0"},
+    "node_modules/react": {"version": "18.2.0"},
+    "node_modules/react/node_modules/loose-envify": {"version": "1.4.0"},
+    "node_modules/shared": {"resolved": "packages/shared", "link": true},
+    "packages/shared": {"name": "shared", "version": "0.0.1"}
+  }
+}`
+
+func TestParsePackageLock(t *testing.T) {
+	pkgs, err := parsePackageLock([]byte(packageLockV3))
+	require.NoError(t, err)
+	assert.Equal(t, []Package{
+		{Ecosystem: EcosystemNpm, Name: "core", Version: "7.24.0", Namespace: "@babel"},
+		{Ecosystem: EcosystemNpm, Name: "react", Version: "18.2.0"},
+		{Ecosystem: EcosystemNpm, Name: "loose-envify", Version: "1.4.0"},
+	}, pkgs)
+}
+
+func TestParsePackageLock_V1(t *testing.T) {
+	data := `{
+  "name": "my-app",
+  "lockfileVersion": 1,
+  "dependencies": {
+    "@types/node": {"version": "20.11.0"},
+    "react": {"version": "18.2.0", "dependencies": {"loose-envify": {"version": "1.4.0"}}}
+  }
+}`
+	pkgs, err := parsePackageLock([]byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, []Package{
+		{Ecosystem: EcosystemNpm, Name: "node", Version: "20.11.0", Namespace: "@types"},
+		{Ecosystem: EcosystemNpm, Name: "react", Version: "18.2.0"},
+		{Ecosystem: EcosystemNpm, Name: "loose-envify", Version: "1.4.0"},
+	}, pkgs)
+}
+
+func TestParsePackageLock_Invalid(t *testing.T) {
+	_, err := parsePackageLock([]byte("{"))
+	assert.Error(t, err)
+}
+
+func TestParseYarnLock_V1(t *testing.T) {
+	data := `# THIS IS AN AUTOGENERATED FILE. DO NOT EDIT THIS FILE DIRECTLY.
+# yarn lockfile v1
+
+
+"@babel/core@^7.0.0", "@babel/core@^7.24.0":
+  version "7.24.0"
+  resolved "https://registry.yarnpkg.com/@babel/core/-/core-7.24.0.tgz"
+  dependencies:
+    semver "^6.3.1"
+
+react@^18.2.0:
+  version "18.2.0"
+  resolved "https://registry.yarnpkg.com/react/-/react-18.2.0.tgz"
+
+"string-width-cjs@npm:string-width@^4.2.0":
+  version "4.2.3"
+  resolved "https://registry.yarnpkg.com/string-width/-/string-width-4.2.3.tgz"
+
+"types-node@npm:@types/node@^20.11.0":
+  version "20.11.0"
+  resolved "https://registry.yarnpkg.com/@types/node/-/node-20.11.0.tgz"
+`
+	pkgs, err := parseYarnLock([]byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, []Package{
+		{Ecosystem: EcosystemNpm, Name: "core", Version: "7.24.0", Namespace: "@babel"},
+		{Ecosystem: EcosystemNpm, Name: "react", Version: "18.2.0"},
+		{Ecosystem: EcosystemNpm, Name: "string-width", Version: "4.2.3"},
+		{Ecosystem: EcosystemNpm, Name: "node", Version: "20.11.0", Namespace: "@types"},
+	}, pkgs)
+}
+
+func TestParseYarnLock_Berry(t *testing.T) {
+	data := `# This file is generated by running "yarn install" inside your project.
+
+__metadata:
+  version: 8
+  cacheKey: 10c0
+
+"@babel/core@npm:^7.24.0":
+  version: 7.24.0
+  resolution: "@babel/core@npm:7.24.0"
+  languageName: node
+  linkType: hard
+
+"my-app@workspace:.":
+  version: 0.0.0-use.local
+  resolution: "my-app@workspace:."
+  languageName: unknown
+  linkType: soft
+
+"react@npm:^18.2.0":
+  version: 18.2.0
+  resolution: "react@npm:18.2.0"
+
+"resolve@npm:^1.22.0":
+  version: 1.22.8
+  resolution: "resolve@npm:1.22.8"
+
+"resolve@patch:resolve@npm%3A^1.22.0#~builtin<compat/resolve>":
+  version: 1.22.8
+  resolution: "resolve@patch:resolve@npm%3A1.22.8#~builtin<compat/resolve>::version=1.22.8&hash=c3c19d"
+
+"string-width-cjs@npm:string-width@^4.2.0":
+  version: 4.2.3
+  resolution: "string-width@npm:4.2.3"
+`
+	pkgs, err := parseYarnLock([]byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, []Package{
+		{Ecosystem: EcosystemNpm, Name: "core", Version: "7.24.0", Namespace: "@babel"},
+		{Ecosystem: EcosystemNpm, Name: "react", Version: "18.2.0"},
+		{Ecosystem: EcosystemNpm, Name: "resolve", Version: "1.22.8"},
+		{Ecosystem: EcosystemNpm, Name: "string-width", Version: "4.2.3"},
+	}, pkgs)
+}
+
+func TestSplitNpmDescriptor(t *testing.T) {
+	testCases := map[string][2]string{
+		"lodash@^4.17.21":                     {"lodash", "^4.17.21"},
+		"@babel/core@^7.0.0":                  {"@babel/core", "^7.0.0"},
+		"@babel/core@npm:^7.24.0":             {"@babel/core", "npm:^7.24.0"},
+		"foo@npm:bar@^1.0.0":                  {"bar", "^1.0.0"},
+		"foo@npm:@scope/bar@^1.0.0":           {"@scope/bar", "^1.0.0"},
+		"resolve@patch:resolve@npm%3A^1.22.0": {"resolve", "patch:resolve@npm%3A^1.22.0"},
+		"lodash":                              {"lodash", ""},
+	}
+	for descriptor, expected := range testCases {
+		name, versionRange := splitNpmDescriptor(descriptor)
+		assert.Equal(t, expected, [2]string{name, versionRange}, descriptor)
+	}
+}
+
+func TestParsePnpmLock(t *testing.T) {
+	testCases := map[string]string{
+		"v5": `lockfileVersion: 5.4
+
+importers:
+  .:
+    specifiers:
+      react: ^18.2.0
+
+packages:
+
+  /@babel/core/7.24.0:
+    resolution: {integrity: sha512-abc}
+    dev: false
+
+  /react/18.2.0_react-dom@18.2.0:
+    resolution: {integrity: sha512-def}
+`,
+		"v6": `lockfileVersion: '6.0'
+
+dependencies:
+  react:
+    specifier: ^18.2.0
+    version: 18.2.0
+
+packages:
+
+  /@babel/core@7.24.0:
+    resolution: {integrity: sha512-abc}
+
+  /react@18.2.0(react-dom@18.2.0):
+    resolution: {integrity: sha512-def}
+`,
+		"v9": `lockfileVersion: '9.0'
+
+packages:
+
+  '@babel/core@7.24.0':
+    resolution: {integrity: sha512-abc}
+
+  react@18.2.0:
+    resolution: {integrity: sha512-def}
+
+snapshots:
+
+  react@18.2.0(react-dom@18.2.0):
+    dependencies:
+      loose-envify: 1.4.0
+`,
+	}
+	for name, data := range testCases {
+		pkgs, err := parsePnpmLock([]byte(data))
+		require.NoError(t, err, name)
+		assert.Equal(t, []Package{
+			{Ecosystem: EcosystemNpm, Name: "core", Version: "7.24.0", Namespace: "@babel"},
+			{Ecosystem: EcosystemNpm, Name: "react", Version: "18.2.0"},
+		}, pkgs, name)
+	}
+}
+
+func TestNpmPackage_Invalid(t *testing.T) {
+	assert.Nil(t, npmPackage("", "1.0.0"))
+	assert.Nil(t, npmPackage("@babel", "1.0.0"))
+	assert.Nil(t, npmPackage("@babel/core/extra", "1.0.0"))
+}
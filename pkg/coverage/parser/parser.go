@@ -1,31 +1,56 @@
 package parser
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"strings"
 )
 
 const (
-	EcosystemJava   = "Java"
-	EcosystemPython = "Python"
+	EcosystemJava    = "Java"
+	EcosystemPython  = "Python"
+	EcosystemNpm     = "npm"
+	EcosystemUnknown = "Unknown" // SBOM components without a package URL
 )
 
 const (
-	FormatCSV          = "csv"
-	FormatRequirements = "requirements.txt"
+	FormatCSV           = "csv"
+	FormatRequirements  = "requirements.txt"
+	FormatCycloneDXJSON = "cyclonedx-json"
+	FormatCycloneDXXML  = "cyclonedx-xml"
+	FormatSPDXJSON      = "spdx-json"
+	FormatSPDXTagValue  = "spdx-tag-value"
+	FormatPackageLock   = "package-lock.json"
+	FormatYarnLock      = "yarn.lock"
+	FormatPnpmLock      = "pnpm-lock.yaml"
+	FormatPom           = "pom.xml"
+	FormatGradleLock    = "gradle.lockfile"
+	FormatMavenTree     = "maven-dependency-tree"
+	FormatPoetryLock    = "poetry.lock"
+	FormatPipfileLock   = "Pipfile.lock"
+	FormatUvLock        = "uv.lock"
+	FormatPyproject     = "pyproject.toml"
 )
 
 type Package struct {
-	Ecosystem string
-	Name      string
-	Version   string
-	Namespace string
+	Ecosystem  string
+	Name       string
+	Version    string
+	Namespace  string
+	Constraint string // Version specifier of dependencies without a pinned version, such as >=2.31,<3
 }
 
+// AnyVersion is the constraint of dependencies without any version specifier
+const AnyVersion = "*"
+
 type ParseResult struct {
 	Packages    []Package
 	InputFormat string
+	Warnings    []string // Manifest entries that could not be resolved to a package
 }
 
 // Parse detects the format of the manifest file and extracts package metadata.
@@ -35,17 +60,46 @@ func Parse(filename string, r io.Reader) (*ParseResult, error) {
 		return nil, fmt.Errorf("reading manifest: %w", err)
 	}
 
-	format, err := detectFormat(filename)
+	format, err := detectFormat(filename, data)
 	if err != nil {
 		return nil, err
 	}
 
 	var packages []Package
+	var warnings []string
 	switch format {
 	case FormatCSV:
 		packages, err = parseCSV(data)
 	case FormatRequirements:
 		packages, err = parseRequirements(data)
+	case FormatCycloneDXJSON:
+		packages, err = parseCycloneDXJSON(data)
+	case FormatCycloneDXXML:
+		packages, err = parseCycloneDXXML(data)
+	case FormatSPDXJSON:
+		packages, err = parseSPDXJSON(data)
+	case FormatSPDXTagValue:
+		packages, err = parseSPDXTagValue(data)
+	case FormatPackageLock:
+		packages, err = parsePackageLock(data)
+	case FormatYarnLock:
+		packages, err = parseYarnLock(data)
+	case FormatPnpmLock:
+		packages, err = parsePnpmLock(data)
+	case FormatPom:
+		packages, warnings, err = parsePom(data)
+	case FormatGradleLock:
+		packages, warnings, err = parseGradleLockfile(data)
+	case FormatMavenTree:
+		packages, warnings, err = parseMavenDependencyTree(data)
+	case FormatPoetryLock:
+		packages, err = parsePoetryLock(data)
+	case FormatPipfileLock:
+		packages, err = parsePipfileLock(data)
+	case FormatUvLock:
+		packages, err = parseUvLock(data)
+	case FormatPyproject:
+		packages, err = parsePyproject(data)
 	}
 	if err != nil {
 		return nil, err
@@ -54,20 +108,120 @@ func Parse(filename string, r io.Reader) (*ParseResult, error) {
 	return &ParseResult{
 		Packages:    deduplicate(packages),
 		InputFormat: format,
+		Warnings:    warnings,
 	}, nil
 }
 
-func detectFormat(filename string) (string, error) {
+// detectFormat detects the format from the file name, or from the content for SBOMs with a generic name such as bom.json
+// and for dependency trees
+func detectFormat(filename string, data []byte) (string, error) {
 	lower := strings.ToLower(filename)
 	switch {
 	case strings.HasSuffix(lower, ".csv"):
 		return FormatCSV, nil
 	case strings.HasSuffix(lower, "requirements.txt"):
 		return FormatRequirements, nil
+	case strings.HasSuffix(lower, "poetry.lock"):
+		return FormatPoetryLock, nil
+	case strings.HasSuffix(lower, "pipfile.lock"):
+		return FormatPipfileLock, nil
+	case strings.HasSuffix(lower, "uv.lock"):
+		return FormatUvLock, nil
+	case strings.HasSuffix(lower, "pyproject.toml"):
+		return FormatPyproject, nil
+	case strings.HasSuffix(lower, "package-lock.json"), strings.HasSuffix(lower, "npm-shrinkwrap.json"):
+		return FormatPackageLock, nil
+	case strings.HasSuffix(lower, "yarn.lock"):
+		return FormatYarnLock, nil
+	case strings.HasSuffix(lower, "pnpm-lock.yaml"):
+		return FormatPnpmLock, nil
+	case strings.HasSuffix(lower, "pom.xml"), strings.HasSuffix(lower, ".pom"):
+		return FormatPom, nil
+	case strings.HasSuffix(lower, "gradle.lockfile"):
+		return FormatGradleLock, nil
+	case strings.HasSuffix(lower, ".cdx.json"):
+		return FormatCycloneDXJSON, nil
+	case strings.HasSuffix(lower, ".cdx.xml"):
+		return FormatCycloneDXXML, nil
+	case strings.HasSuffix(lower, ".spdx.json"):
+		return FormatSPDXJSON, nil
+	case strings.HasSuffix(lower, ".spdx"):
+		return FormatSPDXTagValue, nil
+	}
+	if format := sniffFormat(data); format != "" {
+		return format, nil
 	}
 	return "", fmt.Errorf("unsupported manifest format for file %q", filename)
 }
 
+// sniffFormat recognizes CycloneDX and SPDX documents and Maven dependency trees from their content, returning an
+// empty string otherwise
+func sniffFormat(data []byte) string {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		var document struct {
+			BomFormat   string `json:"bomFormat"`
+			SPDXVersion string `json:"spdxVersion"`
+		}
+		if json.Unmarshal(trimmed, &document) != nil {
+			return ""
+		}
+		if strings.EqualFold(document.BomFormat, "CycloneDX") {
+			return FormatCycloneDXJSON
+		}
+		if strings.HasPrefix(document.SPDXVersion, "SPDX-") {
+			return FormatSPDXJSON
+		}
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		decoder := xml.NewDecoder(bytes.NewReader(trimmed))
+		for {
+			token, err := decoder.Token()
+			if err != nil {
+				return ""
+			}
+			if start, ok := token.(xml.StartElement); ok {
+				if start.Name.Local == "bom" && strings.Contains(start.Name.Space, "cyclonedx.org") {
+					return FormatCycloneDXXML
+				}
+				return ""
+			}
+		}
+	case isMavenDependencyTree(trimmed):
+		return FormatMavenTree
+	default:
+		scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			if strings.HasPrefix(line, "SPDXVersion:") {
+				return FormatSPDXTagValue
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+// componentPackage returns the package of an SBOM component, from its package URL if it has one, or else from its name
+// and version. Returns nil for components of unsupported ecosystems.
+func componentPackage(purl string, namespace string, name string, version string) *Package {
+	if purl != "" {
+		return parsePURL(purl)
+	}
+	if name == "" {
+		return nil
+	}
+	return &Package{
+		Ecosystem: EcosystemUnknown,
+		Name:      name,
+		Version:   version,
+		Namespace: namespace,
+	}
+}
+
 func deduplicate(pkgs []Package) []Package {
 	seen := make(map[string]struct{}, len(pkgs))
 	result := make([]Package, 0, len(pkgs))
@@ -9,11 +9,96 @@ import (
 )
 
 func TestDetectFormat_CaseInsensitive(t *testing.T) {
-	format, err := detectFormat("REPORT.CSV")
+	format, err := detectFormat("REPORT.CSV", nil)
 	assert.NoError(t, err)
 	assert.Equal(t, FormatCSV, format)
 }
 
+func TestDetectFormat_SBOMFileNames(t *testing.T) {
+	testCases := map[string]string{
+		"app.cdx.json":  FormatCycloneDXJSON,
+		"app.CDX.XML":   FormatCycloneDXXML,
+		"app.spdx.json": FormatSPDXJSON,
+		"app.spdx":      FormatSPDXTagValue,
+	}
+	for filename, expected := range testCases {
+		format, err := detectFormat(filename, nil)
+		assert.NoError(t, err, filename)
+		assert.Equal(t, expected, format, filename)
+	}
+}
+
+func TestDetectFormat_NpmLockfiles(t *testing.T) {
+	testCases := map[string]string{
+		"package-lock.json":     FormatPackageLock,
+		"npm-shrinkwrap.json":   FormatPackageLock,
+		"frontend/yarn.lock":    FormatYarnLock,
+		"pnpm-lock.yaml":        FormatPnpmLock,
+		"web-package-lock.json": FormatPackageLock,
+	}
+	for filename, expected := range testCases {
+		format, err := detectFormat(filename, nil)
+		assert.NoError(t, err, filename)
+		assert.Equal(t, expected, format, filename)
+	}
+}
+
+func TestDetectFormat_JavaManifests(t *testing.T) {
+	testCases := map[string]string{
+		"pom.xml":                     FormatPom,
+		"my-app-1.0.0.pom":            FormatPom,
+		"gradle.lockfile":             FormatGradleLock,
+		"buildscript-gradle.lockfile": FormatGradleLock,
+	}
+	for filename, expected := range testCases {
+		format, err := detectFormat(filename, nil)
+		assert.NoError(t, err, filename)
+		assert.Equal(t, expected, format, filename)
+	}
+
+	format, err := detectFormat("tree.txt", []byte(mavenDependencyTree))
+	assert.NoError(t, err)
+	assert.Equal(t, FormatMavenTree, format)
+}
+
+func TestDetectFormat_PythonManifests(t *testing.T) {
+	testCases := map[string]string{
+		"poetry.lock":            FormatPoetryLock,
+		"Pipfile.lock":           FormatPipfileLock,
+		"uv.lock":                FormatUvLock,
+		"service/pyproject.toml": FormatPyproject,
+	}
+	for filename, expected := range testCases {
+		format, err := detectFormat(filename, nil)
+		assert.NoError(t, err, filename)
+		assert.Equal(t, expected, format, filename)
+	}
+}
+
+func TestDetectFormat_Sniffing(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Data     string
+		Expected string
+	}{
+		{Name: "CycloneDX JSON", Data: cycloneDXJSON, Expected: FormatCycloneDXJSON},
+		{Name: "CycloneDX XML", Data: cycloneDXXML, Expected: FormatCycloneDXXML},
+		{Name: "SPDX JSON", Data: spdxJSON, Expected: FormatSPDXJSON},
+		{Name: "SPDX tag-value", Data: spdxTagValue, Expected: FormatSPDXTagValue},
+		{Name: "Byte order mark", Data: "\ufeff" + cycloneDXJSON, Expected: FormatCycloneDXJSON},
+	}
+	for _, testCase := range testCases {
+		format, err := detectFormat("bom", []byte(testCase.Data))
+		assert.NoError(t, err, testCase.Name)
+		assert.Equal(t, testCase.Expected, format, testCase.Name)
+	}
+
+	for _, data := range []string{`{"name": "package.json"}`, `<project></project>`, "flask==3.0.3", `{`} {
+		_, err := detectFormat("bom.json", []byte(data))
+		assert.Error(t, err, data)
+	}
+}
+
 func TestDeduplicate(t *testing.T) {
 	pkgs := []Package{
 		{Ecosystem: "Java", Name: "spring-core", Version: "5.3.20", Namespace: "org.springframework"},
@@ -42,6 +127,33 @@ func TestParse_Requirements(t *testing.T) {
 	assert.Len(t, result.Packages, 2)
 }
 
+func TestParse_SBOM(t *testing.T) {
+	result, err := Parse("bom.json", strings.NewReader(cycloneDXJSON))
+	require.NoError(t, err)
+	assert.Equal(t, FormatCycloneDXJSON, result.InputFormat)
+	assert.Len(t, result.Packages, 4)
+
+	result, err = Parse("sbom.spdx", strings.NewReader(spdxTagValue))
+	require.NoError(t, err)
+	assert.Equal(t, FormatSPDXTagValue, result.InputFormat)
+	assert.Len(t, result.Packages, 3)
+}
+
+func TestParse_NpmLockfile(t *testing.T) {
+	result, err := Parse("package-lock.json", strings.NewReader(packageLockV3))
+	require.NoError(t, err)
+	assert.Equal(t, FormatPackageLock, result.InputFormat)
+	assert.Len(t, result.Packages, 3)
+}
+
+func TestParse_Warnings(t *testing.T) {
+	result, err := Parse("pom.xml", strings.NewReader(pomXML))
+	require.NoError(t, err)
+	assert.Equal(t, FormatPom, result.InputFormat)
+	assert.Len(t, result.Packages, 3)
+	assert.Len(t, result.Warnings, 2)
+}
+
 func TestParse_UnsupportedFormat(t *testing.T) {
 	_, err := Parse("data.zip", strings.NewReader("binary data"))
 	assert.Error(t, err)
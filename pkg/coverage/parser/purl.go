@@ -1,6 +1,9 @@
 package parser
 
-import "strings"
+import (
+	"net/url"
+	"strings"
+)
 
 // parsePURL extracts package info from a Package URL string.
 // Returns nil for unsupported ecosystems or malformed PURLs.
@@ -21,6 +24,8 @@ func parsePURL(raw string) *Package {
 		ecosystem = EcosystemJava
 	case "pypi":
 		ecosystem = EcosystemPython
+	case "npm":
+		ecosystem = EcosystemNpm
 	default:
 		return nil
 	}
@@ -29,12 +34,13 @@ func parsePURL(raw string) *Package {
 		remainder = remainder[:idx]
 	}
 
-	var nameBlock, version string
-	if idx := strings.LastIndex(remainder, "@"); idx >= 0 {
-		nameBlock = remainder[:idx]
-		version = remainder[idx+1:]
-	} else {
-		nameBlock = remainder
+	// The version follows the @ of the last segment, the @ of an npm scope may not be percent-encoded, such as
+	// pkg:npm/@babel/core without a version
+	nameBlock, version := remainder, ""
+	nameStart := strings.LastIndex(remainder, "/") + 1
+	if idx := strings.LastIndex(remainder[nameStart:], "@"); idx >= 0 {
+		nameBlock = remainder[:nameStart+idx]
+		version = remainder[nameStart+idx+1:]
 	}
 
 	var namespace, name string
@@ -45,14 +51,32 @@ func parsePURL(raw string) *Package {
 		name = nameBlock
 	}
 
+	// The @ of npm scopes is percent-encoded, such as pkg:npm/%40babel/core@7.0.0
+	namespace, name, version = unescapePURL(namespace), unescapePURL(name), unescapePURL(version)
+	if ecosystem == EcosystemNpm && namespace != "" && !strings.HasPrefix(namespace, "@") {
+		namespace = "@" + namespace
+	}
+
 	if name == "" {
 		return nil
 	}
 
-	return &Package{
+	pkg := &Package{
 		Ecosystem: ecosystem,
 		Name:      name,
 		Version:   version,
 		Namespace: namespace,
 	}
+	// A package URL without a version matches any version of the package
+	if version == "" {
+		pkg.Constraint = AnyVersion
+	}
+	return pkg
+}
+
+func unescapePURL(segment string) string {
+	if unescaped, err := url.PathUnescape(segment); err == nil {
+		return unescaped
+	}
+	return segment
 }
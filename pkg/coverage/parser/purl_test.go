@@ -25,8 +25,45 @@ func TestParsePURL_PyPI(t *testing.T) {
 	assert.Empty(t, pkg.Namespace)
 }
 
+func TestParsePURL_Npm(t *testing.T) {
+	pkg := parsePURL("pkg:npm/react@18.2.0")
+	require.NotNil(t, pkg)
+	assert.Equal(t, EcosystemNpm, pkg.Ecosystem)
+	assert.Equal(t, "react", pkg.Name)
+	assert.Equal(t, "18.2.0", pkg.Version)
+	assert.Empty(t, pkg.Namespace)
+
+	pkg = parsePURL("pkg:npm/%40babel/core@7.24.0")
+	require.NotNil(t, pkg)
+	assert.Equal(t, "core", pkg.Name)
+	assert.Equal(t, "7.24.0", pkg.Version)
+	assert.Equal(t, "@babel", pkg.Namespace)
+
+	pkg = parsePURL("pkg:npm/types/node@20.11.0")
+	require.NotNil(t, pkg)
+	assert.Equal(t, "node", pkg.Name)
+	assert.Equal(t, "@types", pkg.Namespace)
+
+	pkg = parsePURL("pkg:npm/@babel/core@7.24.0")
+	require.NotNil(t, pkg)
+	assert.Equal(t, "core", pkg.Name)
+	assert.Equal(t, "7.24.0", pkg.Version)
+	assert.Equal(t, "@babel", pkg.Namespace)
+}
+
+func TestParsePURL_NoVersion(t *testing.T) {
+	for _, purl := range []string{"pkg:npm/%40babel/core", "pkg:npm/@babel/core", "pkg:npm/%40babel/core?foo=bar"} {
+		pkg := parsePURL(purl)
+		require.NotNil(t, pkg, purl)
+		assert.Equal(t, Package{Ecosystem: EcosystemNpm, Name: "core", Namespace: "@babel", Constraint: AnyVersion}, *pkg, purl)
+	}
+
+	pkg := parsePURL("pkg:maven/org.springframework/spring-core")
+	require.NotNil(t, pkg)
+	assert.Equal(t, Package{Ecosystem: EcosystemJava, Name: "spring-core", Namespace: "org.springframework", Constraint: AnyVersion}, *pkg)
+}
+
 func TestParsePURL_UnsupportedEcosystem(t *testing.T) {
-	assert.Nil(t, parsePURL("pkg:npm/react@18.2.0"))
 	assert.Nil(t, parsePURL("pkg:golang/github.com/gin-gonic/gin@1.9.1"))
 	assert.Nil(t, parsePURL("pkg:cargo/serde@1.0.0"))
 }
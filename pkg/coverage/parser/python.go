@@ -0,0 +1,188 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// pythonPackage returns the package of a Python distribution, its name normalized per PEP 503. Dependencies without
+// a version or specifier accept any version.
+func pythonPackage(name string, version string, constraint string) Package {
+	if version == "" && constraint == "" {
+		constraint = AnyVersion
+	}
+	return Package{
+		Ecosystem:  EcosystemPython,
+		Name:       normalizePythonName(name),
+		Version:    version,
+		Constraint: constraint,
+	}
+}
+
+// normalizePythonName applies PEP 503 normalization: lowercase, replace runs of [-_.] with -.
+func normalizePythonName(name string) string {
+	var b strings.Builder
+	b.Grow(len(name))
+	separatorWritten := false
+	for _, ch := range strings.ToLower(strings.TrimSpace(name)) {
+		if ch == '-' || ch == '_' || ch == '.' {
+			if !separatorWritten {
+				b.WriteByte('-')
+				separatorWritten = true
+			}
+		} else {
+			b.WriteRune(ch)
+			separatorWritten = false
+		}
+	}
+	return b.String()
+}
+
+type pipfileLockPackage struct {
+	Version string `json:"version"`
+}
+
+type pythonLockPackage struct {
+	Name    string `toml:"name"`
+	Version string `toml:"version"`
+	Source  struct {
+		Editable string `toml:"editable"`
+		Virtual  string `toml:"virtual"`
+	} `toml:"source"`
+}
+
+// parsePoetryLock reads the locked packages of a poetry.lock file
+func parsePoetryLock(data []byte) ([]Package, error) {
+	var lock struct {
+		Packages []pythonLockPackage `toml:"package"`
+	}
+	if err := toml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("poetry.lock read error: %w", err)
+	}
+	var packages []Package
+	for _, pkg := range lock.Packages {
+		if pkg.Name != "" {
+			packages = append(packages, pythonPackage(pkg.Name, pkg.Version, ""))
+		}
+	}
+	return packages, nil
+}
+
+// parseUvLock reads the locked packages of a uv.lock file, skipping the packages of the project itself
+func parseUvLock(data []byte) ([]Package, error) {
+	var lock struct {
+		Packages []pythonLockPackage `toml:"package"`
+	}
+	if err := toml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("uv.lock read error: %w", err)
+	}
+	var packages []Package
+	for _, pkg := range lock.Packages {
+		if pkg.Name == "" || pkg.Source.Editable != "" || pkg.Source.Virtual != "" {
+			continue
+		}
+		packages = append(packages, pythonPackage(pkg.Name, pkg.Version, ""))
+	}
+	return packages, nil
+}
+
+// parsePipfileLock reads the default and development packages of a Pipfile.lock file, whose versions are pinned
+// as ==1.2.3. Packages installed from a VCS or a path have no version.
+func parsePipfileLock(data []byte) ([]Package, error) {
+	var lock struct {
+		Default map[string]pipfileLockPackage `json:"default"`
+		Develop map[string]pipfileLockPackage `json:"develop"`
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("reading Pipfile.lock: %w", err)
+	}
+	var packages []Package
+	for _, section := range []map[string]pipfileLockPackage{lock.Default, lock.Develop} {
+		for _, name := range slices.Sorted(maps.Keys(section)) {
+			version := strings.TrimLeft(section[name].Version, "=")
+			packages = append(packages, pythonPackage(name, version, ""))
+		}
+	}
+	return packages, nil
+}
+
+// parsePyproject reads the PEP 621 dependencies and optional dependencies of a pyproject.toml file, and the
+// dependencies of its Poetry groups. Dependencies pinned with == have a version, the specifier of other dependencies
+// is kept as their constraint.
+func parsePyproject(data []byte) ([]Package, error) {
+	var pyproject struct {
+		Project struct {
+			Dependencies         []string            `toml:"dependencies"`
+			OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+		} `toml:"project"`
+		Tool struct {
+			Poetry struct {
+				Dependencies    map[string]any `toml:"dependencies"`
+				DevDependencies map[string]any `toml:"dev-dependencies"`
+				Group           map[string]struct {
+					Dependencies map[string]any `toml:"dependencies"`
+				} `toml:"group"`
+			} `toml:"poetry"`
+		} `toml:"tool"`
+	}
+	if err := toml.Unmarshal(data, &pyproject); err != nil {
+		return nil, fmt.Errorf("pyproject.toml read error: %w", err)
+	}
+
+	requirements := pyproject.Project.Dependencies
+	for _, extra := range slices.Sorted(maps.Keys(pyproject.Project.OptionalDependencies)) {
+		requirements = append(requirements, pyproject.Project.OptionalDependencies[extra]...)
+	}
+
+	var packages []Package
+	for _, requirement := range requirements {
+		name, specifier := parsePEP508(requirement)
+		if name == "" {
+			continue
+		}
+		if pinned, ok := strings.CutPrefix(specifier, "=="); ok && !strings.ContainsAny(pinned, ",*") {
+			packages = append(packages, pythonPackage(name, strings.TrimPrefix(pinned, "="), ""))
+		} else {
+			packages = append(packages, pythonPackage(name, "", specifier))
+		}
+	}
+
+	poetry := pyproject.Tool.Poetry
+	groups := []map[string]any{poetry.Dependencies, poetry.DevDependencies}
+	for _, group := range slices.Sorted(maps.Keys(poetry.Group)) {
+		groups = append(groups, poetry.Group[group].Dependencies)
+	}
+	for _, dependencies := range groups {
+		for _, name := range slices.Sorted(maps.Keys(dependencies)) {
+			// The Python version the project requires is not a package
+			if name == "python" {
+				continue
+			}
+			packages = append(packages, poetryPackage(name, dependencies[name]))
+		}
+	}
+	return packages, nil
+}
+
+// poetryPackage returns the package of a Poetry dependency, whose constraint is either a string, such as ^2.31 or *,
+// or the version of a table, such as { version = "^2.31", extras = ["socks"] }. A bare version, such as 2.31.0, pins
+// that version. Dependencies from a VCS or a path, or with multiple constraints, accept any version.
+func poetryPackage(name string, value any) Package {
+	var constraint string
+	switch value := value.(type) {
+	case string:
+		constraint = value
+	case map[string]any:
+		constraint, _ = value["version"].(string)
+	}
+	constraint = strings.ReplaceAll(strings.TrimSpace(constraint), " ", "")
+	if pinned := strings.TrimPrefix(constraint, "=="); pinned != "" && !strings.ContainsAny(pinned, "*,<>^~!=|") {
+		return pythonPackage(name, pinned, "")
+	}
+	return pythonPackage(name, "", constraint)
+}
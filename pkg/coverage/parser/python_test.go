@@ -0,0 +1,179 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePoetryLock(t *testing.T) {
+	data := `# This file is automatically @generated by Poetry 1.8.2 and should not be changed by hand.
+
+[[package]]
+name = "Flask"
+version = "3.0.3"
+description = "A simple framework for building complex web applications."
+optional = false
+python-versions = ">=3.8"
+files = [
+    {file = "flask-3.0.3-py3-none-any.whl", hash = "sha256:abc"},
+]
+
+[package.dependencies]
+Werkzeug = ">=3.0.0"
+
+[[package]]
+name = "ruamel.yaml"
+version = "0.18.6"
+description = "ruamel.yaml is a YAML parser/emitter"
+optional = false
+python-versions = ">=3.7"
+
+[metadata]
+lock-version = "2.0"
+python-versions = "^3.11"
+content-hash = "abc"
+`
+	pkgs, err := parsePoetryLock([]byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, []Package{
+		{Ecosystem: EcosystemPython, Name: "flask", Version: "3.0.3"},
+		{Ecosystem: EcosystemPython, Name: "ruamel-yaml", Version: "0.18.6"},
+	}, pkgs)
+
+	_, err = parsePoetryLock([]byte("[[package]\n"))
+	assert.Error(t, err)
+}
+
+func TestParseUvLock(t *testing.T) {
+	data := `version = 1
+requires-python = ">=3.12"
+
+[[package]]
+name = "my-service"
+version = "0.1.0"
+source = { editable = "." }
+dependencies = [{ name = "requests" }]
+
+[[package]]
+name = "requests"
+version = "2.32.3"
+source = { registry = "https://pypi.org/simple" }
+sdist = { url = "https://files.pythonhosted.org/requests-2.32.3.tar.gz", hash = "sha256:abc", size = 131218 }
+
+[[package]]
+name = "typing-extensions"
+version = "4.12.2"
+source = { registry = "https://pypi.org/simple" }
+`
+	pkgs, err := parseUvLock([]byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, []Package{
+		{Ecosystem: EcosystemPython, Name: "requests", Version: "2.32.3"},
+		{Ecosystem: EcosystemPython, Name: "typing-extensions", Version: "4.12.2"},
+	}, pkgs)
+}
+
+func TestParsePipfileLock(t *testing.T) {
+	data := `{
+  "_meta": {"hash": {"sha256": "abc"}, "pipfile-spec": 6},
+  "default": {
+    "Django": {"hashes": ["sha256:abc"], "index": "pypi", "version": "==5.0.4"},
+    "my_lib": {"git": "https://github.com/example/my-lib.git", "ref": "abc"}
+  },
+  "develop": {
+    "pytest": {"version": "==8.1.1"}
+  }
+}`
+	pkgs, err := parsePipfileLock([]byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, []Package{
+		{Ecosystem: EcosystemPython, Name: "django", Version: "5.0.4"},
+		{Ecosystem: EcosystemPython, Name: "my-lib", Constraint: AnyVersion},
+		{Ecosystem: EcosystemPython, Name: "pytest", Version: "8.1.1"},
+	}, pkgs)
+}
+
+func TestParsePyproject(t *testing.T) {
+	data := `[build-system]
+requires = ["hatchling"]
+build-backend = "hatchling.build"
+
+[project]
+name = "my-service"
+version = "0.1.0"
+dependencies = [
+    "Flask==3.0.3",
+    "requests[socks] >= 2.31, < 3",
+    "numpy (>=1.26)",
+    "typing_extensions; python_version < '3.11'",
+    "Django==5.0.*",
+]
+
+[project.optional-dependencies]
+test = ["pytest~=8.1"]
+`
+	pkgs, err := parsePyproject([]byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, []Package{
+		{Ecosystem: EcosystemPython, Name: "flask", Version: "3.0.3"},
+		{Ecosystem: EcosystemPython, Name: "requests", Constraint: ">=2.31,<3"},
+		{Ecosystem: EcosystemPython, Name: "numpy", Constraint: ">=1.26"},
+		{Ecosystem: EcosystemPython, Name: "typing-extensions", Constraint: AnyVersion},
+		{Ecosystem: EcosystemPython, Name: "django", Constraint: "==5.0.*"},
+		{Ecosystem: EcosystemPython, Name: "pytest", Constraint: "~=8.1"},
+	}, pkgs)
+}
+
+func TestParsePyproject_Poetry(t *testing.T) {
+	data := `[tool.poetry]
+name = "my-service"
+version = "0.1.0"
+
+[tool.poetry.dependencies]
+python = "^3.11"
+requests = "*"
+flask = "3.0.3"
+numpy = { version = ">=1.26, <2", extras = ["blas"] }
+mylib = { git = "https://github.com/example/mylib.git" }
+
+[tool.poetry.group.test.dependencies]
+pytest = "^8.1"
+`
+	pkgs, err := parsePyproject([]byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, []Package{
+		{Ecosystem: EcosystemPython, Name: "flask", Version: "3.0.3"},
+		{Ecosystem: EcosystemPython, Name: "mylib", Constraint: AnyVersion},
+		{Ecosystem: EcosystemPython, Name: "numpy", Constraint: ">=1.26,<2"},
+		{Ecosystem: EcosystemPython, Name: "requests", Constraint: AnyVersion},
+		{Ecosystem: EcosystemPython, Name: "pytest", Constraint: "^8.1"},
+	}, pkgs)
+
+	// Unconstrained dependencies are valid packages, accepting any version
+	for _, pkg := range pkgs {
+		assert.True(t, pkg.Version != "" || pkg.Constraint != "", pkg.Name)
+	}
+}
+
+func TestParsePEP508(t *testing.T) {
+	testCases := map[string][2]string{
+		"flask":                                 {"flask", ""},
+		"flask == 2.3.0":                        {"flask", "==2.3.0"},
+		"requests[socks,security]>=2.31":        {"requests", ">=2.31"},
+		"pip @ https://example.com/pip.whl":     {"pip", ""},
+		`scipy>=1.13; python_version >= "3.10"`: {"scipy", ">=1.13"},
+	}
+	for requirement, expected := range testCases {
+		name, specifier := parsePEP508(requirement)
+		assert.Equal(t, expected[0], name, requirement)
+		assert.Equal(t, expected[1], specifier, requirement)
+	}
+}
+
+func TestNormalizePythonName(t *testing.T) {
+	assert.Equal(t, "flask", normalizePythonName("Flask"))
+	assert.Equal(t, "ruamel-yaml", normalizePythonName("ruamel.yaml"))
+	assert.Equal(t, "my-package", normalizePythonName("My__Package"))
+}
@@ -41,45 +41,17 @@ func parseRequirements(data []byte) ([]Package, error) {
 			line = strings.TrimSpace(before)
 		}
 
-		// Strip environment markers
-		if before, _, found := strings.Cut(line, ";"); found {
-			line = strings.TrimSpace(before)
-		}
-
-		// Handle direct URL references (PEP 508): name @ https://...
-		if before, _, found := strings.Cut(line, " @ "); found {
-			line = strings.TrimSpace(before)
-		}
-
-		// Strip extras: name[extra1,extra2]
-		if before, after, found := strings.Cut(line, "["); found {
-			_, rest, _ := strings.Cut(after, "]")
-			line = before + rest
-		}
-
-		// Split name and version specifier
-		var version string
-		pkgName := line
-		// Only == and === pin an exact version
-		if idx := strings.IndexAny(line, "=~!><"); idx >= 0 {
-			pkgName = line[:idx]
-			op := line[idx:]
-			rest := strings.TrimLeft(op, "=~!><")
-			if strings.HasPrefix(op, "==") {
-				version, _, _ = strings.Cut(strings.TrimSpace(rest), ",")
-			}
-		}
-		pkgName = strings.TrimSpace(pkgName)
-
+		pkgName, specifier := parsePEP508(line)
 		if pkgName == "" {
 			continue
 		}
 
-		packages = append(packages, Package{
-			Ecosystem: EcosystemPython,
-			Name:      pkgName,
-			Version:   version,
-		})
+		// Only == and === pin an exact version
+		var version string
+		if pinned, ok := strings.CutPrefix(specifier, "=="); ok {
+			version, _, _ = strings.Cut(strings.TrimSpace(strings.TrimPrefix(pinned, "=")), ",")
+		}
+		packages = append(packages, pythonPackage(pkgName, version, ""))
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -90,3 +62,35 @@ func parseRequirements(data []byte) ([]Package, error) {
 	}
 	return packages, nil
 }
+
+// parsePEP508 splits a dependency specification, such as requests[socks]>=2.31,<3; python_version>"3.8", into
+// the package name and the version specifier, removing extras, environment markers and URLs
+func parsePEP508(requirement string) (string, string) {
+	line := strings.TrimSpace(requirement)
+
+	// Strip environment markers
+	if before, _, found := strings.Cut(line, ";"); found {
+		line = strings.TrimSpace(before)
+	}
+
+	// Handle direct URL references (PEP 508): name @ https://...
+	if before, _, found := strings.Cut(line, "@"); found {
+		line = strings.TrimSpace(before)
+	}
+
+	// Strip extras: name[extra1,extra2]
+	if before, after, found := strings.Cut(line, "["); found {
+		_, rest, _ := strings.Cut(after, "]")
+		line = before + rest
+	}
+
+	// Split name and version specifier, the specifier being optionally in parentheses
+	name := line
+	var specifier string
+	if idx := strings.IndexAny(line, "=~!><( "); idx >= 0 {
+		name = line[:idx]
+		specifier = strings.Trim(strings.TrimSpace(line[idx:]), "()")
+		specifier = strings.ReplaceAll(strings.TrimSpace(specifier), " ", "")
+	}
+	return strings.TrimSpace(name), specifier
+}
@@ -0,0 +1,148 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	spdxDocumentID        = "SPDXRef-DOCUMENT"
+	spdxDescribes         = "DESCRIBES"
+	spdxPURLReferenceType = "purl"
+)
+
+// spdxPackage is a package of an SPDX document, either JSON or tag-value
+type spdxPackage struct {
+	SPDXID       string            `json:"SPDXID"`
+	Name         string            `json:"name"`
+	VersionInfo  string            `json:"versionInfo"`
+	ExternalRefs []spdxExternalRef `json:"externalRefs"`
+}
+
+type spdxExternalRef struct {
+	ReferenceType    string `json:"referenceType"`
+	ReferenceLocator string `json:"referenceLocator"`
+}
+
+func (p spdxPackage) purl() string {
+	for _, ref := range p.ExternalRefs {
+		if ref.ReferenceType == spdxPURLReferenceType {
+			return ref.ReferenceLocator
+		}
+	}
+	return ""
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+func parseSPDXJSON(data []byte) ([]Package, error) {
+	var document struct {
+		DocumentDescribes []string           `json:"documentDescribes"`
+		Packages          []spdxPackage      `json:"packages"`
+		Relationships     []spdxRelationship `json:"relationships"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("SPDX JSON read error: %w", err)
+	}
+	described := slices.Concat(document.DocumentDescribes, spdxDescribed(document.Relationships))
+	return spdxPackages(document.Packages, described), nil
+}
+
+// parseSPDXTagValue reads the packages of an SPDX tag-value document, where each package starts with a PackageName tag
+func parseSPDXTagValue(data []byte) ([]Package, error) {
+	var packages []spdxPackage
+	var relationships []spdxRelationship
+	inText := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Skip multi-line <text> values, which may contain lines looking like tags
+		if inText {
+			inText = !strings.Contains(line, "</text>")
+			continue
+		}
+		tag, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		tag = strings.TrimSpace(tag)
+		value = strings.TrimSpace(value)
+		if strings.HasPrefix(value, "<text>") {
+			inText = !strings.Contains(value, "</text>")
+			continue
+		}
+
+		switch tag {
+		case "PackageName":
+			packages = append(packages, spdxPackage{Name: value})
+		case "SPDXID":
+			if len(packages) > 0 {
+				packages[len(packages)-1].SPDXID = value
+			}
+		case "PackageVersion":
+			if len(packages) > 0 {
+				packages[len(packages)-1].VersionInfo = value
+			}
+		case "ExternalRef":
+			// ExternalRef: <category> <type> <locator>
+			fields := strings.Fields(value)
+			if len(packages) > 0 && len(fields) == 3 {
+				pkg := &packages[len(packages)-1]
+				pkg.ExternalRefs = append(pkg.ExternalRefs, spdxExternalRef{ReferenceType: fields[1], ReferenceLocator: fields[2]})
+			}
+		case "Relationship":
+			// Relationship: <element> <type> <related element>
+			if fields := strings.Fields(value); len(fields) == 3 {
+				relationships = append(relationships, spdxRelationship{
+					SPDXElementID:      fields[0],
+					RelationshipType:   fields[1],
+					RelatedSPDXElement: fields[2],
+				})
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("SPDX tag-value read error: %w", err)
+	}
+	return spdxPackages(packages, spdxDescribed(relationships)), nil
+}
+
+// spdxDescribed returns the IDs of the packages the document describes
+func spdxDescribed(relationships []spdxRelationship) []string {
+	var described []string
+	for _, relationship := range relationships {
+		if relationship.SPDXElementID == spdxDocumentID && relationship.RelationshipType == spdxDescribes {
+			described = append(described, relationship.RelatedSPDXElement)
+		}
+	}
+	return described
+}
+
+// spdxPackages returns the packages of an SPDX document, except the ones the document describes, such as the scanned
+// image or application, which are not dependencies
+func spdxPackages(packages []spdxPackage, described []string) []Package {
+	var result []Package
+	for _, p := range packages {
+		if p.SPDXID != "" && slices.Contains(described, p.SPDXID) {
+			continue
+		}
+		version := p.VersionInfo
+		if version == "NOASSERTION" {
+			version = ""
+		}
+		if pkg := componentPackage(p.purl(), "", p.Name, version); pkg != nil {
+			result = append(result, *pkg)
+		}
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const spdxJSON = `{
+  "spdxVersion": "SPDX-2.3",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "my-image",
+  "packages": [
+    {"SPDXID": "SPDXRef-image", "name": "my-image", "versionInfo": "latest"},
+    {"SPDXID": "SPDXRef-spring", "name": "spring-core", "versionInfo": "5.3.20",
+     "externalRefs": [
+       {"referenceCategory": "SECURITY", "referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:vmware:spring_framework:5.3.20:*:*:*:*:*:*:*"},
+       {"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:maven/org.springframework/spring-core@5.3.20"}
+     ]},
+    {"SPDXID": "SPDXRef-flask", "name": "flask", "versionInfo": "3.0.3",
+     "externalRefs": [{"referenceCategory": "PACKAGE_MANAGER", "referenceType": "purl", "referenceLocator": "pkg:pypi/flask@3.0.3"}]},
+    {"SPDXID": "SPDXRef-other", "name": "other-lib", "versionInfo": "NOASSERTION"}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-image"},
+    {"spdxElementId": "SPDXRef-image", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-spring"}
+  ]
+}`
+
+const spdxTagValue = `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: my-image
+DocumentComment: <text>Generated for
+PackageName: not-a-package
+</text>
+Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-image
+
+PackageName: my-image
+SPDXID: SPDXRef-image
+PackageVersion: latest
+
+PackageName: spring-core
+SPDXID: SPDXRef-spring
+PackageVersion: 5.3.20
+ExternalRef: SECURITY cpe23Type cpe:2.3:a:vmware:spring_framework:5.3.20:*:*:*:*:*:*:*
+ExternalRef: PACKAGE-MANAGER purl pkg:maven/org.springframework/spring-core@5.3.20
+
+PackageName: flask
+SPDXID: SPDXRef-flask
+PackageVersion: 3.0.3
+PackageComment: <text>Web framework</text>
+ExternalRef: PACKAGE-MANAGER purl pkg:pypi/flask@3.0.3
+
+PackageName: other-lib
+SPDXID: SPDXRef-other
+PackageVersion: NOASSERTION
+`
+
+var spdxExpected = []Package{
+	{Ecosystem: EcosystemJava, Name: "spring-core", Version: "5.3.20", Namespace: "org.springframework"},
+	{Ecosystem: EcosystemPython, Name: "flask", Version: "3.0.3"},
+	{Ecosystem: EcosystemUnknown, Name: "other-lib"},
+}
+
+func TestParseSPDXJSON(t *testing.T) {
+	pkgs, err := parseSPDXJSON([]byte(spdxJSON))
+	require.NoError(t, err)
+	assert.Equal(t, spdxExpected, pkgs)
+}
+
+func TestParseSPDXJSON_DocumentDescribes(t *testing.T) {
+	data := `{"spdxVersion": "SPDX-2.2", "documentDescribes": ["SPDXRef-app"], "packages": [
+		{"SPDXID": "SPDXRef-app", "name": "my-app"},
+		{"SPDXID": "SPDXRef-flask", "name": "flask", "versionInfo": "3.0.3"}
+	]}`
+	pkgs, err := parseSPDXJSON([]byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, []Package{{Ecosystem: EcosystemUnknown, Name: "flask", Version: "3.0.3"}}, pkgs)
+}
+
+func TestParseSPDXTagValue(t *testing.T) {
+	pkgs, err := parseSPDXTagValue([]byte(spdxTagValue))
+	require.NoError(t, err)
+	assert.Equal(t, spdxExpected, pkgs)
+}
@@ -10,10 +10,13 @@ import (
 
 	"github.com/content-services/content-sources-backend/pkg/api"
 	"github.com/content-services/content-sources-backend/pkg/clients/pulp_client"
+	"github.com/content-services/content-sources-backend/pkg/config"
 	ce "github.com/content-services/content-sources-backend/pkg/errors"
 	"github.com/content-services/content-sources-backend/pkg/models"
 	"github.com/content-services/content-sources-backend/pkg/tasks/payloads"
+	"github.com/content-services/content-sources-backend/pkg/tasks/queue"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type adminTaskInfoDaoImpl struct {
@@ -176,3 +179,200 @@ func getPulpData(ctx context.Context, ti models.TaskInfo, pulpClient pulp_client
 	}
 	return api.PulpResponse{}, errors.New("incorrect task type")
 }
+
+// deadLetterErrorSQL normalizes a task's error, so failures that only differ by UUIDs, hrefs or counts are grouped together
+const deadLetterErrorSQL = `left(regexp_replace(regexp_replace(coalesce(tasks.error, ''), ` +
+	`'[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}', '<uuid>', 'gi'), '[0-9]+', '<n>', 'g'), 500)`
+
+const deadLetterSignatureSQL = `md5(` + deadLetterErrorSQL + `)`
+
+type deadLetterGroup struct {
+	Typename       string
+	ErrorSignature string
+	Error          string
+	Count          int64
+	OrgCount       int64
+	FirstFailedAt  *time.Time
+	LastFailedAt   *time.Time
+}
+
+// deadLetterTasks returns a query of failed tasks that will not be retried automatically, either because
+// they exhausted their retries, their type is not requeueable, or a cancellation was attempted
+func (a adminTaskInfoDaoImpl) deadLetterTasks(db *gorm.DB, orgID string, typenames []string, errorSignature string) *gorm.DB {
+	query := db.Model(&models.TaskInfo{}).
+		Where("tasks.status = ?", config.TaskStatusFailed).
+		Where("(tasks.next_retry_time IS NULL OR tasks.retries >= ? OR tasks.cancel_attempted = true)", queue.MaxTaskRetries)
+	if orgID != "" {
+		query = query.Where("tasks.org_id = ?", orgID)
+	}
+	if len(typenames) > 0 {
+		query = query.Where("tasks.type IN ?", typenames)
+	}
+	if errorSignature != "" {
+		query = query.Where(deadLetterSignatureSQL+" = ?", errorSignature)
+	}
+	return query
+}
+
+func (a adminTaskInfoDaoImpl) ListDeadLetterGroups(
+	ctx context.Context,
+	pageData api.PaginationData,
+	filterData api.AdminDeadLetterFilterData,
+) (api.AdminDeadLetterGroupCollectionResponse, int64, error) {
+	var total int64
+	var typenames []string
+	if filterData.Typename != "" {
+		typenames = strings.Split(filterData.Typename, ",")
+	}
+
+	groupsQuery := a.deadLetterTasks(a.db.WithContext(ctx), filterData.OrgId, typenames, filterData.ErrorSignature).
+		Select("tasks.type AS typename, " +
+			deadLetterSignatureSQL + " AS error_signature, " +
+			deadLetterErrorSQL + " AS normalized_error, " +
+			"count(*) AS count, count(DISTINCT tasks.org_id) AS org_count, " +
+			"min(tasks.finished_at) AS first_failed_at, max(tasks.finished_at) AS last_failed_at").
+		Group("typename, error_signature, normalized_error")
+
+	result := a.db.WithContext(ctx).Table("(?) AS dead_letter_groups", groupsQuery).Count(&total)
+	if result.Error != nil {
+		return api.AdminDeadLetterGroupCollectionResponse{}, total, TasksDBToApiError(result.Error, nil)
+	}
+
+	sortMap := map[string]string{
+		"typename":        "typename",
+		"count":           "count",
+		"org_count":       "org_count",
+		"first_failed_at": "first_failed_at",
+		"last_failed_at":  "last_failed_at",
+	}
+	order := convertSortByToSQL(pageData.SortBy, sortMap, "count desc")
+
+	var groups []deadLetterGroup
+	result = a.db.WithContext(ctx).Table("(?) AS dead_letter_groups", groupsQuery).
+		Select("typename, error_signature, normalized_error AS error, count, org_count, first_failed_at, last_failed_at").
+		Order(order).Order("error_signature").
+		Offset(pageData.Offset).Limit(pageData.Limit).
+		Scan(&groups)
+	if result.Error != nil {
+		return api.AdminDeadLetterGroupCollectionResponse{}, total, TasksDBToApiError(result.Error, nil)
+	}
+
+	data := make([]api.AdminDeadLetterGroupResponse, len(groups))
+	for i, group := range groups {
+		data[i] = api.AdminDeadLetterGroupResponse{
+			Typename:       group.Typename,
+			ErrorSignature: group.ErrorSignature,
+			Error:          group.Error,
+			Count:          group.Count,
+			OrgCount:       group.OrgCount,
+		}
+		if group.FirstFailedAt != nil {
+			data[i].FirstFailedAt = group.FirstFailedAt.Format(time.RFC3339)
+		}
+		if group.LastFailedAt != nil {
+			data[i].LastFailedAt = group.LastFailedAt.Format(time.RFC3339)
+		}
+	}
+	return api.AdminDeadLetterGroupCollectionResponse{Data: data}, total, nil
+}
+
+// lockDeadLetterTasks returns the ids of the dead-lettered tasks selected by request, locking them for the rest of the transaction
+func (a adminTaskInfoDaoImpl) lockDeadLetterTasks(tx *gorm.DB, request api.AdminDeadLetterRequest) ([]string, error) {
+	query := a.deadLetterTasks(tx, request.OrgId, request.Typenames, request.ErrorSignature)
+	if len(request.TaskUUIDs) > 0 {
+		query = query.Where("tasks.id IN ?", UuidifyStrings(request.TaskUUIDs))
+	}
+
+	var ids []string
+	err := query.Clauses(clause.Locking{Strength: "UPDATE"}).Order("tasks.id").Pluck("tasks.id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (a adminTaskInfoDaoImpl) RetryDeadLetterTasks(ctx context.Context, request api.AdminDeadLetterRequest) ([]string, error) {
+	var ids []string
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		ids, err = a.lockDeadLetterTasks(tx, request)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		retries := gorm.Expr("retries")
+		if request.ResetRetries {
+			retries = gorm.Expr("0")
+		}
+		err = tx.Model(&models.TaskInfo{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"started_at":       nil,
+			"finished_at":      nil,
+			"token":            nil,
+			"next_retry_time":  nil,
+			"cancel_attempted": false,
+			"status":           config.TaskStatusPending,
+			"retries":          retries,
+			"queued_at":        gorm.Expr("clock_timestamp()"),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		// Dependents were canceled when these tasks failed, so they need to run again too
+		err = tx.Model(&models.TaskInfo{}).
+			Where("id IN (SELECT task_id FROM task_dependencies WHERE dependency_id IN ?)", ids).
+			Where("status = ? AND finished_at IS NULL AND error = ?", config.TaskStatusCanceled, "parent task failed").
+			Updates(map[string]interface{}{
+				"error":            nil,
+				"cancel_attempted": false,
+				"status":           config.TaskStatusPending,
+				"queued_at":        gorm.Expr("clock_timestamp()"),
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		// Wake up the workers listening for new tasks
+		return tx.Exec("NOTIFY tasks").Error
+	})
+	if err != nil {
+		return nil, TasksDBToApiError(err, nil)
+	}
+	return ids, nil
+}
+
+func (a adminTaskInfoDaoImpl) CancelDeadLetterTasks(ctx context.Context, request api.AdminDeadLetterRequest) ([]string, error) {
+	var ids []string
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		ids, err = a.lockDeadLetterTasks(tx, request)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		return tx.Model(&models.TaskInfo{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"status":           config.TaskStatusCanceled,
+			"cancel_attempted": true,
+		}).Error
+	})
+	if err != nil {
+		return nil, TasksDBToApiError(err, nil)
+	}
+	return ids, nil
+}
+
+func (a adminTaskInfoDaoImpl) PurgeDeadLetterTasks(ctx context.Context, request api.AdminDeadLetterRequest) ([]string, error) {
+	var ids []string
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		ids, err = a.lockDeadLetterTasks(tx, request)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		// task_dependencies and task_heartbeats rows are removed by cascade
+		return tx.Where("id IN ?", ids).Delete(&models.TaskInfo{}).Error
+	})
+	if err != nil {
+		return nil, TasksDBToApiError(err, nil)
+	}
+	return ids, nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/content-services/content-sources-backend/pkg/models"
 	"github.com/content-services/content-sources-backend/pkg/seeds"
 	"github.com/content-services/content-sources-backend/pkg/tasks/payloads"
+	"github.com/content-services/content-sources-backend/pkg/tasks/queue"
 	"github.com/content-services/content-sources-backend/pkg/utils"
 	zest "github.com/content-services/zest/release/v2026"
 	"github.com/google/uuid"
@@ -773,3 +774,146 @@ func (suite *AdminTaskSuite) TestGetPulpDataInvalidPayload() {
 	_, parseErr := getPulpData(context.Background(), task, mockPulpClient)
 	assert.Error(t, parseErr)
 }
+
+func (suite *AdminTaskSuite) createFailedTask(orgID string, typename string, taskError string, retries int, nextRetryTime *time.Time) models.TaskInfo {
+	queued := time.Now().Add(-time.Minute * 10)
+	started := time.Now().Add(-time.Minute * 5)
+	finished := time.Now()
+	task := models.TaskInfo{
+		Id:            uuid.New(),
+		Typename:      typename,
+		Payload:       []byte("{}"),
+		OrgId:         orgID,
+		Dependencies:  make([]string, 0),
+		Token:         uuid.New(),
+		Queued:        &queued,
+		Started:       &started,
+		Finished:      &finished,
+		Error:         &taskError,
+		Status:        config.TaskStatusFailed,
+		Retries:       retries,
+		NextRetryTime: nextRetryTime,
+	}
+	err := suite.tx.Create(&task).Error
+	assert.NoError(suite.T(), err)
+	return task
+}
+
+func (suite *AdminTaskSuite) TestListDeadLetterGroups() {
+	t := suite.T()
+	orgID := seeds.RandomOrgId()
+	otherOrgID := seeds.RandomOrgId()
+	nextRetryTime := time.Now().Add(time.Hour)
+
+	// Errors only differing by UUIDs and numbers share a group
+	suite.createFailedTask(orgID, "dead letter type", fmt.Sprintf("repository %s failed after 3 attempts", uuid.NewString()), queue.MaxTaskRetries, nil)
+	suite.createFailedTask(otherOrgID, "dead letter type", fmt.Sprintf("repository %s failed after 12 attempts", uuid.NewString()), queue.MaxTaskRetries, nil)
+	suite.createFailedTask(orgID, "dead letter type", "something else went wrong", 0, nil)
+	// Will be retried automatically, so is not dead-lettered
+	suite.createFailedTask(orgID, "dead letter type", "something else went wrong", 1, &nextRetryTime)
+
+	response, total, err := suite.dao.ListDeadLetterGroups(context.Background(), api.PaginationData{Limit: 10}, api.AdminDeadLetterFilterData{Typename: "dead letter type"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, response.Data, 2)
+
+	// sorted by count descending
+	assert.Equal(t, "repository <uuid> failed after <n> attempts", response.Data[0].Error)
+	assert.Equal(t, int64(2), response.Data[0].Count)
+	assert.Equal(t, int64(2), response.Data[0].OrgCount)
+	assert.NotEmpty(t, response.Data[0].FirstFailedAt)
+	assert.Equal(t, "something else went wrong", response.Data[1].Error)
+	assert.Equal(t, int64(1), response.Data[1].Count)
+
+	// Filter by signature and org
+	response, total, err = suite.dao.ListDeadLetterGroups(context.Background(), api.PaginationData{Limit: 10}, api.AdminDeadLetterFilterData{
+		OrgId:          otherOrgID,
+		ErrorSignature: response.Data[0].ErrorSignature,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, int64(1), response.Data[0].Count)
+}
+
+func (suite *AdminTaskSuite) TestRetryDeadLetterTasks() {
+	t := suite.T()
+	orgID := seeds.RandomOrgId()
+
+	task := suite.createFailedTask(orgID, "dead letter type", "failed", queue.MaxTaskRetries, nil)
+	other := suite.createFailedTask(orgID, "other dead letter type", "failed", queue.MaxTaskRetries, nil)
+
+	// A dependent canceled because the task failed is retried along with it
+	dependent := models.TaskInfo{
+		Id:              uuid.New(),
+		Typename:        "dead letter type",
+		OrgId:           orgID,
+		Status:          config.TaskStatusCanceled,
+		Error:           utils.Ptr("parent task failed"),
+		CancelAttempted: true,
+	}
+	assert.NoError(t, suite.tx.Create(&dependent).Error)
+	assert.NoError(t, suite.tx.Exec("INSERT INTO task_dependencies VALUES (?, ?)", dependent.Id, task.Id).Error)
+
+	ids, err := suite.dao.RetryDeadLetterTasks(context.Background(), api.AdminDeadLetterRequest{
+		OrgId:        orgID,
+		Typenames:    []string{"dead letter type"},
+		ResetRetries: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{task.Id.String()}, ids)
+
+	var retried models.TaskInfo
+	assert.NoError(t, suite.tx.Where("id = ?", task.Id).First(&retried).Error)
+	assert.Equal(t, config.TaskStatusPending, retried.Status)
+	assert.Equal(t, 0, retried.Retries)
+	assert.Nil(t, retried.Started)
+	assert.Nil(t, retried.Finished)
+
+	assert.NoError(t, suite.tx.Where("id = ?", dependent.Id).First(&retried).Error)
+	assert.Equal(t, config.TaskStatusPending, retried.Status)
+	assert.False(t, retried.CancelAttempted)
+	assert.Nil(t, retried.Error)
+
+	assert.NoError(t, suite.tx.Where("id = ?", other.Id).First(&retried).Error)
+	assert.Equal(t, config.TaskStatusFailed, retried.Status)
+}
+
+func (suite *AdminTaskSuite) TestCancelDeadLetterTasks() {
+	t := suite.T()
+	orgID := seeds.RandomOrgId()
+
+	task := suite.createFailedTask(orgID, "dead letter type", "failed", queue.MaxTaskRetries, nil)
+
+	ids, err := suite.dao.CancelDeadLetterTasks(context.Background(), api.AdminDeadLetterRequest{TaskUUIDs: []string{task.Id.String()}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{task.Id.String()}, ids)
+
+	var canceled models.TaskInfo
+	assert.NoError(t, suite.tx.Where("id = ?", task.Id).First(&canceled).Error)
+	assert.Equal(t, config.TaskStatusCanceled, canceled.Status)
+	assert.True(t, canceled.CancelAttempted)
+
+	// Canceled tasks are no longer dead-lettered
+	ids, err = suite.dao.CancelDeadLetterTasks(context.Background(), api.AdminDeadLetterRequest{TaskUUIDs: []string{task.Id.String()}})
+	assert.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+func (suite *AdminTaskSuite) TestPurgeDeadLetterTasks() {
+	t := suite.T()
+	orgID := seeds.RandomOrgId()
+
+	task := suite.createFailedTask(orgID, "dead letter type", "failed", queue.MaxTaskRetries, nil)
+	nextRetryTime := time.Now().Add(time.Hour)
+	retrying := suite.createFailedTask(orgID, "dead letter type", "failed", 0, &nextRetryTime)
+
+	ids, err := suite.dao.PurgeDeadLetterTasks(context.Background(), api.AdminDeadLetterRequest{OrgId: orgID})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{task.Id.String()}, ids)
+
+	var count int64
+	assert.NoError(t, suite.tx.Model(&models.TaskInfo{}).Where("id = ?", task.Id).Count(&count).Error)
+	assert.Equal(t, int64(0), count)
+	assert.NoError(t, suite.tx.Model(&models.TaskInfo{}).Where("id = ?", retrying.Id).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
@@ -0,0 +1,120 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/config"
+	ce "github.com/content-services/content-sources-backend/pkg/errors"
+	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type auditEventDaoImpl struct {
+	db *gorm.DB
+}
+
+func GetAuditEventDao(db *gorm.DB) AuditEventDao {
+	return auditEventDaoImpl{db: db}
+}
+
+func (d auditEventDaoImpl) Create(ctx context.Context, auditEvent models.AuditEvent) error {
+	if err := d.db.WithContext(ctx).Create(&auditEvent).Error; err != nil {
+		return fmt.Errorf("could not create audit event: %w", err)
+	}
+	return nil
+}
+
+// Cleanup deletes the audit events older than options.audit_event_retain_days_limit
+func (d auditEventDaoImpl) Cleanup(ctx context.Context) error {
+	result := d.db.WithContext(ctx).
+		Where("created_at < (current_date - make_interval(days => ?))", config.Get().Options.AuditEventRetainDaysLimit).
+		Delete(&models.AuditEvent{})
+	if result.Error != nil {
+		return fmt.Errorf("could not clean up audit events: %w", result.Error)
+	}
+	log.Logger.Debug().Msgf("Cleaned up %v old audit events", result.RowsAffected)
+	return nil
+}
+
+func (d auditEventDaoImpl) List(ctx context.Context, orgID string, pageData api.PaginationData, filterData api.AuditEventFilterData) (api.AuditEventCollectionResponse, int64, error) {
+	var total int64
+	auditEvents := make([]models.AuditEvent, 0)
+
+	filteredDB := d.filteredDB(ctx, orgID, filterData)
+	if err := filteredDB.Count(&total).Error; err != nil {
+		return api.AuditEventCollectionResponse{}, 0, auditEventDBToApiError(err)
+	}
+
+	sortMap := map[string]string{
+		"created_at":  "created_at",
+		"username":    "username",
+		"status_code": "status_code",
+	}
+	order := convertSortByToSQL(pageData.SortBy, sortMap, "created_at desc")
+	if err := filteredDB.Order(order).Offset(pageData.Offset).Limit(pageData.Limit).Find(&auditEvents).Error; err != nil {
+		return api.AuditEventCollectionResponse{}, 0, auditEventDBToApiError(err)
+	}
+	return api.AuditEventCollectionResponse{Data: auditEventsModelToApi(auditEvents)}, total, nil
+}
+
+// Export returns the most recent audit events matching the filters, up to api.AuditEventExportLimit
+func (d auditEventDaoImpl) Export(ctx context.Context, orgID string, filterData api.AuditEventFilterData) ([]api.AuditEventResponse, error) {
+	auditEvents := make([]models.AuditEvent, 0)
+	err := d.filteredDB(ctx, orgID, filterData).
+		Order("created_at desc").
+		Limit(api.AuditEventExportLimit).
+		Find(&auditEvents).Error
+	if err != nil {
+		return nil, auditEventDBToApiError(err)
+	}
+	return auditEventsModelToApi(auditEvents), nil
+}
+
+func (d auditEventDaoImpl) filteredDB(ctx context.Context, orgID string, filterData api.AuditEventFilterData) *gorm.DB {
+	filteredDB := d.db.WithContext(ctx).Model(&models.AuditEvent{}).Where("org_id = ?", orgID)
+	if filterData.ObjectUUID != "" {
+		filteredDB = filteredDB.Where("? = ANY(object_uuids)", filterData.ObjectUUID)
+	}
+	if filterData.Username != "" {
+		filteredDB = filteredDB.Where("username = ?", filterData.Username)
+	}
+	if filterData.StartTime != nil {
+		filteredDB = filteredDB.Where("created_at >= ?", *filterData.StartTime)
+	}
+	if filterData.EndTime != nil {
+		filteredDB = filteredDB.Where("created_at < ?", *filterData.EndTime)
+	}
+	return filteredDB
+}
+
+func auditEventDBToApiError(e error) *ce.DaoError {
+	daoError := ce.DaoError{Message: e.Error()}
+	daoError.Wrap(e)
+	return &daoError
+}
+
+func auditEventsModelToApi(auditEvents []models.AuditEvent) []api.AuditEventResponse {
+	data := make([]api.AuditEventResponse, len(auditEvents))
+	for i, auditEvent := range auditEvents {
+		data[i] = api.AuditEventResponse{
+			UUID:        auditEvent.UUID,
+			Username:    auditEvent.Username,
+			AccountID:   auditEvent.AccountID,
+			RequestID:   auditEvent.RequestID,
+			Method:      auditEvent.Method,
+			Route:       auditEvent.Route,
+			Path:        auditEvent.Path,
+			ObjectUUIDs: auditEvent.ObjectUUIDs,
+			Request:     auditEvent.Request,
+			StatusCode:  auditEvent.StatusCode,
+			CreatedAt:   auditEvent.CreatedAt,
+		}
+		if auditEvent.Error != nil {
+			data[i].Error = *auditEvent.Error
+		}
+	}
+	return data
+}
@@ -0,0 +1,118 @@
+package dao
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/content-services/content-sources-backend/pkg/seeds"
+	"github.com/content-services/content-sources-backend/pkg/utils"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditEventSuite struct {
+	*DaoSuite
+}
+
+func TestAuditEventSuite(t *testing.T) {
+	m := DaoSuite{}
+	r := AuditEventSuite{DaoSuite: &m}
+	suite.Run(t, &r)
+}
+
+func (s *AuditEventSuite) createAuditEvent(orgID string, username string, createdAt time.Time, objectUUIDs ...string) {
+	dao := auditEventDaoImpl{db: s.tx}
+	err := dao.Create(context.Background(), models.AuditEvent{
+		Base:        models.Base{CreatedAt: createdAt},
+		OrgID:       orgID,
+		Username:    username,
+		Method:      http.MethodDelete,
+		Route:       "/repositories/:uuid",
+		Path:        "/api/content-sources/v1/repositories/" + objectUUIDs[0],
+		ObjectUUIDs: pq.StringArray(objectUUIDs),
+		StatusCode:  http.StatusNoContent,
+	})
+	require.NoError(s.T(), err)
+}
+
+func (s *AuditEventSuite) TestList() {
+	t := s.T()
+	dao := auditEventDaoImpl{db: s.tx}
+	orgID := seeds.RandomOrgId()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	s.createAuditEvent(orgID, "alice", now.Add(-2*time.Hour), "a")
+	s.createAuditEvent(orgID, "bob", now.Add(-time.Hour), "b", "c")
+	s.createAuditEvent(orgID, "alice", now, "c")
+	s.createAuditEvent(seeds.RandomOrgId(), "alice", now, "a")
+
+	list, total, err := dao.List(context.Background(), orgID, api.PaginationData{Limit: 10}, api.AuditEventFilterData{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, list.Data, 3)
+	assert.Equal(t, []string{"c"}, list.Data[0].ObjectUUIDs)
+	assert.Equal(t, http.StatusNoContent, list.Data[0].StatusCode)
+
+	list, total, err = dao.List(context.Background(), orgID, api.PaginationData{Limit: 10}, api.AuditEventFilterData{ObjectUUID: "c"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, "alice", list.Data[0].Username)
+	assert.Equal(t, "bob", list.Data[1].Username)
+
+	_, total, err = dao.List(context.Background(), orgID, api.PaginationData{Limit: 10}, api.AuditEventFilterData{Username: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+
+	list, total, err = dao.List(context.Background(), orgID, api.PaginationData{Limit: 10}, api.AuditEventFilterData{
+		StartTime: utils.Ptr(now.Add(-time.Hour)),
+		EndTime:   utils.Ptr(now),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "bob", list.Data[0].Username)
+}
+
+func (s *AuditEventSuite) TestExport() {
+	t := s.T()
+	dao := auditEventDaoImpl{db: s.tx}
+	orgID := seeds.RandomOrgId()
+	now := time.Now().UTC()
+
+	s.createAuditEvent(orgID, "alice", now.Add(-time.Hour), "a")
+	s.createAuditEvent(orgID, "bob", now, "b")
+
+	auditEvents, err := dao.Export(context.Background(), orgID, api.AuditEventFilterData{})
+	require.NoError(t, err)
+	require.Len(t, auditEvents, 2)
+	assert.Equal(t, "bob", auditEvents[0].Username)
+	assert.Equal(t, "alice", auditEvents[1].Username)
+
+	auditEvents, err = dao.Export(context.Background(), orgID, api.AuditEventFilterData{Username: "alice"})
+	require.NoError(t, err)
+	require.Len(t, auditEvents, 1)
+	assert.Equal(t, []string{"a"}, auditEvents[0].ObjectUUIDs)
+}
+
+func (s *AuditEventSuite) TestCleanup() {
+	t := s.T()
+	dao := auditEventDaoImpl{db: s.tx}
+	orgID := seeds.RandomOrgId()
+	retainDays := config.Get().Options.AuditEventRetainDaysLimit
+	now := time.Now().UTC()
+
+	s.createAuditEvent(orgID, "alice", now.AddDate(0, 0, -retainDays-2), "a")
+	s.createAuditEvent(orgID, "bob", now.AddDate(0, 0, -retainDays+2), "b")
+
+	require.NoError(t, dao.Cleanup(context.Background()))
+	auditEvents, err := dao.Export(context.Background(), orgID, api.AuditEventFilterData{})
+	require.NoError(t, err)
+	require.Len(t, auditEvents, 1)
+	assert.Equal(t, "bob", auditEvents[0].Username)
+}
@@ -0,0 +1,191 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/coverage/matcher"
+	ce "github.com/content-services/content-sources-backend/pkg/errors"
+	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// coverageDemandGroupBy groups the demand signals by package, regardless of the version
+const coverageDemandGroupBy = "ecosystem, COALESCE(namespace, ''), name"
+
+type coverageDemandSignalDaoImpl struct {
+	db *gorm.DB
+}
+
+// coverageDemandGroup is a row of the demand signals aggregated by package
+type coverageDemandGroup struct {
+	Ecosystem      string
+	Namespace      string
+	Name           string
+	SignalCount    int
+	OrgCount       int
+	UnmatchedCount int
+	PartialCount   int
+	FirstSeenAt    time.Time
+	LastSeenAt     time.Time
+	Versions       pq.StringArray `gorm:"type:text[]"`
+}
+
+// coverageDemandTrendRow is a row of the demand signals aggregated by package and period
+type coverageDemandTrendRow struct {
+	PeriodStart time.Time
+	Ecosystem   string
+	Namespace   string
+	Name        string
+	SignalCount int
+	OrgCount    int
+}
+
+type coverageDemandKey struct {
+	Ecosystem string
+	Namespace string
+	Name      string
+}
+
+func (d coverageDemandSignalDaoImpl) List(ctx context.Context, pageData api.PaginationData, filterData api.CoverageDemandFilterData) (api.CoverageDemandSummaryCollectionResponse, int64, error) {
+	var total int64
+	groupedDB := d.groupedDB(ctx, filterData)
+	if err := d.db.WithContext(ctx).Table("(?) AS demand", groupedDB).Count(&total).Error; err != nil {
+		return api.CoverageDemandSummaryCollectionResponse{}, 0, coverageDemandDBToApiError(err)
+	}
+
+	sortMap := map[string]string{
+		"org_count":     "org_count",
+		"signal_count":  "signal_count",
+		"last_seen_at":  "last_seen_at",
+		"first_seen_at": "first_seen_at",
+		"name":          "name",
+	}
+	order := convertSortByToSQL(pageData.SortBy, sortMap, "org_count desc, signal_count desc, name asc")
+	var groups []coverageDemandGroup
+	if err := groupedDB.Order(order).Offset(pageData.Offset).Limit(pageData.Limit).Scan(&groups).Error; err != nil {
+		return api.CoverageDemandSummaryCollectionResponse{}, 0, coverageDemandDBToApiError(err)
+	}
+
+	data, err := d.summaries(ctx, filterData, groups)
+	if err != nil {
+		return api.CoverageDemandSummaryCollectionResponse{}, 0, err
+	}
+	return api.CoverageDemandSummaryCollectionResponse{Data: data}, total, nil
+}
+
+// Export returns the most requested packages matching the filters, up to api.CoverageDemandExportLimit
+func (d coverageDemandSignalDaoImpl) Export(ctx context.Context, filterData api.CoverageDemandFilterData) ([]api.CoverageDemandSummaryResponse, error) {
+	var groups []coverageDemandGroup
+	err := d.groupedDB(ctx, filterData).
+		Order("org_count desc, signal_count desc, name asc").
+		Limit(api.CoverageDemandExportLimit).
+		Scan(&groups).Error
+	if err != nil {
+		return nil, coverageDemandDBToApiError(err)
+	}
+	return d.summaries(ctx, filterData, groups)
+}
+
+func (d coverageDemandSignalDaoImpl) filteredDB(ctx context.Context, filterData api.CoverageDemandFilterData) *gorm.DB {
+	filteredDB := d.db.WithContext(ctx).Model(&models.CoverageDemandSignal{})
+	if filterData.Ecosystem != "" {
+		filteredDB = filteredDB.Where("ecosystem = ?", filterData.Ecosystem)
+	}
+	if filterData.Search != "" {
+		containsSearch := "%" + filterData.Search + "%"
+		filteredDB = filteredDB.Where("name ILIKE ? OR namespace ILIKE ?", containsSearch, containsSearch)
+	}
+	if filterData.StartTime != nil {
+		filteredDB = filteredDB.Where("created_at >= ?", *filterData.StartTime)
+	}
+	if filterData.EndTime != nil {
+		filteredDB = filteredDB.Where("created_at < ?", *filterData.EndTime)
+	}
+	return filteredDB
+}
+
+func (d coverageDemandSignalDaoImpl) groupedDB(ctx context.Context, filterData api.CoverageDemandFilterData) *gorm.DB {
+	return d.filteredDB(ctx, filterData).
+		Select(`ecosystem, COALESCE(namespace, '') AS namespace, name,
+			COUNT(*) AS signal_count,
+			COUNT(DISTINCT org_id) AS org_count,
+			COUNT(*) FILTER (WHERE match_status = ?) AS unmatched_count,
+			COUNT(*) FILTER (WHERE match_status = ?) AS partial_count,
+			MIN(created_at) AS first_seen_at,
+			MAX(created_at) AS last_seen_at,
+			ARRAY_AGG(DISTINCT version) AS versions`,
+			models.CoverageDemandMatchStatusNone, models.CoverageDemandMatchStatusPartial).
+		Group(coverageDemandGroupBy)
+}
+
+// summaries converts the aggregated packages to responses, along with the trend of their demand signals
+func (d coverageDemandSignalDaoImpl) summaries(ctx context.Context, filterData api.CoverageDemandFilterData, groups []coverageDemandGroup) ([]api.CoverageDemandSummaryResponse, error) {
+	data := make([]api.CoverageDemandSummaryResponse, len(groups))
+	if len(groups) == 0 {
+		return data, nil
+	}
+
+	interval := filterData.Interval
+	if interval == "" {
+		interval = api.CoverageDemandIntervalWeek
+	}
+	keys := make([][]any, len(groups))
+	for i, group := range groups {
+		keys[i] = []any{group.Ecosystem, group.Namespace, group.Name}
+	}
+	var rows []coverageDemandTrendRow
+	err := d.filteredDB(ctx, filterData).
+		Select(`date_trunc(?, created_at) AS period_start, ecosystem, COALESCE(namespace, '') AS namespace, name,
+			COUNT(*) AS signal_count,
+			COUNT(DISTINCT org_id) AS org_count`, interval).
+		Where("("+coverageDemandGroupBy+") IN ?", keys).
+		Group("1, 2, 3, 4").
+		Order("1").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, coverageDemandDBToApiError(err)
+	}
+	trends := map[coverageDemandKey][]api.CoverageDemandTrendItem{}
+	for _, row := range rows {
+		key := coverageDemandKey{Ecosystem: row.Ecosystem, Namespace: row.Namespace, Name: row.Name}
+		trends[key] = append(trends[key], api.CoverageDemandTrendItem{
+			PeriodStart: row.PeriodStart,
+			SignalCount: row.SignalCount,
+			OrgCount:    row.OrgCount,
+		})
+	}
+
+	for i, group := range groups {
+		data[i] = api.CoverageDemandSummaryResponse{
+			Ecosystem:      group.Ecosystem,
+			Namespace:      group.Namespace,
+			Name:           group.Name,
+			VersionCount:   len(group.Versions),
+			SignalCount:    group.SignalCount,
+			OrgCount:       group.OrgCount,
+			UnmatchedCount: group.UnmatchedCount,
+			PartialCount:   group.PartialCount,
+			FirstSeenAt:    group.FirstSeenAt,
+			LastSeenAt:     group.LastSeenAt,
+			Trend:          trends[coverageDemandKey{Ecosystem: group.Ecosystem, Namespace: group.Namespace, Name: group.Name}],
+		}
+		for _, version := range group.Versions {
+			if data[i].MinVersion == "" || matcher.CompareVersions(group.Ecosystem, version, data[i].MinVersion) < 0 {
+				data[i].MinVersion = version
+			}
+			if data[i].MaxVersion == "" || matcher.CompareVersions(group.Ecosystem, version, data[i].MaxVersion) > 0 {
+				data[i].MaxVersion = version
+			}
+		}
+	}
+	return data, nil
+}
+
+func coverageDemandDBToApiError(e error) *ce.DaoError {
+	daoError := ce.DaoError{Message: e.Error()}
+	daoError.Wrap(e)
+	return &daoError
+}
@@ -0,0 +1,94 @@
+package dao
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/coverage/matcher"
+	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/content-services/content-sources-backend/pkg/seeds"
+	"github.com/content-services/content-sources-backend/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type CoverageDemandSignalDaoSuite struct {
+	*DaoSuite
+}
+
+func TestCoverageDemandSignalDaoSuite(t *testing.T) {
+	m := DaoSuite{}
+	r := CoverageDemandSignalDaoSuite{DaoSuite: &m}
+	suite.Run(t, &r)
+}
+
+func (s *CoverageDemandSignalDaoSuite) createSignal(orgID string, namespace *string, name string, version string, matchStatus string, createdAt time.Time) {
+	signal := models.CoverageDemandSignal{
+		CreatedAt:   createdAt,
+		OrgID:       &orgID,
+		Ecosystem:   matcher.EcosystemJava,
+		Namespace:   namespace,
+		Name:        name,
+		Version:     version,
+		MatchStatus: matchStatus,
+		Source:      models.CoverageDemandSourceProspectDriven,
+	}
+	require.NoError(s.T(), s.tx.Create(&signal).Error)
+}
+
+func (s *CoverageDemandSignalDaoSuite) TestList() {
+	t := s.T()
+	dao := coverageDemandSignalDaoImpl{db: s.tx}
+	// Packages are named after a random prefix to ignore the signals of other tests
+	prefix := uuid.NewString()
+	popular, other := prefix+"-popular", prefix+"-other"
+	orgA, orgB := seeds.RandomOrgId(), seeds.RandomOrgId()
+	week := time.Date(2026, 9, 7, 12, 0, 0, 0, time.UTC)
+
+	s.createSignal(orgA, utils.Ptr("io.netty"), popular, "4.1.9.Final", models.CoverageDemandMatchStatusNone, week.Add(-7*24*time.Hour))
+	s.createSignal(orgA, utils.Ptr("io.netty"), popular, "4.1.108.Final", models.CoverageDemandMatchStatusNone, week)
+	s.createSignal(orgB, utils.Ptr("io.netty"), popular, "4.1.10.Final", models.CoverageDemandMatchStatusPartial, week)
+	s.createSignal(orgA, nil, other, "1.0.0", models.CoverageDemandMatchStatusNone, week)
+
+	list, total, err := dao.List(context.Background(), api.PaginationData{Limit: 10}, api.CoverageDemandFilterData{Search: prefix})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, list.Data, 2)
+
+	summary := list.Data[0]
+	assert.Equal(t, "io.netty", summary.Namespace)
+	assert.Equal(t, popular, summary.Name)
+	assert.Equal(t, 3, summary.SignalCount)
+	assert.Equal(t, 2, summary.OrgCount)
+	assert.Equal(t, 2, summary.UnmatchedCount)
+	assert.Equal(t, 1, summary.PartialCount)
+	assert.Equal(t, 3, summary.VersionCount)
+	assert.Equal(t, "4.1.9.Final", summary.MinVersion)
+	assert.Equal(t, "4.1.108.Final", summary.MaxVersion)
+	require.Len(t, summary.Trend, 2)
+	assert.Equal(t, 1, summary.Trend[0].SignalCount)
+	assert.Equal(t, 2, summary.Trend[1].SignalCount)
+	assert.Equal(t, 2, summary.Trend[1].OrgCount)
+	assert.Equal(t, "", list.Data[1].Namespace)
+	assert.Equal(t, other, list.Data[1].Name)
+
+	list, total, err = dao.List(context.Background(), api.PaginationData{Limit: 10}, api.CoverageDemandFilterData{
+		Search:    prefix,
+		StartTime: utils.Ptr(week),
+		Interval:  api.CoverageDemandIntervalDay,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, 2, list.Data[0].SignalCount)
+	assert.Len(t, list.Data[0].Trend, 1)
+
+	exported, err := dao.Export(context.Background(), api.CoverageDemandFilterData{Search: other})
+	require.NoError(t, err)
+	require.Len(t, exported, 1)
+	assert.Equal(t, other, exported[0].Name)
+	assert.Equal(t, "1.0.0", exported[0].MinVersion)
+}
@@ -1,19 +1,30 @@
 package dao
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
 
 	"github.com/content-services/content-sources-backend/pkg/api"
 	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/coverage/matcher"
 	ce "github.com/content-services/content-sources-backend/pkg/errors"
+	"github.com/content-services/content-sources-backend/pkg/lightwell/severity"
 	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/content-services/content-sources-backend/pkg/utils"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
 type CreateCoverageReportParams struct {
-	OrgID     string
-	AccountID *string
+	OrgID       string
+	AccountID   *string
+	InputFormat string   // Format of the manifest detected by the parser
+	Warnings    []string // Manifest entries the parser could not resolve to a package
 }
 
 type CreateCoverageUploadParams struct {
@@ -23,10 +34,47 @@ type CreateCoverageUploadParams struct {
 	SizeBytes  int64
 }
 
+type RefreshCoverageReportParams struct {
+	ScheduledRefresh *bool // Defaults to the setting of the refreshed report
+	Automatic        bool  // Refresh created by the scheduled refresh job, superseded by the next one
+}
+
+type MatchCoverageRefreshParams struct {
+	Catalog           []matcher.Package // Packages of the catalog, one per version
+	CatalogSnapshotAt time.Time
+}
+
+// ScheduledCoverageRefresh is a coverage report to re-evaluate periodically
+type ScheduledCoverageRefresh struct {
+	UUID      string
+	OrgID     string
+	AccountID *string
+	Covered   int // Number of exact and partial matches
+}
+
+// exportPackagesPageSize is how many packages are loaded at a time by ExportPackages
+const exportPackagesPageSize = 1000
+
 type coverageReportDaoImpl struct {
 	db *gorm.DB
 }
 
+// coverageAdvisory is an advisory of a lightwell repository, along with the content type of the repository
+type coverageAdvisory struct {
+	ContentType   string
+	PackageName   string
+	AdvisoryID    string
+	Severity      string
+	FixedVersions pq.StringArray `gorm:"type:text[]"`
+}
+
+// coverageEcosystems maps the content types of the lightwell repositories to the ecosystems of coverage reports
+var coverageEcosystems = map[string]string{
+	config.ContentTypeMaven:  matcher.EcosystemJava,
+	config.ContentTypePython: matcher.EcosystemPython,
+	config.ContentTypeNpm:    matcher.EcosystemNpm,
+}
+
 func (d coverageReportDaoImpl) Create(ctx context.Context, reportParams CreateCoverageReportParams, uploadParams CreateCoverageUploadParams) (api.CoverageReportResponse, error) {
 	var report models.CoverageReport
 	var upload models.CoverageUpload
@@ -66,8 +114,45 @@ func (d coverageReportDaoImpl) ListPackages(ctx context.Context, orgID string, r
 		return api.CoverageReportPackageCollectionResponse{}, 0, &ce.DaoError{Message: "Coverage report not found", NotFound: true}
 	}
 
-	query := d.db.WithContext(ctx).Model(&models.CoverageReportPackage{}).Where("coverage_report_uuid = ?", reportUUID)
+	query := d.packagesDB(ctx, reportUUID, filterData)
+	if filterData.Vulnerable != nil {
+		vulnerableUUIDs, err := d.vulnerablePackageUUIDs(ctx, d.packagesDB(ctx, reportUUID, filterData))
+		if err != nil {
+			return api.CoverageReportPackageCollectionResponse{}, 0, d.toApiError(err)
+		}
+		// Passed as a single array parameter, as large reports may have more vulnerable packages than bind parameters
+		if *filterData.Vulnerable {
+			query = query.Where("uuid = ANY(?::uuid[])", pq.Array(vulnerableUUIDs))
+		} else {
+			query = query.Where("NOT (uuid = ANY(?::uuid[]))", pq.Array(vulnerableUUIDs))
+		}
+	}
+
+	var totalPackages int64
+	if err := query.Count(&totalPackages).Error; err != nil {
+		return api.CoverageReportPackageCollectionResponse{}, 0, d.toApiError(err)
+	}
+
+	var packages []models.CoverageReportPackage
+	if err := query.Order("name ASC").Offset(pageData.Offset).Limit(pageData.Limit).Find(&packages).Error; err != nil {
+		return api.CoverageReportPackageCollectionResponse{}, 0, d.toApiError(err)
+	}
+	advisories, err := d.packageAdvisories(ctx, packages)
+	if err != nil {
+		return api.CoverageReportPackageCollectionResponse{}, 0, d.toApiError(err)
+	}
+
+	items := make([]api.CoverageReportPackageResponse, len(packages))
+	for i, pkg := range packages {
+		items[i] = d.packageModelToResponse(pkg)
+		annotateAdvisories(&items[i], advisories[pkg.UUID])
+	}
+
+	return api.CoverageReportPackageCollectionResponse{Data: items}, totalPackages, nil
+}
 
+func (d coverageReportDaoImpl) packagesDB(ctx context.Context, reportUUID string, filterData api.ListCoverageReportPackagesRequest) *gorm.DB {
+	query := d.db.WithContext(ctx).Model(&models.CoverageReportPackage{}).Where("coverage_report_uuid = ?", reportUUID)
 	if filterData.Search != "" {
 		query = query.Where("name ILIKE ?", "%"+filterData.Search+"%")
 	}
@@ -81,33 +166,552 @@ func (d coverageReportDaoImpl) ListPackages(ctx context.Context, orgID string, r
 			query = query.Where("match_status = ?", models.CoverageMatchStatusNone)
 		}
 	}
+	return query
+}
 
-	var totalPackages int64
-	if err := query.Count(&totalPackages).Error; err != nil {
-		return api.CoverageReportPackageCollectionResponse{}, 0, d.toApiError(err)
+// coverageAdvisoryExistsSQL restricts the packages of a report to the covered ones with a lightwell advisory for their
+// name in their ecosystem, mirroring matcher.AdvisoryPackageName and coverageEcosystems. Only the version comparison is
+// left to the matcher.
+const coverageAdvisoryExistsSQL = `match_status <> ? AND EXISTS (
+	SELECT 1 FROM lightwell_advisories la
+	INNER JOIN repository_configurations rc ON rc.uuid = la.repository_configuration_uuid
+	INNER JOIN repositories r ON r.uuid = rc.repository_uuid
+	WHERE rc.org_id = ?
+	AND r.content_type = CASE coverage_report_packages.ecosystem WHEN ? THEN ? WHEN ? THEN ? WHEN ? THEN ? END
+	AND LOWER(la.package_name) = CASE
+		WHEN coverage_report_packages.ecosystem = ?
+			THEN LOWER(COALESCE(coverage_report_packages.namespace, '')) || ':' || LOWER(coverage_report_packages.name)
+		WHEN coverage_report_packages.ecosystem = ? AND COALESCE(coverage_report_packages.namespace, '') NOT IN ('', '-')
+			THEN '@' || LTRIM(LOWER(coverage_report_packages.namespace), '@') || '/' || LOWER(coverage_report_packages.name)
+		ELSE LOWER(coverage_report_packages.name)
+	END
+)`
+
+// coverageAdvisoryBatchSize is the number of candidate packages compared to their advisories at once
+const coverageAdvisoryBatchSize = 1000
+
+// vulnerablePackageUUIDs returns the UUIDs of the packages of a query affected by a lightwell advisory. Packages with
+// an advisory for their name are selected in SQL, then compared to the fixed versions in batches.
+func (d coverageReportDaoImpl) vulnerablePackageUUIDs(ctx context.Context, query *gorm.DB) ([]string, error) {
+	vulnerableUUIDs := []string{}
+	var candidates []models.CoverageReportPackage
+	err := query.
+		Where(coverageAdvisoryExistsSQL,
+			models.CoverageMatchStatusNone,
+			config.LightwellOrg,
+			matcher.EcosystemJava, config.ContentTypeMaven,
+			matcher.EcosystemPython, config.ContentTypePython,
+			matcher.EcosystemNpm, config.ContentTypeNpm,
+			matcher.EcosystemJava,
+			matcher.EcosystemNpm).
+		FindInBatches(&candidates, coverageAdvisoryBatchSize, func(_ *gorm.DB, _ int) error {
+			advisories, err := d.packageAdvisories(ctx, candidates)
+			if err != nil {
+				return err
+			}
+			vulnerableUUIDs = append(vulnerableUUIDs, slices.Collect(maps.Keys(advisories))...)
+			return nil
+		}).Error
+	if err != nil {
+		return nil, err
 	}
+	return vulnerableUUIDs, nil
+}
 
-	var packages []models.CoverageReportPackage
-	if err := query.Order("name ASC").Offset(pageData.Offset).Limit(pageData.Limit).Find(&packages).Error; err != nil {
-		return api.CoverageReportPackageCollectionResponse{}, 0, d.toApiError(err)
+// packageAdvisories returns the advisories of the lightwell repositories affecting the version of each covered
+// package, by package UUID
+func (d coverageReportDaoImpl) packageAdvisories(ctx context.Context, packages []models.CoverageReportPackage) (map[string][]coverageAdvisory, error) {
+	names := map[string]struct{}{}
+	for _, pkg := range packages {
+		if pkg.MatchStatus != models.CoverageMatchStatusNone {
+			names[matcher.AdvisoryPackageName(coveragePackageToMatcher(pkg))] = struct{}{}
+		}
+	}
+	if len(names) == 0 {
+		return map[string][]coverageAdvisory{}, nil
 	}
 
-	items := make([]api.CoverageReportPackageResponse, len(packages))
-	for i, pkg := range packages {
-		items[i] = api.CoverageReportPackageResponse{
-			Name:      pkg.Name,
-			Version:   pkg.Version,
-			Ecosystem: pkg.Ecosystem,
-			Covered:   pkg.MatchStatus != models.CoverageMatchStatusNone,
+	var advisories []coverageAdvisory
+	err := d.db.WithContext(ctx).
+		Table("lightwell_advisories AS la").
+		Select("r.content_type, LOWER(la.package_name) AS package_name, la.advisory_id, la.severity, la.fixed_versions").
+		Joins("INNER JOIN repository_configurations rc ON rc.uuid = la.repository_configuration_uuid").
+		Joins("INNER JOIN repositories r ON r.uuid = rc.repository_uuid").
+		Where("rc.org_id = ? AND LOWER(la.package_name) IN ?", config.LightwellOrg, slices.Collect(maps.Keys(names))).
+		Order("la.advisory_id").
+		Scan(&advisories).Error
+	if err != nil {
+		return nil, err
+	}
+
+	type advisoryKey struct {
+		Ecosystem   string
+		PackageName string
+	}
+	byPackage := map[advisoryKey][]coverageAdvisory{}
+	for _, advisory := range advisories {
+		key := advisoryKey{Ecosystem: coverageEcosystems[advisory.ContentType], PackageName: advisory.PackageName}
+		byPackage[key] = append(byPackage[key], advisory)
+	}
+
+	affected := map[string][]coverageAdvisory{}
+	for _, pkg := range packages {
+		if pkg.MatchStatus == models.CoverageMatchStatusNone {
+			continue
+		}
+		key := advisoryKey{Ecosystem: pkg.Ecosystem, PackageName: matcher.AdvisoryPackageName(coveragePackageToMatcher(pkg))}
+		for _, advisory := range byPackage[key] {
+			if matcher.IsVulnerable(pkg.Ecosystem, pkg.Version, advisory.FixedVersions) {
+				affected[pkg.UUID] = append(affected[pkg.UUID], advisory)
+			}
 		}
 	}
+	return affected, nil
+}
 
-	return api.CoverageReportPackageCollectionResponse{Data: items}, totalPackages, nil
+// Compare returns the changes from a completed coverage report to another one. Packages are identified by their
+// ecosystem, namespace and name, packages with the same version being paired first.
+func (d coverageReportDaoImpl) Compare(ctx context.Context, orgID string, uuid string, otherUUID string) (api.CoverageReportComparisonResponse, error) {
+	var reports [2]models.CoverageReport
+	var packages [2][]models.CoverageReportPackage
+	for i, reportUUID := range []string{uuid, otherUUID} {
+		if err := d.db.WithContext(ctx).Where("uuid = ? AND org_id = ?", reportUUID, orgID).First(&reports[i]).Error; err != nil {
+			return api.CoverageReportComparisonResponse{}, d.toApiError(err)
+		}
+		if reports[i].Status != config.TaskStatusCompleted {
+			return api.CoverageReportComparisonResponse{}, &ce.DaoError{
+				Message:       fmt.Sprintf("Coverage report %s is not completed", reportUUID),
+				BadValidation: true,
+			}
+		}
+		err := d.db.WithContext(ctx).Where("coverage_report_uuid = ?", reportUUID).Order("version ASC").Find(&packages[i]).Error
+		if err != nil {
+			return api.CoverageReportComparisonResponse{}, d.toApiError(err)
+		}
+	}
+
+	comparison := api.CoverageReportComparisonResponse{
+		UUID:            uuid,
+		OtherUUID:       otherUUID,
+		NewlyCovered:    []api.CoverageReportPackageResponse{},
+		NewlyUncovered:  []api.CoverageReportPackageResponse{},
+		VersionChanges:  []api.CoverageReportVersionChange{},
+		EcosystemDeltas: ecosystemDeltas(reports[0].EcosystemCoverageSummary, reports[1].EcosystemCoverageSummary),
+	}
+
+	type packageKey struct{ ecosystem, namespace, name string }
+	keyOf := func(pkg models.CoverageReportPackage) packageKey {
+		key := packageKey{ecosystem: pkg.Ecosystem, name: pkg.Name}
+		if pkg.Namespace != nil {
+			key.namespace = *pkg.Namespace
+		}
+		return key
+	}
+	previous := map[packageKey][]models.CoverageReportPackage{}
+	for _, pkg := range packages[0] {
+		previous[keyOf(pkg)] = append(previous[keyOf(pkg)], pkg)
+	}
+	var changed []models.CoverageReportPackage
+	for _, pkg := range packages[1] {
+		candidates := previous[keyOf(pkg)]
+		idx := slices.IndexFunc(candidates, func(p models.CoverageReportPackage) bool { return p.Version == pkg.Version })
+		if idx < 0 {
+			changed = append(changed, pkg)
+			continue
+		}
+		d.comparePackages(&comparison, &candidates[idx], pkg)
+		previous[keyOf(pkg)] = slices.Delete(candidates, idx, idx+1)
+	}
+	for _, pkg := range changed {
+		candidates := previous[keyOf(pkg)]
+		if len(candidates) == 0 {
+			d.comparePackages(&comparison, nil, pkg)
+			continue
+		}
+		d.comparePackages(&comparison, &candidates[0], pkg)
+		previous[keyOf(pkg)] = candidates[1:]
+	}
+
+	sortPackages := func(items []api.CoverageReportPackageResponse) {
+		slices.SortFunc(items, func(a, b api.CoverageReportPackageResponse) int {
+			return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Version, b.Version))
+		})
+	}
+	sortPackages(comparison.NewlyCovered)
+	sortPackages(comparison.NewlyUncovered)
+	slices.SortFunc(comparison.VersionChanges, func(a, b api.CoverageReportVersionChange) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.OtherVersion, b.OtherVersion))
+	})
+	return comparison, nil
+}
+
+// Refresh creates a pending coverage report linked to a completed report, whose packages are matched against the
+// current catalog by InternalOnly_MatchRefresh.
+func (d coverageReportDaoImpl) Refresh(ctx context.Context, orgID string, uuid string, params RefreshCoverageReportParams) (api.CoverageReportResponse, error) {
+	var source models.CoverageReport
+	if err := d.db.WithContext(ctx).Where("uuid = ? AND org_id = ?", uuid, orgID).First(&source).Error; err != nil {
+		return api.CoverageReportResponse{}, d.toApiError(err)
+	}
+	if source.Status != config.TaskStatusCompleted {
+		return api.CoverageReportResponse{}, &ce.DaoError{
+			Message:       fmt.Sprintf("Coverage report %s is not completed", uuid),
+			BadValidation: true,
+		}
+	}
+
+	report := models.CoverageReport{
+		OrgID:            source.OrgID,
+		AccountID:        source.AccountID,
+		Status:           config.TaskStatusPending,
+		InputFormat:      source.InputFormat,
+		Warnings:         source.Warnings,
+		SourceReportUUID: &source.UUID,
+		ScheduledRefresh: source.ScheduledRefresh,
+		Automatic:        params.Automatic,
+	}
+	if params.ScheduledRefresh != nil {
+		report.ScheduledRefresh = *params.ScheduledRefresh
+	}
+	// Automatic refreshes of a report are all linked to it, so that the latest one replaces the previous ones
+	if params.Automatic && source.Automatic && source.SourceReportUUID != nil {
+		report.SourceReportUUID = source.SourceReportUUID
+	}
+	if err := d.db.WithContext(ctx).Create(&report).Error; err != nil {
+		return api.CoverageReportResponse{}, d.toApiError(err)
+	}
+	return d.modelToResponse(report), nil
+}
+
+// SetAnalysisTask records the task analyzing a coverage report
+func (d coverageReportDaoImpl) SetAnalysisTask(ctx context.Context, orgID string, uuid string, taskUUID string) error {
+	result := d.db.WithContext(ctx).Model(&models.CoverageReport{}).
+		Where("uuid = ? AND org_id = ?", uuid, orgID).
+		Update("analysis_task_uuid", taskUUID)
+	if result.Error != nil {
+		return d.toApiError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return d.toApiError(gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+// InternalOnly_MatchRefresh matches the packages of the report a pending refresh was created from against the catalog,
+// and completes the refresh with the result. A scheduled refresh moves to the new report, so that only the latest
+// report of a manifest is re-evaluated, and an automatic refresh deletes the previous automatic refreshes of the report.
+func (d coverageReportDaoImpl) InternalOnly_MatchRefresh(ctx context.Context, uuid string, params MatchCoverageRefreshParams) (api.CoverageReportResponse, error) {
+	var report models.CoverageReport
+	if err := d.db.WithContext(ctx).Where("uuid = ?", uuid).First(&report).Error; err != nil {
+		return api.CoverageReportResponse{}, d.toApiError(err)
+	}
+	if report.SourceReportUUID == nil || report.Status != config.TaskStatusPending {
+		return api.CoverageReportResponse{}, &ce.DaoError{
+			Message:       fmt.Sprintf("Coverage report %s is not a pending refresh", uuid),
+			BadValidation: true,
+		}
+	}
+
+	var sourcePackages []models.CoverageReportPackage
+	if err := d.db.WithContext(ctx).Where("coverage_report_uuid = ?", *report.SourceReportUUID).Order("name ASC").Find(&sourcePackages).Error; err != nil {
+		return api.CoverageReportResponse{}, d.toApiError(err)
+	}
+	parsedPackages := make([]matcher.Package, len(sourcePackages))
+	for i, pkg := range sourcePackages {
+		parsedPackages[i] = coveragePackageToMatcher(pkg)
+	}
+	results, summary := matcher.MatchCatalog(params.Catalog, parsedPackages, params.CatalogSnapshotAt)
+
+	completedAt := time.Now()
+	ecosystemSummary := make(models.EcosystemCoverageSummary, len(summary.EcosystemCoverageSummary))
+	for i, entry := range summary.EcosystemCoverageSummary {
+		ecosystemSummary[i] = models.EcosystemCoverageSummaryEntry(entry)
+	}
+	slices.SortFunc(ecosystemSummary, func(a, b models.EcosystemCoverageSummaryEntry) int {
+		return cmp.Compare(a.Ecosystem, b.Ecosystem)
+	})
+	report.Status = config.TaskStatusCompleted
+	report.Total = &summary.Total
+	report.ExactMatches = &summary.ExactMatches
+	report.PartialMatches = &summary.PartialMatches
+	report.Unmatched = &summary.Unmatched
+	report.EcosystemCoverageSummary = &ecosystemSummary
+	report.CatalogSnapshotAt = &summary.CatalogSnapshotAt
+	report.CompletedAt = &completedAt
+
+	packages := make([]models.CoverageReportPackage, len(results))
+	for i, result := range results {
+		packages[i] = models.CoverageReportPackage{
+			CoverageReportUUID: report.UUID,
+			Ecosystem:          result.Ecosystem,
+			Name:               result.Name,
+			Version:            result.Version,
+			Namespace:          sourcePackages[i].Namespace,
+			VersionConstraint:  sourcePackages[i].VersionConstraint,
+			MatchStatus:        result.MatchStatus,
+		}
+		if result.Suggestions.NearestPatch != "" {
+			packages[i].NearestPatchVersion = utils.Ptr(result.Suggestions.NearestPatch)
+		}
+		if result.Suggestions.SameMinor != "" {
+			packages[i].SameMinorVersion = utils.Ptr(result.Suggestions.SameMinor)
+		}
+		if result.Suggestions.Latest != "" {
+			packages[i].LatestVersion = utils.Ptr(result.Suggestions.Latest)
+		}
+	}
+
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&report).Error; err != nil {
+			return err
+		}
+		err := tx.Model(&models.CoverageReport{}).
+			Where("uuid = ? AND scheduled_refresh = ?", *report.SourceReportUUID, true).
+			Update("scheduled_refresh", false).Error
+		if err != nil {
+			return err
+		}
+		if report.Automatic {
+			err = tx.Where("source_report_uuid = ? AND automatic = ? AND uuid <> ? AND status IN ?",
+				*report.SourceReportUUID, true, report.UUID, []string{config.TaskStatusCompleted, config.TaskStatusFailed}).
+				Delete(&models.CoverageReport{}).Error
+			if err != nil {
+				return err
+			}
+		}
+		if len(packages) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(&packages, 500).Error
+	})
+	if err != nil {
+		return api.CoverageReportResponse{}, d.toApiError(err)
+	}
+
+	return d.modelToResponse(report), nil
+}
+
+// InternalOnly_FailAnalysis marks a coverage report as failed with the error of its analysis
+func (d coverageReportDaoImpl) InternalOnly_FailAnalysis(ctx context.Context, uuid string, analysisErr error) error {
+	err := d.db.WithContext(ctx).Model(&models.CoverageReport{}).
+		Where("uuid = ?", uuid).
+		Updates(map[string]any{"status": config.TaskStatusFailed, "analysis_task_error": analysisErr.Error()}).Error
+	if err != nil {
+		return d.toApiError(err)
+	}
+	return nil
+}
+
+// FetchExport returns a coverage report to export, which has to be completed
+func (d coverageReportDaoImpl) FetchExport(ctx context.Context, orgID string, uuid string) (api.CoverageReportResponse, error) {
+	var report models.CoverageReport
+	if err := d.db.WithContext(ctx).Where("uuid = ? AND org_id = ?", uuid, orgID).First(&report).Error; err != nil {
+		return api.CoverageReportResponse{}, d.toApiError(err)
+	}
+	if report.Status != config.TaskStatusCompleted {
+		return api.CoverageReportResponse{}, &ce.DaoError{
+			Message:       fmt.Sprintf("Coverage report %s is not completed", uuid),
+			BadValidation: true,
+		}
+	}
+	return d.modelToResponse(report), nil
+}
+
+// ExportPackages passes all the packages of a coverage report fetched with FetchExport to write, a page at a time,
+// ordered by ecosystem and name, so exports of large reports are not held in memory
+func (d coverageReportDaoImpl) ExportPackages(ctx context.Context, uuid string, write func(packages []api.CoverageReportPackageResponse) error) error {
+	for offset := 0; ; offset += exportPackagesPageSize {
+		var packages []models.CoverageReportPackage
+		err := d.db.WithContext(ctx).
+			Where("coverage_report_uuid = ?", uuid).
+			Order("ecosystem ASC, namespace ASC NULLS FIRST, name ASC, version ASC, uuid ASC").
+			Offset(offset).
+			Limit(exportPackagesPageSize).
+			Find(&packages).Error
+		if err != nil {
+			return d.toApiError(err)
+		}
+		if len(packages) == 0 {
+			return nil
+		}
+		advisories, err := d.packageAdvisories(ctx, packages)
+		if err != nil {
+			return d.toApiError(err)
+		}
+
+		page := make([]api.CoverageReportPackageResponse, len(packages))
+		for i, pkg := range packages {
+			page[i] = d.packageModelToResponse(pkg)
+			annotateAdvisories(&page[i], advisories[pkg.UUID])
+		}
+		if err := write(page); err != nil {
+			return err
+		}
+		if len(packages) < exportPackagesPageSize {
+			return nil
+		}
+	}
+}
+
+// InternalOnly_ListScheduledRefreshes lists the completed coverage reports to re-evaluate periodically, skipping the
+// reports with a refresh still pending, and the reports matched against the catalog since its content last changed.
+// The catalog is considered changed when the time its content changed is not known.
+func (d coverageReportDaoImpl) InternalOnly_ListScheduledRefreshes(ctx context.Context) ([]ScheduledCoverageRefresh, error) {
+	catalogUpdatedAt := d.db.Model(&models.Repository{}).
+		Select("COALESCE(MAX(repositories.last_introspection_update_time), 'infinity')").
+		Joins("INNER JOIN repository_configurations rc ON rc.repository_uuid = repositories.uuid").
+		Where("rc.org_id = ? AND repositories.content_type IN ?", config.LightwellOrg,
+			[]string{config.ContentTypeMaven, config.ContentTypePython, config.ContentTypeNpm})
+
+	var reports []models.CoverageReport
+	err := d.db.WithContext(ctx).
+		Where("scheduled_refresh = ? AND status = ?", true, config.TaskStatusCompleted).
+		Where("NOT EXISTS (SELECT 1 FROM coverage_reports refresh WHERE refresh.status IN ? AND "+
+			"(refresh.source_report_uuid = coverage_reports.uuid OR (coverage_reports.automatic AND refresh.source_report_uuid = coverage_reports.source_report_uuid)))",
+			[]string{config.TaskStatusPending, config.TaskStatusRunning}).
+		Where("catalog_snapshot_at IS NULL OR catalog_snapshot_at < (?)", catalogUpdatedAt).
+		Order("org_id, created_at").
+		Find(&reports).Error
+	if err != nil {
+		return nil, d.toApiError(err)
+	}
+
+	refreshes := make([]ScheduledCoverageRefresh, len(reports))
+	for i, report := range reports {
+		refreshes[i] = ScheduledCoverageRefresh{UUID: report.UUID, OrgID: report.OrgID, AccountID: report.AccountID}
+		if report.ExactMatches != nil {
+			refreshes[i].Covered += *report.ExactMatches
+		}
+		if report.PartialMatches != nil {
+			refreshes[i].Covered += *report.PartialMatches
+		}
+	}
+	return refreshes, nil
+}
+
+// comparePackages records the changes of a package of the other report, previous being the same package in the
+// report, or nil when the package was not in the report
+func (d coverageReportDaoImpl) comparePackages(comparison *api.CoverageReportComparisonResponse, previous *models.CoverageReportPackage, pkg models.CoverageReportPackage) {
+	covered := pkg.MatchStatus != models.CoverageMatchStatusNone
+	previouslyCovered := previous != nil && previous.MatchStatus != models.CoverageMatchStatusNone
+	switch {
+	case covered && (previous == nil || !previouslyCovered):
+		comparison.NewlyCovered = append(comparison.NewlyCovered, d.packageModelToResponse(pkg))
+	case !covered && (previous == nil || previouslyCovered):
+		comparison.NewlyUncovered = append(comparison.NewlyUncovered, d.packageModelToResponse(pkg))
+	}
+	if previous != nil && previous.Version != pkg.Version {
+		change := api.CoverageReportVersionChange{
+			Name:         pkg.Name,
+			Ecosystem:    pkg.Ecosystem,
+			Version:      previous.Version,
+			OtherVersion: pkg.Version,
+			Covered:      previouslyCovered,
+			OtherCovered: covered,
+		}
+		if pkg.Namespace != nil {
+			change.Namespace = *pkg.Namespace
+		}
+		comparison.VersionChanges = append(comparison.VersionChanges, change)
+	}
+}
+
+// ecosystemDeltas returns the change of the counts of each ecosystem, ecosystems missing from a report having no packages
+func ecosystemDeltas(summary *models.EcosystemCoverageSummary, otherSummary *models.EcosystemCoverageSummary) []api.EcosystemCoverageSummary {
+	deltas := map[string]*api.EcosystemCoverageSummary{}
+	add := func(summary *models.EcosystemCoverageSummary, sign int) {
+		if summary == nil {
+			return
+		}
+		for _, entry := range *summary {
+			delta, ok := deltas[entry.Ecosystem]
+			if !ok {
+				delta = &api.EcosystemCoverageSummary{Ecosystem: entry.Ecosystem}
+				deltas[entry.Ecosystem] = delta
+			}
+			delta.Total += sign * entry.Total
+			delta.ExactMatches += sign * entry.ExactMatches
+			delta.PartialMatches += sign * entry.PartialMatches
+			delta.Unmatched += sign * entry.Unmatched
+		}
+	}
+	add(summary, -1)
+	add(otherSummary, 1)
+
+	result := make([]api.EcosystemCoverageSummary, 0, len(deltas))
+	for _, ecosystem := range slices.Sorted(maps.Keys(deltas)) {
+		result = append(result, *deltas[ecosystem])
+	}
+	return result
+}
+
+func (d coverageReportDaoImpl) packageModelToResponse(pkg models.CoverageReportPackage) api.CoverageReportPackageResponse {
+	resp := api.CoverageReportPackageResponse{
+		Name:        pkg.Name,
+		Version:     pkg.Version,
+		Ecosystem:   pkg.Ecosystem,
+		Covered:     pkg.MatchStatus != models.CoverageMatchStatusNone,
+		MatchStatus: pkg.MatchStatus,
+	}
+	if pkg.Namespace != nil {
+		resp.Namespace = *pkg.Namespace
+	}
+	if pkg.VersionConstraint != nil {
+		resp.VersionConstraint = *pkg.VersionConstraint
+	}
+	if pkg.LatestVersion != nil {
+		suggested := api.CoverageSuggestedVersions{Latest: *pkg.LatestVersion}
+		if pkg.NearestPatchVersion != nil {
+			suggested.NearestPatch = *pkg.NearestPatchVersion
+		}
+		if pkg.SameMinorVersion != nil {
+			suggested.SameMinor = *pkg.SameMinorVersion
+		}
+		resp.SuggestedVersions = &suggested
+	}
+	return resp
+}
+
+// annotateAdvisories sets the advisories affecting the version of a package
+func annotateAdvisories(resp *api.CoverageReportPackageResponse, advisories []coverageAdvisory) {
+	if len(advisories) == 0 {
+		return
+	}
+	severities := make([]string, len(advisories))
+	var fixedVersions []string
+	for i, advisory := range advisories {
+		// The same advisory may be synced from several repositories
+		if !slices.Contains(resp.AdvisoryIDs, advisory.AdvisoryID) {
+			resp.AdvisoryIDs = append(resp.AdvisoryIDs, advisory.AdvisoryID)
+		}
+		severities[i] = advisory.Severity
+		fixedVersions = append(fixedVersions, advisory.FixedVersions...)
+	}
+	resp.HighestSeverity = severity.Highest(severities)
+	if len(fixedVersions) > 0 {
+		resp.FixedVersions = matcher.SortVersions(resp.Ecosystem, fixedVersions)
+	}
+}
+
+func coveragePackageToMatcher(pkg models.CoverageReportPackage) matcher.Package {
+	parsed := matcher.Package{Ecosystem: pkg.Ecosystem, Name: pkg.Name, Version: pkg.Version}
+	if pkg.Namespace != nil {
+		parsed.Namespace = *pkg.Namespace
+	}
+	if pkg.VersionConstraint != nil {
+		parsed.Constraint = *pkg.VersionConstraint
+	}
+	return parsed
 }
 
 func (d coverageReportDaoImpl) coverageReportCreateParamsToModels(report CreateCoverageReportParams, upload CreateCoverageUploadParams, modelReport *models.CoverageReport, modelUpload *models.CoverageUpload) {
 	modelReport.OrgID = report.OrgID
 	modelReport.AccountID = report.AccountID
+	if report.InputFormat != "" {
+		modelReport.InputFormat = utils.Ptr(report.InputFormat)
+	}
+	if len(report.Warnings) > 0 {
+		modelReport.Warnings = report.Warnings
+	}
 
 	modelUpload.UUID = upload.UUID
 	modelUpload.StorageKey = upload.StorageKey
@@ -117,10 +721,19 @@ func (d coverageReportDaoImpl) coverageReportCreateParamsToModels(report CreateC
 
 func (d coverageReportDaoImpl) modelToResponse(r models.CoverageReport) api.CoverageReportResponse {
 	resp := api.CoverageReportResponse{
-		UUID:        r.UUID,
-		Status:      r.Status,
-		CreatedAt:   r.CreatedAt,
-		CompletedAt: r.CompletedAt,
+		UUID:              r.UUID,
+		Status:            r.Status,
+		CreatedAt:         r.CreatedAt,
+		CompletedAt:       r.CompletedAt,
+		Warnings:          []string{},
+		ScheduledRefresh:  r.ScheduledRefresh,
+		CatalogSnapshotAt: r.CatalogSnapshotAt,
+	}
+	if r.Warnings != nil {
+		resp.Warnings = r.Warnings
+	}
+	if r.SourceReportUUID != nil {
+		resp.SourceReportUUID = *r.SourceReportUUID
 	}
 	if r.InputFormat != nil {
 		resp.InputFormat = *r.InputFormat
@@ -4,14 +4,17 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/content-services/content-sources-backend/pkg/api"
 	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/coverage/matcher"
 	ce "github.com/content-services/content-sources-backend/pkg/errors"
 	"github.com/content-services/content-sources-backend/pkg/models"
 	"github.com/content-services/content-sources-backend/pkg/seeds"
 	"github.com/content-services/content-sources-backend/pkg/utils"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -73,6 +76,18 @@ func (s *CoverageReportDaoSuite) TestFetch() {
 	assert.Equal(s.T(), 1, resp.PartialMatches)
 	assert.Equal(s.T(), 1, resp.Unmatched)
 	assert.Equal(s.T(), "CycloneDX", resp.InputFormat)
+	assert.Empty(s.T(), resp.Warnings)
+}
+
+func (s *CoverageReportDaoSuite) TestFetchWarnings() {
+	orgID := seeds.RandomOrgId()
+	report := s.createReport(orgID, config.TaskStatusCompleted)
+	report.Warnings = pq.StringArray{"pom.xml dependency org.slf4j:slf4j-api: version ${slf4j.version} could not be resolved"}
+	require.NoError(s.T(), s.tx.Save(&report).Error)
+
+	resp, err := s.dao().Fetch(context.Background(), orgID, report.UUID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []string{"pom.xml dependency org.slf4j:slf4j-api: version ${slf4j.version} could not be resolved"}, resp.Warnings)
 }
 
 func (s *CoverageReportDaoSuite) TestFetchNotFound() {
@@ -119,6 +134,468 @@ func (s *CoverageReportDaoSuite) TestListPackages() {
 	assert.False(s.T(), byName["unknown-pkg"].Covered)
 }
 
+func (s *CoverageReportDaoSuite) TestListPackagesVersionConstraint() {
+	orgID := seeds.RandomOrgId()
+	report := s.createReport(orgID, config.TaskStatusCompleted)
+	pkg := models.CoverageReportPackage{
+		CoverageReportUUID: report.UUID,
+		Name:               "requests",
+		Ecosystem:          "Python",
+		VersionConstraint:  utils.Ptr(">=2.31,<3"),
+		MatchStatus:        models.CoverageMatchStatusPartial,
+	}
+	require.NoError(s.T(), s.tx.Create(&pkg).Error)
+
+	resp, _, err := s.dao().ListPackages(context.Background(), orgID, report.UUID,
+		api.PaginationData{Limit: 100, Offset: 0}, api.ListCoverageReportPackagesRequest{})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), resp.Data, 1)
+	assert.Empty(s.T(), resp.Data[0].Version)
+	assert.Equal(s.T(), ">=2.31,<3", resp.Data[0].VersionConstraint)
+}
+
+func (s *CoverageReportDaoSuite) TestListPackagesSuggestedVersions() {
+	orgID := seeds.RandomOrgId()
+	report := s.createReport(orgID, config.TaskStatusCompleted)
+	partial := s.createPackage(report.UUID, "spring-core", "5.3.20", "Java", models.CoverageMatchStatusPartial)
+	partial.NearestPatchVersion = utils.Ptr("5.3.27")
+	partial.SameMinorVersion = utils.Ptr("5.3.31")
+	partial.LatestVersion = utils.Ptr("6.1.0")
+	require.NoError(s.T(), s.tx.Save(&partial).Error)
+	s.createPackage(report.UUID, "guava", "32.0", "Java", models.CoverageMatchStatusExact)
+
+	resp, _, err := s.dao().ListPackages(context.Background(), orgID, report.UUID,
+		api.PaginationData{Limit: 100, Offset: 0}, api.ListCoverageReportPackagesRequest{})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), resp.Data, 2)
+	assert.Equal(s.T(), "guava", resp.Data[0].Name)
+	assert.Nil(s.T(), resp.Data[0].SuggestedVersions)
+	assert.Equal(s.T(), &api.CoverageSuggestedVersions{NearestPatch: "5.3.27", SameMinor: "5.3.31", Latest: "6.1.0"}, resp.Data[1].SuggestedVersions)
+}
+
+func (s *CoverageReportDaoSuite) TestListPackagesAdvisories() {
+	orgID := seeds.RandomOrgId()
+	report := s.createReport(orgID, config.TaskStatusCompleted)
+	// Packages are named randomly to ignore the advisories of other tests
+	vulnerableName, fixedName, uncoveredName := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	vulnerable := s.createPackage(report.UUID, vulnerableName, "2.0.5", "Python", models.CoverageMatchStatusExact)
+	s.createPackage(report.UUID, fixedName, "3.1.0", "Python", models.CoverageMatchStatusExact)
+	s.createPackage(report.UUID, uncoveredName, "1.0.0", "Python", models.CoverageMatchStatusNone)
+
+	repo := models.Repository{Origin: config.OriginLightwell, ContentType: config.ContentTypePython, LastIntrospectionStatus: config.StatusValid}
+	require.NoError(s.T(), s.tx.Create(&repo).Error)
+	repoConfig := models.RepositoryConfiguration{Name: uuid.NewString(), OrgID: config.LightwellOrg, RepositoryUUID: repo.UUID}
+	require.NoError(s.T(), s.tx.Create(&repoConfig).Error)
+	err := GetLightwellAdvisoryDao(s.tx).SyncForRepository(context.Background(), repoConfig.UUID, repoConfig.Name, []LightwellAdvisoryInput{
+		{AdvisoryID: "FAKE-002", Severity: "9.8", PackageName: vulnerableName, FixedVersions: []string{"2.1.0", "1.9.3"}, Checksum: "a"},
+		{AdvisoryID: "FAKE-001", Severity: "5.3", PackageName: vulnerableName, FixedVersions: []string{"2.0.6"}, Checksum: "b"},
+		{AdvisoryID: "FAKE-003", Severity: "7.5", PackageName: fixedName, FixedVersions: []string{"3.0.2"}, Checksum: "c"},
+		{AdvisoryID: "FAKE-004", Severity: "7.5", PackageName: uncoveredName, Checksum: "d"},
+	})
+	require.NoError(s.T(), err)
+
+	resp, total, err := s.dao().ListPackages(context.Background(), orgID, report.UUID,
+		api.PaginationData{Limit: 100, Offset: 0}, api.ListCoverageReportPackagesRequest{})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(3), total)
+	byName := map[string]api.CoverageReportPackageResponse{}
+	for _, p := range resp.Data {
+		byName[p.Name] = p
+	}
+	assert.Equal(s.T(), []string{"FAKE-001", "FAKE-002"}, byName[vulnerableName].AdvisoryIDs)
+	assert.Equal(s.T(), "critical", byName[vulnerableName].HighestSeverity)
+	assert.Equal(s.T(), []string{"1.9.3", "2.0.6", "2.1.0"}, byName[vulnerableName].FixedVersions)
+	assert.Empty(s.T(), byName[fixedName].AdvisoryIDs)
+	assert.Empty(s.T(), byName[uncoveredName].AdvisoryIDs)
+
+	resp, total, err = s.dao().ListPackages(context.Background(), orgID, report.UUID,
+		api.PaginationData{Limit: 100, Offset: 0}, api.ListCoverageReportPackagesRequest{Vulnerable: utils.Ptr(true)})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(1), total)
+	require.Len(s.T(), resp.Data, 1)
+	assert.Equal(s.T(), vulnerable.Name, resp.Data[0].Name)
+
+	_, total, err = s.dao().ListPackages(context.Background(), orgID, report.UUID,
+		api.PaginationData{Limit: 100, Offset: 0}, api.ListCoverageReportPackagesRequest{Vulnerable: utils.Ptr(false)})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(2), total)
+}
+
+func (s *CoverageReportDaoSuite) TestListPackagesVulnerableNamespaces() {
+	orgID := seeds.RandomOrgId()
+	report := s.createReport(orgID, config.TaskStatusCompleted)
+	namespace := uuid.NewString()
+	javaPkg := s.createPackage(report.UUID, "spring-core", "5.3.20", "Java", models.CoverageMatchStatusExact)
+	javaPkg.Namespace = utils.Ptr(namespace)
+	require.NoError(s.T(), s.tx.Save(&javaPkg).Error)
+	npmPkg := s.createPackage(report.UUID, "Core", "1.0.0", "npm", models.CoverageMatchStatusExact)
+	npmPkg.Namespace = utils.Ptr("@" + namespace)
+	require.NoError(s.T(), s.tx.Save(&npmPkg).Error)
+	// Same name as the Java advisory, but in another ecosystem
+	s.createPackage(report.UUID, namespace+":spring-core", "1.0.0", "Python", models.CoverageMatchStatusExact)
+
+	for contentType, packageName := range map[string]string{
+		config.ContentTypeMaven: namespace + ":spring-core",
+		config.ContentTypeNpm:   "@" + namespace + "/core",
+	} {
+		repo := models.Repository{Origin: config.OriginLightwell, ContentType: contentType, LastIntrospectionStatus: config.StatusValid}
+		require.NoError(s.T(), s.tx.Create(&repo).Error)
+		repoConfig := models.RepositoryConfiguration{Name: uuid.NewString(), OrgID: config.LightwellOrg, RepositoryUUID: repo.UUID}
+		require.NoError(s.T(), s.tx.Create(&repoConfig).Error)
+		err := GetLightwellAdvisoryDao(s.tx).SyncForRepository(context.Background(), repoConfig.UUID, repoConfig.Name, []LightwellAdvisoryInput{
+			{AdvisoryID: "FAKE-" + contentType, Severity: "7.5", PackageName: packageName, FixedVersions: []string{"9.0.0"}, Checksum: contentType},
+		})
+		require.NoError(s.T(), err)
+	}
+
+	resp, total, err := s.dao().ListPackages(context.Background(), orgID, report.UUID,
+		api.PaginationData{Limit: 100, Offset: 0}, api.ListCoverageReportPackagesRequest{Vulnerable: utils.Ptr(true)})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(2), total)
+	require.Len(s.T(), resp.Data, 2)
+	assert.Equal(s.T(), "Core", resp.Data[0].Name)
+	assert.Equal(s.T(), "spring-core", resp.Data[1].Name)
+}
+
+func (s *CoverageReportDaoSuite) TestCompare() {
+	orgID := seeds.RandomOrgId()
+	report := s.createReport(orgID, config.TaskStatusCompleted)
+	report.EcosystemCoverageSummary = &models.EcosystemCoverageSummary{
+		{Ecosystem: "Java", Total: 3, ExactMatches: 1, PartialMatches: 1, Unmatched: 1},
+		{Ecosystem: "npm", Total: 1, Unmatched: 1},
+	}
+	require.NoError(s.T(), s.tx.Save(&report).Error)
+	other := s.createReport(orgID, config.TaskStatusCompleted)
+	other.EcosystemCoverageSummary = &models.EcosystemCoverageSummary{
+		{Ecosystem: "Java", Total: 3, ExactMatches: 2, Unmatched: 1},
+		{Ecosystem: "Python", Total: 1, ExactMatches: 1},
+	}
+	require.NoError(s.T(), s.tx.Save(&other).Error)
+
+	s.createPackage(report.UUID, "spring-core", "5.3.20", "Java", models.CoverageMatchStatusPartial)
+	s.createPackage(report.UUID, "guava", "32.0", "Java", models.CoverageMatchStatusExact)
+	s.createPackage(report.UUID, "internal-lib", "1.0", "Java", models.CoverageMatchStatusNone)
+	s.createPackage(report.UUID, "left-pad", "1.3.0", "npm", models.CoverageMatchStatusNone)
+	s.createPackage(other.UUID, "spring-core", "5.3.31", "Java", models.CoverageMatchStatusExact)
+	s.createPackage(other.UUID, "guava", "32.0", "Java", models.CoverageMatchStatusExact)
+	s.createPackage(other.UUID, "internal-lib", "1.0", "Java", models.CoverageMatchStatusNone)
+	s.createPackage(other.UUID, "flask", "3.0.3", "Python", models.CoverageMatchStatusExact)
+
+	comparison, err := s.dao().Compare(context.Background(), orgID, report.UUID, other.UUID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), report.UUID, comparison.UUID)
+	assert.Equal(s.T(), other.UUID, comparison.OtherUUID)
+	assert.Equal(s.T(), []api.CoverageReportPackageResponse{
+		{Name: "flask", Version: "3.0.3", Ecosystem: "Python", Covered: true, MatchStatus: models.CoverageMatchStatusExact},
+	}, comparison.NewlyCovered)
+	assert.Empty(s.T(), comparison.NewlyUncovered)
+	assert.Equal(s.T(), []api.CoverageReportVersionChange{
+		{Name: "spring-core", Ecosystem: "Java", Version: "5.3.20", OtherVersion: "5.3.31", Covered: true, OtherCovered: true},
+	}, comparison.VersionChanges)
+	assert.Equal(s.T(), []api.EcosystemCoverageSummary{
+		{Ecosystem: "Java", ExactMatches: 1, PartialMatches: -1},
+		{Ecosystem: "Python", Total: 1, ExactMatches: 1},
+		{Ecosystem: "npm", Total: -1, Unmatched: -1},
+	}, comparison.EcosystemDeltas)
+}
+
+func (s *CoverageReportDaoSuite) TestCompareNotCompleted() {
+	orgID := seeds.RandomOrgId()
+	report := s.createReport(orgID, config.TaskStatusCompleted)
+	other := s.createReport(orgID, config.TaskStatusPending)
+
+	_, err := s.dao().Compare(context.Background(), orgID, report.UUID, other.UUID)
+	require.Error(s.T(), err)
+	var daoError *ce.DaoError
+	require.True(s.T(), errors.As(err, &daoError))
+	assert.True(s.T(), daoError.BadValidation)
+
+	_, err = s.dao().Compare(context.Background(), seeds.RandomOrgId(), report.UUID, report.UUID)
+	require.Error(s.T(), err)
+	require.True(s.T(), errors.As(err, &daoError))
+	assert.True(s.T(), daoError.NotFound)
+}
+
+func (s *CoverageReportDaoSuite) TestRefresh() {
+	orgID := seeds.RandomOrgId()
+	report := s.createReport(orgID, config.TaskStatusCompleted)
+	report.AccountID = utils.Ptr("account")
+	report.InputFormat = utils.Ptr("requirements.txt")
+	report.Warnings = pq.StringArray{"unresolved"}
+	report.ScheduledRefresh = true
+	require.NoError(s.T(), s.tx.Save(&report).Error)
+
+	s.createPackage(report.UUID, "requests", "2.31.0", "Python", models.CoverageMatchStatusNone)
+	s.createPackage(report.UUID, "idna", "3.7", "Python", models.CoverageMatchStatusNone)
+	s.createPackage(report.UUID, "flask", "3.0.3", "Python", models.CoverageMatchStatusExact)
+
+	pending, err := s.dao().Refresh(context.Background(), orgID, report.UUID, RefreshCoverageReportParams{})
+	require.NoError(s.T(), err)
+	assert.NotEqual(s.T(), report.UUID, pending.UUID)
+	assert.Equal(s.T(), report.UUID, pending.SourceReportUUID)
+	assert.Equal(s.T(), config.TaskStatusPending, pending.Status)
+	assert.True(s.T(), pending.ScheduledRefresh)
+
+	taskUUID := uuid.NewString()
+	require.NoError(s.T(), s.dao().SetAnalysisTask(context.Background(), orgID, pending.UUID, taskUUID))
+	err = s.dao().SetAnalysisTask(context.Background(), seeds.RandomOrgId(), pending.UUID, taskUUID)
+	var daoError *ce.DaoError
+	require.True(s.T(), errors.As(err, &daoError))
+	assert.True(s.T(), daoError.NotFound)
+
+	// A report with a pending refresh is not refreshed again
+	refreshes, err := s.dao().InternalOnly_ListScheduledRefreshes(context.Background())
+	require.NoError(s.T(), err)
+	for _, refresh := range refreshes {
+		assert.NotEqual(s.T(), report.UUID, refresh.UUID)
+	}
+
+	snapshotAt := time.Now().UTC().Truncate(time.Second)
+	refreshed, err := s.dao().InternalOnly_MatchRefresh(context.Background(), pending.UUID, MatchCoverageRefreshParams{
+		Catalog: []matcher.Package{
+			{Ecosystem: matcher.EcosystemPython, Name: "requests", Version: "2.31.0"},
+			{Ecosystem: matcher.EcosystemPython, Name: "idna", Version: "3.8"},
+		},
+		CatalogSnapshotAt: snapshotAt,
+	})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), pending.UUID, refreshed.UUID)
+	assert.Equal(s.T(), report.UUID, refreshed.SourceReportUUID)
+	assert.Equal(s.T(), config.TaskStatusCompleted, refreshed.Status)
+	assert.Equal(s.T(), taskUUID, refreshed.AnalysisTaskUUID)
+	assert.Equal(s.T(), "requirements.txt", refreshed.InputFormat)
+	assert.Equal(s.T(), []string{"unresolved"}, refreshed.Warnings)
+	assert.True(s.T(), refreshed.ScheduledRefresh)
+	assert.Equal(s.T(), 3, refreshed.Total)
+	assert.Equal(s.T(), 1, refreshed.ExactMatches)
+	assert.Equal(s.T(), 1, refreshed.PartialMatches)
+	assert.Equal(s.T(), 1, refreshed.Unmatched)
+	assert.Equal(s.T(), []api.EcosystemCoverageSummary{
+		{Ecosystem: "Python", Total: 3, ExactMatches: 1, PartialMatches: 1, Unmatched: 1},
+	}, refreshed.EcosystemCoverageSummary)
+
+	packages, total, err := s.dao().ListPackages(context.Background(), orgID, refreshed.UUID, api.PaginationData{Limit: 100}, api.ListCoverageReportPackagesRequest{})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(3), total)
+	assert.Equal(s.T(), []api.CoverageReportPackageResponse{
+		{Name: "flask", Version: "3.0.3", Ecosystem: "Python", Covered: false, MatchStatus: models.CoverageMatchStatusNone},
+		{Name: "idna", Version: "3.7", Ecosystem: "Python", Covered: true, MatchStatus: models.CoverageMatchStatusPartial, SuggestedVersions: &api.CoverageSuggestedVersions{Latest: "3.8"}},
+		{Name: "requests", Version: "2.31.0", Ecosystem: "Python", Covered: true, MatchStatus: models.CoverageMatchStatusExact},
+	}, packages.Data)
+
+	// The scheduled refresh moves to the new report once it is completed
+	var source models.CoverageReport
+	require.NoError(s.T(), s.tx.Where("uuid = ?", report.UUID).First(&source).Error)
+	assert.False(s.T(), source.ScheduledRefresh)
+
+	refreshes, err = s.dao().InternalOnly_ListScheduledRefreshes(context.Background())
+	require.NoError(s.T(), err)
+	assert.Contains(s.T(), refreshes, ScheduledCoverageRefresh{UUID: refreshed.UUID, OrgID: orgID, AccountID: utils.Ptr("account"), Covered: 2})
+
+	// A completed refresh is not matched again
+	_, err = s.dao().InternalOnly_MatchRefresh(context.Background(), refreshed.UUID, MatchCoverageRefreshParams{})
+	require.True(s.T(), errors.As(err, &daoError))
+	assert.True(s.T(), daoError.BadValidation)
+
+	// Refreshing without a scheduled refresh does not schedule the new report
+	unscheduled, err := s.dao().Refresh(context.Background(), orgID, refreshed.UUID, RefreshCoverageReportParams{
+		ScheduledRefresh: utils.Ptr(false),
+	})
+	require.NoError(s.T(), err)
+	assert.False(s.T(), unscheduled.ScheduledRefresh)
+	unscheduled, err = s.dao().InternalOnly_MatchRefresh(context.Background(), unscheduled.UUID, MatchCoverageRefreshParams{CatalogSnapshotAt: snapshotAt})
+	require.NoError(s.T(), err)
+	assert.False(s.T(), unscheduled.ScheduledRefresh)
+	assert.Equal(s.T(), 3, unscheduled.Unmatched)
+}
+
+func (s *CoverageReportDaoSuite) TestRefreshFailed() {
+	orgID := seeds.RandomOrgId()
+	report := s.createReport(orgID, config.TaskStatusCompleted)
+	report.ScheduledRefresh = true
+	require.NoError(s.T(), s.tx.Save(&report).Error)
+
+	pending, err := s.dao().Refresh(context.Background(), orgID, report.UUID, RefreshCoverageReportParams{})
+	require.NoError(s.T(), err)
+	require.NoError(s.T(), s.dao().InternalOnly_FailAnalysis(context.Background(), pending.UUID, errors.New("catalog is not available")))
+
+	failed, err := s.dao().Fetch(context.Background(), orgID, pending.UUID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), config.TaskStatusFailed, failed.Status)
+	assert.Equal(s.T(), "catalog is not available", failed.AnalysisTaskError)
+
+	// The refreshed report keeps its scheduled refresh, to be refreshed again
+	refreshes, err := s.dao().InternalOnly_ListScheduledRefreshes(context.Background())
+	require.NoError(s.T(), err)
+	assert.Contains(s.T(), refreshes, ScheduledCoverageRefresh{UUID: report.UUID, OrgID: orgID})
+}
+
+func (s *CoverageReportDaoSuite) TestRefreshNotCompleted() {
+	orgID := seeds.RandomOrgId()
+	report := s.createReport(orgID, config.TaskStatusPending)
+
+	_, err := s.dao().Refresh(context.Background(), orgID, report.UUID, RefreshCoverageReportParams{})
+	require.Error(s.T(), err)
+	var daoError *ce.DaoError
+	require.True(s.T(), errors.As(err, &daoError))
+	assert.True(s.T(), daoError.BadValidation)
+
+	_, err = s.dao().Refresh(context.Background(), seeds.RandomOrgId(), report.UUID, RefreshCoverageReportParams{})
+	require.Error(s.T(), err)
+	require.True(s.T(), errors.As(err, &daoError))
+	assert.True(s.T(), daoError.NotFound)
+
+	// A report that is not a refresh can't be matched as one
+	_, err = s.dao().InternalOnly_MatchRefresh(context.Background(), report.UUID, MatchCoverageRefreshParams{})
+	require.True(s.T(), errors.As(err, &daoError))
+	assert.True(s.T(), daoError.BadValidation)
+}
+
+func (s *CoverageReportDaoSuite) TestListScheduledRefreshesSkipsCurrentCatalog() {
+	orgID := seeds.RandomOrgId()
+	catalogUpdatedAt := time.Now()
+	repo := models.Repository{
+		Origin:                      config.OriginLightwell,
+		ContentType:                 config.ContentTypePython,
+		PublishedDistURL:            "https://lightwell.example.com/" + uuid.NewString() + "/",
+		LastIntrospectionUpdateTime: &catalogUpdatedAt,
+	}
+	require.NoError(s.T(), s.tx.Create(&repo).Error)
+	require.NoError(s.T(), s.tx.Create(&models.RepositoryConfiguration{
+		Name:           "lightwell " + repo.UUID,
+		OrgID:          config.LightwellOrg,
+		RepositoryUUID: repo.UUID,
+	}).Error)
+
+	scheduledReport := func(catalogSnapshotAt *time.Time) models.CoverageReport {
+		report := s.createReport(orgID, config.TaskStatusCompleted)
+		report.ScheduledRefresh = true
+		report.CatalogSnapshotAt = catalogSnapshotAt
+		require.NoError(s.T(), s.tx.Save(&report).Error)
+		return report
+	}
+	stale := scheduledReport(utils.Ptr(catalogUpdatedAt.Add(-24 * time.Hour)))
+	current := scheduledReport(utils.Ptr(catalogUpdatedAt.Add(24 * time.Hour)))
+	neverMatched := scheduledReport(nil)
+
+	refreshes, err := s.dao().InternalOnly_ListScheduledRefreshes(context.Background())
+	require.NoError(s.T(), err)
+	assert.Contains(s.T(), refreshes, ScheduledCoverageRefresh{UUID: stale.UUID, OrgID: orgID})
+	assert.Contains(s.T(), refreshes, ScheduledCoverageRefresh{UUID: neverMatched.UUID, OrgID: orgID})
+	assert.NotContains(s.T(), refreshes, ScheduledCoverageRefresh{UUID: current.UUID, OrgID: orgID})
+}
+
+func (s *CoverageReportDaoSuite) TestAutomaticRefreshReplacesPrevious() {
+	orgID := seeds.RandomOrgId()
+	report := s.createReport(orgID, config.TaskStatusCompleted)
+	report.ScheduledRefresh = true
+	require.NoError(s.T(), s.tx.Save(&report).Error)
+	s.createPackage(report.UUID, "requests", "2.31.0", "Python", models.CoverageMatchStatusNone)
+
+	// Keep the catalog snapshot in the past, so the refreshes stay scheduled whatever the catalog
+	snapshotAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	refresh := func(uuid string) api.CoverageReportResponse {
+		pending, err := s.dao().Refresh(context.Background(), orgID, uuid, RefreshCoverageReportParams{Automatic: true})
+		require.NoError(s.T(), err)
+		assert.Equal(s.T(), report.UUID, pending.SourceReportUUID)
+		return pending
+	}
+	match := func(uuid string) api.CoverageReportResponse {
+		refreshed, err := s.dao().InternalOnly_MatchRefresh(context.Background(), uuid, MatchCoverageRefreshParams{CatalogSnapshotAt: snapshotAt})
+		require.NoError(s.T(), err)
+		return refreshed
+	}
+
+	first := match(refresh(report.UUID).UUID)
+	second := refresh(first.UUID)
+
+	// The previous refresh has a pending refresh, so is not refreshed again
+	refreshes, err := s.dao().InternalOnly_ListScheduledRefreshes(context.Background())
+	require.NoError(s.T(), err)
+	for _, refresh := range refreshes {
+		assert.NotEqual(s.T(), first.UUID, refresh.UUID)
+	}
+
+	second = match(second.UUID)
+	assert.True(s.T(), second.ScheduledRefresh)
+	var count int64
+	require.NoError(s.T(), s.tx.Model(&models.CoverageReport{}).Where("uuid = ?", first.UUID).Count(&count).Error)
+	assert.Equal(s.T(), int64(0), count, "the previous automatic refresh is deleted")
+
+	// The refreshed report is kept
+	_, err = s.dao().Fetch(context.Background(), orgID, report.UUID)
+	assert.NoError(s.T(), err)
+
+	// A refresh created by a user is refreshed like the report it refreshes
+	manual, err := s.dao().Refresh(context.Background(), orgID, second.UUID, RefreshCoverageReportParams{})
+	require.NoError(s.T(), err)
+	manual = match(manual.UUID)
+	assert.True(s.T(), manual.ScheduledRefresh)
+	third, err := s.dao().Refresh(context.Background(), orgID, manual.UUID, RefreshCoverageReportParams{Automatic: true})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), manual.UUID, third.SourceReportUUID)
+	match(third.UUID)
+	_, err = s.dao().Fetch(context.Background(), orgID, second.UUID)
+	assert.NoError(s.T(), err)
+}
+
+func (s *CoverageReportDaoSuite) TestExport() {
+	orgID := seeds.RandomOrgId()
+	report := s.createReport(orgID, config.TaskStatusCompleted)
+	snapshotAt := time.Date(2026, 10, 1, 6, 0, 0, 0, time.UTC)
+	report.CatalogSnapshotAt = &snapshotAt
+	report.EcosystemCoverageSummary = &models.EcosystemCoverageSummary{{Ecosystem: "Java", Total: 1, ExactMatches: 1}, {Ecosystem: "Python", Total: 2, PartialMatches: 1, Unmatched: 1}}
+	require.NoError(s.T(), s.tx.Save(&report).Error)
+	s.createPackage(report.UUID, "urllib3", "2.0.7", "Python", models.CoverageMatchStatusNone)
+	s.createPackage(report.UUID, "spring-core", "6.1.0", "Java", models.CoverageMatchStatusExact)
+	s.createPackage(report.UUID, "idna", "3.7", "Python", models.CoverageMatchStatusPartial)
+
+	exported, err := s.dao().FetchExport(context.Background(), orgID, report.UUID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), report.UUID, exported.UUID)
+	require.NotNil(s.T(), exported.CatalogSnapshotAt)
+	assert.True(s.T(), snapshotAt.Equal(*exported.CatalogSnapshotAt))
+	assert.Len(s.T(), exported.EcosystemCoverageSummary, 2)
+
+	var packages []api.CoverageReportPackageResponse
+	pages := 0
+	err = s.dao().ExportPackages(context.Background(), report.UUID, func(page []api.CoverageReportPackageResponse) error {
+		pages++
+		packages = append(packages, page...)
+		return nil
+	})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, pages)
+	require.Len(s.T(), packages, 3)
+	assert.Equal(s.T(), "spring-core", packages[0].Name)
+	assert.Equal(s.T(), models.CoverageMatchStatusExact, packages[0].MatchStatus)
+	assert.Equal(s.T(), "idna", packages[1].Name)
+	assert.Equal(s.T(), models.CoverageMatchStatusPartial, packages[1].MatchStatus)
+	assert.Equal(s.T(), "urllib3", packages[2].Name)
+	assert.Equal(s.T(), models.CoverageMatchStatusNone, packages[2].MatchStatus)
+
+	// An error of the writer stops the export
+	writeErr := errors.New("client went away")
+	err = s.dao().ExportPackages(context.Background(), report.UUID, func(page []api.CoverageReportPackageResponse) error {
+		return writeErr
+	})
+	assert.ErrorIs(s.T(), err, writeErr)
+}
+
+func (s *CoverageReportDaoSuite) TestExportNotCompleted() {
+	orgID := seeds.RandomOrgId()
+	report := s.createReport(orgID, config.TaskStatusPending)
+
+	_, err := s.dao().FetchExport(context.Background(), orgID, report.UUID)
+	var daoError *ce.DaoError
+	require.True(s.T(), errors.As(err, &daoError))
+	assert.True(s.T(), daoError.BadValidation)
+
+	_, err = s.dao().FetchExport(context.Background(), seeds.RandomOrgId(), report.UUID)
+	require.True(s.T(), errors.As(err, &daoError))
+	assert.True(s.T(), daoError.NotFound)
+}
+
 func (s *CoverageReportDaoSuite) TestListPackagesFilterByCoveredTrue() {
 	orgID := seeds.RandomOrgId()
 	report := s.createReport(orgID, config.TaskStatusCompleted)
@@ -224,8 +701,10 @@ func (s *CoverageReportDaoSuite) TestCreate() {
 	uploadUUID := uuid.NewString()
 	report, err := dao.Create(context.Background(),
 		CreateCoverageReportParams{
-			OrgID:     orgIDTest,
-			AccountID: utils.Ptr("account-1"),
+			OrgID:       orgIDTest,
+			AccountID:   utils.Ptr("account-1"),
+			InputFormat: "pom.xml",
+			Warnings:    []string{"pom.xml dependency org.example:managed: version is not managed in this file"},
 		},
 		CreateCoverageUploadParams{
 			UUID:       uploadUUID,
@@ -242,6 +721,9 @@ func (s *CoverageReportDaoSuite) TestCreate() {
 	assert.Equal(s.T(), config.TaskStatusPending, readReport.Status)
 	assert.Equal(s.T(), orgIDTest, readReport.OrgID)
 	assert.Equal(s.T(), "account-1", *readReport.AccountID)
+	assert.Equal(s.T(), "pom.xml", *readReport.InputFormat)
+	assert.Equal(s.T(), []string{"pom.xml dependency org.example:managed: version is not managed in this file"}, []string(readReport.Warnings))
+	assert.Equal(s.T(), []string(readReport.Warnings), report.Warnings)
 
 	var readUpload models.CoverageUpload
 	err = s.tx.Where("uuid = ?", uploadUUID).First(&readUpload).Error
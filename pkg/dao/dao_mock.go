@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/event"
 	"github.com/content-services/content-sources-backend/pkg/models"
 	"github.com/content-services/tang/pkg/tangy"
 	"github.com/content-services/yummy/pkg/yum"
@@ -2408,6 +2409,78 @@ func (_c *MockRpmDao_FetchForRepository_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// FetchTemplateAvailableErrataCounts provides a mock function for the type MockRpmDao
+func (_mock *MockRpmDao) FetchTemplateAvailableErrataCounts(ctx context.Context, orgID string, templateUUID string) (api.ErrataCounts, error) {
+	ret := _mock.Called(ctx, orgID, templateUUID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchTemplateAvailableErrataCounts")
+	}
+
+	var r0 api.ErrataCounts
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (api.ErrataCounts, error)); ok {
+		return returnFunc(ctx, orgID, templateUUID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) api.ErrataCounts); ok {
+		r0 = returnFunc(ctx, orgID, templateUUID)
+	} else {
+		r0 = ret.Get(0).(api.ErrataCounts)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, orgID, templateUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRpmDao_FetchTemplateAvailableErrataCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchTemplateAvailableErrataCounts'
+type MockRpmDao_FetchTemplateAvailableErrataCounts_Call struct {
+	*mock.Call
+}
+
+// FetchTemplateAvailableErrataCounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - templateUUID string
+func (_e *MockRpmDao_Expecter) FetchTemplateAvailableErrataCounts(ctx interface{}, orgID interface{}, templateUUID interface{}) *MockRpmDao_FetchTemplateAvailableErrataCounts_Call {
+	return &MockRpmDao_FetchTemplateAvailableErrataCounts_Call{Call: _e.mock.On("FetchTemplateAvailableErrataCounts", ctx, orgID, templateUUID)}
+}
+
+func (_c *MockRpmDao_FetchTemplateAvailableErrataCounts_Call) Run(run func(ctx context.Context, orgID string, templateUUID string)) *MockRpmDao_FetchTemplateAvailableErrataCounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRpmDao_FetchTemplateAvailableErrataCounts_Call) Return(errataCounts api.ErrataCounts, err error) *MockRpmDao_FetchTemplateAvailableErrataCounts_Call {
+	_c.Call.Return(errataCounts, err)
+	return _c
+}
+
+func (_c *MockRpmDao_FetchTemplateAvailableErrataCounts_Call) RunAndReturn(run func(ctx context.Context, orgID string, templateUUID string) (api.ErrataCounts, error)) *MockRpmDao_FetchTemplateAvailableErrataCounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FetchTemplateErrataIDs provides a mock function for the type MockRpmDao
 func (_mock *MockRpmDao) FetchTemplateErrataIDs(ctx context.Context, orgId string, templateUUID string) ([]string, error) {
 	ret := _mock.Called(ctx, orgId, templateUUID)
@@ -5768,6 +5841,59 @@ func (_c *MockMetricsDao_RepositoryConfigsCount_Call) RunAndReturn(run func(ctx
 	return _c
 }
 
+// TaskBacklogByType provides a mock function for the type MockMetricsDao
+func (_mock *MockMetricsDao) TaskBacklogByType(ctx context.Context) []TaskTypeBacklog {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TaskBacklogByType")
+	}
+
+	var r0 []TaskTypeBacklog
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []TaskTypeBacklog); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]TaskTypeBacklog)
+		}
+	}
+	return r0
+}
+
+// MockMetricsDao_TaskBacklogByType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TaskBacklogByType'
+type MockMetricsDao_TaskBacklogByType_Call struct {
+	*mock.Call
+}
+
+// TaskBacklogByType is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMetricsDao_Expecter) TaskBacklogByType(ctx interface{}) *MockMetricsDao_TaskBacklogByType_Call {
+	return &MockMetricsDao_TaskBacklogByType_Call{Call: _e.mock.On("TaskBacklogByType", ctx)}
+}
+
+func (_c *MockMetricsDao_TaskBacklogByType_Call) Run(run func(ctx context.Context)) *MockMetricsDao_TaskBacklogByType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMetricsDao_TaskBacklogByType_Call) Return(taskTypeBacklogs []TaskTypeBacklog) *MockMetricsDao_TaskBacklogByType_Call {
+	_c.Call.Return(taskTypeBacklogs)
+	return _c
+}
+
+func (_c *MockMetricsDao_TaskBacklogByType_Call) RunAndReturn(run func(ctx context.Context) []TaskTypeBacklog) *MockMetricsDao_TaskBacklogByType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TaskPendingTimeAverageByType provides a mock function for the type MockMetricsDao
 func (_mock *MockMetricsDao) TaskPendingTimeAverageByType(ctx context.Context) []TaskTypePendingTimeAverage {
 	ret := _mock.Called(ctx)
@@ -6175,6 +6301,84 @@ func (_c *MockTaskInfoDao_Fetch_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// FetchActiveTaskByDedupKey provides a mock function for the type MockTaskInfoDao
+func (_mock *MockTaskInfoDao) FetchActiveTaskByDedupKey(ctx context.Context, orgID string, taskType string, dedupKey string) (string, error) {
+	ret := _mock.Called(ctx, orgID, taskType, dedupKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchActiveTaskByDedupKey")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (string, error)); ok {
+		return returnFunc(ctx, orgID, taskType, dedupKey)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = returnFunc(ctx, orgID, taskType, dedupKey)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, orgID, taskType, dedupKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskInfoDao_FetchActiveTaskByDedupKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchActiveTaskByDedupKey'
+type MockTaskInfoDao_FetchActiveTaskByDedupKey_Call struct {
+	*mock.Call
+}
+
+// FetchActiveTaskByDedupKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - taskType string
+//   - dedupKey string
+func (_e *MockTaskInfoDao_Expecter) FetchActiveTaskByDedupKey(ctx interface{}, orgID interface{}, taskType interface{}, dedupKey interface{}) *MockTaskInfoDao_FetchActiveTaskByDedupKey_Call {
+	return &MockTaskInfoDao_FetchActiveTaskByDedupKey_Call{Call: _e.mock.On("FetchActiveTaskByDedupKey", ctx, orgID, taskType, dedupKey)}
+}
+
+func (_c *MockTaskInfoDao_FetchActiveTaskByDedupKey_Call) Run(run func(ctx context.Context, orgID string, taskType string, dedupKey string)) *MockTaskInfoDao_FetchActiveTaskByDedupKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskInfoDao_FetchActiveTaskByDedupKey_Call) Return(s string, err error) *MockTaskInfoDao_FetchActiveTaskByDedupKey_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockTaskInfoDao_FetchActiveTaskByDedupKey_Call) RunAndReturn(run func(ctx context.Context, orgID string, taskType string, dedupKey string) (string, error)) *MockTaskInfoDao_FetchActiveTaskByDedupKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FetchActiveTasks provides a mock function for the type MockTaskInfoDao
 func (_mock *MockTaskInfoDao) FetchActiveTasks(ctx context.Context, orgID string, objectUUID string, taskTypes ...string) ([]string, error) {
 	// string
@@ -6268,6 +6472,78 @@ func (_c *MockTaskInfoDao_FetchActiveTasks_Call) RunAndReturn(run func(ctx conte
 	return _c
 }
 
+// FetchGraph provides a mock function for the type MockTaskInfoDao
+func (_mock *MockTaskInfoDao) FetchGraph(ctx context.Context, orgID string, id string) (api.TaskGraphResponse, error) {
+	ret := _mock.Called(ctx, orgID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchGraph")
+	}
+
+	var r0 api.TaskGraphResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (api.TaskGraphResponse, error)); ok {
+		return returnFunc(ctx, orgID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) api.TaskGraphResponse); ok {
+		r0 = returnFunc(ctx, orgID, id)
+	} else {
+		r0 = ret.Get(0).(api.TaskGraphResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, orgID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskInfoDao_FetchGraph_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchGraph'
+type MockTaskInfoDao_FetchGraph_Call struct {
+	*mock.Call
+}
+
+// FetchGraph is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - id string
+func (_e *MockTaskInfoDao_Expecter) FetchGraph(ctx interface{}, orgID interface{}, id interface{}) *MockTaskInfoDao_FetchGraph_Call {
+	return &MockTaskInfoDao_FetchGraph_Call{Call: _e.mock.On("FetchGraph", ctx, orgID, id)}
+}
+
+func (_c *MockTaskInfoDao_FetchGraph_Call) Run(run func(ctx context.Context, orgID string, id string)) *MockTaskInfoDao_FetchGraph_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskInfoDao_FetchGraph_Call) Return(taskGraphResponse api.TaskGraphResponse, err error) *MockTaskInfoDao_FetchGraph_Call {
+	_c.Call.Return(taskGraphResponse, err)
+	return _c
+}
+
+func (_c *MockTaskInfoDao_FetchGraph_Call) RunAndReturn(run func(ctx context.Context, orgID string, id string) (api.TaskGraphResponse, error)) *MockTaskInfoDao_FetchGraph_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // List provides a mock function for the type MockTaskInfoDao
 func (_mock *MockTaskInfoDao) List(ctx context.Context, OrgID string, pageData api.PaginationData, filterData api.TaskInfoFilterData) (api.TaskInfoCollectionResponse, int64, error) {
 	ret := _mock.Called(ctx, OrgID, pageData, filterData)
@@ -6379,6 +6655,74 @@ func (_m *MockAdminTaskDao) EXPECT() *MockAdminTaskDao_Expecter {
 	return &MockAdminTaskDao_Expecter{mock: &_m.Mock}
 }
 
+// CancelDeadLetterTasks provides a mock function for the type MockAdminTaskDao
+func (_mock *MockAdminTaskDao) CancelDeadLetterTasks(ctx context.Context, request api.AdminDeadLetterRequest) ([]string, error) {
+	ret := _mock.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelDeadLetterTasks")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.AdminDeadLetterRequest) ([]string, error)); ok {
+		return returnFunc(ctx, request)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.AdminDeadLetterRequest) []string); ok {
+		r0 = returnFunc(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, api.AdminDeadLetterRequest) error); ok {
+		r1 = returnFunc(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdminTaskDao_CancelDeadLetterTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelDeadLetterTasks'
+type MockAdminTaskDao_CancelDeadLetterTasks_Call struct {
+	*mock.Call
+}
+
+// CancelDeadLetterTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request api.AdminDeadLetterRequest
+func (_e *MockAdminTaskDao_Expecter) CancelDeadLetterTasks(ctx interface{}, request interface{}) *MockAdminTaskDao_CancelDeadLetterTasks_Call {
+	return &MockAdminTaskDao_CancelDeadLetterTasks_Call{Call: _e.mock.On("CancelDeadLetterTasks", ctx, request)}
+}
+
+func (_c *MockAdminTaskDao_CancelDeadLetterTasks_Call) Run(run func(ctx context.Context, request api.AdminDeadLetterRequest)) *MockAdminTaskDao_CancelDeadLetterTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 api.AdminDeadLetterRequest
+		if args[1] != nil {
+			arg1 = args[1].(api.AdminDeadLetterRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAdminTaskDao_CancelDeadLetterTasks_Call) Return(strings []string, err error) *MockAdminTaskDao_CancelDeadLetterTasks_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockAdminTaskDao_CancelDeadLetterTasks_Call) RunAndReturn(run func(ctx context.Context, request api.AdminDeadLetterRequest) ([]string, error)) *MockAdminTaskDao_CancelDeadLetterTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Fetch provides a mock function for the type MockAdminTaskDao
 func (_mock *MockAdminTaskDao) Fetch(ctx context.Context, id string) (api.AdminTaskInfoResponse, error) {
 	ret := _mock.Called(ctx, id)
@@ -6523,11 +6867,225 @@ func (_c *MockAdminTaskDao_List_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
-// NewMockDomainDao creates a new instance of MockDomainDao. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockDomainDao(t interface {
-	mock.TestingT
-	Cleanup(func())
+// ListDeadLetterGroups provides a mock function for the type MockAdminTaskDao
+func (_mock *MockAdminTaskDao) ListDeadLetterGroups(ctx context.Context, pageData api.PaginationData, filterData api.AdminDeadLetterFilterData) (api.AdminDeadLetterGroupCollectionResponse, int64, error) {
+	ret := _mock.Called(ctx, pageData, filterData)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeadLetterGroups")
+	}
+
+	var r0 api.AdminDeadLetterGroupCollectionResponse
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.PaginationData, api.AdminDeadLetterFilterData) (api.AdminDeadLetterGroupCollectionResponse, int64, error)); ok {
+		return returnFunc(ctx, pageData, filterData)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.PaginationData, api.AdminDeadLetterFilterData) api.AdminDeadLetterGroupCollectionResponse); ok {
+		r0 = returnFunc(ctx, pageData, filterData)
+	} else {
+		r0 = ret.Get(0).(api.AdminDeadLetterGroupCollectionResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, api.PaginationData, api.AdminDeadLetterFilterData) int64); ok {
+		r1 = returnFunc(ctx, pageData, filterData)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, api.PaginationData, api.AdminDeadLetterFilterData) error); ok {
+		r2 = returnFunc(ctx, pageData, filterData)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockAdminTaskDao_ListDeadLetterGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeadLetterGroups'
+type MockAdminTaskDao_ListDeadLetterGroups_Call struct {
+	*mock.Call
+}
+
+// ListDeadLetterGroups is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pageData api.PaginationData
+//   - filterData api.AdminDeadLetterFilterData
+func (_e *MockAdminTaskDao_Expecter) ListDeadLetterGroups(ctx interface{}, pageData interface{}, filterData interface{}) *MockAdminTaskDao_ListDeadLetterGroups_Call {
+	return &MockAdminTaskDao_ListDeadLetterGroups_Call{Call: _e.mock.On("ListDeadLetterGroups", ctx, pageData, filterData)}
+}
+
+func (_c *MockAdminTaskDao_ListDeadLetterGroups_Call) Run(run func(ctx context.Context, pageData api.PaginationData, filterData api.AdminDeadLetterFilterData)) *MockAdminTaskDao_ListDeadLetterGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 api.PaginationData
+		if args[1] != nil {
+			arg1 = args[1].(api.PaginationData)
+		}
+		var arg2 api.AdminDeadLetterFilterData
+		if args[2] != nil {
+			arg2 = args[2].(api.AdminDeadLetterFilterData)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAdminTaskDao_ListDeadLetterGroups_Call) Return(adminDeadLetterGroupCollectionResponse api.AdminDeadLetterGroupCollectionResponse, n int64, err error) *MockAdminTaskDao_ListDeadLetterGroups_Call {
+	_c.Call.Return(adminDeadLetterGroupCollectionResponse, n, err)
+	return _c
+}
+
+func (_c *MockAdminTaskDao_ListDeadLetterGroups_Call) RunAndReturn(run func(ctx context.Context, pageData api.PaginationData, filterData api.AdminDeadLetterFilterData) (api.AdminDeadLetterGroupCollectionResponse, int64, error)) *MockAdminTaskDao_ListDeadLetterGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeDeadLetterTasks provides a mock function for the type MockAdminTaskDao
+func (_mock *MockAdminTaskDao) PurgeDeadLetterTasks(ctx context.Context, request api.AdminDeadLetterRequest) ([]string, error) {
+	ret := _mock.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeadLetterTasks")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.AdminDeadLetterRequest) ([]string, error)); ok {
+		return returnFunc(ctx, request)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.AdminDeadLetterRequest) []string); ok {
+		r0 = returnFunc(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, api.AdminDeadLetterRequest) error); ok {
+		r1 = returnFunc(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdminTaskDao_PurgeDeadLetterTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeadLetterTasks'
+type MockAdminTaskDao_PurgeDeadLetterTasks_Call struct {
+	*mock.Call
+}
+
+// PurgeDeadLetterTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request api.AdminDeadLetterRequest
+func (_e *MockAdminTaskDao_Expecter) PurgeDeadLetterTasks(ctx interface{}, request interface{}) *MockAdminTaskDao_PurgeDeadLetterTasks_Call {
+	return &MockAdminTaskDao_PurgeDeadLetterTasks_Call{Call: _e.mock.On("PurgeDeadLetterTasks", ctx, request)}
+}
+
+func (_c *MockAdminTaskDao_PurgeDeadLetterTasks_Call) Run(run func(ctx context.Context, request api.AdminDeadLetterRequest)) *MockAdminTaskDao_PurgeDeadLetterTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 api.AdminDeadLetterRequest
+		if args[1] != nil {
+			arg1 = args[1].(api.AdminDeadLetterRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAdminTaskDao_PurgeDeadLetterTasks_Call) Return(strings []string, err error) *MockAdminTaskDao_PurgeDeadLetterTasks_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockAdminTaskDao_PurgeDeadLetterTasks_Call) RunAndReturn(run func(ctx context.Context, request api.AdminDeadLetterRequest) ([]string, error)) *MockAdminTaskDao_PurgeDeadLetterTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetryDeadLetterTasks provides a mock function for the type MockAdminTaskDao
+func (_mock *MockAdminTaskDao) RetryDeadLetterTasks(ctx context.Context, request api.AdminDeadLetterRequest) ([]string, error) {
+	ret := _mock.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryDeadLetterTasks")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.AdminDeadLetterRequest) ([]string, error)); ok {
+		return returnFunc(ctx, request)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.AdminDeadLetterRequest) []string); ok {
+		r0 = returnFunc(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, api.AdminDeadLetterRequest) error); ok {
+		r1 = returnFunc(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdminTaskDao_RetryDeadLetterTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetryDeadLetterTasks'
+type MockAdminTaskDao_RetryDeadLetterTasks_Call struct {
+	*mock.Call
+}
+
+// RetryDeadLetterTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request api.AdminDeadLetterRequest
+func (_e *MockAdminTaskDao_Expecter) RetryDeadLetterTasks(ctx interface{}, request interface{}) *MockAdminTaskDao_RetryDeadLetterTasks_Call {
+	return &MockAdminTaskDao_RetryDeadLetterTasks_Call{Call: _e.mock.On("RetryDeadLetterTasks", ctx, request)}
+}
+
+func (_c *MockAdminTaskDao_RetryDeadLetterTasks_Call) Run(run func(ctx context.Context, request api.AdminDeadLetterRequest)) *MockAdminTaskDao_RetryDeadLetterTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 api.AdminDeadLetterRequest
+		if args[1] != nil {
+			arg1 = args[1].(api.AdminDeadLetterRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAdminTaskDao_RetryDeadLetterTasks_Call) Return(strings []string, err error) *MockAdminTaskDao_RetryDeadLetterTasks_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockAdminTaskDao_RetryDeadLetterTasks_Call) RunAndReturn(run func(ctx context.Context, request api.AdminDeadLetterRequest) ([]string, error)) *MockAdminTaskDao_RetryDeadLetterTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDomainDao creates a new instance of MockDomainDao. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDomainDao(t interface {
+	mock.TestingT
+	Cleanup(func())
 }) *MockDomainDao {
 	mock := &MockDomainDao{}
 	mock.Mock.Test(t)
@@ -8764,6 +9322,75 @@ func (_c *MockTemplateDao_Update_Call) RunAndReturn(run func(ctx context.Context
 	return _c
 }
 
+// UpdateAvailableErrataCounts provides a mock function for the type MockTemplateDao
+func (_mock *MockTemplateDao) UpdateAvailableErrataCounts(ctx context.Context, orgID string, templateUUID string, counts api.ErrataCounts) error {
+	ret := _mock.Called(ctx, orgID, templateUUID, counts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAvailableErrataCounts")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, api.ErrataCounts) error); ok {
+		r0 = returnFunc(ctx, orgID, templateUUID, counts)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTemplateDao_UpdateAvailableErrataCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAvailableErrataCounts'
+type MockTemplateDao_UpdateAvailableErrataCounts_Call struct {
+	*mock.Call
+}
+
+// UpdateAvailableErrataCounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - templateUUID string
+//   - counts api.ErrataCounts
+func (_e *MockTemplateDao_Expecter) UpdateAvailableErrataCounts(ctx interface{}, orgID interface{}, templateUUID interface{}, counts interface{}) *MockTemplateDao_UpdateAvailableErrataCounts_Call {
+	return &MockTemplateDao_UpdateAvailableErrataCounts_Call{Call: _e.mock.On("UpdateAvailableErrataCounts", ctx, orgID, templateUUID, counts)}
+}
+
+func (_c *MockTemplateDao_UpdateAvailableErrataCounts_Call) Run(run func(ctx context.Context, orgID string, templateUUID string, counts api.ErrataCounts)) *MockTemplateDao_UpdateAvailableErrataCounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 api.ErrataCounts
+		if args[3] != nil {
+			arg3 = args[3].(api.ErrataCounts)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTemplateDao_UpdateAvailableErrataCounts_Call) Return(err error) *MockTemplateDao_UpdateAvailableErrataCounts_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTemplateDao_UpdateAvailableErrataCounts_Call) RunAndReturn(run func(ctx context.Context, orgID string, templateUUID string, counts api.ErrataCounts) error) *MockTemplateDao_UpdateAvailableErrataCounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateDistributionHrefs provides a mock function for the type MockTemplateDao
 func (_mock *MockTemplateDao) UpdateDistributionHrefs(ctx context.Context, templateUUID string, repoUUIDs []string, snapshots []models.Snapshot, repoDistributionMap map[string]string) error {
 	ret := _mock.Called(ctx, templateUUID, repoUUIDs, snapshots, repoDistributionMap)
@@ -10316,90 +10943,231 @@ func (_m *MockCoverageReportDao) EXPECT() *MockCoverageReportDao_Expecter {
 	return &MockCoverageReportDao_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function for the type MockCoverageReportDao
-func (_mock *MockCoverageReportDao) Create(ctx context.Context, report CreateCoverageReportParams, upload CreateCoverageUploadParams) (api.CoverageReportResponse, error) {
-	ret := _mock.Called(ctx, report, upload)
+// Compare provides a mock function for the type MockCoverageReportDao
+func (_mock *MockCoverageReportDao) Compare(ctx context.Context, orgID string, uuid string, otherUUID string) (api.CoverageReportComparisonResponse, error) {
+	ret := _mock.Called(ctx, orgID, uuid, otherUUID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for Compare")
 	}
 
-	var r0 api.CoverageReportResponse
+	var r0 api.CoverageReportComparisonResponse
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, CreateCoverageReportParams, CreateCoverageUploadParams) (api.CoverageReportResponse, error)); ok {
-		return returnFunc(ctx, report, upload)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (api.CoverageReportComparisonResponse, error)); ok {
+		return returnFunc(ctx, orgID, uuid, otherUUID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, CreateCoverageReportParams, CreateCoverageUploadParams) api.CoverageReportResponse); ok {
-		r0 = returnFunc(ctx, report, upload)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) api.CoverageReportComparisonResponse); ok {
+		r0 = returnFunc(ctx, orgID, uuid, otherUUID)
 	} else {
-		r0 = ret.Get(0).(api.CoverageReportResponse)
+		r0 = ret.Get(0).(api.CoverageReportComparisonResponse)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, CreateCoverageReportParams, CreateCoverageUploadParams) error); ok {
-		r1 = returnFunc(ctx, report, upload)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, orgID, uuid, otherUUID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockCoverageReportDao_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockCoverageReportDao_Create_Call struct {
+// MockCoverageReportDao_Compare_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Compare'
+type MockCoverageReportDao_Compare_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
+// Compare is a helper method to define mock.On call
 //   - ctx context.Context
-//   - report CreateCoverageReportParams
-//   - upload CreateCoverageUploadParams
-func (_e *MockCoverageReportDao_Expecter) Create(ctx interface{}, report interface{}, upload interface{}) *MockCoverageReportDao_Create_Call {
-	return &MockCoverageReportDao_Create_Call{Call: _e.mock.On("Create", ctx, report, upload)}
+//   - orgID string
+//   - uuid string
+//   - otherUUID string
+func (_e *MockCoverageReportDao_Expecter) Compare(ctx interface{}, orgID interface{}, uuid interface{}, otherUUID interface{}) *MockCoverageReportDao_Compare_Call {
+	return &MockCoverageReportDao_Compare_Call{Call: _e.mock.On("Compare", ctx, orgID, uuid, otherUUID)}
 }
 
-func (_c *MockCoverageReportDao_Create_Call) Run(run func(ctx context.Context, report CreateCoverageReportParams, upload CreateCoverageUploadParams)) *MockCoverageReportDao_Create_Call {
+func (_c *MockCoverageReportDao_Compare_Call) Run(run func(ctx context.Context, orgID string, uuid string, otherUUID string)) *MockCoverageReportDao_Compare_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 CreateCoverageReportParams
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(CreateCoverageReportParams)
+			arg1 = args[1].(string)
 		}
-		var arg2 CreateCoverageUploadParams
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(CreateCoverageUploadParams)
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockCoverageReportDao_Create_Call) Return(coverageReportResponse api.CoverageReportResponse, err error) *MockCoverageReportDao_Create_Call {
-	_c.Call.Return(coverageReportResponse, err)
+func (_c *MockCoverageReportDao_Compare_Call) Return(coverageReportComparisonResponse api.CoverageReportComparisonResponse, err error) *MockCoverageReportDao_Compare_Call {
+	_c.Call.Return(coverageReportComparisonResponse, err)
 	return _c
 }
 
-func (_c *MockCoverageReportDao_Create_Call) RunAndReturn(run func(ctx context.Context, report CreateCoverageReportParams, upload CreateCoverageUploadParams) (api.CoverageReportResponse, error)) *MockCoverageReportDao_Create_Call {
+func (_c *MockCoverageReportDao_Compare_Call) RunAndReturn(run func(ctx context.Context, orgID string, uuid string, otherUUID string) (api.CoverageReportComparisonResponse, error)) *MockCoverageReportDao_Compare_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Fetch provides a mock function for the type MockCoverageReportDao
-func (_mock *MockCoverageReportDao) Fetch(ctx context.Context, orgID string, uuid string) (api.CoverageReportResponse, error) {
-	ret := _mock.Called(ctx, orgID, uuid)
+// Create provides a mock function for the type MockCoverageReportDao
+func (_mock *MockCoverageReportDao) Create(ctx context.Context, report CreateCoverageReportParams, upload CreateCoverageUploadParams) (api.CoverageReportResponse, error) {
+	ret := _mock.Called(ctx, report, upload)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Fetch")
+		panic("no return value specified for Create")
 	}
 
 	var r0 api.CoverageReportResponse
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (api.CoverageReportResponse, error)); ok {
-		return returnFunc(ctx, orgID, uuid)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, CreateCoverageReportParams, CreateCoverageUploadParams) (api.CoverageReportResponse, error)); ok {
+		return returnFunc(ctx, report, upload)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, CreateCoverageReportParams, CreateCoverageUploadParams) api.CoverageReportResponse); ok {
+		r0 = returnFunc(ctx, report, upload)
+	} else {
+		r0 = ret.Get(0).(api.CoverageReportResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, CreateCoverageReportParams, CreateCoverageUploadParams) error); ok {
+		r1 = returnFunc(ctx, report, upload)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCoverageReportDao_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockCoverageReportDao_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - report CreateCoverageReportParams
+//   - upload CreateCoverageUploadParams
+func (_e *MockCoverageReportDao_Expecter) Create(ctx interface{}, report interface{}, upload interface{}) *MockCoverageReportDao_Create_Call {
+	return &MockCoverageReportDao_Create_Call{Call: _e.mock.On("Create", ctx, report, upload)}
+}
+
+func (_c *MockCoverageReportDao_Create_Call) Run(run func(ctx context.Context, report CreateCoverageReportParams, upload CreateCoverageUploadParams)) *MockCoverageReportDao_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 CreateCoverageReportParams
+		if args[1] != nil {
+			arg1 = args[1].(CreateCoverageReportParams)
+		}
+		var arg2 CreateCoverageUploadParams
+		if args[2] != nil {
+			arg2 = args[2].(CreateCoverageUploadParams)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCoverageReportDao_Create_Call) Return(coverageReportResponse api.CoverageReportResponse, err error) *MockCoverageReportDao_Create_Call {
+	_c.Call.Return(coverageReportResponse, err)
+	return _c
+}
+
+func (_c *MockCoverageReportDao_Create_Call) RunAndReturn(run func(ctx context.Context, report CreateCoverageReportParams, upload CreateCoverageUploadParams) (api.CoverageReportResponse, error)) *MockCoverageReportDao_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportPackages provides a mock function for the type MockCoverageReportDao
+func (_mock *MockCoverageReportDao) ExportPackages(ctx context.Context, uuid string, write func(packages []api.CoverageReportPackageResponse) error) error {
+	ret := _mock.Called(ctx, uuid, write)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportPackages")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, func(packages []api.CoverageReportPackageResponse) error) error); ok {
+		r0 = returnFunc(ctx, uuid, write)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCoverageReportDao_ExportPackages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportPackages'
+type MockCoverageReportDao_ExportPackages_Call struct {
+	*mock.Call
+}
+
+// ExportPackages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uuid string
+//   - write func(packages []api.CoverageReportPackageResponse) error
+func (_e *MockCoverageReportDao_Expecter) ExportPackages(ctx interface{}, uuid interface{}, write interface{}) *MockCoverageReportDao_ExportPackages_Call {
+	return &MockCoverageReportDao_ExportPackages_Call{Call: _e.mock.On("ExportPackages", ctx, uuid, write)}
+}
+
+func (_c *MockCoverageReportDao_ExportPackages_Call) Run(run func(ctx context.Context, uuid string, write func(packages []api.CoverageReportPackageResponse) error)) *MockCoverageReportDao_ExportPackages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 func(packages []api.CoverageReportPackageResponse) error
+		if args[2] != nil {
+			arg2 = args[2].(func(packages []api.CoverageReportPackageResponse) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCoverageReportDao_ExportPackages_Call) Return(err error) *MockCoverageReportDao_ExportPackages_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCoverageReportDao_ExportPackages_Call) RunAndReturn(run func(ctx context.Context, uuid string, write func(packages []api.CoverageReportPackageResponse) error) error) *MockCoverageReportDao_ExportPackages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Fetch provides a mock function for the type MockCoverageReportDao
+func (_mock *MockCoverageReportDao) Fetch(ctx context.Context, orgID string, uuid string) (api.CoverageReportResponse, error) {
+	ret := _mock.Called(ctx, orgID, uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Fetch")
+	}
+
+	var r0 api.CoverageReportResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (api.CoverageReportResponse, error)); ok {
+		return returnFunc(ctx, orgID, uuid)
 	}
 	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) api.CoverageReportResponse); ok {
 		r0 = returnFunc(ctx, orgID, uuid)
@@ -10460,54 +11228,46 @@ func (_c *MockCoverageReportDao_Fetch_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
-// ListPackages provides a mock function for the type MockCoverageReportDao
-func (_mock *MockCoverageReportDao) ListPackages(ctx context.Context, orgID string, reportUUID string, pageData api.PaginationData, filterData api.ListCoverageReportPackagesRequest) (api.CoverageReportPackageCollectionResponse, int64, error) {
-	ret := _mock.Called(ctx, orgID, reportUUID, pageData, filterData)
+// FetchExport provides a mock function for the type MockCoverageReportDao
+func (_mock *MockCoverageReportDao) FetchExport(ctx context.Context, orgID string, uuid string) (api.CoverageReportResponse, error) {
+	ret := _mock.Called(ctx, orgID, uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListPackages")
+		panic("no return value specified for FetchExport")
 	}
 
-	var r0 api.CoverageReportPackageCollectionResponse
-	var r1 int64
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, api.PaginationData, api.ListCoverageReportPackagesRequest) (api.CoverageReportPackageCollectionResponse, int64, error)); ok {
-		return returnFunc(ctx, orgID, reportUUID, pageData, filterData)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, api.PaginationData, api.ListCoverageReportPackagesRequest) api.CoverageReportPackageCollectionResponse); ok {
-		r0 = returnFunc(ctx, orgID, reportUUID, pageData, filterData)
-	} else {
-		r0 = ret.Get(0).(api.CoverageReportPackageCollectionResponse)
+	var r0 api.CoverageReportResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (api.CoverageReportResponse, error)); ok {
+		return returnFunc(ctx, orgID, uuid)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, api.PaginationData, api.ListCoverageReportPackagesRequest) int64); ok {
-		r1 = returnFunc(ctx, orgID, reportUUID, pageData, filterData)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) api.CoverageReportResponse); ok {
+		r0 = returnFunc(ctx, orgID, uuid)
 	} else {
-		r1 = ret.Get(1).(int64)
+		r0 = ret.Get(0).(api.CoverageReportResponse)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, api.PaginationData, api.ListCoverageReportPackagesRequest) error); ok {
-		r2 = returnFunc(ctx, orgID, reportUUID, pageData, filterData)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, orgID, uuid)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockCoverageReportDao_ListPackages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPackages'
-type MockCoverageReportDao_ListPackages_Call struct {
+// MockCoverageReportDao_FetchExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchExport'
+type MockCoverageReportDao_FetchExport_Call struct {
 	*mock.Call
 }
 
-// ListPackages is a helper method to define mock.On call
+// FetchExport is a helper method to define mock.On call
 //   - ctx context.Context
 //   - orgID string
-//   - reportUUID string
-//   - pageData api.PaginationData
-//   - filterData api.ListCoverageReportPackagesRequest
-func (_e *MockCoverageReportDao_Expecter) ListPackages(ctx interface{}, orgID interface{}, reportUUID interface{}, pageData interface{}, filterData interface{}) *MockCoverageReportDao_ListPackages_Call {
-	return &MockCoverageReportDao_ListPackages_Call{Call: _e.mock.On("ListPackages", ctx, orgID, reportUUID, pageData, filterData)}
+//   - uuid string
+func (_e *MockCoverageReportDao_Expecter) FetchExport(ctx interface{}, orgID interface{}, uuid interface{}) *MockCoverageReportDao_FetchExport_Call {
+	return &MockCoverageReportDao_FetchExport_Call{Call: _e.mock.On("FetchExport", ctx, orgID, uuid)}
 }
 
-func (_c *MockCoverageReportDao_ListPackages_Call) Run(run func(ctx context.Context, orgID string, reportUUID string, pageData api.PaginationData, filterData api.ListCoverageReportPackagesRequest)) *MockCoverageReportDao_ListPackages_Call {
+func (_c *MockCoverageReportDao_FetchExport_Call) Run(run func(ctx context.Context, orgID string, uuid string)) *MockCoverageReportDao_FetchExport_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -10521,31 +11281,1960 @@ func (_c *MockCoverageReportDao_ListPackages_Call) Run(run func(ctx context.Cont
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
-		var arg3 api.PaginationData
-		if args[3] != nil {
-			arg3 = args[3].(api.PaginationData)
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCoverageReportDao_FetchExport_Call) Return(coverageReportResponse api.CoverageReportResponse, err error) *MockCoverageReportDao_FetchExport_Call {
+	_c.Call.Return(coverageReportResponse, err)
+	return _c
+}
+
+func (_c *MockCoverageReportDao_FetchExport_Call) RunAndReturn(run func(ctx context.Context, orgID string, uuid string) (api.CoverageReportResponse, error)) *MockCoverageReportDao_FetchExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InternalOnly_FailAnalysis provides a mock function for the type MockCoverageReportDao
+func (_mock *MockCoverageReportDao) InternalOnly_FailAnalysis(ctx context.Context, uuid string, analysisErr error) error {
+	ret := _mock.Called(ctx, uuid, analysisErr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InternalOnly_FailAnalysis")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, error) error); ok {
+		r0 = returnFunc(ctx, uuid, analysisErr)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCoverageReportDao_InternalOnly_FailAnalysis_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InternalOnly_FailAnalysis'
+type MockCoverageReportDao_InternalOnly_FailAnalysis_Call struct {
+	*mock.Call
+}
+
+// InternalOnly_FailAnalysis is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uuid string
+//   - analysisErr error
+func (_e *MockCoverageReportDao_Expecter) InternalOnly_FailAnalysis(ctx interface{}, uuid interface{}, analysisErr interface{}) *MockCoverageReportDao_InternalOnly_FailAnalysis_Call {
+	return &MockCoverageReportDao_InternalOnly_FailAnalysis_Call{Call: _e.mock.On("InternalOnly_FailAnalysis", ctx, uuid, analysisErr)}
+}
+
+func (_c *MockCoverageReportDao_InternalOnly_FailAnalysis_Call) Run(run func(ctx context.Context, uuid string, analysisErr error)) *MockCoverageReportDao_InternalOnly_FailAnalysis_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
 		}
-		var arg4 api.ListCoverageReportPackagesRequest
-		if args[4] != nil {
-			arg4 = args[4].(api.ListCoverageReportPackagesRequest)
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 error
+		if args[2] != nil {
+			arg2 = args[2].(error)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
-			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockCoverageReportDao_ListPackages_Call) Return(coverageReportPackageCollectionResponse api.CoverageReportPackageCollectionResponse, n int64, err error) *MockCoverageReportDao_ListPackages_Call {
-	_c.Call.Return(coverageReportPackageCollectionResponse, n, err)
+func (_c *MockCoverageReportDao_InternalOnly_FailAnalysis_Call) Return(err error) *MockCoverageReportDao_InternalOnly_FailAnalysis_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockCoverageReportDao_ListPackages_Call) RunAndReturn(run func(ctx context.Context, orgID string, reportUUID string, pageData api.PaginationData, filterData api.ListCoverageReportPackagesRequest) (api.CoverageReportPackageCollectionResponse, int64, error)) *MockCoverageReportDao_ListPackages_Call {
+func (_c *MockCoverageReportDao_InternalOnly_FailAnalysis_Call) RunAndReturn(run func(ctx context.Context, uuid string, analysisErr error) error) *MockCoverageReportDao_InternalOnly_FailAnalysis_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InternalOnly_ListScheduledRefreshes provides a mock function for the type MockCoverageReportDao
+func (_mock *MockCoverageReportDao) InternalOnly_ListScheduledRefreshes(ctx context.Context) ([]ScheduledCoverageRefresh, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InternalOnly_ListScheduledRefreshes")
+	}
+
+	var r0 []ScheduledCoverageRefresh
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]ScheduledCoverageRefresh, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []ScheduledCoverageRefresh); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ScheduledCoverageRefresh)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCoverageReportDao_InternalOnly_ListScheduledRefreshes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InternalOnly_ListScheduledRefreshes'
+type MockCoverageReportDao_InternalOnly_ListScheduledRefreshes_Call struct {
+	*mock.Call
+}
+
+// InternalOnly_ListScheduledRefreshes is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCoverageReportDao_Expecter) InternalOnly_ListScheduledRefreshes(ctx interface{}) *MockCoverageReportDao_InternalOnly_ListScheduledRefreshes_Call {
+	return &MockCoverageReportDao_InternalOnly_ListScheduledRefreshes_Call{Call: _e.mock.On("InternalOnly_ListScheduledRefreshes", ctx)}
+}
+
+func (_c *MockCoverageReportDao_InternalOnly_ListScheduledRefreshes_Call) Run(run func(ctx context.Context)) *MockCoverageReportDao_InternalOnly_ListScheduledRefreshes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCoverageReportDao_InternalOnly_ListScheduledRefreshes_Call) Return(scheduledCoverageRefreshs []ScheduledCoverageRefresh, err error) *MockCoverageReportDao_InternalOnly_ListScheduledRefreshes_Call {
+	_c.Call.Return(scheduledCoverageRefreshs, err)
+	return _c
+}
+
+func (_c *MockCoverageReportDao_InternalOnly_ListScheduledRefreshes_Call) RunAndReturn(run func(ctx context.Context) ([]ScheduledCoverageRefresh, error)) *MockCoverageReportDao_InternalOnly_ListScheduledRefreshes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InternalOnly_MatchRefresh provides a mock function for the type MockCoverageReportDao
+func (_mock *MockCoverageReportDao) InternalOnly_MatchRefresh(ctx context.Context, uuid string, params MatchCoverageRefreshParams) (api.CoverageReportResponse, error) {
+	ret := _mock.Called(ctx, uuid, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InternalOnly_MatchRefresh")
+	}
+
+	var r0 api.CoverageReportResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, MatchCoverageRefreshParams) (api.CoverageReportResponse, error)); ok {
+		return returnFunc(ctx, uuid, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, MatchCoverageRefreshParams) api.CoverageReportResponse); ok {
+		r0 = returnFunc(ctx, uuid, params)
+	} else {
+		r0 = ret.Get(0).(api.CoverageReportResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, MatchCoverageRefreshParams) error); ok {
+		r1 = returnFunc(ctx, uuid, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCoverageReportDao_InternalOnly_MatchRefresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InternalOnly_MatchRefresh'
+type MockCoverageReportDao_InternalOnly_MatchRefresh_Call struct {
+	*mock.Call
+}
+
+// InternalOnly_MatchRefresh is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uuid string
+//   - params MatchCoverageRefreshParams
+func (_e *MockCoverageReportDao_Expecter) InternalOnly_MatchRefresh(ctx interface{}, uuid interface{}, params interface{}) *MockCoverageReportDao_InternalOnly_MatchRefresh_Call {
+	return &MockCoverageReportDao_InternalOnly_MatchRefresh_Call{Call: _e.mock.On("InternalOnly_MatchRefresh", ctx, uuid, params)}
+}
+
+func (_c *MockCoverageReportDao_InternalOnly_MatchRefresh_Call) Run(run func(ctx context.Context, uuid string, params MatchCoverageRefreshParams)) *MockCoverageReportDao_InternalOnly_MatchRefresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 MatchCoverageRefreshParams
+		if args[2] != nil {
+			arg2 = args[2].(MatchCoverageRefreshParams)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCoverageReportDao_InternalOnly_MatchRefresh_Call) Return(coverageReportResponse api.CoverageReportResponse, err error) *MockCoverageReportDao_InternalOnly_MatchRefresh_Call {
+	_c.Call.Return(coverageReportResponse, err)
+	return _c
+}
+
+func (_c *MockCoverageReportDao_InternalOnly_MatchRefresh_Call) RunAndReturn(run func(ctx context.Context, uuid string, params MatchCoverageRefreshParams) (api.CoverageReportResponse, error)) *MockCoverageReportDao_InternalOnly_MatchRefresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPackages provides a mock function for the type MockCoverageReportDao
+func (_mock *MockCoverageReportDao) ListPackages(ctx context.Context, orgID string, reportUUID string, pageData api.PaginationData, filterData api.ListCoverageReportPackagesRequest) (api.CoverageReportPackageCollectionResponse, int64, error) {
+	ret := _mock.Called(ctx, orgID, reportUUID, pageData, filterData)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPackages")
+	}
+
+	var r0 api.CoverageReportPackageCollectionResponse
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, api.PaginationData, api.ListCoverageReportPackagesRequest) (api.CoverageReportPackageCollectionResponse, int64, error)); ok {
+		return returnFunc(ctx, orgID, reportUUID, pageData, filterData)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, api.PaginationData, api.ListCoverageReportPackagesRequest) api.CoverageReportPackageCollectionResponse); ok {
+		r0 = returnFunc(ctx, orgID, reportUUID, pageData, filterData)
+	} else {
+		r0 = ret.Get(0).(api.CoverageReportPackageCollectionResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, api.PaginationData, api.ListCoverageReportPackagesRequest) int64); ok {
+		r1 = returnFunc(ctx, orgID, reportUUID, pageData, filterData)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, api.PaginationData, api.ListCoverageReportPackagesRequest) error); ok {
+		r2 = returnFunc(ctx, orgID, reportUUID, pageData, filterData)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockCoverageReportDao_ListPackages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPackages'
+type MockCoverageReportDao_ListPackages_Call struct {
+	*mock.Call
+}
+
+// ListPackages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - reportUUID string
+//   - pageData api.PaginationData
+//   - filterData api.ListCoverageReportPackagesRequest
+func (_e *MockCoverageReportDao_Expecter) ListPackages(ctx interface{}, orgID interface{}, reportUUID interface{}, pageData interface{}, filterData interface{}) *MockCoverageReportDao_ListPackages_Call {
+	return &MockCoverageReportDao_ListPackages_Call{Call: _e.mock.On("ListPackages", ctx, orgID, reportUUID, pageData, filterData)}
+}
+
+func (_c *MockCoverageReportDao_ListPackages_Call) Run(run func(ctx context.Context, orgID string, reportUUID string, pageData api.PaginationData, filterData api.ListCoverageReportPackagesRequest)) *MockCoverageReportDao_ListPackages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 api.PaginationData
+		if args[3] != nil {
+			arg3 = args[3].(api.PaginationData)
+		}
+		var arg4 api.ListCoverageReportPackagesRequest
+		if args[4] != nil {
+			arg4 = args[4].(api.ListCoverageReportPackagesRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCoverageReportDao_ListPackages_Call) Return(coverageReportPackageCollectionResponse api.CoverageReportPackageCollectionResponse, n int64, err error) *MockCoverageReportDao_ListPackages_Call {
+	_c.Call.Return(coverageReportPackageCollectionResponse, n, err)
+	return _c
+}
+
+func (_c *MockCoverageReportDao_ListPackages_Call) RunAndReturn(run func(ctx context.Context, orgID string, reportUUID string, pageData api.PaginationData, filterData api.ListCoverageReportPackagesRequest) (api.CoverageReportPackageCollectionResponse, int64, error)) *MockCoverageReportDao_ListPackages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refresh provides a mock function for the type MockCoverageReportDao
+func (_mock *MockCoverageReportDao) Refresh(ctx context.Context, orgID string, uuid string, params RefreshCoverageReportParams) (api.CoverageReportResponse, error) {
+	ret := _mock.Called(ctx, orgID, uuid, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 api.CoverageReportResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, RefreshCoverageReportParams) (api.CoverageReportResponse, error)); ok {
+		return returnFunc(ctx, orgID, uuid, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, RefreshCoverageReportParams) api.CoverageReportResponse); ok {
+		r0 = returnFunc(ctx, orgID, uuid, params)
+	} else {
+		r0 = ret.Get(0).(api.CoverageReportResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, RefreshCoverageReportParams) error); ok {
+		r1 = returnFunc(ctx, orgID, uuid, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCoverageReportDao_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type MockCoverageReportDao_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - uuid string
+//   - params RefreshCoverageReportParams
+func (_e *MockCoverageReportDao_Expecter) Refresh(ctx interface{}, orgID interface{}, uuid interface{}, params interface{}) *MockCoverageReportDao_Refresh_Call {
+	return &MockCoverageReportDao_Refresh_Call{Call: _e.mock.On("Refresh", ctx, orgID, uuid, params)}
+}
+
+func (_c *MockCoverageReportDao_Refresh_Call) Run(run func(ctx context.Context, orgID string, uuid string, params RefreshCoverageReportParams)) *MockCoverageReportDao_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 RefreshCoverageReportParams
+		if args[3] != nil {
+			arg3 = args[3].(RefreshCoverageReportParams)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCoverageReportDao_Refresh_Call) Return(coverageReportResponse api.CoverageReportResponse, err error) *MockCoverageReportDao_Refresh_Call {
+	_c.Call.Return(coverageReportResponse, err)
+	return _c
+}
+
+func (_c *MockCoverageReportDao_Refresh_Call) RunAndReturn(run func(ctx context.Context, orgID string, uuid string, params RefreshCoverageReportParams) (api.CoverageReportResponse, error)) *MockCoverageReportDao_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetAnalysisTask provides a mock function for the type MockCoverageReportDao
+func (_mock *MockCoverageReportDao) SetAnalysisTask(ctx context.Context, orgID string, uuid string, taskUUID string) error {
+	ret := _mock.Called(ctx, orgID, uuid, taskUUID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAnalysisTask")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, orgID, uuid, taskUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCoverageReportDao_SetAnalysisTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAnalysisTask'
+type MockCoverageReportDao_SetAnalysisTask_Call struct {
+	*mock.Call
+}
+
+// SetAnalysisTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - uuid string
+//   - taskUUID string
+func (_e *MockCoverageReportDao_Expecter) SetAnalysisTask(ctx interface{}, orgID interface{}, uuid interface{}, taskUUID interface{}) *MockCoverageReportDao_SetAnalysisTask_Call {
+	return &MockCoverageReportDao_SetAnalysisTask_Call{Call: _e.mock.On("SetAnalysisTask", ctx, orgID, uuid, taskUUID)}
+}
+
+func (_c *MockCoverageReportDao_SetAnalysisTask_Call) Run(run func(ctx context.Context, orgID string, uuid string, taskUUID string)) *MockCoverageReportDao_SetAnalysisTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCoverageReportDao_SetAnalysisTask_Call) Return(err error) *MockCoverageReportDao_SetAnalysisTask_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCoverageReportDao_SetAnalysisTask_Call) RunAndReturn(run func(ctx context.Context, orgID string, uuid string, taskUUID string) error) *MockCoverageReportDao_SetAnalysisTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookDao creates a new instance of MockWebhookDao. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookDao(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookDao {
+	mock := &MockWebhookDao{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockWebhookDao is an autogenerated mock type for the WebhookDao type
+type MockWebhookDao struct {
+	mock.Mock
+}
+
+type MockWebhookDao_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookDao) EXPECT() *MockWebhookDao_Expecter {
+	return &MockWebhookDao_Expecter{mock: &_m.Mock}
+}
+
+// ClaimDueDeliveries provides a mock function for the type MockWebhookDao
+func (_mock *MockWebhookDao) ClaimDueDeliveries(ctx context.Context, limit int) ([]models.WebhookDelivery, error) {
+	ret := _mock.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDueDeliveries")
+	}
+
+	var r0 []models.WebhookDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.WebhookDelivery, error)); ok {
+		return returnFunc(ctx, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.WebhookDelivery); ok {
+		r0 = returnFunc(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookDelivery)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookDao_ClaimDueDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDueDeliveries'
+type MockWebhookDao_ClaimDueDeliveries_Call struct {
+	*mock.Call
+}
+
+// ClaimDueDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *MockWebhookDao_Expecter) ClaimDueDeliveries(ctx interface{}, limit interface{}) *MockWebhookDao_ClaimDueDeliveries_Call {
+	return &MockWebhookDao_ClaimDueDeliveries_Call{Call: _e.mock.On("ClaimDueDeliveries", ctx, limit)}
+}
+
+func (_c *MockWebhookDao_ClaimDueDeliveries_Call) Run(run func(ctx context.Context, limit int)) *MockWebhookDao_ClaimDueDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookDao_ClaimDueDeliveries_Call) Return(webhookDeliverys []models.WebhookDelivery, err error) *MockWebhookDao_ClaimDueDeliveries_Call {
+	_c.Call.Return(webhookDeliverys, err)
+	return _c
+}
+
+func (_c *MockWebhookDao_ClaimDueDeliveries_Call) RunAndReturn(run func(ctx context.Context, limit int) ([]models.WebhookDelivery, error)) *MockWebhookDao_ClaimDueDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockWebhookDao
+func (_mock *MockWebhookDao) Create(ctx context.Context, req api.WebhookRequest) (api.WebhookResponse, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 api.WebhookResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.WebhookRequest) (api.WebhookResponse, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.WebhookRequest) api.WebhookResponse); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Get(0).(api.WebhookResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, api.WebhookRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookDao_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockWebhookDao_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req api.WebhookRequest
+func (_e *MockWebhookDao_Expecter) Create(ctx interface{}, req interface{}) *MockWebhookDao_Create_Call {
+	return &MockWebhookDao_Create_Call{Call: _e.mock.On("Create", ctx, req)}
+}
+
+func (_c *MockWebhookDao_Create_Call) Run(run func(ctx context.Context, req api.WebhookRequest)) *MockWebhookDao_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 api.WebhookRequest
+		if args[1] != nil {
+			arg1 = args[1].(api.WebhookRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookDao_Create_Call) Return(webhookResponse api.WebhookResponse, err error) *MockWebhookDao_Create_Call {
+	_c.Call.Return(webhookResponse, err)
+	return _c
+}
+
+func (_c *MockWebhookDao_Create_Call) RunAndReturn(run func(ctx context.Context, req api.WebhookRequest) (api.WebhookResponse, error)) *MockWebhookDao_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTestDelivery provides a mock function for the type MockWebhookDao
+func (_mock *MockWebhookDao) CreateTestDelivery(ctx context.Context, orgID string, webhookUUID string) (models.WebhookDelivery, error) {
+	ret := _mock.Called(ctx, orgID, webhookUUID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTestDelivery")
+	}
+
+	var r0 models.WebhookDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (models.WebhookDelivery, error)); ok {
+		return returnFunc(ctx, orgID, webhookUUID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) models.WebhookDelivery); ok {
+		r0 = returnFunc(ctx, orgID, webhookUUID)
+	} else {
+		r0 = ret.Get(0).(models.WebhookDelivery)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, orgID, webhookUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookDao_CreateTestDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTestDelivery'
+type MockWebhookDao_CreateTestDelivery_Call struct {
+	*mock.Call
+}
+
+// CreateTestDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - webhookUUID string
+func (_e *MockWebhookDao_Expecter) CreateTestDelivery(ctx interface{}, orgID interface{}, webhookUUID interface{}) *MockWebhookDao_CreateTestDelivery_Call {
+	return &MockWebhookDao_CreateTestDelivery_Call{Call: _e.mock.On("CreateTestDelivery", ctx, orgID, webhookUUID)}
+}
+
+func (_c *MockWebhookDao_CreateTestDelivery_Call) Run(run func(ctx context.Context, orgID string, webhookUUID string)) *MockWebhookDao_CreateTestDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookDao_CreateTestDelivery_Call) Return(webhookDelivery models.WebhookDelivery, err error) *MockWebhookDao_CreateTestDelivery_Call {
+	_c.Call.Return(webhookDelivery, err)
+	return _c
+}
+
+func (_c *MockWebhookDao_CreateTestDelivery_Call) RunAndReturn(run func(ctx context.Context, orgID string, webhookUUID string) (models.WebhookDelivery, error)) *MockWebhookDao_CreateTestDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockWebhookDao
+func (_mock *MockWebhookDao) Delete(ctx context.Context, orgID string, uuid string) error {
+	ret := _mock.Called(ctx, orgID, uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, orgID, uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookDao_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockWebhookDao_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - uuid string
+func (_e *MockWebhookDao_Expecter) Delete(ctx interface{}, orgID interface{}, uuid interface{}) *MockWebhookDao_Delete_Call {
+	return &MockWebhookDao_Delete_Call{Call: _e.mock.On("Delete", ctx, orgID, uuid)}
+}
+
+func (_c *MockWebhookDao_Delete_Call) Run(run func(ctx context.Context, orgID string, uuid string)) *MockWebhookDao_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookDao_Delete_Call) Return(err error) *MockWebhookDao_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookDao_Delete_Call) RunAndReturn(run func(ctx context.Context, orgID string, uuid string) error) *MockWebhookDao_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Dispatch provides a mock function for the type MockWebhookDao
+func (_mock *MockWebhookDao) Dispatch(ctx context.Context, orgID string, eventType string, data any) error {
+	ret := _mock.Called(ctx, orgID, eventType, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Dispatch")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, any) error); ok {
+		r0 = returnFunc(ctx, orgID, eventType, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookDao_Dispatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Dispatch'
+type MockWebhookDao_Dispatch_Call struct {
+	*mock.Call
+}
+
+// Dispatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - eventType string
+//   - data any
+func (_e *MockWebhookDao_Expecter) Dispatch(ctx interface{}, orgID interface{}, eventType interface{}, data interface{}) *MockWebhookDao_Dispatch_Call {
+	return &MockWebhookDao_Dispatch_Call{Call: _e.mock.On("Dispatch", ctx, orgID, eventType, data)}
+}
+
+func (_c *MockWebhookDao_Dispatch_Call) Run(run func(ctx context.Context, orgID string, eventType string, data any)) *MockWebhookDao_Dispatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 any
+		if args[3] != nil {
+			arg3 = args[3].(any)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookDao_Dispatch_Call) Return(err error) *MockWebhookDao_Dispatch_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookDao_Dispatch_Call) RunAndReturn(run func(ctx context.Context, orgID string, eventType string, data any) error) *MockWebhookDao_Dispatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Fetch provides a mock function for the type MockWebhookDao
+func (_mock *MockWebhookDao) Fetch(ctx context.Context, orgID string, uuid string) (api.WebhookResponse, error) {
+	ret := _mock.Called(ctx, orgID, uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Fetch")
+	}
+
+	var r0 api.WebhookResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (api.WebhookResponse, error)); ok {
+		return returnFunc(ctx, orgID, uuid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) api.WebhookResponse); ok {
+		r0 = returnFunc(ctx, orgID, uuid)
+	} else {
+		r0 = ret.Get(0).(api.WebhookResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, orgID, uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookDao_Fetch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Fetch'
+type MockWebhookDao_Fetch_Call struct {
+	*mock.Call
+}
+
+// Fetch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - uuid string
+func (_e *MockWebhookDao_Expecter) Fetch(ctx interface{}, orgID interface{}, uuid interface{}) *MockWebhookDao_Fetch_Call {
+	return &MockWebhookDao_Fetch_Call{Call: _e.mock.On("Fetch", ctx, orgID, uuid)}
+}
+
+func (_c *MockWebhookDao_Fetch_Call) Run(run func(ctx context.Context, orgID string, uuid string)) *MockWebhookDao_Fetch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookDao_Fetch_Call) Return(webhookResponse api.WebhookResponse, err error) *MockWebhookDao_Fetch_Call {
+	_c.Call.Return(webhookResponse, err)
+	return _c
+}
+
+func (_c *MockWebhookDao_Fetch_Call) RunAndReturn(run func(ctx context.Context, orgID string, uuid string) (api.WebhookResponse, error)) *MockWebhookDao_Fetch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockWebhookDao
+func (_mock *MockWebhookDao) List(ctx context.Context, orgID string, pageData api.PaginationData) (api.WebhookCollectionResponse, int64, error) {
+	ret := _mock.Called(ctx, orgID, pageData)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 api.WebhookCollectionResponse
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, api.PaginationData) (api.WebhookCollectionResponse, int64, error)); ok {
+		return returnFunc(ctx, orgID, pageData)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, api.PaginationData) api.WebhookCollectionResponse); ok {
+		r0 = returnFunc(ctx, orgID, pageData)
+	} else {
+		r0 = ret.Get(0).(api.WebhookCollectionResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, api.PaginationData) int64); ok {
+		r1 = returnFunc(ctx, orgID, pageData)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, api.PaginationData) error); ok {
+		r2 = returnFunc(ctx, orgID, pageData)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockWebhookDao_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockWebhookDao_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - pageData api.PaginationData
+func (_e *MockWebhookDao_Expecter) List(ctx interface{}, orgID interface{}, pageData interface{}) *MockWebhookDao_List_Call {
+	return &MockWebhookDao_List_Call{Call: _e.mock.On("List", ctx, orgID, pageData)}
+}
+
+func (_c *MockWebhookDao_List_Call) Run(run func(ctx context.Context, orgID string, pageData api.PaginationData)) *MockWebhookDao_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 api.PaginationData
+		if args[2] != nil {
+			arg2 = args[2].(api.PaginationData)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookDao_List_Call) Return(webhookCollectionResponse api.WebhookCollectionResponse, n int64, err error) *MockWebhookDao_List_Call {
+	_c.Call.Return(webhookCollectionResponse, n, err)
+	return _c
+}
+
+func (_c *MockWebhookDao_List_Call) RunAndReturn(run func(ctx context.Context, orgID string, pageData api.PaginationData) (api.WebhookCollectionResponse, int64, error)) *MockWebhookDao_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeliveries provides a mock function for the type MockWebhookDao
+func (_mock *MockWebhookDao) ListDeliveries(ctx context.Context, orgID string, webhookUUID string, pageData api.PaginationData) (api.WebhookDeliveryCollectionResponse, int64, error) {
+	ret := _mock.Called(ctx, orgID, webhookUUID, pageData)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeliveries")
+	}
+
+	var r0 api.WebhookDeliveryCollectionResponse
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, api.PaginationData) (api.WebhookDeliveryCollectionResponse, int64, error)); ok {
+		return returnFunc(ctx, orgID, webhookUUID, pageData)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, api.PaginationData) api.WebhookDeliveryCollectionResponse); ok {
+		r0 = returnFunc(ctx, orgID, webhookUUID, pageData)
+	} else {
+		r0 = ret.Get(0).(api.WebhookDeliveryCollectionResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, api.PaginationData) int64); ok {
+		r1 = returnFunc(ctx, orgID, webhookUUID, pageData)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, api.PaginationData) error); ok {
+		r2 = returnFunc(ctx, orgID, webhookUUID, pageData)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockWebhookDao_ListDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeliveries'
+type MockWebhookDao_ListDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - webhookUUID string
+//   - pageData api.PaginationData
+func (_e *MockWebhookDao_Expecter) ListDeliveries(ctx interface{}, orgID interface{}, webhookUUID interface{}, pageData interface{}) *MockWebhookDao_ListDeliveries_Call {
+	return &MockWebhookDao_ListDeliveries_Call{Call: _e.mock.On("ListDeliveries", ctx, orgID, webhookUUID, pageData)}
+}
+
+func (_c *MockWebhookDao_ListDeliveries_Call) Run(run func(ctx context.Context, orgID string, webhookUUID string, pageData api.PaginationData)) *MockWebhookDao_ListDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 api.PaginationData
+		if args[3] != nil {
+			arg3 = args[3].(api.PaginationData)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookDao_ListDeliveries_Call) Return(webhookDeliveryCollectionResponse api.WebhookDeliveryCollectionResponse, n int64, err error) *MockWebhookDao_ListDeliveries_Call {
+	_c.Call.Return(webhookDeliveryCollectionResponse, n, err)
+	return _c
+}
+
+func (_c *MockWebhookDao_ListDeliveries_Call) RunAndReturn(run func(ctx context.Context, orgID string, webhookUUID string, pageData api.PaginationData) (api.WebhookDeliveryCollectionResponse, int64, error)) *MockWebhookDao_ListDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordDeliveryAttempt provides a mock function for the type MockWebhookDao
+func (_mock *MockWebhookDao) RecordDeliveryAttempt(ctx context.Context, delivery models.WebhookDelivery, responseCode *int, attemptErr error) (api.WebhookDeliveryResponse, error) {
+	ret := _mock.Called(ctx, delivery, responseCode, attemptErr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordDeliveryAttempt")
+	}
+
+	var r0 api.WebhookDeliveryResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.WebhookDelivery, *int, error) (api.WebhookDeliveryResponse, error)); ok {
+		return returnFunc(ctx, delivery, responseCode, attemptErr)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.WebhookDelivery, *int, error) api.WebhookDeliveryResponse); ok {
+		r0 = returnFunc(ctx, delivery, responseCode, attemptErr)
+	} else {
+		r0 = ret.Get(0).(api.WebhookDeliveryResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.WebhookDelivery, *int, error) error); ok {
+		r1 = returnFunc(ctx, delivery, responseCode, attemptErr)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookDao_RecordDeliveryAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordDeliveryAttempt'
+type MockWebhookDao_RecordDeliveryAttempt_Call struct {
+	*mock.Call
+}
+
+// RecordDeliveryAttempt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - delivery models.WebhookDelivery
+//   - responseCode *int
+//   - attemptErr error
+func (_e *MockWebhookDao_Expecter) RecordDeliveryAttempt(ctx interface{}, delivery interface{}, responseCode interface{}, attemptErr interface{}) *MockWebhookDao_RecordDeliveryAttempt_Call {
+	return &MockWebhookDao_RecordDeliveryAttempt_Call{Call: _e.mock.On("RecordDeliveryAttempt", ctx, delivery, responseCode, attemptErr)}
+}
+
+func (_c *MockWebhookDao_RecordDeliveryAttempt_Call) Run(run func(ctx context.Context, delivery models.WebhookDelivery, responseCode *int, attemptErr error)) *MockWebhookDao_RecordDeliveryAttempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.WebhookDelivery
+		if args[1] != nil {
+			arg1 = args[1].(models.WebhookDelivery)
+		}
+		var arg2 *int
+		if args[2] != nil {
+			arg2 = args[2].(*int)
+		}
+		var arg3 error
+		if args[3] != nil {
+			arg3 = args[3].(error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookDao_RecordDeliveryAttempt_Call) Return(webhookDeliveryResponse api.WebhookDeliveryResponse, err error) *MockWebhookDao_RecordDeliveryAttempt_Call {
+	_c.Call.Return(webhookDeliveryResponse, err)
+	return _c
+}
+
+func (_c *MockWebhookDao_RecordDeliveryAttempt_Call) RunAndReturn(run func(ctx context.Context, delivery models.WebhookDelivery, responseCode *int, attemptErr error) (api.WebhookDeliveryResponse, error)) *MockWebhookDao_RecordDeliveryAttempt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockWebhookDao
+func (_mock *MockWebhookDao) Update(ctx context.Context, orgID string, uuid string, req api.WebhookUpdateRequest) (api.WebhookResponse, error) {
+	ret := _mock.Called(ctx, orgID, uuid, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 api.WebhookResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, api.WebhookUpdateRequest) (api.WebhookResponse, error)); ok {
+		return returnFunc(ctx, orgID, uuid, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, api.WebhookUpdateRequest) api.WebhookResponse); ok {
+		r0 = returnFunc(ctx, orgID, uuid, req)
+	} else {
+		r0 = ret.Get(0).(api.WebhookResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, api.WebhookUpdateRequest) error); ok {
+		r1 = returnFunc(ctx, orgID, uuid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookDao_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockWebhookDao_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - uuid string
+//   - req api.WebhookUpdateRequest
+func (_e *MockWebhookDao_Expecter) Update(ctx interface{}, orgID interface{}, uuid interface{}, req interface{}) *MockWebhookDao_Update_Call {
+	return &MockWebhookDao_Update_Call{Call: _e.mock.On("Update", ctx, orgID, uuid, req)}
+}
+
+func (_c *MockWebhookDao_Update_Call) Run(run func(ctx context.Context, orgID string, uuid string, req api.WebhookUpdateRequest)) *MockWebhookDao_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 api.WebhookUpdateRequest
+		if args[3] != nil {
+			arg3 = args[3].(api.WebhookUpdateRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookDao_Update_Call) Return(webhookResponse api.WebhookResponse, err error) *MockWebhookDao_Update_Call {
+	_c.Call.Return(webhookResponse, err)
+	return _c
+}
+
+func (_c *MockWebhookDao_Update_Call) RunAndReturn(run func(ctx context.Context, orgID string, uuid string, req api.WebhookUpdateRequest) (api.WebhookResponse, error)) *MockWebhookDao_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOutboxDao creates a new instance of MockOutboxDao. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOutboxDao(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOutboxDao {
+	mock := &MockOutboxDao{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOutboxDao is an autogenerated mock type for the OutboxDao type
+type MockOutboxDao struct {
+	mock.Mock
+}
+
+type MockOutboxDao_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOutboxDao) EXPECT() *MockOutboxDao_Expecter {
+	return &MockOutboxDao_Expecter{mock: &_m.Mock}
+}
+
+// ClaimDue provides a mock function for the type MockOutboxDao
+func (_mock *MockOutboxDao) ClaimDue(ctx context.Context, limit int) ([]models.OutboxEvent, error) {
+	ret := _mock.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDue")
+	}
+
+	var r0 []models.OutboxEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.OutboxEvent, error)); ok {
+		return returnFunc(ctx, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.OutboxEvent); ok {
+		r0 = returnFunc(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.OutboxEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOutboxDao_ClaimDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDue'
+type MockOutboxDao_ClaimDue_Call struct {
+	*mock.Call
+}
+
+// ClaimDue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *MockOutboxDao_Expecter) ClaimDue(ctx interface{}, limit interface{}) *MockOutboxDao_ClaimDue_Call {
+	return &MockOutboxDao_ClaimDue_Call{Call: _e.mock.On("ClaimDue", ctx, limit)}
+}
+
+func (_c *MockOutboxDao_ClaimDue_Call) Run(run func(ctx context.Context, limit int)) *MockOutboxDao_ClaimDue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxDao_ClaimDue_Call) Return(outboxEvents []models.OutboxEvent, err error) *MockOutboxDao_ClaimDue_Call {
+	_c.Call.Return(outboxEvents, err)
+	return _c
+}
+
+func (_c *MockOutboxDao_ClaimDue_Call) RunAndReturn(run func(ctx context.Context, limit int) ([]models.OutboxEvent, error)) *MockOutboxDao_ClaimDue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Cleanup provides a mock function for the type MockOutboxDao
+func (_mock *MockOutboxDao) Cleanup(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cleanup")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOutboxDao_Cleanup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cleanup'
+type MockOutboxDao_Cleanup_Call struct {
+	*mock.Call
+}
+
+// Cleanup is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockOutboxDao_Expecter) Cleanup(ctx interface{}) *MockOutboxDao_Cleanup_Call {
+	return &MockOutboxDao_Cleanup_Call{Call: _e.mock.On("Cleanup", ctx)}
+}
+
+func (_c *MockOutboxDao_Cleanup_Call) Run(run func(ctx context.Context)) *MockOutboxDao_Cleanup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxDao_Cleanup_Call) Return(err error) *MockOutboxDao_Cleanup_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOutboxDao_Cleanup_Call) RunAndReturn(run func(ctx context.Context) error) *MockOutboxDao_Cleanup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Queue provides a mock function for the type MockOutboxDao
+func (_mock *MockOutboxDao) Queue(ctx context.Context, orgID string, eventName event.EventName, channels []string, objects []event.Object) error {
+	ret := _mock.Called(ctx, orgID, eventName, channels, objects)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Queue")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, event.EventName, []string, []event.Object) error); ok {
+		r0 = returnFunc(ctx, orgID, eventName, channels, objects)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOutboxDao_Queue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Queue'
+type MockOutboxDao_Queue_Call struct {
+	*mock.Call
+}
+
+// Queue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - eventName event.EventName
+//   - channels []string
+//   - objects []event.Object
+func (_e *MockOutboxDao_Expecter) Queue(ctx interface{}, orgID interface{}, eventName interface{}, channels interface{}, objects interface{}) *MockOutboxDao_Queue_Call {
+	return &MockOutboxDao_Queue_Call{Call: _e.mock.On("Queue", ctx, orgID, eventName, channels, objects)}
+}
+
+func (_c *MockOutboxDao_Queue_Call) Run(run func(ctx context.Context, orgID string, eventName event.EventName, channels []string, objects []event.Object)) *MockOutboxDao_Queue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 event.EventName
+		if args[2] != nil {
+			arg2 = args[2].(event.EventName)
+		}
+		var arg3 []string
+		if args[3] != nil {
+			arg3 = args[3].([]string)
+		}
+		var arg4 []event.Object
+		if args[4] != nil {
+			arg4 = args[4].([]event.Object)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxDao_Queue_Call) Return(err error) *MockOutboxDao_Queue_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOutboxDao_Queue_Call) RunAndReturn(run func(ctx context.Context, orgID string, eventName event.EventName, channels []string, objects []event.Object) error) *MockOutboxDao_Queue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordAttempt provides a mock function for the type MockOutboxDao
+func (_mock *MockOutboxDao) RecordAttempt(ctx context.Context, outboxEvent models.OutboxEvent, attemptErr error) error {
+	ret := _mock.Called(ctx, outboxEvent, attemptErr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordAttempt")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.OutboxEvent, error) error); ok {
+		r0 = returnFunc(ctx, outboxEvent, attemptErr)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOutboxDao_RecordAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordAttempt'
+type MockOutboxDao_RecordAttempt_Call struct {
+	*mock.Call
+}
+
+// RecordAttempt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - outboxEvent models.OutboxEvent
+//   - attemptErr error
+func (_e *MockOutboxDao_Expecter) RecordAttempt(ctx interface{}, outboxEvent interface{}, attemptErr interface{}) *MockOutboxDao_RecordAttempt_Call {
+	return &MockOutboxDao_RecordAttempt_Call{Call: _e.mock.On("RecordAttempt", ctx, outboxEvent, attemptErr)}
+}
+
+func (_c *MockOutboxDao_RecordAttempt_Call) Run(run func(ctx context.Context, outboxEvent models.OutboxEvent, attemptErr error)) *MockOutboxDao_RecordAttempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.OutboxEvent
+		if args[1] != nil {
+			arg1 = args[1].(models.OutboxEvent)
+		}
+		var arg2 error
+		if args[2] != nil {
+			arg2 = args[2].(error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxDao_RecordAttempt_Call) Return(err error) *MockOutboxDao_RecordAttempt_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOutboxDao_RecordAttempt_Call) RunAndReturn(run func(ctx context.Context, outboxEvent models.OutboxEvent, attemptErr error) error) *MockOutboxDao_RecordAttempt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAuditEventDao creates a new instance of MockAuditEventDao. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditEventDao(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditEventDao {
+	mock := &MockAuditEventDao{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAuditEventDao is an autogenerated mock type for the AuditEventDao type
+type MockAuditEventDao struct {
+	mock.Mock
+}
+
+type MockAuditEventDao_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditEventDao) EXPECT() *MockAuditEventDao_Expecter {
+	return &MockAuditEventDao_Expecter{mock: &_m.Mock}
+}
+
+// Cleanup provides a mock function for the type MockAuditEventDao
+func (_mock *MockAuditEventDao) Cleanup(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cleanup")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuditEventDao_Cleanup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cleanup'
+type MockAuditEventDao_Cleanup_Call struct {
+	*mock.Call
+}
+
+// Cleanup is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAuditEventDao_Expecter) Cleanup(ctx interface{}) *MockAuditEventDao_Cleanup_Call {
+	return &MockAuditEventDao_Cleanup_Call{Call: _e.mock.On("Cleanup", ctx)}
+}
+
+func (_c *MockAuditEventDao_Cleanup_Call) Run(run func(ctx context.Context)) *MockAuditEventDao_Cleanup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditEventDao_Cleanup_Call) Return(err error) *MockAuditEventDao_Cleanup_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuditEventDao_Cleanup_Call) RunAndReturn(run func(ctx context.Context) error) *MockAuditEventDao_Cleanup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockAuditEventDao
+func (_mock *MockAuditEventDao) Create(ctx context.Context, auditEvent models.AuditEvent) error {
+	ret := _mock.Called(ctx, auditEvent)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.AuditEvent) error); ok {
+		r0 = returnFunc(ctx, auditEvent)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuditEventDao_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockAuditEventDao_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - auditEvent models.AuditEvent
+func (_e *MockAuditEventDao_Expecter) Create(ctx interface{}, auditEvent interface{}) *MockAuditEventDao_Create_Call {
+	return &MockAuditEventDao_Create_Call{Call: _e.mock.On("Create", ctx, auditEvent)}
+}
+
+func (_c *MockAuditEventDao_Create_Call) Run(run func(ctx context.Context, auditEvent models.AuditEvent)) *MockAuditEventDao_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.AuditEvent
+		if args[1] != nil {
+			arg1 = args[1].(models.AuditEvent)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditEventDao_Create_Call) Return(err error) *MockAuditEventDao_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuditEventDao_Create_Call) RunAndReturn(run func(ctx context.Context, auditEvent models.AuditEvent) error) *MockAuditEventDao_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Export provides a mock function for the type MockAuditEventDao
+func (_mock *MockAuditEventDao) Export(ctx context.Context, orgID string, filterData api.AuditEventFilterData) ([]api.AuditEventResponse, error) {
+	ret := _mock.Called(ctx, orgID, filterData)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 []api.AuditEventResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, api.AuditEventFilterData) ([]api.AuditEventResponse, error)); ok {
+		return returnFunc(ctx, orgID, filterData)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, api.AuditEventFilterData) []api.AuditEventResponse); ok {
+		r0 = returnFunc(ctx, orgID, filterData)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]api.AuditEventResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, api.AuditEventFilterData) error); ok {
+		r1 = returnFunc(ctx, orgID, filterData)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuditEventDao_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type MockAuditEventDao_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - filterData api.AuditEventFilterData
+func (_e *MockAuditEventDao_Expecter) Export(ctx interface{}, orgID interface{}, filterData interface{}) *MockAuditEventDao_Export_Call {
+	return &MockAuditEventDao_Export_Call{Call: _e.mock.On("Export", ctx, orgID, filterData)}
+}
+
+func (_c *MockAuditEventDao_Export_Call) Run(run func(ctx context.Context, orgID string, filterData api.AuditEventFilterData)) *MockAuditEventDao_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 api.AuditEventFilterData
+		if args[2] != nil {
+			arg2 = args[2].(api.AuditEventFilterData)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditEventDao_Export_Call) Return(auditEventResponses []api.AuditEventResponse, err error) *MockAuditEventDao_Export_Call {
+	_c.Call.Return(auditEventResponses, err)
+	return _c
+}
+
+func (_c *MockAuditEventDao_Export_Call) RunAndReturn(run func(ctx context.Context, orgID string, filterData api.AuditEventFilterData) ([]api.AuditEventResponse, error)) *MockAuditEventDao_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockAuditEventDao
+func (_mock *MockAuditEventDao) List(ctx context.Context, orgID string, pageData api.PaginationData, filterData api.AuditEventFilterData) (api.AuditEventCollectionResponse, int64, error) {
+	ret := _mock.Called(ctx, orgID, pageData, filterData)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 api.AuditEventCollectionResponse
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, api.PaginationData, api.AuditEventFilterData) (api.AuditEventCollectionResponse, int64, error)); ok {
+		return returnFunc(ctx, orgID, pageData, filterData)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, api.PaginationData, api.AuditEventFilterData) api.AuditEventCollectionResponse); ok {
+		r0 = returnFunc(ctx, orgID, pageData, filterData)
+	} else {
+		r0 = ret.Get(0).(api.AuditEventCollectionResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, api.PaginationData, api.AuditEventFilterData) int64); ok {
+		r1 = returnFunc(ctx, orgID, pageData, filterData)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, api.PaginationData, api.AuditEventFilterData) error); ok {
+		r2 = returnFunc(ctx, orgID, pageData, filterData)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockAuditEventDao_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockAuditEventDao_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID string
+//   - pageData api.PaginationData
+//   - filterData api.AuditEventFilterData
+func (_e *MockAuditEventDao_Expecter) List(ctx interface{}, orgID interface{}, pageData interface{}, filterData interface{}) *MockAuditEventDao_List_Call {
+	return &MockAuditEventDao_List_Call{Call: _e.mock.On("List", ctx, orgID, pageData, filterData)}
+}
+
+func (_c *MockAuditEventDao_List_Call) Run(run func(ctx context.Context, orgID string, pageData api.PaginationData, filterData api.AuditEventFilterData)) *MockAuditEventDao_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 api.PaginationData
+		if args[2] != nil {
+			arg2 = args[2].(api.PaginationData)
+		}
+		var arg3 api.AuditEventFilterData
+		if args[3] != nil {
+			arg3 = args[3].(api.AuditEventFilterData)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditEventDao_List_Call) Return(auditEventCollectionResponse api.AuditEventCollectionResponse, n int64, err error) *MockAuditEventDao_List_Call {
+	_c.Call.Return(auditEventCollectionResponse, n, err)
+	return _c
+}
+
+func (_c *MockAuditEventDao_List_Call) RunAndReturn(run func(ctx context.Context, orgID string, pageData api.PaginationData, filterData api.AuditEventFilterData) (api.AuditEventCollectionResponse, int64, error)) *MockAuditEventDao_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCoverageDemandSignalDao creates a new instance of MockCoverageDemandSignalDao. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCoverageDemandSignalDao(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCoverageDemandSignalDao {
+	mock := &MockCoverageDemandSignalDao{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCoverageDemandSignalDao is an autogenerated mock type for the CoverageDemandSignalDao type
+type MockCoverageDemandSignalDao struct {
+	mock.Mock
+}
+
+type MockCoverageDemandSignalDao_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCoverageDemandSignalDao) EXPECT() *MockCoverageDemandSignalDao_Expecter {
+	return &MockCoverageDemandSignalDao_Expecter{mock: &_m.Mock}
+}
+
+// Export provides a mock function for the type MockCoverageDemandSignalDao
+func (_mock *MockCoverageDemandSignalDao) Export(ctx context.Context, filterData api.CoverageDemandFilterData) ([]api.CoverageDemandSummaryResponse, error) {
+	ret := _mock.Called(ctx, filterData)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 []api.CoverageDemandSummaryResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.CoverageDemandFilterData) ([]api.CoverageDemandSummaryResponse, error)); ok {
+		return returnFunc(ctx, filterData)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.CoverageDemandFilterData) []api.CoverageDemandSummaryResponse); ok {
+		r0 = returnFunc(ctx, filterData)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]api.CoverageDemandSummaryResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, api.CoverageDemandFilterData) error); ok {
+		r1 = returnFunc(ctx, filterData)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCoverageDemandSignalDao_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type MockCoverageDemandSignalDao_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filterData api.CoverageDemandFilterData
+func (_e *MockCoverageDemandSignalDao_Expecter) Export(ctx interface{}, filterData interface{}) *MockCoverageDemandSignalDao_Export_Call {
+	return &MockCoverageDemandSignalDao_Export_Call{Call: _e.mock.On("Export", ctx, filterData)}
+}
+
+func (_c *MockCoverageDemandSignalDao_Export_Call) Run(run func(ctx context.Context, filterData api.CoverageDemandFilterData)) *MockCoverageDemandSignalDao_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 api.CoverageDemandFilterData
+		if args[1] != nil {
+			arg1 = args[1].(api.CoverageDemandFilterData)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCoverageDemandSignalDao_Export_Call) Return(coverageDemandSummaryResponses []api.CoverageDemandSummaryResponse, err error) *MockCoverageDemandSignalDao_Export_Call {
+	_c.Call.Return(coverageDemandSummaryResponses, err)
+	return _c
+}
+
+func (_c *MockCoverageDemandSignalDao_Export_Call) RunAndReturn(run func(ctx context.Context, filterData api.CoverageDemandFilterData) ([]api.CoverageDemandSummaryResponse, error)) *MockCoverageDemandSignalDao_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockCoverageDemandSignalDao
+func (_mock *MockCoverageDemandSignalDao) List(ctx context.Context, pageData api.PaginationData, filterData api.CoverageDemandFilterData) (api.CoverageDemandSummaryCollectionResponse, int64, error) {
+	ret := _mock.Called(ctx, pageData, filterData)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 api.CoverageDemandSummaryCollectionResponse
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.PaginationData, api.CoverageDemandFilterData) (api.CoverageDemandSummaryCollectionResponse, int64, error)); ok {
+		return returnFunc(ctx, pageData, filterData)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.PaginationData, api.CoverageDemandFilterData) api.CoverageDemandSummaryCollectionResponse); ok {
+		r0 = returnFunc(ctx, pageData, filterData)
+	} else {
+		r0 = ret.Get(0).(api.CoverageDemandSummaryCollectionResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, api.PaginationData, api.CoverageDemandFilterData) int64); ok {
+		r1 = returnFunc(ctx, pageData, filterData)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, api.PaginationData, api.CoverageDemandFilterData) error); ok {
+		r2 = returnFunc(ctx, pageData, filterData)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockCoverageDemandSignalDao_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockCoverageDemandSignalDao_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pageData api.PaginationData
+//   - filterData api.CoverageDemandFilterData
+func (_e *MockCoverageDemandSignalDao_Expecter) List(ctx interface{}, pageData interface{}, filterData interface{}) *MockCoverageDemandSignalDao_List_Call {
+	return &MockCoverageDemandSignalDao_List_Call{Call: _e.mock.On("List", ctx, pageData, filterData)}
+}
+
+func (_c *MockCoverageDemandSignalDao_List_Call) Run(run func(ctx context.Context, pageData api.PaginationData, filterData api.CoverageDemandFilterData)) *MockCoverageDemandSignalDao_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 api.PaginationData
+		if args[1] != nil {
+			arg1 = args[1].(api.PaginationData)
+		}
+		var arg2 api.CoverageDemandFilterData
+		if args[2] != nil {
+			arg2 = args[2].(api.CoverageDemandFilterData)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCoverageDemandSignalDao_List_Call) Return(coverageDemandSummaryCollectionResponse api.CoverageDemandSummaryCollectionResponse, n int64, err error) *MockCoverageDemandSignalDao_List_Call {
+	_c.Call.Return(coverageDemandSummaryCollectionResponse, n, err)
+	return _c
+}
+
+func (_c *MockCoverageDemandSignalDao_List_Call) RunAndReturn(run func(ctx context.Context, pageData api.PaginationData, filterData api.CoverageDemandFilterData) (api.CoverageDemandSummaryCollectionResponse, int64, error)) *MockCoverageDemandSignalDao_List_Call {
 	_c.Call.Return(run)
 	return _c
 }
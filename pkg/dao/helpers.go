@@ -8,8 +8,10 @@ import (
 	"github.com/content-services/content-sources-backend/pkg/config"
 	ce "github.com/content-services/content-sources-backend/pkg/errors"
 	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/content-services/content-sources-backend/pkg/rbac"
 	"github.com/content-services/content-sources-backend/pkg/utils"
 	uuid2 "github.com/google/uuid"
+	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
 )
 
@@ -201,3 +203,23 @@ func splitVersionFilters(values []string) (majors []string, minors []string) {
 
 	return majors, minors
 }
+
+// reportObject registers a created repository or template in its workspace for per object permissions, the default
+// workspace of the organization when workspaceID is nil. Failures are only logged, as the object is already committed,
+// and the report-workspace-objects job reports it again.
+func reportObject(ctx context.Context, resource rbac.Resource, orgID string, uuid string, workspaceID *string) {
+	var workspace string
+	if workspaceID != nil {
+		workspace = *workspaceID
+	}
+	if err := rbac.ReportObject(ctx, resource, orgID, uuid, workspace); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("uuid", uuid).Msgf("error reporting %s to kessel", resource)
+	}
+}
+
+// deleteObject unregisters a deleted repository or template. Failures are only logged, as the object is already deleted.
+func deleteObject(ctx context.Context, resource rbac.Resource, uuid string) {
+	if err := rbac.DeleteObject(ctx, resource, uuid); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("uuid", uuid).Msgf("error deleting %s from kessel", resource)
+	}
+}
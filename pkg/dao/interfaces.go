@@ -11,6 +11,7 @@ import (
 	"github.com/content-services/content-sources-backend/pkg/clients/pulp_client"
 	"github.com/content-services/content-sources-backend/pkg/clients/roadmap_client"
 	csdb "github.com/content-services/content-sources-backend/pkg/db"
+	"github.com/content-services/content-sources-backend/pkg/event"
 	"github.com/content-services/content-sources-backend/pkg/models"
 	"github.com/content-services/tang/pkg/tangy"
 	"github.com/content-services/yummy/pkg/yum"
@@ -37,6 +38,9 @@ type DaoRegistry struct {
 	LightwellVulnerability LightwellVulnerabilityDao
 	UserPreference         UserPreferenceDao
 	CoverageReport         CoverageReportDao
+	Webhook                WebhookDao
+	AuditEvent             AuditEventDao
+	CoverageDemandSignal   CoverageDemandSignalDao
 }
 
 func GetDaoRegistry(db *gorm.DB) *DaoRegistry {
@@ -84,6 +88,9 @@ func GetDaoRegistry(db *gorm.DB) *DaoRegistry {
 		LightwellVulnerability: newLightwellVulnerabilityDao(csdb.LightwellQueries),
 		UserPreference:         userPreferenceDaoImpl{db: db},
 		CoverageReport:         coverageReportDaoImpl{db: db},
+		Webhook:                webhookDaoImpl{db: db},
+		AuditEvent:             auditEventDaoImpl{db: db},
+		CoverageDemandSignal:   coverageDemandSignalDaoImpl{db: db},
 	}
 	return &reg
 }
@@ -138,6 +145,7 @@ type RpmDao interface {
 	ListTemplateErrata(ctx context.Context, orgId string, templateUUID string, filters tangy.ErrataListFilters, pageOpts api.PaginationData) ([]api.SnapshotErrata, int, error)
 	FetchForRepository(ctx context.Context, orgID string, repositoryConfigUUID string, rpmUUIDs []string) ([]models.Rpm, error)
 	FetchTemplateErrataIDs(ctx context.Context, orgId string, templateUUID string) ([]string, error)
+	FetchTemplateAvailableErrataCounts(ctx context.Context, orgID string, templateUUID string) (api.ErrataCounts, error)
 }
 
 type RepositoryDao interface {
@@ -188,6 +196,7 @@ type MetricsDao interface {
 	PendingTasksOldestTask(ctx context.Context) float64
 	RHReposSnapshotNotCompletedInLast36HoursCount(ctx context.Context) int64
 	TaskPendingTimeAverageByType(ctx context.Context) []TaskTypePendingTimeAverage
+	TaskBacklogByType(ctx context.Context) []TaskTypeBacklog
 	TemplatesUseLatestCount(ctx context.Context) int
 	TemplatesUseDateCount(ctx context.Context) int
 	TemplatesUpdatedInLast24HoursCount(ctx context.Context) int
@@ -197,13 +206,19 @@ type MetricsDao interface {
 type TaskInfoDao interface {
 	Fetch(ctx context.Context, OrgID string, id string) (api.TaskInfoResponse, error)
 	List(ctx context.Context, OrgID string, pageData api.PaginationData, filterData api.TaskInfoFilterData) (api.TaskInfoCollectionResponse, int64, error)
+	FetchGraph(ctx context.Context, orgID string, id string) (api.TaskGraphResponse, error)
 	FetchActiveTasks(ctx context.Context, orgID string, objectUUID string, taskTypes ...string) ([]string, error)
+	FetchActiveTaskByDedupKey(ctx context.Context, orgID string, taskType string, dedupKey string) (string, error)
 	Cleanup(ctx context.Context) error
 }
 
 type AdminTaskDao interface {
 	Fetch(ctx context.Context, id string) (api.AdminTaskInfoResponse, error)
 	List(ctx context.Context, pageData api.PaginationData, filterData api.AdminTaskFilterData) (api.AdminTaskInfoCollectionResponse, int64, error)
+	ListDeadLetterGroups(ctx context.Context, pageData api.PaginationData, filterData api.AdminDeadLetterFilterData) (api.AdminDeadLetterGroupCollectionResponse, int64, error)
+	RetryDeadLetterTasks(ctx context.Context, request api.AdminDeadLetterRequest) ([]string, error)
+	CancelDeadLetterTasks(ctx context.Context, request api.AdminDeadLetterRequest) ([]string, error)
+	PurgeDeadLetterTasks(ctx context.Context, request api.AdminDeadLetterRequest) ([]string, error)
 }
 
 type DomainDao interface {
@@ -244,6 +259,7 @@ type TemplateDao interface {
 	DeleteTemplateRepoConfigs(ctx context.Context, templateUUID string, keepRepoConfigUUIDs []string) error
 	UpdateLastUpdateTask(ctx context.Context, taskUUID string, orgID string, templateUUID string) error
 	UpdateLastError(ctx context.Context, orgID string, templateUUID string, lastUpdateSnapshotError string) error
+	UpdateAvailableErrataCounts(ctx context.Context, orgID string, templateUUID string, counts api.ErrataCounts) error
 	SetEnvironmentCreated(ctx context.Context, templateUUID string) error
 	UpdateSnapshots(ctx context.Context, templateUUID string, repoUUIDs []string, snapshots []models.Snapshot) error
 	DeleteTemplateSnapshot(ctx context.Context, snapshotUUID string) error
@@ -295,4 +311,44 @@ type CoverageReportDao interface {
 	Create(ctx context.Context, report CreateCoverageReportParams, upload CreateCoverageUploadParams) (api.CoverageReportResponse, error)
 	Fetch(ctx context.Context, orgID string, uuid string) (api.CoverageReportResponse, error)
 	ListPackages(ctx context.Context, orgID string, reportUUID string, pageData api.PaginationData, filterData api.ListCoverageReportPackagesRequest) (api.CoverageReportPackageCollectionResponse, int64, error)
+	Compare(ctx context.Context, orgID string, uuid string, otherUUID string) (api.CoverageReportComparisonResponse, error)
+	Refresh(ctx context.Context, orgID string, uuid string, params RefreshCoverageReportParams) (api.CoverageReportResponse, error)
+	SetAnalysisTask(ctx context.Context, orgID string, uuid string, taskUUID string) error
+	FetchExport(ctx context.Context, orgID string, uuid string) (api.CoverageReportResponse, error)
+	ExportPackages(ctx context.Context, uuid string, write func(packages []api.CoverageReportPackageResponse) error) error
+	InternalOnly_ListScheduledRefreshes(ctx context.Context) ([]ScheduledCoverageRefresh, error)
+	InternalOnly_MatchRefresh(ctx context.Context, uuid string, params MatchCoverageRefreshParams) (api.CoverageReportResponse, error)
+	InternalOnly_FailAnalysis(ctx context.Context, uuid string, analysisErr error) error
+}
+
+type WebhookDao interface {
+	Create(ctx context.Context, req api.WebhookRequest) (api.WebhookResponse, error)
+	Fetch(ctx context.Context, orgID string, uuid string) (api.WebhookResponse, error)
+	List(ctx context.Context, orgID string, pageData api.PaginationData) (api.WebhookCollectionResponse, int64, error)
+	Update(ctx context.Context, orgID string, uuid string, req api.WebhookUpdateRequest) (api.WebhookResponse, error)
+	Delete(ctx context.Context, orgID string, uuid string) error
+	ListDeliveries(ctx context.Context, orgID string, webhookUUID string, pageData api.PaginationData) (api.WebhookDeliveryCollectionResponse, int64, error)
+	Dispatch(ctx context.Context, orgID string, eventType string, data any) error
+	CreateTestDelivery(ctx context.Context, orgID string, webhookUUID string) (models.WebhookDelivery, error)
+	ClaimDueDeliveries(ctx context.Context, limit int) ([]models.WebhookDelivery, error)
+	RecordDeliveryAttempt(ctx context.Context, delivery models.WebhookDelivery, responseCode *int, attemptErr error) (api.WebhookDeliveryResponse, error)
+}
+
+type OutboxDao interface {
+	Queue(ctx context.Context, orgID string, eventName event.EventName, channels []string, objects []event.Object) error
+	ClaimDue(ctx context.Context, limit int) ([]models.OutboxEvent, error)
+	RecordAttempt(ctx context.Context, outboxEvent models.OutboxEvent, attemptErr error) error
+	Cleanup(ctx context.Context) error
+}
+
+type AuditEventDao interface {
+	Create(ctx context.Context, auditEvent models.AuditEvent) error
+	List(ctx context.Context, orgID string, pageData api.PaginationData, filterData api.AuditEventFilterData) (api.AuditEventCollectionResponse, int64, error)
+	Export(ctx context.Context, orgID string, filterData api.AuditEventFilterData) ([]api.AuditEventResponse, error)
+	Cleanup(ctx context.Context) error
+}
+
+type CoverageDemandSignalDao interface {
+	List(ctx context.Context, pageData api.PaginationData, filterData api.CoverageDemandFilterData) (api.CoverageDemandSummaryCollectionResponse, int64, error)
+	Export(ctx context.Context, filterData api.CoverageDemandFilterData) ([]api.CoverageDemandSummaryResponse, error)
 }
@@ -21,6 +21,13 @@ type TaskTypePendingTimeAverage struct {
 	PendingTime float64 `gorm:"column:pending_task_avg"`
 }
 
+type TaskTypeBacklog struct {
+	Type    string `gorm:"column:type"`
+	Pending int64  `gorm:"column:pending"` // Tasks waiting to be started, including those blocked by their dependencies
+	Ready   int64  `gorm:"column:ready"`   // Pending tasks whose dependencies have all finished
+	Running int64  `gorm:"column:running"`
+}
+
 type metricsDaoImpl struct {
 	db *gorm.DB
 }
@@ -195,6 +202,25 @@ func (d metricsDaoImpl) TaskPendingTimeAverageByType(ctx context.Context) []Task
 	return output
 }
 
+// TaskBacklogByType counts the pending, ready and running tasks of each task type
+func (d metricsDaoImpl) TaskBacklogByType(ctx context.Context) []TaskTypeBacklog {
+	var output []TaskTypeBacklog
+	d.db.WithContext(ctx).
+		Model(&models.TaskInfo{}).
+		Select(`tasks.type,
+			count(*) filter (where tasks.status = ?) as pending,
+			count(*) filter (where tasks.status = ? and not exists (
+				select 1 from task_dependencies td join tasks dep on td.dependency_id = dep.id
+				where td.task_id = tasks.id and dep.finished_at is null
+			)) as ready,
+			count(*) filter (where tasks.status = ?) as running`,
+			config.TaskStatusPending, config.TaskStatusPending, config.TaskStatusRunning).
+		Where("tasks.status in ?", []string{config.TaskStatusPending, config.TaskStatusRunning}).
+		Group("tasks.type").
+		Find(&output)
+	return output
+}
+
 func (d metricsDaoImpl) TemplatesUseLatestCount(ctx context.Context) int {
 	var output int64 = -1
 	d.db.WithContext(ctx).
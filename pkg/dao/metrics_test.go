@@ -559,3 +559,38 @@ func (s *MetricsSuite) TestTemplatesUpdateTaskPendingAverage() {
 		}
 	}
 }
+
+func (s *MetricsSuite) TestTaskBacklogByType() {
+	t := s.T()
+	tx := s.tx
+
+	typename := "backlog-" + seeds.RandStringBytes(10)
+	createTask := func(status string, finished *time.Time) uuid2.UUID {
+		id := uuid2.New()
+		res := tx.Create(utils.Ptr(models.TaskInfo{
+			Id:       id,
+			Token:    uuid2.New(),
+			Typename: typename,
+			Queued:   utils.Ptr(time.Now()),
+			Finished: finished,
+			Status:   status,
+		}))
+		require.NoError(t, res.Error)
+		return id
+	}
+
+	running := createTask(config.TaskStatusRunning, nil)
+	completed := createTask(config.TaskStatusCompleted, utils.Ptr(time.Now()))
+	createTask(config.TaskStatusPending, nil)
+	blocked := createTask(config.TaskStatusPending, nil)
+	unblocked := createTask(config.TaskStatusPending, nil)
+	require.NoError(t, tx.Exec("INSERT INTO task_dependencies VALUES (?, ?)", blocked, running).Error)
+	require.NoError(t, tx.Exec("INSERT INTO task_dependencies VALUES (?, ?)", unblocked, completed).Error)
+
+	backlog := s.dao.TaskBacklogByType(context.Background())
+	i := slices.IndexFunc(backlog, func(b TaskTypeBacklog) bool {
+		return b.Type == typename
+	})
+	require.True(t, i >= 0)
+	assert.Equal(t, TaskTypeBacklog{Type: typename, Pending: 3, Ready: 2, Running: 1}, backlog[i])
+}
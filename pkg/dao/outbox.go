@@ -0,0 +1,166 @@
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/event"
+	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/content-services/content-sources-backend/pkg/utils"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// outboxLease is how long a claimed event is hidden from other relays while it is being sent
+const outboxLease = time.Minute * 5
+
+// claimOutboxEventsQuery claims due events that are the oldest pending event of each of their objects on their channel,
+// so that events about an object are sent in order, and pushes back their next attempt so only one relay sends each of them
+const claimOutboxEventsQuery = `
+	UPDATE event_outbox SET next_attempt_at = ?
+	WHERE id IN (
+		SELECT o.id FROM event_outbox o
+		WHERE o.status = ? AND o.next_attempt_at <= ?
+		AND NOT EXISTS (
+			SELECT 1 FROM event_outbox earlier
+			WHERE earlier.status = o.status
+			AND earlier.channel = o.channel
+			AND earlier.object_uuids && o.object_uuids
+			AND earlier.id < o.id
+		)
+		ORDER BY o.id ASC
+		LIMIT ?
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING id`
+
+type outboxDaoImpl struct {
+	db *gorm.DB
+}
+
+func GetOutboxDao(db *gorm.DB) OutboxDao {
+	return outboxDaoImpl{db: db}
+}
+
+// queueEvents writes one event per channel about all the objects with tx, so the events are only sent if tx is committed
+func queueEvents(tx *gorm.DB, orgID string, eventName event.EventName, channels []string, objects []event.Object) error {
+	if len(objects) == 0 {
+		return nil
+	}
+	payload, err := json.Marshal(objects)
+	if err != nil {
+		return fmt.Errorf("could not marshal event payload: %w", err)
+	}
+	objectUUIDs := pq.StringArray{}
+	for _, object := range objects {
+		if !slices.Contains(objectUUIDs, object.ObjectUUID()) {
+			objectUUIDs = append(objectUUIDs, object.ObjectUUID())
+		}
+	}
+	events := make([]models.OutboxEvent, 0, len(channels))
+	for _, channel := range channels {
+		events = append(events, models.OutboxEvent{
+			OrgID:       orgID,
+			Channel:     channel,
+			EventType:   eventName.String(),
+			ObjectUUIDs: objectUUIDs,
+			Payload:     payload,
+		})
+	}
+	if err := tx.Create(&events).Error; err != nil {
+		return fmt.Errorf("could not queue events: %w", err)
+	}
+	return nil
+}
+
+// queueNotification writes a notification about the objects with tx, see event.SendNotification
+func queueNotification[T event.Object](tx *gorm.DB, orgID string, eventName event.EventName, payloads []T) error {
+	channels := []string{event.ChannelNotifications, event.ChannelWebhooks}
+	return queueEvents(tx, orgID, eventName, channels, toEventObjects(payloads))
+}
+
+// queueTemplateEvent writes a template event about the templates with tx, see event.SendTemplateEvent
+func queueTemplateEvent[T event.Object](tx *gorm.DB, orgID string, eventName event.EventName, templates []T) error {
+	channels := []string{event.ChannelTemplateEvents, event.ChannelWebhooks}
+	return queueEvents(tx, orgID, eventName, channels, toEventObjects(templates))
+}
+
+func toEventObjects[T event.Object](payloads []T) []event.Object {
+	objects := make([]event.Object, len(payloads))
+	for i, payload := range payloads {
+		objects[i] = payload
+	}
+	return objects
+}
+
+// Queue records events outside of any transaction, it lets event.SendNotification and event.SendTemplateEvent use the outbox
+func (d outboxDaoImpl) Queue(ctx context.Context, orgID string, eventName event.EventName, channels []string, objects []event.Object) error {
+	return queueEvents(d.db.WithContext(ctx), orgID, eventName, channels, objects)
+}
+
+// ClaimDue returns up to limit events that are due, in the order they have to be sent
+func (d outboxDaoImpl) ClaimDue(ctx context.Context, limit int) ([]models.OutboxEvent, error) {
+	var ids []int64
+	now := time.Now()
+	err := d.db.WithContext(ctx).
+		Raw(claimOutboxEventsQuery, now.Add(outboxLease), models.OutboxEventPending, now, limit).
+		Scan(&ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not claim outbox events: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var events []models.OutboxEvent
+	err = d.db.WithContext(ctx).Where("id IN ?", ids).Order("id ASC").Find(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch outbox events: %w", err)
+	}
+	return events, nil
+}
+
+// RecordAttempt marks a sent event as sent. Failed events are retried with an exponential backoff until the configured
+// maximum attempts, the event is then kept as failed, and the next events about the same objects are sent.
+func (d outboxDaoImpl) RecordAttempt(ctx context.Context, outboxEvent models.OutboxEvent, attemptErr error) error {
+	settings := config.Get().Outbox
+	outboxEvent.Attempts++
+	switch {
+	case attemptErr == nil:
+		outboxEvent.Status = models.OutboxEventSent
+		outboxEvent.Error = nil
+	case outboxEvent.Attempts >= settings.MaxAttempts:
+		outboxEvent.Status = models.OutboxEventFailed
+		outboxEvent.Error = utils.Ptr(attemptErr.Error())
+	default:
+		outboxEvent.Error = utils.Ptr(attemptErr.Error())
+		wait := settings.RetryWait * time.Duration(1<<min(outboxEvent.Attempts-1, 16))
+		outboxEvent.NextAttemptAt = time.Now().Add(wait)
+	}
+
+	err := d.db.WithContext(ctx).Model(&outboxEvent).
+		Select("attempts", "error", "status", "next_attempt_at").
+		Updates(&outboxEvent).Error
+	if err != nil {
+		return fmt.Errorf("could not record outbox event attempt: %w", err)
+	}
+	return nil
+}
+
+// Cleanup deletes the sent and failed events older than the configured retention
+func (d outboxDaoImpl) Cleanup(ctx context.Context) error {
+	result := d.db.WithContext(ctx).
+		Where("status IN ?", []string{models.OutboxEventSent, models.OutboxEventFailed}).
+		Where("created_at < (current_date - make_interval(days => ?))", config.Get().Outbox.RetainDaysLimit).
+		Delete(&models.OutboxEvent{})
+	if result.Error != nil {
+		return fmt.Errorf("could not clean up outbox events: %w", result.Error)
+	}
+	log.Logger.Debug().Msgf("Cleaned up %v old outbox events", result.RowsAffected)
+	return nil
+}
@@ -0,0 +1,161 @@
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/event"
+	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/content-services/content-sources-backend/pkg/seeds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type OutboxSuite struct {
+	*DaoSuite
+}
+
+func TestOutboxSuite(t *testing.T) {
+	m := DaoSuite{}
+	r := OutboxSuite{DaoSuite: &m}
+	suite.Run(t, &r)
+}
+
+func (s *OutboxSuite) TestQueue() {
+	t := s.T()
+	orgID := seeds.RandomOrgId()
+	payloads := []event.RepositoryPayload{{UUID: "repo-1", Name: "one"}, {UUID: "repo-2", Name: "two"}}
+
+	require.NoError(t, queueNotification(s.tx, orgID, event.RepositoryCreated, payloads))
+
+	// The objects of a change are queued in a single event per channel
+	var events []models.OutboxEvent
+	require.NoError(t, s.tx.Where("org_id = ?", orgID).Order("id").Find(&events).Error)
+	require.Len(t, events, 2)
+	assert.Equal(t, event.ChannelNotifications, events[0].Channel)
+	assert.Equal(t, event.ChannelWebhooks, events[1].Channel)
+	assert.Equal(t, []string{"repo-1", "repo-2"}, []string(events[0].ObjectUUIDs))
+	assert.Equal(t, event.RepositoryCreated.String(), events[0].EventType)
+	assert.Equal(t, models.OutboxEventPending, events[0].Status)
+	var payload []event.RepositoryPayload
+	require.NoError(t, json.Unmarshal(events[0].Payload, &payload))
+	assert.Equal(t, payloads, payload)
+
+	// Events queued in a transaction that rolls back are never sent
+	_ = s.tx.Transaction(func(tx *gorm.DB) error {
+		require.NoError(t, queueTemplateEvent(tx, orgID, event.TemplateCreated, []event.TemplateEvent{{UUID: "template"}}))
+		return errors.New("rollback")
+	})
+	var count int64
+	require.NoError(t, s.tx.Model(&models.OutboxEvent{}).Where("org_id = ?", orgID).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+}
+
+func (s *OutboxSuite) TestClaimInOrder() {
+	t := s.T()
+	dao := outboxDaoImpl{db: s.tx}
+	orgID := seeds.RandomOrgId()
+	ctx := context.Background()
+	channels := []string{event.ChannelNotifications}
+
+	require.NoError(t, dao.Queue(ctx, orgID, event.RepositoryCreated, channels, []event.Object{event.RepositoryPayload{UUID: "repo-1"}}))
+	require.NoError(t, dao.Queue(ctx, orgID, event.RepositoryUpdated, channels, []event.Object{event.RepositoryPayload{UUID: "repo-1"}}))
+	require.NoError(t, dao.Queue(ctx, orgID, event.RepositoryCreated, channels, []event.Object{event.RepositoryPayload{UUID: "repo-2"}}))
+	require.NoError(t, dao.Queue(ctx, orgID, event.RepositoryDeleted, channels, []event.Object{event.RepositoryPayload{UUID: "repo-2"}, event.RepositoryPayload{UUID: "repo-3"}}))
+
+	// Only the first event of each object is claimed, an event about several objects waits for the earlier events of each
+	claimed, err := dao.ClaimDue(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 2)
+	assert.Equal(t, []string{"repo-1"}, []string(claimed[0].ObjectUUIDs))
+	assert.Equal(t, event.RepositoryCreated.String(), claimed[0].EventType)
+	assert.Equal(t, []string{"repo-2"}, []string(claimed[1].ObjectUUIDs))
+
+	// Claimed events are not claimed again, and still block the next event of their object
+	again, err := dao.ClaimDue(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+
+	require.NoError(t, dao.RecordAttempt(ctx, claimed[0], nil))
+	require.NoError(t, dao.RecordAttempt(ctx, claimed[1], nil))
+	next, err := dao.ClaimDue(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, next, 2)
+	assert.Equal(t, []string{"repo-1"}, []string(next[0].ObjectUUIDs))
+	assert.Equal(t, event.RepositoryUpdated.String(), next[0].EventType)
+	assert.Equal(t, []string{"repo-2", "repo-3"}, []string(next[1].ObjectUUIDs))
+
+	// Sent events are kept until the cleanup
+	var sent models.OutboxEvent
+	require.NoError(t, s.tx.First(&sent, claimed[0].ID).Error)
+	assert.Equal(t, models.OutboxEventSent, sent.Status)
+	assert.Equal(t, 1, sent.Attempts)
+}
+
+func (s *OutboxSuite) TestRecordAttemptFailures() {
+	t := s.T()
+	dao := outboxDaoImpl{db: s.tx}
+	orgID := seeds.RandomOrgId()
+	ctx := context.Background()
+	channels := []string{event.ChannelTemplateEvents}
+
+	require.NoError(t, dao.Queue(ctx, orgID, event.TemplateCreated, channels, []event.Object{event.TemplateEvent{UUID: "template"}}))
+	require.NoError(t, dao.Queue(ctx, orgID, event.TemplateUpdated, channels, []event.Object{event.TemplateEvent{UUID: "template"}}))
+	claimed, err := dao.ClaimDue(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	outboxEvent := claimed[0]
+	maxAttempts := config.Get().Outbox.MaxAttempts
+	for attempt := 1; attempt < maxAttempts; attempt++ {
+		require.NoError(t, dao.RecordAttempt(ctx, outboxEvent, errors.New("broker is down")))
+		require.NoError(t, s.tx.First(&outboxEvent, outboxEvent.ID).Error)
+		assert.Equal(t, models.OutboxEventPending, outboxEvent.Status)
+		assert.Equal(t, attempt, outboxEvent.Attempts)
+		wait := config.Get().Outbox.RetryWait * time.Duration(1<<(attempt-1))
+		assert.WithinDuration(t, time.Now().Add(wait), outboxEvent.NextAttemptAt, time.Minute)
+	}
+
+	// The following event is sent once the first one failed every attempt
+	require.NoError(t, dao.RecordAttempt(ctx, outboxEvent, errors.New("broker is down")))
+	require.NoError(t, s.tx.First(&outboxEvent, outboxEvent.ID).Error)
+	assert.Equal(t, models.OutboxEventFailed, outboxEvent.Status)
+	require.NotNil(t, outboxEvent.Error)
+	assert.Equal(t, "broker is down", *outboxEvent.Error)
+
+	next, err := dao.ClaimDue(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, next, 1)
+	assert.Equal(t, event.TemplateUpdated.String(), next[0].EventType)
+}
+
+func (s *OutboxSuite) TestCleanup() {
+	t := s.T()
+	dao := outboxDaoImpl{db: s.tx}
+	orgID := seeds.RandomOrgId()
+	retainDays := config.Get().Outbox.RetainDaysLimit
+	old := time.Now().AddDate(0, 0, -retainDays-2)
+	recent := time.Now().AddDate(0, 0, -retainDays+2)
+
+	events := []models.OutboxEvent{
+		{OrgID: orgID, Channel: event.ChannelWebhooks, EventType: "old-sent", Status: models.OutboxEventSent, CreatedAt: old},
+		{OrgID: orgID, Channel: event.ChannelWebhooks, EventType: "old-failed", Status: models.OutboxEventFailed, CreatedAt: old},
+		{OrgID: orgID, Channel: event.ChannelWebhooks, EventType: "old-pending", Status: models.OutboxEventPending, CreatedAt: old},
+		{OrgID: orgID, Channel: event.ChannelWebhooks, EventType: "recent-sent", Status: models.OutboxEventSent, CreatedAt: recent},
+	}
+	for i := range events {
+		events[i].Payload = json.RawMessage(`[]`)
+	}
+	require.NoError(t, s.tx.Create(&events).Error)
+
+	require.NoError(t, dao.Cleanup(context.Background()))
+	var eventTypes []string
+	require.NoError(t, s.tx.Model(&models.OutboxEvent{}).Where("org_id = ?", orgID).Order("id").Pluck("event_type", &eventTypes).Error)
+	assert.Equal(t, []string{"old-pending", "recent-sent"}, eventTypes)
+}
@@ -22,6 +22,9 @@ type MockDaoRegistry struct {
 	LightwellVulnerability MockLightwellVulnerabilityDao
 	UserPreference         MockUserPreferenceDao
 	CoverageReport         MockCoverageReportDao
+	Webhook                MockWebhookDao
+	AuditEvent             MockAuditEventDao
+	CoverageDemandSignal   MockCoverageDemandSignalDao
 }
 
 func (m *MockDaoRegistry) ToDaoRegistry() *DaoRegistry {
@@ -43,6 +46,9 @@ func (m *MockDaoRegistry) ToDaoRegistry() *DaoRegistry {
 		LightwellVulnerability: &m.LightwellVulnerability,
 		UserPreference:         &m.UserPreference,
 		CoverageReport:         &m.CoverageReport,
+		Webhook:                &m.Webhook,
+		AuditEvent:             &m.AuditEvent,
+		CoverageDemandSignal:   &m.CoverageDemandSignal,
 	}
 	return &r
 }
@@ -66,6 +72,9 @@ func GetMockDaoRegistry(t *testing.T) *MockDaoRegistry {
 		LightwellVulnerability: *NewMockLightwellVulnerabilityDao(t),
 		UserPreference:         *NewMockUserPreferenceDao(t),
 		CoverageReport:         *NewMockCoverageReportDao(t),
+		Webhook:                *NewMockWebhookDao(t),
+		AuditEvent:             *NewMockAuditEventDao(t),
+		CoverageDemandSignal:   *NewMockCoverageDemandSignalDao(t),
 	}
 	return &reg
 }
@@ -182,11 +182,14 @@ func (r repositoryDaoImpl) MarkAsNotPublic(ctx context.Context, url string) erro
 }
 
 // InternalOnly_UpdateCounts updates the package_count, build_count, and version_count fields for a repository
+// InternalOnly_UpdateCounts updates the content counts of a repository we don't manage, recording that its content
+// changed as the time of its last introspection with updates
 func (r repositoryDaoImpl) InternalOnly_UpdateCounts(ctx context.Context, repoUUID string, packageCount int, buildCount int, versionCount int) error {
 	res := r.db.WithContext(ctx).Model(&models.Repository{}).Where("uuid = ?", repoUUID).Updates(map[string]interface{}{
-		"package_count": packageCount,
-		"build_count":   buildCount,
-		"version_count": versionCount,
+		"package_count":                  packageCount,
+		"build_count":                    buildCount,
+		"version_count":                  versionCount,
+		"last_introspection_update_time": time.Now(),
 	})
 	if res.Error != nil {
 		return fmt.Errorf("could not update counts: %w", res.Error)
@@ -589,6 +589,7 @@ func (s *RepositorySuite) TestInternalOnly_UpdatePackageCount() {
 	assert.Equal(t, newCount*2, repo.BuildCount)
 	assert.Equal(t, newCount*3, repo.VersionCount)
 	assert.NotEqual(t, initialCount, repo.PackageCount)
+	assert.NotNil(t, repo.LastIntrospectionUpdateTime, "the content change is recorded")
 
 	// Update to zero
 	err = dao.InternalOnly_UpdateCounts(context.Background(), s.repo.UUID, 0, 0, 0)
@@ -18,6 +18,7 @@ import (
 	ce "github.com/content-services/content-sources-backend/pkg/errors"
 	"github.com/content-services/content-sources-backend/pkg/event"
 	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/content-services/content-sources-backend/pkg/rbac"
 	"github.com/content-services/content-sources-backend/pkg/utils"
 	"github.com/content-services/yummy/pkg/yum"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -117,6 +118,22 @@ func isCreatableOrigin(origin *string) bool {
 }
 
 func (r repositoryConfigDaoImpl) Create(ctx context.Context, newRepoReq api.RepositoryRequest) (api.RepositoryResponse, error) {
+	var created api.RepositoryResponse
+	// The repository and its created event are committed together
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		txDao := r
+		txDao.db = tx
+		created, err = txDao.create(ctx, newRepoReq)
+		return err
+	})
+	if err == nil {
+		reportObject(ctx, rbac.ResourceRepositories, created.OrgID, created.UUID, newRepoReq.WorkspaceID)
+	}
+	return created, err
+}
+
+func (r repositoryConfigDaoImpl) create(ctx context.Context, newRepoReq api.RepositoryRequest) (api.RepositoryResponse, error) {
 	var newRepo models.Repository
 	var newRepoConfig models.RepositoryConfiguration
 
@@ -187,11 +204,10 @@ func (r repositoryConfigDaoImpl) Create(ctx context.Context, newRepoReq api.Repo
 	created.URL = newRepo.URL
 	created.LastIntrospectionStatus = newRepo.LastIntrospectionStatus
 
-	event.SendNotification(
-		newRepoConfig.OrgID,
-		event.RepositoryCreated,
-		[]event.RepositoryPayload{event.MapRepositoryPayload(created)},
-	)
+	err = queueNotification(r.db.WithContext(ctx), newRepoConfig.OrgID, event.RepositoryCreated, []event.RepositoryPayload{event.MapRepositoryPayload(created)})
+	if err != nil {
+		return api.RepositoryResponse{}, err
+	}
 
 	return created, nil
 }
@@ -204,16 +220,25 @@ func (r repositoryConfigDaoImpl) BulkCreate(ctx context.Context, newRepositories
 		var err error
 		responses, errs = r.bulkCreate(ctx, tx.WithContext(ctx), newRepositories)
 		if len(errs) > 0 {
-			err = errors.New("rollback bulk create")
+			return errors.New("rollback bulk create")
+		}
+
+		payloads := make([]event.RepositoryPayload, len(responses))
+		for i := range responses {
+			payloads[i] = event.MapRepositoryPayload(responses[i])
+		}
+		err = queueNotification(tx.WithContext(ctx), *newRepositories[0].OrgID, event.RepositoryCreated, payloads)
+		if err != nil {
+			responses = []api.RepositoryResponse{}
+			errs = []error{err}
 		}
 		return err
 	})
-
-	payloads := make([]event.RepositoryPayload, len(responses))
-	for i := range responses {
-		payloads[i] = event.MapRepositoryPayload(responses[i])
+	if len(errs) == 0 {
+		for i := range responses {
+			reportObject(ctx, rbac.ResourceRepositories, responses[i].OrgID, responses[i].UUID, newRepositories[i].WorkspaceID)
+		}
 	}
-	event.SendNotification(*newRepositories[0].OrgID, event.RepositoryCreated, payloads)
 
 	return responses, errs
 }
@@ -638,6 +663,10 @@ func (r repositoryConfigDaoImpl) filteredDbForList(OrgID string, filteredDB *gor
 		filteredDB = filteredDB.Where("repository_configurations.uuid IN ?", UuidifyStrings(uuids))
 	}
 
+	if filterData.AllowedUUIDs != nil {
+		filteredDB = filteredDB.Where("repository_configurations.org_id <> ? OR repository_configurations.uuid IN ?", OrgID, UuidifyStrings(filterData.AllowedUUIDs))
+	}
+
 	if filterData.AvailableForArch != "" {
 		filteredDB = filteredDB.Where("arch = ? OR arch = '' OR arch = 'any'", filterData.AvailableForArch)
 	}
@@ -1024,26 +1053,12 @@ func (r repositoryConfigDaoImpl) Update(ctx context.Context, orgID, uuid string,
 		repositoryResponse := api.RepositoryResponse{}
 		ModelToApiFields(repoConfig, &repositoryResponse)
 
-		event.SendNotification(
-			orgID,
-			event.RepositoryUpdated,
-			[]event.RepositoryPayload{event.MapRepositoryPayload(repositoryResponse)},
-		)
-		return nil
+		return queueNotification(tx, orgID, event.RepositoryUpdated, []event.RepositoryPayload{event.MapRepositoryPayload(repositoryResponse)})
 	})
 	if err != nil {
 		return updatedUrl, err
 	}
 
-	repositoryResponse := api.RepositoryResponse{}
-	ModelToApiFields(repoConfig, &repositoryResponse)
-
-	event.SendNotification(
-		orgID,
-		event.RepositoryUpdated,
-		[]event.RepositoryPayload{event.MapRepositoryPayload(repositoryResponse)},
-	)
-
 	repoConfig.Repository = models.Repository{}
 	if err := r.db.WithContext(ctx).Model(&repoConfig).Omit("LastSnapshot").Updates(repoConfig.MapForUpdate()).Error; err != nil {
 		return updatedUrl, RepositoryDBErrorToApi(err, nil)
@@ -1052,20 +1067,83 @@ func (r repositoryConfigDaoImpl) Update(ctx context.Context, orgID, uuid string,
 	return updatedUrl, nil
 }
 
+// InternalOnly_ResetFailedSnapshotCount resets the consecutive failed snapshots of the repository config, and sends a
+// recovery notification if they had reached the notification threshold
 func (r repositoryConfigDaoImpl) InternalOnly_ResetFailedSnapshotCount(ctx context.Context, rcUuid string) error {
-	res := r.db.WithContext(ctx).Model(models.RepositoryConfiguration{}).Where("uuid = ?", rcUuid).UpdateColumn("failed_snapshot_count", 0)
-	if res.Error != nil {
-		return fmt.Errorf("failed to update failed_snapshot_count: %w", res.Error)
-	}
-	return nil
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var previousCounts []int64
+		err := tx.Raw(`
+			UPDATE repository_configurations SET failed_snapshot_count = 0
+			FROM (SELECT uuid, failed_snapshot_count FROM repository_configurations WHERE uuid = ? FOR UPDATE) previous
+			WHERE repository_configurations.uuid = previous.uuid
+			RETURNING previous.failed_snapshot_count`, rcUuid).Scan(&previousCounts).Error
+		if err != nil {
+			return fmt.Errorf("failed to update failed_snapshot_count: %w", err)
+		}
+
+		threshold := config.Get().Options.SnapshotFailureNotificationThreshold
+		if len(previousCounts) == 0 || threshold <= 0 || previousCounts[0] < int64(threshold) {
+			return nil
+		}
+		return queueSnapshotFailuresNotification(tx, rcUuid, event.RepositorySnapshotRecovered, previousCounts[0])
+	})
 }
 
+// InternalOnly_IncrementFailedSnapshotCount counts a failed snapshot of the repository config, and sends a notification
+// when the consecutive failed snapshots reach the notification threshold
 func (r repositoryConfigDaoImpl) InternalOnly_IncrementFailedSnapshotCount(ctx context.Context, rcUuid string) error {
-	res := r.db.WithContext(ctx).Exec("UPDATE repository_configurations SET failed_snapshot_count = failed_snapshot_count + 1  WHERE uuid = ? AND repository_configurations.deleted_at IS NULL", rcUuid)
-	if res.Error != nil {
-		return fmt.Errorf("failed to update failed_snapshot_count: %w", res.Error)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var counts []int64
+		err := tx.Raw(`
+			UPDATE repository_configurations SET failed_snapshot_count = failed_snapshot_count + 1
+			WHERE uuid = ? AND repository_configurations.deleted_at IS NULL
+			RETURNING failed_snapshot_count`, rcUuid).Scan(&counts).Error
+		if err != nil {
+			return fmt.Errorf("failed to update failed_snapshot_count: %w", err)
+		}
+
+		threshold := config.Get().Options.SnapshotFailureNotificationThreshold
+		if len(counts) == 0 || threshold <= 0 || counts[0] != int64(threshold) {
+			return nil
+		}
+		return queueSnapshotFailuresNotification(tx, rcUuid, event.RepositorySnapshotFailure, counts[0])
+	})
+}
+
+// queueSnapshotFailuresNotification notifies the owner of the repository config about its failed snapshots, along with
+// the templates using it. Red Hat and community repositories are skipped, as no organization owns them.
+func queueSnapshotFailuresNotification(tx *gorm.DB, rcUuid string, eventName event.EventName, failedSnapshotCount int64) error {
+	var repoConfig models.RepositoryConfiguration
+	err := tx.Unscoped().Preload("Repository").Where("uuid = ?", rcUuid).First(&repoConfig).Error
+	if err != nil {
+		return RepositoryDBErrorToApi(err, &rcUuid)
 	}
-	return nil
+	if repoConfig.OrgID == config.RedHatOrg || repoConfig.OrgID == config.CommunityOrg {
+		return nil
+	}
+
+	var templates []models.Template
+	err = tx.Model(&models.Template{}).
+		Joins("INNER JOIN templates_repository_configurations ON templates_repository_configurations.template_uuid = templates.uuid").
+		Where("templates_repository_configurations.repository_configuration_uuid = ?", rcUuid).
+		Where("templates_repository_configurations.deleted_at IS NULL").
+		Order("templates.name ASC").
+		Find(&templates).Error
+	if err != nil {
+		return fmt.Errorf("failed to list templates of repository: %w", err)
+	}
+
+	repositoryResponse := api.RepositoryResponse{}
+	ModelToApiFields(repoConfig, &repositoryResponse)
+	payload := event.SnapshotFailuresPayload{
+		RepositoryPayload:   event.MapRepositoryPayload(repositoryResponse),
+		FailedSnapshotCount: failedSnapshotCount,
+		Templates:           make([]event.TemplateReference, len(templates)),
+	}
+	for i, template := range templates {
+		payload.Templates[i] = event.TemplateReference{UUID: template.UUID, Name: template.Name}
+	}
+	return queueNotification(tx, repoConfig.OrgID, eventName, []event.SnapshotFailuresPayload{payload})
 }
 
 // UpdateLastSnapshotTask updates the RepositoryConfig with the latest SnapshotTask
@@ -1158,23 +1236,19 @@ func (r repositoryConfigDaoImpl) SoftDelete(ctx context.Context, orgID string, u
 		return RepositoryDBErrorToApi(err, &uuid)
 	}
 
-	if !repoConfig.DeletedAt.Valid {
-		err = r.db.WithContext(ctx).Delete(&repoConfig).Error
-		if err != nil {
-			return err
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if !repoConfig.DeletedAt.Valid {
+			err = tx.Delete(&repoConfig).Error
+			if err != nil {
+				return err
+			}
 		}
-	}
-
-	repositoryResponse := api.RepositoryResponse{}
-	ModelToApiFields(repoConfig, &repositoryResponse)
 
-	event.SendNotification(
-		orgID,
-		event.RepositoryDeleted,
-		[]event.RepositoryPayload{event.MapRepositoryPayload(repositoryResponse)},
-	)
+		repositoryResponse := api.RepositoryResponse{}
+		ModelToApiFields(repoConfig, &repositoryResponse)
 
-	return nil
+		return queueNotification(tx, orgID, event.RepositoryDeleted, []event.RepositoryPayload{event.MapRepositoryPayload(repositoryResponse)})
+	})
 }
 
 func (r repositoryConfigDaoImpl) Delete(ctx context.Context, orgID string, uuid string) error {
@@ -1188,6 +1262,7 @@ func (r repositoryConfigDaoImpl) Delete(ctx context.Context, orgID string, uuid
 	if err = r.db.WithContext(ctx).Unscoped().Delete(&repoConfig).Error; err != nil {
 		return err
 	}
+	deleteObject(ctx, rbac.ResourceRepositories, repoConfig.UUID)
 
 	return nil
 }
@@ -1200,18 +1275,19 @@ func (r repositoryConfigDaoImpl) BulkDelete(ctx context.Context, orgID string, u
 		var err error
 		responses, errs = r.bulkDelete(ctx, tx, orgID, uuids)
 		if len(errs) > 0 {
-			err = errors.New("rollback bulk delete")
+			return errors.New("rollback bulk delete")
 		}
-		return err
-	})
 
-	if len(responses) > 0 {
 		payloads := make([]event.RepositoryPayload, len(responses))
 		for i := range responses {
 			payloads[i] = event.MapRepositoryPayload(responses[i])
 		}
-		event.SendNotification(orgID, event.RepositoryDeleted, payloads)
-	}
+		err = queueNotification(tx, orgID, event.RepositoryDeleted, payloads)
+		if err != nil {
+			errs = []error{err}
+		}
+		return err
+	})
 
 	return errs
 }
@@ -1293,22 +1369,31 @@ func (r repositoryConfigDaoImpl) validateRepositoryUUIDs(ctx context.Context, or
 
 func (r repositoryConfigDaoImpl) BulkImport(ctx context.Context, reposToImport []api.RepositoryRequest) ([]api.RepositoryImportResponse, []error) {
 	var responses []api.RepositoryImportResponse
+	var created []models.RepositoryConfiguration
 	var errs []error
 
 	_ = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var err error
-		responses, errs = r.bulkImport(tx.WithContext(ctx), reposToImport)
+		responses, created, errs = r.bulkImport(tx.WithContext(ctx), reposToImport)
 		if len(errs) > 0 {
 			err = errors.New("rollback bulk import")
 		}
 		return err
 	})
+	if len(errs) == 0 {
+		for _, repoConfig := range created {
+			reportObject(ctx, rbac.ResourceRepositories, repoConfig.OrgID, repoConfig.UUID, repoConfig.WorkspaceID)
+		}
+	}
 
 	return responses, errs
 }
 
-func (r repositoryConfigDaoImpl) bulkImport(tx *gorm.DB, reposToImport []api.RepositoryRequest) ([]api.RepositoryImportResponse, []error) {
+// bulkImport imports the repositories, returning the repository configurations it created, as repositories already
+// existing in the organization are returned instead of being created
+func (r repositoryConfigDaoImpl) bulkImport(tx *gorm.DB, reposToImport []api.RepositoryRequest) ([]api.RepositoryImportResponse, []models.RepositoryConfiguration, []error) {
 	var dbErr error
+	var created []models.RepositoryConfiguration
 	size := len(reposToImport)
 	newRepoConfigs := make([]models.RepositoryConfiguration, size)
 	newRepos := make([]models.Repository, size)
@@ -1379,6 +1464,7 @@ func (r repositoryConfigDaoImpl) bulkImport(tx *gorm.DB, reposToImport []api.Rep
 					"name":        repo.Name,
 					"description": UploadRepositoryWarning,
 				}}
+				created = append(created, repo)
 			}
 			ModelToImportRepoApi(repo, warnings, &responses[i])
 			continue
@@ -1423,6 +1509,7 @@ func (r repositoryConfigDaoImpl) bulkImport(tx *gorm.DB, reposToImport []api.Rep
 				continue
 			}
 			newRepoConfigs[i].Repository = newRepos[i] // Set repo on config for proper response values
+			created = append(created, newRepoConfigs[i])
 			if dbErr == nil {
 				ModelToImportRepoApi(newRepoConfigs[i], responses[i].Warnings, &responses[i])
 				responses[i].URL = newRepos[i].URL
@@ -1433,9 +1520,9 @@ func (r repositoryConfigDaoImpl) bulkImport(tx *gorm.DB, reposToImport []api.Rep
 	// If there are no errors at all, return empty error slice.
 	// If there is at least 1 error, return empty response slice.
 	if dbErr == nil {
-		return responses, []error{}
+		return responses, created, []error{}
 	}
-	return []api.RepositoryImportResponse{}, errorList
+	return []api.RepositoryImportResponse{}, nil, errorList
 }
 
 func importUploadRepository(tx *gorm.DB, repoToImport api.RepositoryRequest, newRepo models.Repository, newRepoConfig models.RepositoryConfiguration) (models.RepositoryConfiguration, bool, error) {
@@ -1559,11 +1646,12 @@ func ApiUpdateFieldsToModel(apiRepo api.RepositoryUpdateRequest, repoConfig *mod
 }
 
 func ApiFieldsToModel(apiRepo api.RepositoryRequest, repoConfig *models.RepositoryConfiguration, repo *models.Repository) {
-	// Origin can only be set on creation, cannot be changed
+	// Origin and workspace can only be set on creation, cannot be changed
 	if repoConfig.UUID == "" {
 		if apiRepo.Origin != nil {
 			repo.Origin = *apiRepo.Origin
 		}
+		repoConfig.WorkspaceID = apiRepo.WorkspaceID
 	}
 
 	// copied from ApiUpdateFieldsToModel
@@ -16,7 +16,9 @@ import (
 	"github.com/content-services/content-sources-backend/pkg/clients/pulp_client"
 	"github.com/content-services/content-sources-backend/pkg/config"
 	ce "github.com/content-services/content-sources-backend/pkg/errors"
+	"github.com/content-services/content-sources-backend/pkg/event"
 	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/content-services/content-sources-backend/pkg/rbac"
 	"github.com/content-services/content-sources-backend/pkg/seeds"
 	"github.com/content-services/content-sources-backend/pkg/test"
 	test_handler "github.com/content-services/content-sources-backend/pkg/test/handler"
@@ -94,6 +96,40 @@ func (suite *RepositoryConfigSuite) TestCreate() {
 	assert.Equal(t, true, foundRepo.ModuleHotfixes)
 }
 
+func (suite *RepositoryConfigSuite) TestCreateAndDeleteReportObject() {
+	t := suite.T()
+	tx := suite.tx
+	orgID := seeds.RandomOrgId()
+	workspaceID := "sub-workspace"
+
+	reporter := rbac.NewMockObjectClientWrapper(t)
+	rbac.SetObjectReporter(reporter)
+	defer rbac.SetObjectReporter(nil)
+
+	toCreate := api.RepositoryRequest{
+		Name:        utils.Ptr("workspace repo"),
+		URL:         utils.Ptr("http://workspace.example.com/"),
+		OrgID:       &orgID,
+		AccountID:   utils.Ptr(seeds.RandomAccountId()),
+		WorkspaceID: &workspaceID,
+	}
+	reporter.On("ReportObject", mock.Anything, rbac.ResourceRepositories, orgID, mock.AnythingOfType("string"), workspaceID).Return(nil).Once()
+
+	dao := GetRepositoryConfigDao(tx, suite.mockPulpClient, suite.mockFsClient)
+	created, err := dao.Create(context.Background(), toCreate)
+	require.NoError(t, err)
+	reporter.AssertCalled(t, "ReportObject", mock.Anything, rbac.ResourceRepositories, orgID, created.UUID, workspaceID)
+
+	// The workspace is stored, so the report-workspace-objects job reports it in the same workspace
+	var repoConfig models.RepositoryConfiguration
+	require.NoError(t, tx.First(&repoConfig, "uuid = ?", created.UUID).Error)
+	require.NotNil(t, repoConfig.WorkspaceID)
+	assert.Equal(t, workspaceID, *repoConfig.WorkspaceID)
+
+	reporter.On("DeleteObject", mock.Anything, rbac.ResourceRepositories, created.UUID).Return(nil).Once()
+	require.NoError(t, dao.Delete(context.Background(), orgID, created.UUID))
+}
+
 func (suite *RepositoryConfigSuite) TestCreateRedHat() {
 	name := "Updated"
 	url := "http://example.com/"
@@ -877,9 +913,17 @@ func (suite *RepositoryConfigSuite) TestBulkImportOneExists() {
 	_, err = GetRepositoryConfigDao(tx, suite.mockPulpClient, suite.mockFsClient).Create(context.Background(), requests[2])
 	assert.Empty(t, err)
 
+	// Only the repositories created by the import are reported
+	reporter := rbac.NewMockObjectClientWrapper(t)
+	rbac.SetObjectReporter(reporter)
+	defer rbac.SetObjectReporter(nil)
+	reporter.On("ReportObject", mock.Anything, rbac.ResourceRepositories, orgID, mock.AnythingOfType("string"), "").Return(nil).Twice()
+
 	rr, errs := GetRepositoryConfigDao(tx, suite.mockPulpClient, suite.mockFsClient).BulkImport(context.Background(), requests)
 	assert.Empty(t, errs)
 	assert.Equal(t, 4, len(rr))
+	reporter.AssertCalled(t, "ReportObject", mock.Anything, rbac.ResourceRepositories, orgID, rr[1].UUID, "")
+	reporter.AssertCalled(t, "ReportObject", mock.Anything, rbac.ResourceRepositories, orgID, rr[3].UUID, "")
 	assert.NotEmpty(t, rr[0].Warnings)
 	assert.Empty(t, rr[1].Warnings)
 	assert.NotEmpty(t, rr[2].Warnings)
@@ -1951,6 +1995,35 @@ func (suite *RepositoryConfigSuite) TestListFilterUUIDs() {
 	assert.Equal(t, filterData.UUID, response.Data[0].UUID+","+response.Data[1].UUID)
 }
 
+func (suite *RepositoryConfigSuite) TestListFilterAllowedUUIDs() {
+	t := suite.T()
+	orgID := seeds.RandomOrgId()
+
+	repoConfigDao := GetRepositoryConfigDao(suite.tx, suite.mockPulpClient, suite.mockFsClient)
+	suite.mockPulpForListOrFetch(3)
+	suite.mockFsClient.Mock.On("GetEntitledFeatures", context.Background(), orgID).Return([]string{"RHEL-OS-x86_64"}, nil)
+
+	filterData := api.FilterData{Origin: originCustom}
+
+	_, err := seeds.SeedRepositoryConfigurations(suite.tx, 3, seeds.SeedOptions{OrgID: orgID, Versions: &[]string{config.El9}})
+	assert.Nil(t, err)
+	allRepoResp, _, err := repoConfigDao.List(context.Background(), orgID, api.PaginationData{Limit: -1}, filterData)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(allRepoResp.Data))
+
+	// Only the allowed repositories of the organization are listed
+	filterData.AllowedUUIDs = []string{allRepoResp.Data[1].UUID}
+	response, total, err := repoConfigDao.List(context.Background(), orgID, api.PaginationData{Limit: -1}, filterData)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, int(total))
+	assert.Equal(t, allRepoResp.Data[1].UUID, response.Data[0].UUID)
+
+	filterData.AllowedUUIDs = []string{}
+	_, total, err = repoConfigDao.List(context.Background(), orgID, api.PaginationData{Limit: -1}, filterData)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, int(total))
+}
+
 func (suite *RepositoryConfigSuite) TestListFilterVersion() {
 	t := suite.T()
 
@@ -3922,6 +3995,65 @@ func (suite *RepositoryConfigSuite) TestIncrementResetFailedSnapshotCountWithNil
 	assert.NotNil(t, err)
 }
 
+func (suite *RepositoryConfigSuite) TestFailedSnapshotCountNotifications() {
+	t := suite.T()
+	tx := suite.tx
+	daoReg := GetDaoRegistry(tx)
+
+	threshold := config.Get().Options.SnapshotFailureNotificationThreshold
+	config.Get().Options.SnapshotFailureNotificationThreshold = 2
+	defer func() { config.Get().Options.SnapshotFailureNotificationThreshold = threshold }()
+
+	orgID := seeds.RandomOrgId()
+	rConfigs, err := seeds.SeedRepositoryConfigurations(tx, 1, seeds.SeedOptions{OrgID: orgID})
+	require.NoError(t, err)
+	rConfig := rConfigs[0]
+	snapshots, err := seeds.SeedSnapshots(tx, rConfig.UUID, 1)
+	require.NoError(t, err)
+	templates, err := seeds.SeedTemplates(tx, 1, seeds.TemplateSeedOptions{
+		OrgID:                 orgID,
+		RepositoryConfigUUIDs: []string{rConfig.UUID},
+		Snapshots:             snapshots,
+	})
+	require.NoError(t, err)
+
+	outboxEvents := func(eventName event.EventName) []models.OutboxEvent {
+		var events []models.OutboxEvent
+		err := tx.Where("object_uuid = ? AND event_type = ?", rConfig.UUID, eventName.String()).Order("id ASC").Find(&events).Error
+		require.NoError(t, err)
+		return events
+	}
+
+	err = daoReg.RepositoryConfig.InternalOnly_IncrementFailedSnapshotCount(context.Background(), rConfig.UUID)
+	require.NoError(t, err)
+	assert.Empty(t, outboxEvents(event.RepositorySnapshotFailure))
+
+	err = daoReg.RepositoryConfig.InternalOnly_IncrementFailedSnapshotCount(context.Background(), rConfig.UUID)
+	require.NoError(t, err)
+	failures := outboxEvents(event.RepositorySnapshotFailure)
+	require.Len(t, failures, 2)
+	assert.ElementsMatch(t, []string{event.ChannelNotifications, event.ChannelWebhooks}, []string{failures[0].Channel, failures[1].Channel})
+	assert.Equal(t, orgID, failures[0].OrgID)
+	assert.Contains(t, string(failures[0].Payload), `"failed_snapshot_count":2`)
+	assert.Contains(t, string(failures[0].Payload), templates[0].Name)
+
+	// Only the failure reaching the threshold is notified
+	err = daoReg.RepositoryConfig.InternalOnly_IncrementFailedSnapshotCount(context.Background(), rConfig.UUID)
+	require.NoError(t, err)
+	assert.Len(t, outboxEvents(event.RepositorySnapshotFailure), 2)
+
+	err = daoReg.RepositoryConfig.InternalOnly_ResetFailedSnapshotCount(context.Background(), rConfig.UUID)
+	require.NoError(t, err)
+	recoveries := outboxEvents(event.RepositorySnapshotRecovered)
+	require.Len(t, recoveries, 2)
+	assert.Contains(t, string(recoveries[0].Payload), `"failed_snapshot_count":3`)
+
+	// Resetting a repository that was not failing does not notify
+	err = daoReg.RepositoryConfig.InternalOnly_ResetFailedSnapshotCount(context.Background(), rConfig.UUID)
+	require.NoError(t, err)
+	assert.Len(t, outboxEvents(event.RepositorySnapshotRecovered), 2)
+}
+
 func (suite *RepositoryConfigSuite) TestFetchRepoConfigsForTemplate() {
 	t := suite.T()
 	tx := suite.tx
@@ -1069,3 +1069,80 @@ func (r *rpmDaoImpl) FetchTemplateErrataIDs(ctx context.Context, orgID string, t
 	slices.Sort(errataIDs)
 	return errataIDs, nil
 }
+
+// FetchTemplateAvailableErrataCounts counts the errata in the latest snapshots of the template repositories that are
+// missing from the snapshots the template uses
+func (r *rpmDaoImpl) FetchTemplateAvailableErrataCounts(ctx context.Context, orgID string, templateUUID string) (api.ErrataCounts, error) {
+	counts := api.ErrataCounts{}
+	snapshots, err := r.fetchSnapshotsForTemplate(ctx, orgID, templateUUID)
+	if err != nil {
+		return counts, err
+	}
+
+	templateHrefs := []string{}
+	latestHrefs := []string{}
+	for _, snapshot := range snapshots {
+		templateHrefs = append(templateHrefs, snapshot.VersionHref)
+		latest, err := GetSnapshotDao(r.db).FetchLatestSnapshotModel(ctx, snapshot.RepositoryConfigurationUUID)
+		if err != nil {
+			return counts, fmt.Errorf("could not fetch latest snapshot: %w", err)
+		}
+		if latest.UUID != snapshot.UUID {
+			latestHrefs = append(latestHrefs, latest.VersionHref)
+		}
+	}
+	if len(latestHrefs) == 0 {
+		return counts, nil
+	}
+
+	included, err := r.listErrataTypes(ctx, templateHrefs)
+	if err != nil {
+		return counts, err
+	}
+	available, err := r.listErrataTypes(ctx, latestHrefs)
+	if err != nil {
+		return counts, err
+	}
+	for errataID, errataType := range available {
+		if _, ok := included[errataID]; ok {
+			continue
+		}
+		switch errataType {
+		case "security":
+			counts.Security++
+		case "bugfix":
+			counts.Bugfix++
+		case "enhancement":
+			counts.Enhancement++
+		}
+	}
+	return counts, nil
+}
+
+// listErrataTypes returns the type of each errata in the repository versions, by errata ID
+func (r *rpmDaoImpl) listErrataTypes(ctx context.Context, versionHrefs []string) (map[string]string, error) {
+	if config.Tang == nil {
+		return nil, fmt.Errorf("no tang configuration present")
+	}
+
+	limit := TemplateErrataIDsPageLimit
+	offset := 0
+	errataTypes := make(map[string]string)
+	for {
+		page, total, err := (*config.Tang).RpmRepositoryVersionErrataList(ctx, versionHrefs, tangy.ErrataListFilters{}, tangy.PageOptions{
+			Offset: offset,
+			Limit:  limit,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error querying errata in snapshots: %w", err)
+		}
+		for _, errata := range page {
+			errataTypes[errata.ErrataId] = errata.Type
+		}
+		offset += len(page)
+		if offset >= total || len(page) == 0 {
+			break
+		}
+	}
+	return errataTypes, nil
+}
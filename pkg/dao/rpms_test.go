@@ -6,6 +6,7 @@ import (
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/content-services/content-sources-backend/pkg/api"
 	"github.com/content-services/content-sources-backend/pkg/clients/roadmap_client"
@@ -1647,6 +1648,55 @@ func (s *RpmSuite) TestFetchTemplateErrataIDs() {
 	assert.True(s.T(), slices.IsSorted(ids))
 }
 
+func (s *RpmSuite) TestFetchTemplateAvailableErrataCounts() {
+	mTangy, origTangy := mockTangy(s.T())
+	defer func() { config.Tang = origTangy }()
+	ctx := context.Background()
+	dao := GetRpmDao(s.tx, s.mockRoadmapClient)
+	orgId := seeds.RandomOrgId()
+
+	repoConfigs, err := seeds.SeedRepositoryConfigurations(s.tx, 1, seeds.SeedOptions{OrgID: orgId})
+	require.NoError(s.T(), err)
+	repoConfig := repoConfigs[0]
+
+	snaps, err := seeds.SeedSnapshots(s.tx, repoConfig.UUID, 1)
+	require.NoError(s.T(), err)
+	res := s.tx.Model(&models.Snapshot{}).Where("uuid = ?", snaps[0].UUID).Update("version_href", "template_href")
+	require.NoError(s.T(), res.Error)
+	templates, err := seeds.SeedTemplates(s.tx, 1, seeds.TemplateSeedOptions{OrgID: orgId, RepositoryConfigUUIDs: []string{repoConfig.UUID}, Snapshots: snaps})
+	require.NoError(s.T(), err)
+	template := templates[0]
+
+	// No newer snapshot, nothing is available
+	counts, err := dao.FetchTemplateAvailableErrataCounts(ctx, orgId, template.UUID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), api.ErrataCounts{}, counts)
+
+	latest, err := seeds.SeedSnapshots(s.tx, repoConfig.UUID, 1)
+	require.NoError(s.T(), err)
+	res = s.tx.Model(&models.Snapshot{}).Where("uuid = ?", latest[0].UUID).
+		Updates(map[string]interface{}{"version_href": "latest_href", "created_at": time.Now().Add(time.Hour)})
+	require.NoError(s.T(), res.Error)
+
+	pageOpts := tangy.PageOptions{Offset: 0, Limit: TemplateErrataIDsPageLimit}
+	mTangy.On("RpmRepositoryVersionErrataList", ctx, []string{"template_href"}, tangy.ErrataListFilters{}, pageOpts).Return([]tangy.ErrataListItem{
+		{Id: "1", ErrataId: "RHSA-1", Type: "security"},
+		{Id: "2", ErrataId: "RHBA-1", Type: "bugfix"},
+	}, 2, nil)
+	mTangy.On("RpmRepositoryVersionErrataList", ctx, []string{"latest_href"}, tangy.ErrataListFilters{}, pageOpts).Return([]tangy.ErrataListItem{
+		{Id: "1", ErrataId: "RHSA-1", Type: "security"},
+		{Id: "2", ErrataId: "RHBA-1", Type: "bugfix"},
+		{Id: "3", ErrataId: "RHSA-2", Type: "security"},
+		{Id: "4", ErrataId: "RHSA-3", Type: "security"},
+		{Id: "5", ErrataId: "RHBA-2", Type: "bugfix"},
+		{Id: "6", ErrataId: "RHEA-1", Type: "enhancement"},
+	}, 6, nil)
+
+	counts, err = dao.FetchTemplateAvailableErrataCounts(ctx, orgId, template.UUID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), api.ErrataCounts{Security: 2, Bugfix: 1, Enhancement: 1}, counts)
+}
+
 func (s *RpmSuite) TestReadableRepositoryQueryPartnerVisibility() {
 	t := s.T()
 	ownerOrg := seeds.RandomOrgId()
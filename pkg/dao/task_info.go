@@ -35,6 +35,29 @@ const JoinSelectQuery = ` t.id,
        ARRAY (SELECT td.task_id FROM task_dependencies td WHERE td.dependency_id = t.id) as t_dependents
 `
 
+// maxTaskGraphDepth limits how many levels of dependencies FetchGraph follows
+const maxTaskGraphDepth = 10
+
+// upstreamTasksQuery selects the ids of the tasks a task depends on, directly or through other tasks
+const upstreamTasksQuery = `
+	WITH RECURSIVE upstream(id, depth) AS (
+		SELECT dependency_id, 1 FROM task_dependencies WHERE task_id = ?
+		UNION
+		SELECT td.dependency_id, upstream.depth + 1 FROM task_dependencies td JOIN upstream ON td.task_id = upstream.id
+		WHERE upstream.depth < ?
+	)
+	SELECT DISTINCT id FROM upstream`
+
+// downstreamTasksQuery selects the ids of the tasks that depend on a task, directly or through other tasks
+const downstreamTasksQuery = `
+	WITH RECURSIVE downstream(id, depth) AS (
+		SELECT task_id, 1 FROM task_dependencies WHERE dependency_id = ?
+		UNION
+		SELECT td.task_id, downstream.depth + 1 FROM task_dependencies td JOIN downstream ON td.dependency_id = downstream.id
+		WHERE downstream.depth < ?
+	)
+	SELECT DISTINCT id FROM downstream`
+
 type taskInfoDaoImpl struct {
 	db *gorm.DB
 }
@@ -72,7 +95,6 @@ func (t taskInfoDaoImpl) List(
 ) (api.TaskInfoCollectionResponse, int64, error) {
 	var totalTasks int64
 
-	var taskInfo models.TaskInfo
 	tasks := make([]models.TaskInfoRepositoryConfiguration, 0)
 
 	var orgsForQuery []string
@@ -82,11 +104,7 @@ func (t taskInfoDaoImpl) List(
 		orgsForQuery = []string{config.RedHatOrg, orgID, config.CommunityOrg}
 	}
 
-	filteredDB := t.db.WithContext(ctx).Table(taskInfo.TableName()+" AS t ").
-		Select(JoinSelectQuery).
-		Joins("LEFT JOIN repository_configurations rc on t.object_uuid = rc.repository_uuid AND t.object_type = ? AND rc.org_id in (?)", config.ObjectTypeRepository, []string{config.RedHatOrg, orgID, config.CommunityOrg}).
-		Joins("LEFT JOIN templates on t.object_uuid = templates.uuid AND t.object_type = ? AND templates.org_id = ?", config.ObjectTypeTemplate, orgID).
-		Where("t.org_id in (?) AND rc.deleted_at is NULL", orgsForQuery)
+	filteredDB := t.tasksWithObjects(ctx, orgID, orgsForQuery)
 
 	if filterData.Status != "" {
 		filteredDB = filteredDB.Where("t.status = ?", filterData.Status)
@@ -125,6 +143,55 @@ func (t taskInfoDaoImpl) List(
 	return api.TaskInfoCollectionResponse{Data: taskResponses}, totalTasks, nil
 }
 
+// tasksWithObjects returns a query of the tasks of orgsForQuery, joined with the repository or template each task is for
+func (t taskInfoDaoImpl) tasksWithObjects(ctx context.Context, orgID string, orgsForQuery []string) *gorm.DB {
+	var taskInfo models.TaskInfo
+	return t.db.WithContext(ctx).Table(taskInfo.TableName()+" AS t ").
+		Select(JoinSelectQuery).
+		Joins("LEFT JOIN repository_configurations rc on t.object_uuid = rc.repository_uuid AND t.object_type = ? AND rc.org_id in (?)", config.ObjectTypeRepository, []string{config.RedHatOrg, orgID, config.CommunityOrg}).
+		Joins("LEFT JOIN templates on t.object_uuid = templates.uuid AND t.object_type = ? AND templates.org_id = ?", config.ObjectTypeTemplate, orgID).
+		Where("t.org_id in (?) AND rc.deleted_at is NULL", orgsForQuery)
+}
+
+// FetchGraph returns a task along with all the tasks it transitively depends on, and all the tasks that transitively depend on it
+func (t taskInfoDaoImpl) FetchGraph(ctx context.Context, orgID string, id string) (api.TaskGraphResponse, error) {
+	task, err := t.Fetch(ctx, orgID, id)
+	if err != nil {
+		return api.TaskGraphResponse{}, err
+	}
+
+	upstream, err := t.relatedTasks(ctx, orgID, id, upstreamTasksQuery)
+	if err != nil {
+		return api.TaskGraphResponse{}, err
+	}
+	downstream, err := t.relatedTasks(ctx, orgID, id, downstreamTasksQuery)
+	if err != nil {
+		return api.TaskGraphResponse{}, err
+	}
+
+	return api.TaskGraphResponse{
+		Task:       task,
+		Upstream:   upstream,
+		Downstream: downstream,
+	}, nil
+}
+
+// relatedTasks returns the tasks whose ids are selected by relatedQuery for the task, oldest first
+func (t taskInfoDaoImpl) relatedTasks(ctx context.Context, orgID string, id string, relatedQuery string) ([]api.TaskInfoResponse, error) {
+	related := t.db.WithContext(ctx).Raw(relatedQuery, UuidifyString(id), maxTaskGraphDepth)
+
+	tasks := make([]models.TaskInfoRepositoryConfiguration, 0)
+	orgIDs := []string{config.RedHatOrg, orgID, config.CommunityOrg}
+	result := t.tasksWithObjects(ctx, orgID, orgIDs).
+		Where("t.id IN (?)", related).
+		Order("t.queued_at ASC").
+		Find(&tasks)
+	if result.Error != nil {
+		return nil, TasksDBToApiError(result.Error, nil)
+	}
+	return convertTaskInfoToResponses(tasks), nil
+}
+
 func (t taskInfoDaoImpl) Cleanup(ctx context.Context) error {
 	// Delete all completed or failed specified tasks that are older than 20 days
 	// Delete all the canceled tasks that are older than 20 days (by queued time)
@@ -209,6 +276,27 @@ func (t taskInfoDaoImpl) FetchActiveTasks(ctx context.Context, orgID string, obj
 	return uuids, nil
 }
 
+// FetchActiveTaskByDedupKey returns the UUID of a pending or running task enqueued with the given dedup key, or an empty string if there is none
+func (t taskInfoDaoImpl) FetchActiveTaskByDedupKey(ctx context.Context, orgID string, taskType string, dedupKey string) (string, error) {
+	taskInfo := make([]models.TaskInfo, 0)
+	result := t.db.WithContext(ctx).
+		Where("org_id = ?", orgID).
+		Where("type = ?", taskType).
+		Where("dedup_key = ?", dedupKey).
+		Where("status = ? or status = ?", config.TaskStatusPending, config.TaskStatusRunning).
+		Order("queued_at DESC").
+		Limit(1).
+		Find(&taskInfo)
+	if result.Error != nil {
+		return "", result.Error
+	}
+
+	if len(taskInfo) == 0 {
+		return "", nil
+	}
+	return taskInfo[0].Id.String(), nil
+}
+
 func taskInfoModelToApiFields(taskInfo *models.TaskInfoRepositoryConfiguration, apiTaskInfo *api.TaskInfoResponse) {
 	apiTaskInfo.UUID = taskInfo.Id.String()
 	apiTaskInfo.OrgId = taskInfo.OrgId
@@ -741,6 +741,53 @@ func (suite *TaskInfoSuite) TestFetchActiveTasks() {
 	assert.Empty(t, val)
 }
 
+func (suite *TaskInfoSuite) TestFetchActiveTaskByDedupKey() {
+	t := suite.T()
+	dao := GetTaskInfoDao(suite.tx)
+	orgID := seeds.RandomOrgId()
+	dedupKey := uuid.NewString()
+
+	completedTask := models.TaskInfo{
+		Typename: config.RepositorySnapshotTask,
+		Status:   config.TaskStatusCompleted,
+		Token:    uuid.New(),
+		Id:       uuid.New(),
+		OrgId:    orgID,
+		DedupKey: &dedupKey,
+	}
+	createErr := suite.tx.Create(&completedTask).Error
+	require.NoError(t, createErr)
+
+	// Finished tasks are not returned
+	val, err := dao.FetchActiveTaskByDedupKey(context.Background(), orgID, config.RepositorySnapshotTask, dedupKey)
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+
+	runningTask := models.TaskInfo{
+		Typename: config.RepositorySnapshotTask,
+		Status:   config.TaskStatusRunning,
+		Token:    uuid.New(),
+		Id:       uuid.New(),
+		OrgId:    orgID,
+		DedupKey: &dedupKey,
+	}
+	createErr = suite.tx.Create(&runningTask).Error
+	require.NoError(t, createErr)
+
+	val, err = dao.FetchActiveTaskByDedupKey(context.Background(), orgID, config.RepositorySnapshotTask, dedupKey)
+	assert.NoError(t, err)
+	assert.Equal(t, runningTask.Id.String(), val)
+
+	// Key is scoped to the task type and org
+	val, err = dao.FetchActiveTaskByDedupKey(context.Background(), orgID, config.IntrospectTask, dedupKey)
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+
+	val, err = dao.FetchActiveTaskByDedupKey(context.Background(), seeds.RandomOrgId(), config.RepositorySnapshotTask, dedupKey)
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+}
+
 func (suite *TaskInfoSuite) createTask() (models.TaskInfo, models.RepositoryConfiguration) {
 	return suite.createTaskForOrg(orgIDTest)
 }
@@ -880,3 +927,55 @@ func (suite *TaskInfoSuite) TestDeleteTask() {
 	assert.Equal(t, repos[0].UUID, rc.UUID)
 	assert.Empty(t, rc.LastSnapshotTaskUUID)
 }
+
+func (suite *TaskInfoSuite) TestFetchGraph() {
+	t := suite.T()
+	dao := GetTaskInfoDao(suite.tx)
+	orgID := seeds.RandomOrgId()
+
+	// snapshot -> update-latest-snapshot -> update-template-content, plus an unrelated task
+	createTask := func(typename string, status string, queued time.Time, dependencies ...uuid.UUID) models.TaskInfo {
+		task := suite.newTask()
+		task.OrgId = orgID
+		task.Typename = typename
+		task.Status = status
+		task.Queued = &queued
+		assert.NoError(t, suite.tx.Create(&task).Error)
+		for _, dependency := range dependencies {
+			assert.NoError(t, suite.tx.Exec("INSERT INTO task_dependencies VALUES (?, ?)", task.Id, dependency).Error)
+		}
+		return task
+	}
+	now := time.Now()
+	snapshot := createTask(config.RepositorySnapshotTask, config.TaskStatusRunning, now)
+	latest := createTask(config.UpdateLatestSnapshotTask, config.TaskStatusPending, now.Add(time.Second), snapshot.Id)
+	templateUpdate := createTask(config.UpdateTemplateContentTask, config.TaskStatusPending, now.Add(time.Second*2), latest.Id)
+	createTask(config.IntrospectTask, config.TaskStatusCompleted, now)
+
+	graph, err := dao.FetchGraph(context.Background(), orgID, templateUpdate.Id.String())
+	assert.NoError(t, err)
+	assert.Equal(t, templateUpdate.Id.String(), graph.Task.UUID)
+	assert.Empty(t, graph.Downstream)
+	if assert.Len(t, graph.Upstream, 2) {
+		// oldest first
+		assert.Equal(t, snapshot.Id.String(), graph.Upstream[0].UUID)
+		assert.Equal(t, config.TaskStatusRunning, graph.Upstream[0].Status)
+		assert.Equal(t, latest.Id.String(), graph.Upstream[1].UUID)
+		assert.Equal(t, []string{snapshot.Id.String()}, graph.Upstream[1].Dependencies)
+	}
+
+	graph, err = dao.FetchGraph(context.Background(), orgID, snapshot.Id.String())
+	assert.NoError(t, err)
+	assert.Empty(t, graph.Upstream)
+	if assert.Len(t, graph.Downstream, 2) {
+		assert.Equal(t, latest.Id.String(), graph.Downstream[0].UUID)
+		assert.Equal(t, templateUpdate.Id.String(), graph.Downstream[1].UUID)
+	}
+
+	// Tasks of other orgs are not found
+	_, err = dao.FetchGraph(context.Background(), seeds.RandomOrgId(), snapshot.Id.String())
+	assert.Error(t, err)
+	daoError, ok := err.(*ce.DaoError)
+	assert.True(t, ok)
+	assert.True(t, daoError.NotFound)
+}
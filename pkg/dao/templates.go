@@ -18,6 +18,7 @@ import (
 	ce "github.com/content-services/content-sources-backend/pkg/errors"
 	"github.com/content-services/content-sources-backend/pkg/event"
 	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/content-services/content-sources-backend/pkg/rbac"
 	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -90,6 +91,9 @@ func (t templateDaoImpl) Create(ctx context.Context, reqTemplate api.TemplateReq
 		resp, err = t.create(ctx, tx, reqTemplate)
 		return err
 	})
+	if err == nil {
+		reportObject(ctx, rbac.ResourceTemplates, resp.OrgID, resp.UUID, reqTemplate.WorkspaceID)
+	}
 	return resp, err
 }
 
@@ -124,7 +128,10 @@ func (t templateDaoImpl) create(ctx context.Context, tx *gorm.DB, reqTemplate ap
 	templatesModelToApi(modelTemplate, &respTemplate)
 	respTemplate.RepositoryUUIDS = reqTemplate.RepositoryUUIDS
 
-	event.SendTemplateEvent(*reqTemplate.OrgID, event.TemplateCreated, []event.TemplateEvent{event.MapTemplateResponse(respTemplate)})
+	err = queueTemplateEvent(tx, *reqTemplate.OrgID, event.TemplateCreated, []event.TemplateEvent{event.MapTemplateResponse(respTemplate)})
+	if err != nil {
+		return api.TemplateResponse{}, err
+	}
 
 	return respTemplate, nil
 }
@@ -261,19 +268,20 @@ func (t templateDaoImpl) Update(ctx context.Context, orgID string, uuid string,
 	var err error
 
 	err = t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		return t.update(ctx, tx, orgID, uuid, templParams)
-	})
-
-	if err != nil {
-		return resp, err
-	}
-
-	resp, err = t.Fetch(ctx, orgID, uuid, false)
-	if err != nil {
-		return resp, err
-	}
+		err := t.update(ctx, tx, orgID, uuid, templParams)
+		if err != nil {
+			return err
+		}
 
-	event.SendTemplateEvent(orgID, event.TemplateUpdated, []event.TemplateEvent{event.MapTemplateResponse(resp)})
+		// Fetch the updated template within the transaction, so its updated event is committed along with it
+		txDao := t
+		txDao.db = tx
+		resp, err = txDao.Fetch(ctx, orgID, uuid, false)
+		if err != nil {
+			return err
+		}
+		return queueTemplateEvent(tx, orgID, event.TemplateUpdated, []event.TemplateEvent{event.MapTemplateResponse(resp)})
+	})
 
 	return resp, err
 }
@@ -444,6 +452,9 @@ func (t templateDaoImpl) filteredDbForList(orgID string, filteredDB *gorm.DB, fi
 	if filterData.UseLatest {
 		filteredDB = filteredDB.Where("use_latest = ?", filterData.UseLatest)
 	}
+	if filterData.AllowedUUIDs != nil {
+		filteredDB = filteredDB.Where("templates.uuid IN ?", UuidifyStrings(filterData.AllowedUUIDs))
+	}
 	return filteredDB
 }
 
@@ -455,15 +466,15 @@ func (t templateDaoImpl) SoftDelete(ctx context.Context, orgID string, uuid stri
 		return TemplateDBToApiError(err, &uuid)
 	}
 
-	if err = t.db.WithContext(ctx).Delete(&modelTemplate).Error; err != nil {
-		return err
-	}
-
-	var resp api.TemplateResponse
-	templatesModelToApi(modelTemplate, &resp)
-	event.SendTemplateEvent(orgID, event.TemplateDeleted, []event.TemplateEvent{event.MapTemplateResponse(resp)})
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err = tx.Delete(&modelTemplate).Error; err != nil {
+			return err
+		}
 
-	return nil
+		var resp api.TemplateResponse
+		templatesModelToApi(modelTemplate, &resp)
+		return queueTemplateEvent(tx, orgID, event.TemplateDeleted, []event.TemplateEvent{event.MapTemplateResponse(resp)})
+	})
 }
 
 func (t templateDaoImpl) Delete(ctx context.Context, orgID string, uuid string) error {
@@ -477,6 +488,7 @@ func (t templateDaoImpl) Delete(ctx context.Context, orgID string, uuid string)
 	if err = t.db.WithContext(ctx).Unscoped().Delete(&modelTemplate).Error; err != nil {
 		return err
 	}
+	deleteObject(ctx, rbac.ResourceTemplates, modelTemplate.UUID)
 
 	return nil
 }
@@ -614,6 +626,24 @@ func (t templateDaoImpl) UpdateLastError(ctx context.Context, orgID string, temp
 	return nil
 }
 
+// UpdateAvailableErrataCounts stores the errata available to the template in the latest snapshots of its repositories
+func (t templateDaoImpl) UpdateAvailableErrataCounts(ctx context.Context, orgID string, templateUUID string, counts api.ErrataCounts) error {
+	result := t.db.WithContext(ctx).Model(&models.Template{}).
+		Where("org_id = ? AND uuid = ?", orgID, UuidifyString(templateUUID)).
+		UpdateColumns(map[string]interface{}{
+			"available_errata_counts": models.ErrataCounts{
+				Security:    counts.Security,
+				Bugfix:      counts.Bugfix,
+				Enhancement: counts.Enhancement,
+			},
+			"available_errata_counted_at": time.Now(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("could not update available errata counts: %w", result.Error)
+	}
+	return nil
+}
+
 func (t templateDaoImpl) UpdateSnapshots(ctx context.Context, templateUUID string, repoUUIDs []string, snapshots []models.Snapshot) error {
 	var templateRepoConfigs []models.TemplateRepositoryConfiguration
 	var missingRepoUUIDs []string
@@ -794,6 +824,7 @@ func templatesCreateApiToModel(api api.TemplateRequest, model *models.Template)
 	if api.OrgID != nil {
 		model.OrgID = *api.OrgID
 	}
+	model.WorkspaceID = api.WorkspaceID
 	if api.User != nil {
 		model.CreatedBy = *api.User
 		model.LastUpdatedBy = *api.User
@@ -855,6 +886,14 @@ func templatesModelToApi(model models.Template, apiTemplate *api.TemplateRespons
 		apiTemplate.LastUpdateSnapshotError = *model.LastUpdateSnapshotError
 	}
 	apiTemplate.LastUpdateTaskUUID = model.LastUpdateTaskUUID
+	if model.AvailableErrataCounts != nil && !model.UseLatest {
+		apiTemplate.AvailableErrataCounts = &api.ErrataCounts{
+			Security:    model.AvailableErrataCounts.Security,
+			Bugfix:      model.AvailableErrataCounts.Bugfix,
+			Enhancement: model.AvailableErrataCounts.Enhancement,
+		}
+		apiTemplate.AvailableErrataCountedAt = model.AvailableErrataCountedAt
+	}
 	if model.LastUpdateTask != nil {
 		apiTemplate.LastUpdateTask = &api.TaskInfoResponse{
 			UUID:       model.LastUpdateTaskUUID,
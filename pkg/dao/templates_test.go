@@ -14,10 +14,12 @@ import (
 	"github.com/content-services/content-sources-backend/pkg/config"
 	ce "github.com/content-services/content-sources-backend/pkg/errors"
 	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/content-services/content-sources-backend/pkg/rbac"
 	"github.com/content-services/content-sources-backend/pkg/seeds"
 	"github.com/content-services/content-sources-backend/pkg/utils"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
@@ -233,6 +235,39 @@ func (s *TemplateSuite) TestCreate() {
 	assert.Equal(s.T(), *reqTemplate.UseLatest, respTemplate.UseLatest)
 }
 
+func (s *TemplateSuite) TestCreateAndDeleteReportObject() {
+	templateDao := s.templateDao()
+	orgID := orgIDTest
+	workspaceID := "sub-workspace"
+
+	reporter := rbac.NewMockObjectClientWrapper(s.T())
+	rbac.SetObjectReporter(reporter)
+	defer rbac.SetObjectReporter(nil)
+
+	reqTemplate := api.TemplateRequest{
+		Name:            utils.Ptr("workspace template"),
+		RepositoryUUIDS: []string{},
+		Arch:            utils.Ptr(config.AARCH64),
+		Version:         utils.Ptr(config.El8),
+		OrgID:           &orgID,
+		UseLatest:       utils.Ptr(true),
+		WorkspaceID:     &workspaceID,
+	}
+	reporter.On("ReportObject", mock.Anything, rbac.ResourceTemplates, orgID, mock.AnythingOfType("string"), workspaceID).Return(nil).Once()
+
+	respTemplate, err := templateDao.Create(context.Background(), reqTemplate)
+	require.NoError(s.T(), err)
+	reporter.AssertCalled(s.T(), "ReportObject", mock.Anything, rbac.ResourceTemplates, orgID, respTemplate.UUID, workspaceID)
+
+	var template models.Template
+	require.NoError(s.T(), s.tx.First(&template, "uuid = ?", respTemplate.UUID).Error)
+	require.NotNil(s.T(), template.WorkspaceID)
+	assert.Equal(s.T(), workspaceID, *template.WorkspaceID)
+
+	reporter.On("DeleteObject", mock.Anything, rbac.ResourceTemplates, respTemplate.UUID).Return(nil).Once()
+	require.NoError(s.T(), templateDao.Delete(context.Background(), orgID, respTemplate.UUID))
+}
+
 func (s *TemplateSuite) TestCreateDeleteCreateSameName() {
 	templateDao := s.templateDao()
 
@@ -426,6 +461,22 @@ func (s *TemplateSuite) TestListNoTemplates() {
 	assert.Len(s.T(), responses.Data, 0)
 }
 
+func (s *TemplateSuite) TestListFilterAllowedUUIDs() {
+	templateDao := s.templateDao()
+
+	templates, err := seeds.SeedTemplates(s.tx, 3, seeds.TemplateSeedOptions{OrgID: orgIDTest})
+	assert.NoError(s.T(), err)
+
+	responses, total, err := templateDao.List(context.Background(), orgIDTest, false, api.PaginationData{Limit: -1}, api.TemplateFilterData{AllowedUUIDs: []string{templates[2].UUID}})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(1), total)
+	assert.Equal(s.T(), templates[2].UUID, responses.Data[0].UUID)
+
+	_, total, err = templateDao.List(context.Background(), orgIDTest, false, api.PaginationData{Limit: -1}, api.TemplateFilterData{AllowedUUIDs: []string{}})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(0), total)
+}
+
 func (s *TemplateSuite) TestListPageLimit() {
 	templateDao := s.templateDao()
 	var err error
@@ -1161,6 +1212,26 @@ func (s *TemplateSuite) TestUpdateLastError() {
 	assert.Equal(s.T(), lastUpdateSnapshotError, *found.LastUpdateSnapshotError)
 }
 
+func (s *TemplateSuite) TestUpdateAvailableErrataCounts() {
+	template, _ := s.seedWithRepoConfig(orgIDTest, 1, false)
+
+	templateDao := s.templateDao()
+	fetched, err := templateDao.Fetch(context.Background(), orgIDTest, template.UUID, false)
+	require.NoError(s.T(), err)
+	assert.Nil(s.T(), fetched.AvailableErrataCounts)
+
+	counts := api.ErrataCounts{Security: 3, Bugfix: 2, Enhancement: 1}
+	err = templateDao.UpdateAvailableErrataCounts(context.Background(), orgIDTest, template.UUID, counts)
+	require.NoError(s.T(), err)
+
+	fetched, err = templateDao.Fetch(context.Background(), orgIDTest, template.UUID, false)
+	require.NoError(s.T(), err)
+	require.NotNil(s.T(), fetched.AvailableErrataCounts)
+	assert.Equal(s.T(), counts, *fetched.AvailableErrataCounts)
+	require.NotNil(s.T(), fetched.AvailableErrataCountedAt)
+	assert.WithinDuration(s.T(), time.Now(), *fetched.AvailableErrataCountedAt, time.Minute)
+}
+
 func (s *TemplateSuite) TestSetEnvironmentCreated() {
 	template, _ := s.seedWithRepoConfig(orgIDTest, 1, false)
 	assert.False(s.T(), template.RHSMEnvironmentCreated)
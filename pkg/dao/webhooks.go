@@ -0,0 +1,390 @@
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/config"
+	ce "github.com/content-services/content-sources-backend/pkg/errors"
+	"github.com/content-services/content-sources-backend/pkg/event"
+	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/content-services/content-sources-backend/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// webhookDeliveryLease is how long a claimed delivery is hidden from other deliverers while it is being sent
+const webhookDeliveryLease = time.Minute * 5
+
+// claimWebhookDeliveriesQuery pushes back the next attempt of due deliveries, so only one deliverer sends each of them
+const claimWebhookDeliveriesQuery = `
+	UPDATE webhook_deliveries SET next_attempt_at = ?
+	WHERE uuid IN (
+		SELECT uuid FROM webhook_deliveries
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY next_attempt_at ASC
+		LIMIT ?
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING uuid`
+
+type webhookDaoImpl struct {
+	db *gorm.DB
+}
+
+func GetWebhookDao(db *gorm.DB) WebhookDao {
+	return webhookDaoImpl{db: db}
+}
+
+func WebhookDBToApiError(e error, uuid *string) *ce.DaoError {
+	if e == nil {
+		return nil
+	}
+
+	pgError, ok := e.(*pgconn.PgError)
+	if ok && pgError.Code == "23505" {
+		return &ce.DaoError{AlreadyExists: true, Message: "Webhook with this name already belongs to organization"}
+	}
+	dbError, ok := e.(models.Error)
+	if ok {
+		daoError := ce.DaoError{BadValidation: dbError.Validation, Message: dbError.Message}
+		daoError.Wrap(e)
+		return &daoError
+	}
+	daoError := ce.DaoError{Message: e.Error()}
+	if errors.Is(e, gorm.ErrRecordNotFound) {
+		daoError.NotFound = true
+		daoError.Message = "Webhook not found"
+		if uuid != nil {
+			daoError.Message = fmt.Sprintf("Webhook with UUID %s not found", *uuid)
+		}
+	}
+	daoError.Wrap(e)
+	return &daoError
+}
+
+func validateWebhookEventTypes(eventTypes []string) error {
+	valid := event.WebhookEventTypes()
+	for _, eventType := range eventTypes {
+		if !slices.Contains(valid, eventType) {
+			return &ce.DaoError{BadValidation: true, Message: fmt.Sprintf("Invalid event type: %s", eventType)}
+		}
+	}
+	return nil
+}
+
+func (d webhookDaoImpl) Create(ctx context.Context, req api.WebhookRequest) (api.WebhookResponse, error) {
+	if err := validateWebhookEventTypes(req.EventTypes); err != nil {
+		return api.WebhookResponse{}, err
+	}
+
+	webhook := models.Webhook{Enabled: true}
+	webhooksCreateApiToModel(req, &webhook)
+	if err := d.db.WithContext(ctx).Create(&webhook).Error; err != nil {
+		return api.WebhookResponse{}, WebhookDBToApiError(err, nil)
+	}
+	return webhookModelToApi(webhook), nil
+}
+
+func (d webhookDaoImpl) fetch(ctx context.Context, orgID string, uuid string) (models.Webhook, error) {
+	var webhook models.Webhook
+	err := d.db.WithContext(ctx).
+		Where("org_id = ? AND uuid = ?", orgID, UuidifyString(uuid)).
+		First(&webhook).Error
+	if err != nil {
+		return webhook, WebhookDBToApiError(err, &uuid)
+	}
+	return webhook, nil
+}
+
+func (d webhookDaoImpl) Fetch(ctx context.Context, orgID string, uuid string) (api.WebhookResponse, error) {
+	webhook, err := d.fetch(ctx, orgID, uuid)
+	if err != nil {
+		return api.WebhookResponse{}, err
+	}
+	return webhookModelToApi(webhook), nil
+}
+
+func (d webhookDaoImpl) List(ctx context.Context, orgID string, pageData api.PaginationData) (api.WebhookCollectionResponse, int64, error) {
+	var total int64
+	webhooks := make([]models.Webhook, 0)
+
+	filteredDB := d.db.WithContext(ctx).Model(&models.Webhook{}).Where("org_id = ?", orgID)
+	if err := filteredDB.Count(&total).Error; err != nil {
+		return api.WebhookCollectionResponse{}, 0, WebhookDBToApiError(err, nil)
+	}
+
+	sortMap := map[string]string{
+		"name":       "name",
+		"url":        "url",
+		"created_at": "created_at",
+	}
+	order := convertSortByToSQL(pageData.SortBy, sortMap, "name asc")
+	if err := filteredDB.Order(order).Offset(pageData.Offset).Limit(pageData.Limit).Find(&webhooks).Error; err != nil {
+		return api.WebhookCollectionResponse{}, 0, WebhookDBToApiError(err, nil)
+	}
+
+	data := make([]api.WebhookResponse, len(webhooks))
+	for i, webhook := range webhooks {
+		data[i] = webhookModelToApi(webhook)
+	}
+	return api.WebhookCollectionResponse{Data: data}, total, nil
+}
+
+func (d webhookDaoImpl) Update(ctx context.Context, orgID string, uuid string, req api.WebhookUpdateRequest) (api.WebhookResponse, error) {
+	webhook, err := d.fetch(ctx, orgID, uuid)
+	if err != nil {
+		return api.WebhookResponse{}, err
+	}
+
+	if req.Name != nil {
+		webhook.Name = *req.Name
+	}
+	if req.URL != nil {
+		webhook.URL = *req.URL
+	}
+	if req.Secret != nil {
+		webhook.Secret = *req.Secret
+	}
+	if req.EventTypes != nil {
+		if err := validateWebhookEventTypes(req.EventTypes); err != nil {
+			return api.WebhookResponse{}, err
+		}
+		webhook.EventTypes = req.EventTypes
+	}
+	if req.Enabled != nil {
+		webhook.Enabled = *req.Enabled
+	}
+
+	if err := d.db.WithContext(ctx).Save(&webhook).Error; err != nil {
+		return api.WebhookResponse{}, WebhookDBToApiError(err, &uuid)
+	}
+	return webhookModelToApi(webhook), nil
+}
+
+func (d webhookDaoImpl) Delete(ctx context.Context, orgID string, uuid string) error {
+	result := d.db.WithContext(ctx).
+		Where("org_id = ? AND uuid = ?", orgID, UuidifyString(uuid)).
+		Delete(&models.Webhook{})
+	if result.Error != nil {
+		return WebhookDBToApiError(result.Error, &uuid)
+	}
+	if result.RowsAffected == 0 {
+		return WebhookDBToApiError(gorm.ErrRecordNotFound, &uuid)
+	}
+	return nil
+}
+
+func (d webhookDaoImpl) ListDeliveries(ctx context.Context, orgID string, webhookUUID string, pageData api.PaginationData) (api.WebhookDeliveryCollectionResponse, int64, error) {
+	if _, err := d.fetch(ctx, orgID, webhookUUID); err != nil {
+		return api.WebhookDeliveryCollectionResponse{}, 0, err
+	}
+
+	var total int64
+	deliveries := make([]models.WebhookDelivery, 0)
+	filteredDB := d.db.WithContext(ctx).Model(&models.WebhookDelivery{}).
+		Where("org_id = ? AND webhook_uuid = ?", orgID, UuidifyString(webhookUUID))
+	if err := filteredDB.Count(&total).Error; err != nil {
+		return api.WebhookDeliveryCollectionResponse{}, 0, WebhookDBToApiError(err, nil)
+	}
+	err := filteredDB.Order("created_at DESC").Offset(pageData.Offset).Limit(pageData.Limit).Find(&deliveries).Error
+	if err != nil {
+		return api.WebhookDeliveryCollectionResponse{}, 0, WebhookDBToApiError(err, nil)
+	}
+
+	data := make([]api.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		data[i] = webhookDeliveryModelToApi(delivery)
+	}
+	return api.WebhookDeliveryCollectionResponse{Data: data}, total, nil
+}
+
+// Dispatch records a pending delivery of the event for each enabled webhook of the org subscribed to eventType
+func (d webhookDaoImpl) Dispatch(ctx context.Context, orgID string, eventType string, data any) error {
+	var webhooks []models.Webhook
+	err := d.db.WithContext(ctx).
+		Where("org_id = ? AND enabled AND ? = ANY(event_types)", orgID, eventType).
+		Find(&webhooks).Error
+	if err != nil {
+		return fmt.Errorf("could not list webhooks: %w", err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	deliveries := make([]models.WebhookDelivery, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		delivery, err := newWebhookDelivery(webhook, eventType, data, now)
+		if err != nil {
+			return err
+		}
+		delivery.NextAttemptAt = &now
+		deliveries = append(deliveries, delivery)
+	}
+	if err := d.db.WithContext(ctx).Omit("Webhook").Create(&deliveries).Error; err != nil {
+		return fmt.Errorf("could not create webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// CreateTestDelivery records a test event for the webhook. It is not picked up by ClaimDueDeliveries, the caller sends it.
+func (d webhookDaoImpl) CreateTestDelivery(ctx context.Context, orgID string, webhookUUID string) (models.WebhookDelivery, error) {
+	webhook, err := d.fetch(ctx, orgID, webhookUUID)
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+
+	data := map[string]string{"message": "This is a test event sent by content sources."}
+	delivery, err := newWebhookDelivery(webhook, event.WebhookTestEvent, data, time.Now())
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	if err := d.db.WithContext(ctx).Omit("Webhook").Create(&delivery).Error; err != nil {
+		return models.WebhookDelivery{}, WebhookDBToApiError(err, nil)
+	}
+	return delivery, nil
+}
+
+func newWebhookDelivery(webhook models.Webhook, eventType string, data any, now time.Time) (models.WebhookDelivery, error) {
+	deliveryUUID := uuid.NewString()
+	payload, err := json.Marshal(api.WebhookPayload{
+		ID:        deliveryUUID,
+		EventType: eventType,
+		OrgID:     webhook.OrgID,
+		Timestamp: now.UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return models.WebhookDelivery{}, fmt.Errorf("could not marshal webhook payload: %w", err)
+	}
+	return models.WebhookDelivery{
+		Base:        models.Base{UUID: deliveryUUID},
+		WebhookUUID: webhook.UUID,
+		OrgID:       webhook.OrgID,
+		EventType:   eventType,
+		Payload:     payload,
+		Status:      models.WebhookDeliveryPending,
+		Webhook:     webhook,
+	}, nil
+}
+
+// ClaimDueDeliveries returns up to limit pending deliveries whose next attempt is due, along with their webhook
+func (d webhookDaoImpl) ClaimDueDeliveries(ctx context.Context, limit int) ([]models.WebhookDelivery, error) {
+	var uuids []string
+	now := time.Now()
+	err := d.db.WithContext(ctx).
+		Raw(claimWebhookDeliveriesQuery, now.Add(webhookDeliveryLease), models.WebhookDeliveryPending, now, limit).
+		Scan(&uuids).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not claim webhook deliveries: %w", err)
+	}
+	if len(uuids) == 0 {
+		return nil, nil
+	}
+
+	var deliveries []models.WebhookDelivery
+	err = d.db.WithContext(ctx).Preload("Webhook").
+		Where("uuid IN ?", uuids).
+		Order("created_at ASC").
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// RecordDeliveryAttempt saves the outcome of an attempt to send a delivery. Failed deliveries are retried with an
+// exponential backoff until the configured maximum attempts, except test deliveries which are only sent once.
+func (d webhookDaoImpl) RecordDeliveryAttempt(ctx context.Context, delivery models.WebhookDelivery, responseCode *int, attemptErr error) (api.WebhookDeliveryResponse, error) {
+	settings := config.Get().Webhooks
+	now := time.Now()
+
+	delivery.Attempts++
+	delivery.ResponseCode = responseCode
+	delivery.Error = nil
+	delivery.NextAttemptAt = nil
+	switch {
+	case attemptErr == nil:
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.DeliveredAt = &now
+	case delivery.EventType == event.WebhookTestEvent || delivery.Attempts >= settings.MaxAttempts:
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.Error = utils.Ptr(attemptErr.Error())
+	default:
+		delivery.Status = models.WebhookDeliveryPending
+		delivery.Error = utils.Ptr(attemptErr.Error())
+		wait := settings.RetryWait * time.Duration(1<<min(delivery.Attempts-1, 16))
+		delivery.NextAttemptAt = utils.Ptr(now.Add(wait))
+	}
+
+	err := d.db.WithContext(ctx).Model(&delivery).Omit("Webhook").
+		Select("attempts", "response_code", "error", "status", "next_attempt_at", "delivered_at", "updated_at").
+		Updates(&delivery).Error
+	if err != nil {
+		return api.WebhookDeliveryResponse{}, fmt.Errorf("could not record webhook delivery attempt: %w", err)
+	}
+	return webhookDeliveryModelToApi(delivery), nil
+}
+
+func webhooksCreateApiToModel(api api.WebhookRequest, model *models.Webhook) {
+	if api.OrgID != nil {
+		model.OrgID = *api.OrgID
+	}
+	if api.Name != nil {
+		model.Name = *api.Name
+	}
+	if api.URL != nil {
+		model.URL = *api.URL
+	}
+	if api.Secret != nil {
+		model.Secret = *api.Secret
+	}
+	if api.EventTypes != nil {
+		model.EventTypes = api.EventTypes
+	}
+	if api.Enabled != nil {
+		model.Enabled = *api.Enabled
+	}
+	if api.User != nil {
+		model.CreatedBy = *api.User
+	}
+}
+
+func webhookModelToApi(webhook models.Webhook) api.WebhookResponse {
+	return api.WebhookResponse{
+		UUID:       webhook.UUID,
+		Name:       webhook.Name,
+		URL:        webhook.URL,
+		EventTypes: webhook.EventTypes,
+		Enabled:    webhook.Enabled,
+		CreatedBy:  webhook.CreatedBy,
+		CreatedAt:  webhook.CreatedAt,
+		UpdatedAt:  webhook.UpdatedAt,
+	}
+}
+
+func webhookDeliveryModelToApi(delivery models.WebhookDelivery) api.WebhookDeliveryResponse {
+	response := api.WebhookDeliveryResponse{
+		UUID:          delivery.UUID,
+		WebhookUUID:   delivery.WebhookUUID,
+		EventType:     delivery.EventType,
+		Payload:       delivery.Payload,
+		Status:        delivery.Status,
+		Attempts:      delivery.Attempts,
+		ResponseCode:  delivery.ResponseCode,
+		NextAttemptAt: delivery.NextAttemptAt,
+		DeliveredAt:   delivery.DeliveredAt,
+		CreatedAt:     delivery.CreatedAt,
+	}
+	if delivery.Error != nil {
+		response.Error = *delivery.Error
+	}
+	return response
+}
@@ -0,0 +1,239 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/config"
+	ce "github.com/content-services/content-sources-backend/pkg/errors"
+	"github.com/content-services/content-sources-backend/pkg/event"
+	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/content-services/content-sources-backend/pkg/seeds"
+	"github.com/content-services/content-sources-backend/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookSuite struct {
+	*DaoSuite
+}
+
+func TestWebhookSuite(t *testing.T) {
+	m := DaoSuite{}
+	r := WebhookSuite{DaoSuite: &m}
+	suite.Run(t, &r)
+}
+
+func (s *WebhookSuite) createWebhook(orgID string, name string, eventTypes ...string) api.WebhookResponse {
+	dao := webhookDaoImpl{db: s.tx}
+	webhook, err := dao.Create(context.Background(), api.WebhookRequest{
+		Name:       utils.Ptr(name),
+		URL:        utils.Ptr("https://example.com/" + name),
+		Secret:     utils.Ptr("a-very-secret-value"),
+		EventTypes: eventTypes,
+		OrgID:      utils.Ptr(orgID),
+		User:       utils.Ptr("user"),
+	})
+	require.NoError(s.T(), err)
+	return webhook
+}
+
+func (s *WebhookSuite) TestCreateFetchList() {
+	t := s.T()
+	dao := webhookDaoImpl{db: s.tx}
+	orgID := seeds.RandomOrgId()
+
+	created := s.createWebhook(orgID, "b-hook", event.TemplateUpdated.String())
+	assert.True(t, created.Enabled)
+	assert.Equal(t, "user", created.CreatedBy)
+	assert.Equal(t, []string{event.TemplateUpdated.String()}, created.EventTypes)
+	s.createWebhook(orgID, "a-hook", event.RepositoryCreated.String())
+	s.createWebhook(seeds.RandomOrgId(), "other-org", event.RepositoryCreated.String())
+
+	fetched, err := dao.Fetch(context.Background(), orgID, created.UUID)
+	require.NoError(t, err)
+	assert.Equal(t, created.UUID, fetched.UUID)
+
+	_, err = dao.Fetch(context.Background(), seeds.RandomOrgId(), created.UUID)
+	var daoError *ce.DaoError
+	require.ErrorAs(t, err, &daoError)
+	assert.True(t, daoError.NotFound)
+
+	list, total, err := dao.List(context.Background(), orgID, api.PaginationData{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, list.Data, 2)
+	assert.Equal(t, "a-hook", list.Data[0].Name)
+	assert.Equal(t, "b-hook", list.Data[1].Name)
+}
+
+func (s *WebhookSuite) TestCreateInvalid() {
+	t := s.T()
+	dao := webhookDaoImpl{db: s.tx}
+	orgID := seeds.RandomOrgId()
+	req := api.WebhookRequest{
+		Name:       utils.Ptr("hook"),
+		URL:        utils.Ptr("https://example.com/hook"),
+		Secret:     utils.Ptr("a-very-secret-value"),
+		EventTypes: []string{"not-an-event"},
+		OrgID:      utils.Ptr(orgID),
+	}
+	var daoError *ce.DaoError
+
+	_, err := dao.Create(context.Background(), req)
+	require.ErrorAs(t, err, &daoError)
+	assert.True(t, daoError.BadValidation)
+
+	req.EventTypes = []string{event.TemplateCreated.String()}
+	req.URL = utils.Ptr("ftp://example.com/hook")
+	_, err = dao.Create(context.Background(), req)
+	require.ErrorAs(t, err, &daoError)
+	assert.True(t, daoError.BadValidation)
+
+	req.URL = utils.Ptr("https://example.com/hook")
+	req.Secret = utils.Ptr("short")
+	_, err = dao.Create(context.Background(), req)
+	require.ErrorAs(t, err, &daoError)
+	assert.True(t, daoError.BadValidation)
+
+	req.Secret = utils.Ptr("a-very-secret-value")
+	_, err = dao.Create(context.Background(), req)
+	require.NoError(t, err)
+	_, err = dao.Create(context.Background(), req)
+	require.ErrorAs(t, err, &daoError)
+	assert.True(t, daoError.AlreadyExists)
+}
+
+func (s *WebhookSuite) TestUpdateDelete() {
+	t := s.T()
+	dao := webhookDaoImpl{db: s.tx}
+	orgID := seeds.RandomOrgId()
+	created := s.createWebhook(orgID, "hook", event.TemplateUpdated.String())
+
+	updated, err := dao.Update(context.Background(), orgID, created.UUID, api.WebhookUpdateRequest{
+		Enabled:    utils.Ptr(false),
+		EventTypes: []string{event.TemplateDeleted.String(), event.RepositoryDeleted.String()},
+	})
+	require.NoError(t, err)
+	assert.False(t, updated.Enabled)
+	assert.Equal(t, "hook", updated.Name)
+	assert.ElementsMatch(t, []string{event.TemplateDeleted.String(), event.RepositoryDeleted.String()}, updated.EventTypes)
+
+	_, err = dao.Update(context.Background(), orgID, created.UUID, api.WebhookUpdateRequest{URL: utils.Ptr("not a url")})
+	var daoError *ce.DaoError
+	require.ErrorAs(t, err, &daoError)
+	assert.True(t, daoError.BadValidation)
+
+	require.NoError(t, dao.Delete(context.Background(), orgID, created.UUID))
+	err = dao.Delete(context.Background(), orgID, created.UUID)
+	require.ErrorAs(t, err, &daoError)
+	assert.True(t, daoError.NotFound)
+}
+
+func (s *WebhookSuite) TestDispatch() {
+	t := s.T()
+	dao := webhookDaoImpl{db: s.tx}
+	orgID := seeds.RandomOrgId()
+	subscribed := s.createWebhook(orgID, "subscribed", event.TemplateUpdated.String(), event.TemplateDeleted.String())
+	notSubscribed := s.createWebhook(orgID, "not-subscribed", event.RepositoryCreated.String())
+	disabled := s.createWebhook(orgID, "disabled", event.TemplateUpdated.String())
+	_, err := dao.Update(context.Background(), orgID, disabled.UUID, api.WebhookUpdateRequest{Enabled: utils.Ptr(false)})
+	require.NoError(t, err)
+	s.createWebhook(seeds.RandomOrgId(), "other-org", event.TemplateUpdated.String())
+
+	err = dao.Dispatch(context.Background(), orgID, event.TemplateUpdated.String(), map[string]string{"name": "template"})
+	require.NoError(t, err)
+
+	deliveries, total, err := dao.ListDeliveries(context.Background(), orgID, subscribed.UUID, api.PaginationData{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, deliveries.Data, 1)
+	assert.Equal(t, models.WebhookDeliveryPending, deliveries.Data[0].Status)
+	assert.Contains(t, string(deliveries.Data[0].Payload), deliveries.Data[0].UUID)
+	assert.Contains(t, string(deliveries.Data[0].Payload), `"name":"template"`)
+
+	for _, webhook := range []api.WebhookResponse{notSubscribed, disabled} {
+		_, total, err = dao.ListDeliveries(context.Background(), orgID, webhook.UUID, api.PaginationData{Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), total)
+	}
+}
+
+func (s *WebhookSuite) TestClaimAndRecordAttempts() {
+	t := s.T()
+	dao := webhookDaoImpl{db: s.tx}
+	orgID := seeds.RandomOrgId()
+	webhook := s.createWebhook(orgID, "hook", event.TemplateUpdated.String())
+	require.NoError(t, dao.Dispatch(context.Background(), orgID, event.TemplateUpdated.String(), nil))
+
+	claimed, err := dao.ClaimDueDeliveries(context.Background(), 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, webhook.UUID, claimed[0].Webhook.UUID)
+	assert.Equal(t, "a-very-secret-value", claimed[0].Webhook.Secret)
+
+	// A claimed delivery is not returned again while it is being sent
+	again, err := dao.ClaimDueDeliveries(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+
+	maxAttempts := config.Get().Webhooks.MaxAttempts
+	delivery := claimed[0]
+	for attempt := 1; attempt < maxAttempts; attempt++ {
+		response, err := dao.RecordDeliveryAttempt(context.Background(), delivery, utils.Ptr(http.StatusBadGateway), errors.New("bad gateway"))
+		require.NoError(t, err)
+		assert.Equal(t, models.WebhookDeliveryPending, response.Status)
+		assert.Equal(t, attempt, response.Attempts)
+		assert.Equal(t, "bad gateway", response.Error)
+		require.NotNil(t, response.NextAttemptAt)
+		wait := config.Get().Webhooks.RetryWait * time.Duration(1<<(attempt-1))
+		assert.WithinDuration(t, time.Now().Add(wait), *response.NextAttemptAt, time.Minute)
+		delivery.Attempts = response.Attempts
+	}
+
+	response, err := dao.RecordDeliveryAttempt(context.Background(), delivery, nil, errors.New("connection refused"))
+	require.NoError(t, err)
+	assert.Equal(t, models.WebhookDeliveryFailed, response.Status)
+	assert.Equal(t, maxAttempts, response.Attempts)
+	assert.Nil(t, response.NextAttemptAt)
+
+	deliveries, _, err := dao.ListDeliveries(context.Background(), orgID, webhook.UUID, api.PaginationData{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, deliveries.Data, 1)
+	assert.Equal(t, models.WebhookDeliveryFailed, deliveries.Data[0].Status)
+	assert.Equal(t, "connection refused", deliveries.Data[0].Error)
+}
+
+func (s *WebhookSuite) TestTestDelivery() {
+	t := s.T()
+	dao := webhookDaoImpl{db: s.tx}
+	orgID := seeds.RandomOrgId()
+	webhook := s.createWebhook(orgID, "hook", event.TemplateUpdated.String())
+
+	delivery, err := dao.CreateTestDelivery(context.Background(), orgID, webhook.UUID)
+	require.NoError(t, err)
+	assert.Equal(t, event.WebhookTestEvent, delivery.EventType)
+	assert.Equal(t, webhook.URL, delivery.Webhook.URL)
+
+	// Test deliveries are sent by the caller, and are not retried
+	claimed, err := dao.ClaimDueDeliveries(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	response, err := dao.RecordDeliveryAttempt(context.Background(), delivery, utils.Ptr(http.StatusNotFound), errors.New("not found"))
+	require.NoError(t, err)
+	assert.Equal(t, models.WebhookDeliveryFailed, response.Status)
+	assert.Equal(t, 1, response.Attempts)
+
+	success, err := dao.CreateTestDelivery(context.Background(), orgID, webhook.UUID)
+	require.NoError(t, err)
+	response, err = dao.RecordDeliveryAttempt(context.Background(), success, utils.Ptr(http.StatusOK), nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.WebhookDeliverySucceeded, response.Status)
+	assert.NotNil(t, response.DeliveredAt)
+}
@@ -14,46 +14,62 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-// SendNotification sends a repository event to the notifications service using the Action format.
-func SendNotification(orgID string, eventName EventName, repos []RepositoryPayload) {
+// SendNotification sends a repository, snapshot or upload event to the notifications service using the Action format,
+// and to the subscribed webhooks. The event is recorded in the outbox when it is set, to be sent by the relay.
+func SendNotification[T Object](orgID string, eventName EventName, payloads []T) {
+	if queueEvent(orgID, eventName, []string{ChannelNotifications, ChannelWebhooks}, payloads) {
+		return
+	}
+
+	dispatchWebhookEvent(orgID, eventName, payloads)
+
+	events := make([]any, len(payloads))
+	for i, payload := range payloads {
+		events[i] = payload
+	}
+	if err := deliverNotification(orgID, eventName.String(), events); err != nil {
+		log.Error().Err(err).Msg("notification message failed to send")
+	}
+}
+
+func deliverNotification(orgID string, eventType string, payloads []any) error {
 	producer := config.Get().NotificationsProducer
-	if producer != nil && len(repos) > 0 {
-		events := make([]NotificationEvent, len(repos))
-		for i, repo := range repos {
-			events[i] = NotificationEvent{
-				Metadata: map[string]any{},
-				Payload:  repo,
-			}
+	if producer == nil || len(payloads) == 0 {
+		if config.Get().Options.EnableNotifications {
+			log.Warn().Msg("NotificationsProducer is nil")
 		}
+		return nil
+	}
 
-		action := NotificationAction{
-			Version:     NotificationVersion,
-			Bundle:      NotificationBundle,
-			Application: NotificationApplication,
-			EventType:   eventName.String(),
-			Timestamp:   time.Now().UTC().Format(time.RFC3339),
-			OrgID:       orgID,
-			Context:     map[string]any{},
-			Events:      events,
+	events := make([]NotificationEvent, len(payloads))
+	for i, payload := range payloads {
+		events[i] = NotificationEvent{
+			Metadata: map[string]any{},
+			Payload:  payload,
 		}
+	}
 
-		msgBytes, err := json.Marshal(action)
-		if err != nil {
-			log.Error().Err(err).Msg("failed to marshal notification action")
-			return
-		}
+	action := NotificationAction{
+		Version:     NotificationVersion,
+		Bundle:      NotificationBundle,
+		Application: NotificationApplication,
+		EventType:   eventType,
+		Timestamp:   time.Now().UTC().Format(time.RFC3339),
+		OrgID:       orgID,
+		Context:     map[string]any{},
+		Events:      events,
+	}
 
-		_, _, err = producer.Producer.SendMessage(&sarama.ProducerMessage{
-			Topic: producer.Topic,
-			Value: sarama.ByteEncoder(msgBytes),
-		})
-		if err != nil {
-			log.Error().Err(err).Msg("notification message failed to send")
-			return
-		}
-	} else if config.Get().Options.EnableNotifications {
-		log.Warn().Msg("NotificationsProducer is nil")
+	msgBytes, err := json.Marshal(action)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification action: %w", err)
 	}
+
+	_, _, err = producer.Producer.SendMessage(&sarama.ProducerMessage{
+		Topic: producer.Topic,
+		Value: sarama.ByteEncoder(msgBytes),
+	})
+	return err
 }
 
 func SendLightwellNotification(orgID, eventType, severity string, events []NotificationEvent) {
@@ -121,32 +137,42 @@ func SendTestNotification(orgID string, payload json.RawMessage) (json.RawMessag
 	return msgBytes, nil
 }
 
-// SendTemplateEvent - Sends an event about a template to the patch service
-func SendTemplateEvent(orgID string, eventName EventName, templates []TemplateEvent) {
-	if config.Get().TemplateEventClient != nil && len(templates) > 0 {
-		eventNameStr := eventName.String()
-		newUUID, _ := uuid.NewRandom()
-		e := cloudevents.NewEvent()
-		e.SetSource("urn:redhat:source:console:app:repositories")
-		e.SetID(newUUID.String())
-		e.SetType("com.redhat.console.repositories." + eventNameStr)
-		e.SetSubject("urn:redhat:subject:console:rhel:" + eventNameStr)
-		e.SetTime(time.Now())
-		e.SetExtension("redhatorgid", orgID)
-
-		data := templates
-		err := e.SetData(cloudevents.ApplicationJSON, data)
+// SendTemplateEvent - Sends an event about a template to the patch service and to the subscribed webhooks.
+// The event is recorded in the outbox when it is set, to be sent by the relay.
+func SendTemplateEvent[T Object](orgID string, eventName EventName, templates []T) {
+	if queueEvent(orgID, eventName, []string{ChannelTemplateEvents, ChannelWebhooks}, templates) {
+		return
+	}
 
-		if err != nil {
-			log.Error().Err(err).Msg("failed to create cloudevents client")
-			return
-		}
+	dispatchWebhookEvent(orgID, eventName, templates)
 
-		ctx := cloudevents.WithEncodingStructured(context.Background())
-		// Send the event
-		if result := config.Get().TemplateEventClient.Send(ctx, e); cloudevents.IsUndelivered(result) {
-			log.Error().Msgf("Notification message failed to send: %v", result)
-			return
-		}
+	if err := deliverTemplateEvent(context.Background(), orgID, eventName.String(), templates); err != nil {
+		log.Error().Err(err).Msg("template event failed to send")
+	}
+}
+
+func deliverTemplateEvent[T any](ctx context.Context, orgID string, eventType string, templates []T) error {
+	if config.Get().TemplateEventClient == nil || len(templates) == 0 {
+		return nil
+	}
+
+	newUUID, _ := uuid.NewRandom()
+	e := cloudevents.NewEvent()
+	e.SetSource("urn:redhat:source:console:app:repositories")
+	e.SetID(newUUID.String())
+	e.SetType("com.redhat.console.repositories." + eventType)
+	e.SetSubject("urn:redhat:subject:console:rhel:" + eventType)
+	e.SetTime(time.Now())
+	e.SetExtension("redhatorgid", orgID)
+
+	err := e.SetData(cloudevents.ApplicationJSON, templates)
+	if err != nil {
+		return fmt.Errorf("failed to set cloudevent data: %w", err)
+	}
+
+	// Send the event
+	if result := config.Get().TemplateEventClient.Send(cloudevents.WithEncodingStructured(ctx), e); cloudevents.IsUndelivered(result) {
+		return fmt.Errorf("template event failed to send: %w", result)
 	}
+	return nil
 }
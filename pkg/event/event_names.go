@@ -12,6 +12,15 @@ const (
 	TemplateCreated
 	TemplateUpdated
 	TemplateDeleted
+	SnapshotCreated
+	SnapshotFailed
+	SnapshotDeleted
+	UploadCompleted
+	TemplateContentUpdated
+	TemplateContentUpdateFailed
+	RepositorySnapshotFailure
+	RepositorySnapshotRecovered
+	TemplateErrataDigest
 )
 
 func (d EventName) String() string {
@@ -32,8 +41,52 @@ func (d EventName) String() string {
 		return "template-updated"
 	case TemplateDeleted:
 		return "template-deleted"
+	case SnapshotCreated:
+		return "snapshot-created"
+	case SnapshotFailed:
+		return "snapshot-failed"
+	case SnapshotDeleted:
+		return "snapshot-deleted"
+	case UploadCompleted:
+		return "upload-completed"
+	case TemplateContentUpdated:
+		return "template-content-updated"
+	case TemplateContentUpdateFailed:
+		return "template-content-update-failed"
+	case RepositorySnapshotFailure:
+		return "repository-snapshot-failure"
+	case RepositorySnapshotRecovered:
+		return "repository-snapshot-recovered"
+	case TemplateErrataDigest:
+		return "template-errata-digest"
 	// Add more cases here when expanding EventName enum above
 	default:
 		return ""
 	}
 }
+
+// WebhookTestEvent is the event type of the deliveries sent to test a webhook
+const WebhookTestEvent = "webhook-test"
+
+// WebhookEventTypes lists the event types webhooks can subscribe to
+func WebhookEventTypes() []string {
+	return []string{
+		RepositoryCreated.String(),
+		RepositoryIntrospected.String(),
+		RepositoryUpdated.String(),
+		RepositoryIntrospectionFailure.String(),
+		RepositoryDeleted.String(),
+		TemplateCreated.String(),
+		TemplateUpdated.String(),
+		TemplateDeleted.String(),
+		SnapshotCreated.String(),
+		SnapshotFailed.String(),
+		SnapshotDeleted.String(),
+		UploadCompleted.String(),
+		TemplateContentUpdated.String(),
+		TemplateContentUpdateFailed.String(),
+		RepositorySnapshotFailure.String(),
+		RepositorySnapshotRecovered.String(),
+		TemplateErrataDigest.String(),
+	}
+}
@@ -0,0 +1,19 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookEventTypes(t *testing.T) {
+	seen := map[string]bool{}
+	for _, eventType := range WebhookEventTypes() {
+		assert.NotEmpty(t, eventType)
+		assert.False(t, seen[eventType], "duplicate event type %s", eventType)
+		seen[eventType] = true
+	}
+	assert.True(t, seen[SnapshotFailed.String()])
+	assert.True(t, seen[TemplateContentUpdateFailed.String()])
+	assert.False(t, seen[WebhookTestEvent])
+}
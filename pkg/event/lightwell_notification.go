@@ -2,10 +2,10 @@ package event
 
 import (
 	"sort"
-	"strconv"
 	"strings"
 
 	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/lightwell/severity"
 )
 
 const (
@@ -15,20 +15,25 @@ const (
 )
 
 const (
-	LightwellEventTypeJavaRemediated = "java-remediated"
+	LightwellEventTypeJavaRemediated   = "java-remediated"
+	LightwellEventTypeCoverageImproved = "coverage-improved"
 )
 
 const (
-	SeverityCritical  = "critical"
-	SeverityImportant = "important"
-	SeverityModerate  = "moderate"
-	SeverityLow       = "low"
+	SeverityCritical  = severity.Critical
+	SeverityImportant = severity.Important
+	SeverityModerate  = severity.Moderate
+	SeverityLow       = severity.Low
 )
 
 func LightwellPackageLink(pkgName string) string {
 	return strings.TrimSuffix(config.Get().Options.ExternalURL, "/") + "/lightwell/packages/" + pkgName
 }
 
+func LightwellCoverageReportLink(reportUUID string) string {
+	return strings.TrimSuffix(config.Get().Options.ExternalURL, "/") + "/lightwell/coverage-reports/" + reportUUID
+}
+
 func LightwellCVEURL(advisoryID string) string {
 	return strings.TrimSuffix(config.Get().Options.ExternalURL, "/") + "/api/lightwell/cves/" + advisoryID + ".json"
 }
@@ -51,6 +56,17 @@ type LightwellNotificationInput struct {
 	ReferenceURLs []string
 }
 
+// LightwellCoverageImprovedPayload describes a scheduled re-evaluation of a coverage report that covers more packages
+type LightwellCoverageImprovedPayload struct {
+	ReportLink       string `json:"report_link"`
+	ReportUUID       string `json:"report_uuid"`
+	SourceReportUUID string `json:"source_report_uuid"`
+	AccountID        string `json:"account_id,omitempty"`
+	Total            int    `json:"total"`
+	Covered          int    `json:"covered"`
+	PreviousCovered  int    `json:"previous_covered"`
+}
+
 type LightwellPackagePayload struct {
 	PackageLink string                    `json:"package_link"`
 	PackageName string                    `json:"package_name"`
@@ -160,30 +176,14 @@ func buildVersionsKey(versions []string) string {
 
 // CVSSScoreToLabel converts a numeric CVSS score string to a severity label.
 func CVSSScoreToLabel(scoreStr string) string {
-	score, err := strconv.ParseFloat(scoreStr, 64)
-	if err != nil || score <= 0 {
-		return SeverityLow
-	}
-	switch {
-	case score >= 9.0:
-		return SeverityCritical
-	case score >= 7.0:
-		return SeverityImportant
-	case score >= 4.0:
-		return SeverityModerate
-	default:
-		return SeverityLow
-	}
+	return severity.Label(scoreStr)
 }
 
 // MaximumSeverity returns the highest severity label across a set of advisory inputs.
 func MaximumSeverity(advisories []LightwellNotificationInput) string {
-	var maxScore float64
-	for _, d := range advisories {
-		score, err := strconv.ParseFloat(d.Severity, 64)
-		if err == nil && score > maxScore {
-			maxScore = score
-		}
+	scores := make([]string, len(advisories))
+	for i, d := range advisories {
+		scores[i] = d.Severity
 	}
-	return CVSSScoreToLabel(strconv.FormatFloat(maxScore, 'f', 1, 64))
+	return severity.Highest(scores)
 }
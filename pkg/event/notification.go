@@ -1,6 +1,11 @@
 package event
 
-import "github.com/content-services/content-sources-backend/pkg/api"
+import (
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/models"
+)
 
 const (
 	NotificationVersion     = "v1.2.0"
@@ -67,3 +72,84 @@ func MapRepositoryPayload(r api.RepositoryResponse) RepositoryPayload {
 		LastUpdateIntrospectionTime:  r.LastIntrospectionUpdateTime,
 	}
 }
+
+// SnapshotFailuresPayload is sent when the consecutive failed snapshots of a repository reach the configured threshold,
+// and when a snapshot of the repository succeeds again
+type SnapshotFailuresPayload struct {
+	RepositoryPayload
+	FailedSnapshotCount int64               `json:"failed_snapshot_count"` // Consecutive failed snapshots, before the recovery for recovered events
+	Templates           []TemplateReference `json:"templates"`             // Templates using the repository
+}
+
+type TemplateReference struct {
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+}
+
+// TemplateErrataDigestPayload lists the templates of an organization that are missing errata available in the latest
+// snapshots of their repositories
+type TemplateErrataDigestPayload struct {
+	OrgID     string                    `json:"org_id"`
+	Templates []OutdatedTemplatePayload `json:"templates"`
+}
+
+type OutdatedTemplatePayload struct {
+	UUID                  string           `json:"uuid"`
+	Name                  string           `json:"name"`
+	Date                  string           `json:"date"`
+	AvailableErrataCounts api.ErrataCounts `json:"available_errata_counts"`
+}
+
+func MapOutdatedTemplatePayload(t api.TemplateResponse) OutdatedTemplatePayload {
+	payload := OutdatedTemplatePayload{
+		UUID: t.UUID,
+		Name: t.Name,
+		Date: t.Date.Format(time.RFC3339),
+	}
+	if t.AvailableErrataCounts != nil {
+		payload.AvailableErrataCounts = *t.AvailableErrataCounts
+	}
+	return payload
+}
+
+type SnapshotPayload struct {
+	UUID           string           `json:"uuid,omitempty"` // Empty when the snapshot failed
+	RepositoryUUID string           `json:"repository_uuid"`
+	RepositoryName string           `json:"repository_name"`
+	URL            string           `json:"url,omitempty"`
+	ContentCounts  map[string]int64 `json:"content_counts,omitempty"`
+	AddedCounts    map[string]int64 `json:"added_counts,omitempty"`
+	RemovedCounts  map[string]int64 `json:"removed_counts,omitempty"`
+	Error          string           `json:"error,omitempty"`
+}
+
+func MapSnapshotPayload(repo api.RepositoryResponse, snap models.Snapshot) SnapshotPayload {
+	return SnapshotPayload{
+		UUID:           snap.UUID,
+		RepositoryUUID: repo.UUID,
+		RepositoryName: repo.Name,
+		URL:            repo.URL,
+		ContentCounts:  snap.ContentCounts,
+		AddedCounts:    snap.AddedCounts,
+		RemovedCounts:  snap.RemovedCounts,
+	}
+}
+
+// MapSnapshotFailurePayload maps a failed snapshot of repo, along with the error it failed with
+func MapSnapshotFailurePayload(repo api.RepositoryResponse, err error) SnapshotPayload {
+	return SnapshotPayload{
+		RepositoryUUID: repo.UUID,
+		RepositoryName: repo.Name,
+		URL:            repo.URL,
+		Error:          err.Error(),
+	}
+}
+
+type UploadPayload struct {
+	RepositoryUUID string           `json:"repository_uuid"`
+	RepositoryName string           `json:"repository_name"`
+	SnapshotUUID   string           `json:"snapshot_uuid,omitempty"` // Empty when the uploads were already in the repository
+	UploadCount    int              `json:"upload_count"`            // Number of uploads and artifacts added
+	ContentCounts  map[string]int64 `json:"content_counts,omitempty"`
+	AddedCounts    map[string]int64 `json:"added_counts,omitempty"`
+}
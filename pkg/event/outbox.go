@@ -0,0 +1,110 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Channels events are delivered through
+const (
+	ChannelNotifications  = "notifications"   // Notifications service, through kafka
+	ChannelTemplateEvents = "template-events" // Template event consumers, through cloudevents
+	ChannelWebhooks       = "webhooks"        // Webhooks of the organization
+)
+
+// Object is the payload of an event about a single object. Events about the same object are delivered in order.
+type Object interface {
+	ObjectUUID() string
+}
+
+func (r RepositoryPayload) ObjectUUID() string {
+	return r.UUID
+}
+
+func (s SnapshotPayload) ObjectUUID() string {
+	return s.RepositoryUUID
+}
+
+func (u UploadPayload) ObjectUUID() string {
+	return u.RepositoryUUID
+}
+
+func (t TemplateEvent) ObjectUUID() string {
+	return t.UUID
+}
+
+// ObjectUUID of a digest is the organization it is sent to, as it is about all of its templates
+func (d TemplateErrataDigestPayload) ObjectUUID() string {
+	return d.OrgID
+}
+
+// Outbox records events, which are delivered to each channel by a relay once they are committed
+type Outbox interface {
+	Queue(ctx context.Context, orgID string, eventName EventName, channels []string, objects []Object) error
+}
+
+var outbox Outbox
+
+// SetOutbox sets the outbox events are recorded in. Until it is set, events are sent right away and lost if sending fails.
+func SetOutbox(o Outbox) {
+	outbox = o
+}
+
+func toObjects[T Object](payloads []T) []Object {
+	objects := make([]Object, len(payloads))
+	for i, payload := range payloads {
+		objects[i] = payload
+	}
+	return objects
+}
+
+// queueEvent records the event in the outbox, and returns false if it has to be sent right away instead
+func queueEvent[T Object](orgID string, eventName EventName, channels []string, payloads []T) bool {
+	if outbox == nil {
+		return false
+	}
+	if len(payloads) == 0 {
+		return true
+	}
+	err := outbox.Queue(context.Background(), orgID, eventName, channels, toObjects(payloads))
+	if err != nil {
+		log.Error().Err(err).Str("org_id", orgID).Str("event_type", eventName.String()).Msg("failed to queue event, sending it right away")
+		return false
+	}
+	return true
+}
+
+// Deliver sends the payload of an outbox event through its channel. The payload is a json array with an entry per object,
+// they are sent in a single message, except to webhooks which get one call per object.
+// Events for a channel that is not configured are dropped.
+func Deliver(ctx context.Context, channel string, orgID string, eventType string, payload json.RawMessage) error {
+	var objects []json.RawMessage
+	if err := json.Unmarshal(payload, &objects); err != nil {
+		return fmt.Errorf("could not unmarshal event payload: %w", err)
+	}
+	switch channel {
+	case ChannelNotifications:
+		events := make([]any, len(objects))
+		for i, object := range objects {
+			events[i] = object
+		}
+		return deliverNotification(orgID, eventType, events)
+	case ChannelTemplateEvents:
+		return deliverTemplateEvent(ctx, orgID, eventType, objects)
+	case ChannelWebhooks:
+		if webhookDispatcher == nil {
+			return nil
+		}
+		for _, object := range objects {
+			if err := webhookDispatcher.Dispatch(ctx, orgID, eventType, object); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown event channel: %s", channel)
+	}
+}
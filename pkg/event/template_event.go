@@ -31,3 +31,17 @@ func MapTemplateResponse(t api.TemplateResponse) TemplateEvent {
 		RHSMEnvironmentID: t.RHSMEnvironmentID,
 	}
 }
+
+// TemplateContentEvent is sent when the content of a template finished updating, or failed to
+type TemplateContentEvent struct {
+	TemplateEvent
+	Error string `json:"error,omitempty"` // Error the update failed with
+}
+
+func MapTemplateContentEvent(t api.TemplateResponse, err error) TemplateContentEvent {
+	e := TemplateContentEvent{TemplateEvent: MapTemplateResponse(t)}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	return e
+}
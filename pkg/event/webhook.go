@@ -0,0 +1,32 @@
+package event
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// WebhookDispatcher records an event for delivery to the webhooks of the organization subscribed to it
+type WebhookDispatcher interface {
+	Dispatch(ctx context.Context, orgID string, eventType string, data any) error
+}
+
+var webhookDispatcher WebhookDispatcher
+
+// SetWebhookDispatcher sets the dispatcher events are passed to, events are not sent to webhooks until it is set
+func SetWebhookDispatcher(dispatcher WebhookDispatcher) {
+	webhookDispatcher = dispatcher
+}
+
+// dispatchWebhookEvent records one delivery per object, so webhooks receive a separate event for each of them
+func dispatchWebhookEvent[T any](orgID string, eventName EventName, objects []T) {
+	if webhookDispatcher == nil {
+		return
+	}
+	for _, object := range objects {
+		err := webhookDispatcher.Dispatch(context.Background(), orgID, eventName.String(), object)
+		if err != nil {
+			log.Error().Err(err).Str("org_id", orgID).Str("event_type", eventName.String()).Msg("failed to dispatch webhook event")
+		}
+	}
+}
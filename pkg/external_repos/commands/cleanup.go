@@ -23,7 +23,7 @@ import (
 	"gorm.io/gorm"
 )
 
-var allTypes = []string{"repository", "task", "snapshot", "upload", "pulp-orphan"}
+var allTypes = []string{"repository", "task", "snapshot", "upload", "pulp-orphan", "audit", "outbox"}
 
 const maxSnapshotsPerDeleteTask = 100
 
@@ -68,6 +68,20 @@ func CleanupAction(c *cli.Context) error {
 				log.Err(err).Msg("error during task cleanup")
 			}
 
+		case "audit":
+			log.Info().Msg("=== Running audit event cleanup ===")
+			err = dao.GetAuditEventDao(db.DB).Cleanup(ctx)
+			if err != nil {
+				log.Err(err).Msg("error during audit event cleanup")
+			}
+
+		case "outbox":
+			log.Info().Msg("=== Running outbox event cleanup ===")
+			err = dao.GetOutboxDao(db.DB).Cleanup(ctx)
+			if err != nil {
+				log.Err(err).Msg("error during outbox event cleanup")
+			}
+
 		case "snapshot":
 			if config.Get().Features.Snapshots.Enabled {
 				log.Info().Msg("=== Running snapshot cleanup ===")
@@ -0,0 +1,108 @@
+package external_repos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/content-services/content-sources-backend/pkg/clients/pulp_client"
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/coverage/matcher"
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	"github.com/content-services/tang/pkg/tangy"
+)
+
+// coverageCatalogPageSize is the number of packages fetched from tang at a time when loading the catalog
+const coverageCatalogPageSize = 500
+
+// LoadCoverageCatalog lists the packages of the lightwell repositories, one per version, to match coverage reports against
+func LoadCoverageCatalog(ctx context.Context, registry *dao.DaoRegistry, pulpClient pulp_client.PulpClient, tang tangy.Tangy) ([]matcher.Package, error) {
+	repos, err := registry.RepositoryConfig.InternalOnly_FetchRepoConfigForOrg(ctx, config.LightwellOrg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch repoConfig: %w", err)
+	}
+	if len(repos) == 0 {
+		return []matcher.Package{}, nil
+	}
+
+	domainName, err := registry.Domain.Fetch(ctx, config.LightwellOrg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch domain: %w", err)
+	}
+	pulpClient = pulpClient.WithDomain(domainName)
+
+	catalog := []matcher.Package{}
+	for _, repo := range repos {
+		if repo.ContentType != config.ContentTypeMaven && repo.ContentType != config.ContentTypePython && repo.ContentType != config.ContentTypeNpm {
+			continue
+		}
+		repoHref, err := pulpClient.ResolveRepositoryFromBasePath(ctx, repo.PublishedDistBasePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve repo %s: %w", repo.Name, err)
+		}
+		if repoHref == nil {
+			return nil, fmt.Errorf("failed to resolve repo %s", repo.Name)
+		}
+
+		packages, err := coverageCatalogForType(ctx, tang, *repoHref, repo.ContentType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list packages of repo %s: %w", repo.Name, err)
+		}
+		catalog = append(catalog, packages...)
+	}
+	return catalog, nil
+}
+
+// coverageCatalogForType lists the packages of a repository based on its content type, one per version
+func coverageCatalogForType(ctx context.Context, tang tangy.Tangy, repoHref string, contentType string) ([]matcher.Package, error) {
+	var catalog []matcher.Package
+	for offset := 0; ; offset += coverageCatalogPageSize {
+		page := tangy.PageOptions{Offset: offset, Limit: coverageCatalogPageSize}
+		var count, total int
+		switch contentType {
+		case config.ContentTypeMaven:
+			resp, err := tang.MavenPackageList(ctx, repoHref, tangy.MavenPackageListFilters{}, page)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range resp.Results {
+				for _, version := range item.Versions {
+					catalog = append(catalog, matcher.Package{Ecosystem: matcher.EcosystemJava, Namespace: item.GroupID, Name: item.ArtifactID, Version: version})
+				}
+			}
+			count, total = len(resp.Results), resp.Total
+		case config.ContentTypePython:
+			resp, err := tang.PythonPackageList(ctx, repoHref, tangy.PythonPackageListFilters{}, page)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range resp.Results {
+				for _, version := range item.Versions {
+					catalog = append(catalog, matcher.Package{Ecosystem: matcher.EcosystemPython, Name: item.NameNormalized, Version: version})
+				}
+			}
+			count, total = len(resp.Results), resp.Total
+		case config.ContentTypeNpm:
+			resp, err := tang.NpmPackageList(ctx, repoHref, tangy.NpmPackageListFilters{}, page)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range resp.Results {
+				// Scoped packages are listed by their full name, such as @types/node
+				scope, name, found := strings.Cut(item.Name, "/")
+				if !found {
+					scope, name = "", item.Name
+				}
+				for _, version := range item.Versions {
+					catalog = append(catalog, matcher.Package{Ecosystem: matcher.EcosystemNpm, Namespace: scope, Name: name, Version: version})
+				}
+			}
+			count, total = len(resp.Results), resp.Total
+		default:
+			return nil, fmt.Errorf("unknown content type: %s", contentType)
+		}
+		if count == 0 || offset+count >= total {
+			return catalog, nil
+		}
+	}
+}
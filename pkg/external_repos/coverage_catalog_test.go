@@ -0,0 +1,90 @@
+package external_repos
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/clients/pulp_client"
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/coverage/matcher"
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	"github.com/content-services/tang/pkg/tangy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadCoverageCatalog(t *testing.T) {
+	ctx := context.Background()
+	mockDao := dao.GetMockDaoRegistry(t)
+	mockPulpClient := pulp_client.NewMockPulpClient(t)
+	mockTangy := tangy.NewMockTangy(t)
+	mavenHref, pythonHref, npmHref := "maven-href", "python-href", "npm-href"
+
+	mockDao.RepositoryConfig.On("InternalOnly_FetchRepoConfigForOrg", ctx, config.LightwellOrg).Return([]api.RepositoryResponse{
+		{Name: "java", PublishedDistBasePath: "java", ContentType: config.ContentTypeMaven},
+		{Name: "python", PublishedDistBasePath: "python", ContentType: config.ContentTypePython},
+		{Name: "npm", PublishedDistBasePath: "npm", ContentType: config.ContentTypeNpm},
+		{Name: "rpm", PublishedDistBasePath: "rpm", ContentType: config.ContentTypeRpm},
+	}, nil)
+	mockDao.Domain.On("Fetch", ctx, config.LightwellOrg).Return("lightwell-domain", nil)
+	mockPulpClient.On("WithDomain", "lightwell-domain").Return(mockPulpClient)
+	mockPulpClient.On("ResolveRepositoryFromBasePath", ctx, "java").Return(&mavenHref, nil)
+	mockPulpClient.On("ResolveRepositoryFromBasePath", ctx, "python").Return(&pythonHref, nil)
+	mockPulpClient.On("ResolveRepositoryFromBasePath", ctx, "npm").Return(&npmHref, nil)
+
+	firstPage := tangy.PageOptions{Offset: 0, Limit: coverageCatalogPageSize}
+	secondPage := tangy.PageOptions{Offset: coverageCatalogPageSize, Limit: coverageCatalogPageSize}
+	mavenItems := make([]tangy.MavenPackageListItem, coverageCatalogPageSize)
+	for i := range mavenItems {
+		mavenItems[i] = tangy.MavenPackageListItem{GroupID: "org.example", ArtifactID: "filler"}
+	}
+	mavenItems[0] = tangy.MavenPackageListItem{GroupID: "org.springframework", ArtifactID: "spring-core", Versions: []string{"6.1.5", "6.1.6"}}
+	mockTangy.On("MavenPackageList", ctx, mavenHref, tangy.MavenPackageListFilters{}, firstPage).
+		Return(tangy.MavenPackageListResponse{Results: mavenItems, Total: coverageCatalogPageSize + 1}, nil)
+	mockTangy.On("MavenPackageList", ctx, mavenHref, tangy.MavenPackageListFilters{}, secondPage).
+		Return(tangy.MavenPackageListResponse{Results: []tangy.MavenPackageListItem{
+			{GroupID: "io.netty", ArtifactID: "netty-codec-http", Versions: []string{"4.1.108.Final"}},
+		}, Total: coverageCatalogPageSize + 1}, nil)
+	mockTangy.On("PythonPackageList", ctx, pythonHref, tangy.PythonPackageListFilters{}, firstPage).
+		Return(tangy.PythonPackageListResponse{Results: []tangy.PythonPackageListItem{
+			{NameNormalized: "requests", Versions: []string{"2.31.0"}},
+		}, Total: 1}, nil)
+	mockTangy.On("NpmPackageList", ctx, npmHref, tangy.NpmPackageListFilters{}, firstPage).
+		Return(tangy.NpmPackageListResponse{Results: []tangy.NpmPackageListItem{
+			{Name: "@types/node", Versions: []string{"20.11.0"}},
+			{Name: "lodash", Versions: []string{"4.17.21"}},
+		}, Total: 2}, nil)
+
+	catalog, err := LoadCoverageCatalog(ctx, mockDao.ToDaoRegistry(), mockPulpClient, mockTangy)
+	require.NoError(t, err)
+	assert.Equal(t, []matcher.Package{
+		{Ecosystem: matcher.EcosystemJava, Namespace: "org.springframework", Name: "spring-core", Version: "6.1.5"},
+		{Ecosystem: matcher.EcosystemJava, Namespace: "org.springframework", Name: "spring-core", Version: "6.1.6"},
+		{Ecosystem: matcher.EcosystemJava, Namespace: "io.netty", Name: "netty-codec-http", Version: "4.1.108.Final"},
+		{Ecosystem: matcher.EcosystemPython, Name: "requests", Version: "2.31.0"},
+		{Ecosystem: matcher.EcosystemNpm, Namespace: "@types", Name: "node", Version: "20.11.0"},
+		{Ecosystem: matcher.EcosystemNpm, Name: "lodash", Version: "4.17.21"},
+	}, catalog)
+}
+
+func TestLoadCoverageCatalog_Error(t *testing.T) {
+	ctx := context.Background()
+	mockDao := dao.GetMockDaoRegistry(t)
+	mockPulpClient := pulp_client.NewMockPulpClient(t)
+	mockTangy := tangy.NewMockTangy(t)
+	pythonHref := "python-href"
+
+	mockDao.RepositoryConfig.On("InternalOnly_FetchRepoConfigForOrg", ctx, config.LightwellOrg).Return([]api.RepositoryResponse{
+		{Name: "python", PublishedDistBasePath: "python", ContentType: config.ContentTypePython},
+	}, nil)
+	mockDao.Domain.On("Fetch", ctx, config.LightwellOrg).Return("lightwell-domain", nil)
+	mockPulpClient.On("WithDomain", "lightwell-domain").Return(mockPulpClient)
+	mockPulpClient.On("ResolveRepositoryFromBasePath", ctx, "python").Return(&pythonHref, nil)
+	mockTangy.On("PythonPackageList", ctx, pythonHref, tangy.PythonPackageListFilters{}, tangy.PageOptions{Offset: 0, Limit: coverageCatalogPageSize}).
+		Return(tangy.PythonPackageListResponse{}, errors.New("tang is down"))
+
+	_, err := LoadCoverageCatalog(ctx, mockDao.ToDaoRegistry(), mockPulpClient, mockTangy)
+	assert.ErrorContains(t, err, "tang is down")
+}
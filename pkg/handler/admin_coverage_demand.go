@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	ce "github.com/content-services/content-sources-backend/pkg/errors"
+	"github.com/content-services/content-sources-backend/pkg/rbac"
+	"github.com/labstack/echo/v4"
+)
+
+type AdminCoverageDemandHandler struct {
+	DaoRegistry dao.DaoRegistry
+}
+
+func RegisterAdminCoverageDemandRoutes(engine *echo.Group, daoReg *dao.DaoRegistry) {
+	if engine == nil {
+		panic("engine is nil")
+	}
+	if daoReg == nil {
+		panic("daoReg is nil")
+	}
+
+	h := AdminCoverageDemandHandler{
+		DaoRegistry: *daoReg,
+	}
+	addRepoRoute(engine, http.MethodGet, "/admin/coverage_demand/", h.listCoverageDemand, rbac.RbacVerbRead, checkAccessible)
+	addRepoRoute(engine, http.MethodGet, "/admin/coverage_demand/export/", h.exportCoverageDemand, rbac.RbacVerbRead, checkAccessible)
+}
+
+func (h *AdminCoverageDemandHandler) listCoverageDemand(c echo.Context) error {
+	pageData := ParsePagination(c)
+	filterData, err := parseCoverageDemandFilters(c)
+	if err != nil {
+		return err
+	}
+
+	summaries, total, err := h.DaoRegistry.CoverageDemandSignal.List(c.Request().Context(), pageData, filterData)
+	if err != nil {
+		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error listing coverage demand", err.Error())
+	}
+	return c.JSON(http.StatusOK, setCollectionResponseMetadata(&summaries, c, total))
+}
+
+func (h *AdminCoverageDemandHandler) exportCoverageDemand(c echo.Context) error {
+	filterData, err := parseCoverageDemandFilters(c)
+	if err != nil {
+		return err
+	}
+
+	summaries, err := h.DaoRegistry.CoverageDemandSignal.Export(c.Request().Context(), filterData)
+	if err != nil {
+		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error exporting coverage demand", err.Error())
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"ecosystem", "namespace", "name", "min_version", "max_version", "version_count", "signal_count", "org_count", "unmatched_count", "partial_count", "first_seen_at", "last_seen_at", "trend"})
+	for _, summary := range summaries {
+		trend := make([]string, len(summary.Trend))
+		for i, item := range summary.Trend {
+			trend[i] = fmt.Sprintf("%s=%d", item.PeriodStart.Format(time.DateOnly), item.SignalCount)
+		}
+		_ = w.Write([]string{
+			summary.Ecosystem,
+			summary.Namespace,
+			summary.Name,
+			summary.MinVersion,
+			summary.MaxVersion,
+			strconv.Itoa(summary.VersionCount),
+			strconv.Itoa(summary.SignalCount),
+			strconv.Itoa(summary.OrgCount),
+			strconv.Itoa(summary.UnmatchedCount),
+			strconv.Itoa(summary.PartialCount),
+			summary.FirstSeenAt.Format(time.RFC3339),
+			summary.LastSeenAt.Format(time.RFC3339),
+			strings.Join(trend, " "),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return ce.NewErrorResponse(http.StatusInternalServerError, "Error exporting coverage demand", err.Error())
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="coverage_demand.csv"`)
+	return c.Blob(http.StatusOK, "text/csv", buf.Bytes())
+}
+
+func parseCoverageDemandFilters(c echo.Context) (api.CoverageDemandFilterData, error) {
+	startTime, err := parseTimeQueryParam(c, "start_time")
+	if err != nil {
+		return api.CoverageDemandFilterData{}, err
+	}
+	endTime, err := parseTimeQueryParam(c, "end_time")
+	if err != nil {
+		return api.CoverageDemandFilterData{}, err
+	}
+	interval := c.QueryParam("interval")
+	switch interval {
+	case "":
+		interval = api.CoverageDemandIntervalWeek
+	case api.CoverageDemandIntervalDay, api.CoverageDemandIntervalWeek, api.CoverageDemandIntervalMonth:
+	default:
+		return api.CoverageDemandFilterData{}, ce.NewErrorResponse(http.StatusBadRequest, "Error parsing interval",
+			fmt.Sprintf("interval must be one of %s, %s or %s", api.CoverageDemandIntervalDay, api.CoverageDemandIntervalWeek, api.CoverageDemandIntervalMonth))
+	}
+	return api.CoverageDemandFilterData{
+		Ecosystem: c.QueryParam("ecosystem"),
+		Search:    c.QueryParam("search"),
+		StartTime: startTime,
+		EndTime:   endTime,
+		Interval:  interval,
+	}, nil
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	"github.com/content-services/content-sources-backend/pkg/middleware"
+	"github.com/content-services/content-sources-backend/pkg/seeds"
+	"github.com/content-services/content-sources-backend/pkg/test"
+	test_handler "github.com/content-services/content-sources-backend/pkg/test/handler"
+	"github.com/labstack/echo/v4"
+	"github.com/redhatinsights/platform-go-middlewares/v2/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type AdminCoverageDemandSuite struct {
+	suite.Suite
+	reg *dao.MockDaoRegistry
+}
+
+func TestAdminCoverageDemandSuite(t *testing.T) {
+	suite.Run(t, new(AdminCoverageDemandSuite))
+}
+
+func (suite *AdminCoverageDemandSuite) SetupTest() {
+	suite.reg = dao.GetMockDaoRegistry(suite.T())
+	config.Get().Features.AdminTasks.Enabled = true
+	config.Get().Features.AdminTasks.Accounts = &[]string{test_handler.MockAccountNumber}
+}
+
+func (suite *AdminCoverageDemandSuite) serveRouter(req *http.Request) (int, *http.Response, []byte, error) {
+	router := echo.New()
+	router.Use(middleware.WrapMiddlewareWithSkipper(identity.EnforceIdentity, middleware.SkipMiddleware))
+	router.HTTPErrorHandler = config.CustomHTTPErrorHandler
+	pathPrefix := router.Group(api.FullRootPath())
+	RegisterAdminCoverageDemandRoutes(pathPrefix, suite.reg.ToDaoRegistry())
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	response := rr.Result()
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	return response.StatusCode, response, body, err
+}
+
+func (suite *AdminCoverageDemandSuite) TestList() {
+	t := suite.T()
+	startTime := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	filterData := api.CoverageDemandFilterData{Ecosystem: "Java", Search: "netty", StartTime: &startTime, Interval: api.CoverageDemandIntervalMonth}
+	expected := api.CoverageDemandSummaryCollectionResponse{Data: []api.CoverageDemandSummaryResponse{{
+		Ecosystem:   "Java",
+		Namespace:   "io.netty",
+		Name:        "netty-codec-http",
+		MinVersion:  "4.1.100.Final",
+		MaxVersion:  "4.1.108.Final",
+		SignalCount: 3,
+		OrgCount:    2,
+		FirstSeenAt: startTime,
+		LastSeenAt:  startTime,
+		Trend:       []api.CoverageDemandTrendItem{{PeriodStart: startTime, SignalCount: 3, OrgCount: 2}},
+	}}}
+	suite.reg.CoverageDemandSignal.On("List", test.MockCtx(), api.PaginationData{Limit: 100}, filterData).Return(expected, int64(1), nil)
+
+	path := fmt.Sprintf("%s/admin/coverage_demand/?ecosystem=Java&search=netty&start_time=2026-09-01T00:00:00Z&interval=month", api.FullRootPath())
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, _, body, err := suite.serveRouter(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	var resp api.CoverageDemandSummaryCollectionResponse
+	assert.NoError(t, json.Unmarshal(body, &resp))
+	assert.Equal(t, expected.Data, resp.Data)
+	assert.Equal(t, int64(1), resp.Meta.Count)
+}
+
+func (suite *AdminCoverageDemandSuite) TestListInvalidInterval() {
+	t := suite.T()
+
+	path := fmt.Sprintf("%s/admin/coverage_demand/?interval=year", api.FullRootPath())
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, _, _, err := suite.serveRouter(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func (suite *AdminCoverageDemandSuite) TestListNotAccessible() {
+	t := suite.T()
+	config.Get().Features.AdminTasks.Accounts = &[]string{seeds.RandomAccountId()}
+
+	path := fmt.Sprintf("%s/admin/coverage_demand/", api.FullRootPath())
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, _, _, err := suite.serveRouter(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func (suite *AdminCoverageDemandSuite) TestExport() {
+	t := suite.T()
+	seenAt := time.Date(2026, 9, 7, 15, 4, 5, 0, time.UTC)
+	summaries := []api.CoverageDemandSummaryResponse{{
+		Ecosystem:      "Python",
+		Name:           "urllib3",
+		MinVersion:     "2.0.7",
+		MaxVersion:     "2.2.1",
+		VersionCount:   2,
+		SignalCount:    3,
+		OrgCount:       2,
+		UnmatchedCount: 3,
+		FirstSeenAt:    seenAt,
+		LastSeenAt:     seenAt,
+		Trend: []api.CoverageDemandTrendItem{
+			{PeriodStart: time.Date(2026, 8, 31, 0, 0, 0, 0, time.UTC), SignalCount: 1, OrgCount: 1},
+			{PeriodStart: time.Date(2026, 9, 7, 0, 0, 0, 0, time.UTC), SignalCount: 2, OrgCount: 2},
+		},
+	}}
+	suite.reg.CoverageDemandSignal.On("Export", test.MockCtx(), api.CoverageDemandFilterData{Ecosystem: "Python", Interval: api.CoverageDemandIntervalWeek}).
+		Return(summaries, nil)
+
+	path := fmt.Sprintf("%s/admin/coverage_demand/export/?ecosystem=Python", api.FullRootPath())
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, response, body, err := suite.serveRouter(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "text/csv", response.Header.Get(echo.HeaderContentType))
+	assert.Contains(t, response.Header.Get(echo.HeaderContentDisposition), "coverage_demand.csv")
+
+	records, err := csv.NewReader(strings.NewReader(string(body))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, []string{
+		"Python", "", "urllib3", "2.0.7", "2.2.1", "2", "3", "2", "3", "0",
+		"2026-09-07T15:04:05Z", "2026-09-07T15:04:05Z", "2026-08-31=1 2026-09-07=2",
+	}, records[1])
+}
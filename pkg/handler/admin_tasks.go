@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/content-services/content-sources-backend/pkg/clients/feature_service_client"
 	"github.com/content-services/content-sources-backend/pkg/dao"
 	ce "github.com/content-services/content-sources-backend/pkg/errors"
+	"github.com/content-services/content-sources-backend/pkg/middleware"
 	"github.com/content-services/content-sources-backend/pkg/rbac"
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog/log"
@@ -51,6 +54,10 @@ func RegisterAdminTaskRoutes(engine *echo.Group, daoReg *dao.DaoRegistry, fsClie
 	}
 	addRepoRoute(engine, http.MethodGet, "/admin/tasks/", adminTaskHandler.listTasks, rbac.RbacVerbRead, checkAccessible)
 	addRepoRoute(engine, http.MethodGet, "/admin/tasks/:uuid", adminTaskHandler.fetch, rbac.RbacVerbRead, checkAccessible)
+	addRepoRoute(engine, http.MethodGet, "/admin/tasks/dead_letter/", adminTaskHandler.listDeadLetterGroups, rbac.RbacVerbRead, checkAccessible)
+	addRepoRoute(engine, http.MethodPost, "/admin/tasks/dead_letter/retry/", adminTaskHandler.retryDeadLetterTasks, rbac.RbacVerbWrite, checkAccessible)
+	addRepoRoute(engine, http.MethodPost, "/admin/tasks/dead_letter/cancel/", adminTaskHandler.cancelDeadLetterTasks, rbac.RbacVerbWrite, checkAccessible)
+	addRepoRoute(engine, http.MethodPost, "/admin/tasks/dead_letter/purge/", adminTaskHandler.purgeDeadLetterTasks, rbac.RbacVerbWrite, checkAccessible)
 	addRepoRoute(engine, http.MethodGet, "/admin/features/", adminTaskHandler.listFeatures, rbac.RbacVerbRead, checkAccessible)
 	addRepoRoute(engine, http.MethodGet, "/admin/features/:name/content/", adminTaskHandler.listContentForFeature, rbac.RbacVerbRead, checkAccessible)
 }
@@ -77,6 +84,66 @@ func (adminTaskHandler *AdminTaskHandler) fetch(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+func (adminTaskHandler *AdminTaskHandler) listDeadLetterGroups(c echo.Context) error {
+	pageData := ParsePagination(c)
+	filterData := ParseAdminDeadLetterFilters(c)
+
+	groups, totalGroups, err := adminTaskHandler.DaoRegistry.AdminTask.ListDeadLetterGroups(c.Request().Context(), pageData, filterData)
+	if err != nil {
+		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error listing dead-lettered tasks", err.Error())
+	}
+
+	return c.JSON(http.StatusOK, setCollectionResponseMetadata(&groups, c, totalGroups))
+}
+
+func (adminTaskHandler *AdminTaskHandler) retryDeadLetterTasks(c echo.Context) error {
+	return adminTaskHandler.applyDeadLetterAction(c, "retry", adminTaskHandler.DaoRegistry.AdminTask.RetryDeadLetterTasks)
+}
+
+func (adminTaskHandler *AdminTaskHandler) cancelDeadLetterTasks(c echo.Context) error {
+	return adminTaskHandler.applyDeadLetterAction(c, "cancel", adminTaskHandler.DaoRegistry.AdminTask.CancelDeadLetterTasks)
+}
+
+func (adminTaskHandler *AdminTaskHandler) purgeDeadLetterTasks(c echo.Context) error {
+	return adminTaskHandler.applyDeadLetterAction(c, "purge", adminTaskHandler.DaoRegistry.AdminTask.PurgeDeadLetterTasks)
+}
+
+// applyDeadLetterAction binds the request selecting dead-lettered tasks, applies action to them and records the tasks it
+// applied to in the audit event of the request
+func (adminTaskHandler *AdminTaskHandler) applyDeadLetterAction(
+	c echo.Context,
+	action string,
+	apply func(ctx context.Context, request api.AdminDeadLetterRequest) ([]string, error),
+) error {
+	var req api.AdminDeadLetterRequest
+	if err := c.Bind(&req); err != nil {
+		return ce.NewErrorResponse(http.StatusBadRequest, "Error binding parameters", err.Error())
+	}
+	if req.OrgId == "" && len(req.Typenames) == 0 && req.ErrorSignature == "" && len(req.TaskUUIDs) == 0 {
+		return ce.NewErrorResponse(http.StatusBadRequest, "At least one filter is required",
+			"Provide at least one of org_id, typenames, error_signature or task_uuids")
+	}
+	if req.ResetRetries && action != "retry" {
+		return ce.NewErrorResponse(http.StatusBadRequest, "Invalid parameter", "reset_retries can only be used when retrying tasks")
+	}
+
+	taskUUIDs, err := apply(c.Request().Context(), req)
+	if err != nil {
+		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), fmt.Sprintf("Error applying %s to dead-lettered tasks", action), err.Error())
+	}
+	if taskUUIDs == nil {
+		taskUUIDs = []string{}
+	}
+	// The audit event of the request records the filters, add the tasks they matched
+	middleware.AddAuditObjectUUIDs(c, taskUUIDs)
+
+	return c.JSON(http.StatusOK, api.AdminDeadLetterActionResponse{
+		Action:    action,
+		Count:     len(taskUUIDs),
+		TaskUUIDs: taskUUIDs,
+	})
+}
+
 func (adminTaskHandler *AdminTaskHandler) listFeatures(c echo.Context) error {
 	resp, statusCode, err := adminTaskHandler.FeatureServiceClient.ListFeatures(c.Request().Context())
 	if err != nil {
@@ -151,3 +218,20 @@ func ParseAdminTaskFilters(c echo.Context) api.AdminTaskFilterData {
 
 	return filterData
 }
+
+func ParseAdminDeadLetterFilters(c echo.Context) api.AdminDeadLetterFilterData {
+	filterData := api.AdminDeadLetterFilterData{
+		OrgId: DefaultOrgId,
+	}
+	err := echo.QueryParamsBinder(c).
+		String("org_id", &filterData.OrgId).
+		String("type", &filterData.Typename).
+		String("error_signature", &filterData.ErrorSignature).
+		BindError()
+
+	if err != nil {
+		log.Ctx(c.Request().Context()).Info().Err(err).Msg("error parsing filters")
+	}
+
+	return filterData
+}
@@ -489,3 +489,125 @@ func (suite *AdminTasksSuite) TestListContentForFeatureNotFound() {
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusNotFound, code)
 }
+
+func (suite *AdminTasksSuite) TestListDeadLetterGroups() {
+	t := suite.T()
+
+	collection := api.AdminDeadLetterGroupCollectionResponse{
+		Data: []api.AdminDeadLetterGroupResponse{{
+			Typename:       "snapshot",
+			ErrorSignature: "4f6c2b6e0e8e3c0d9d3c7b9d7d1a8b11",
+			Error:          "error syncing repository <uuid>",
+			Count:          3,
+			OrgCount:       2,
+		}},
+	}
+	paginationData := api.PaginationData{Limit: DefaultLimit, Offset: DefaultOffset}
+	filterData := api.AdminDeadLetterFilterData{Typename: "snapshot"}
+	suite.reg.AdminTask.On("ListDeadLetterGroups", test.MockCtx(), paginationData, filterData).Return(collection, int64(1), nil)
+
+	req := httptest.NewRequest(http.MethodGet, api.FullRootPath()+"/admin/tasks/dead_letter/?type=snapshot", nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, body, err := suite.serveAdminTasksRouter(req, true, true)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	response := api.AdminDeadLetterGroupCollectionResponse{}
+	err = json.Unmarshal(body, &response)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), response.Meta.Count)
+	assert.Equal(t, collection.Data, response.Data)
+}
+
+func (suite *AdminTasksSuite) TestRetryDeadLetterTasks() {
+	t := suite.T()
+
+	request := api.AdminDeadLetterRequest{
+		Typenames:    []string{"snapshot"},
+		ResetRetries: true,
+	}
+	taskUUIDs := []string{uuid.NewString(), uuid.NewString()}
+	suite.reg.AdminTask.On("RetryDeadLetterTasks", test.MockCtx(), request).Return(taskUUIDs, nil)
+
+	body, err := json.Marshal(request)
+	assert.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, api.FullRootPath()+"/admin/tasks/dead_letter/retry/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, body, err := suite.serveAdminTasksRouter(req, true, true)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	var response api.AdminDeadLetterActionResponse
+	err = json.Unmarshal(body, &response)
+	assert.Nil(t, err)
+	assert.Equal(t, "retry", response.Action)
+	assert.Equal(t, 2, response.Count)
+	assert.Equal(t, taskUUIDs, response.TaskUUIDs)
+}
+
+func (suite *AdminTasksSuite) TestPurgeDeadLetterTasksNoneFound() {
+	t := suite.T()
+
+	request := api.AdminDeadLetterRequest{OrgId: test_handler.MockOrgId}
+	suite.reg.AdminTask.On("PurgeDeadLetterTasks", test.MockCtx(), request).Return(nil, nil)
+
+	body, err := json.Marshal(request)
+	assert.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, api.FullRootPath()+"/admin/tasks/dead_letter/purge/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, body, err := suite.serveAdminTasksRouter(req, true, true)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	var response api.AdminDeadLetterActionResponse
+	err = json.Unmarshal(body, &response)
+	assert.Nil(t, err)
+	assert.Equal(t, "purge", response.Action)
+	assert.Equal(t, 0, response.Count)
+	assert.Equal(t, []string{}, response.TaskUUIDs)
+}
+
+func (suite *AdminTasksSuite) TestDeadLetterActionRequiresFilter() {
+	t := suite.T()
+
+	for _, action := range []string{"retry", "cancel", "purge"} {
+		req := httptest.NewRequest(http.MethodPost, api.FullRootPath()+"/admin/tasks/dead_letter/"+action+"/", bytes.NewReader([]byte("{}")))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+		code, body, err := suite.serveAdminTasksRouter(req, true, true)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Contains(t, string(body), "At least one filter is required")
+	}
+
+	// reset_retries only applies to retries
+	req := httptest.NewRequest(http.MethodPost, api.FullRootPath()+"/admin/tasks/dead_letter/cancel/",
+		bytes.NewReader([]byte(`{"typenames": ["snapshot"], "reset_retries": true}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, _, err := suite.serveAdminTasksRouter(req, true, true)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func (suite *AdminTasksSuite) TestDeadLetterActionNotAccessible() {
+	t := suite.T()
+
+	req := httptest.NewRequest(http.MethodPost, api.FullRootPath()+"/admin/tasks/dead_letter/purge/",
+		bytes.NewReader([]byte(`{"typenames": ["snapshot"]}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, body, err := suite.serveAdminTasksRouter(req, true, false)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, string(body), "Neither the user nor account is allowed.")
+	suite.reg.AdminTask.AssertNotCalled(t, "PurgeDeadLetterTasks")
+}
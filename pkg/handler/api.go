@@ -87,7 +87,9 @@ func RegisterRoutes(ctx context.Context, engine *echo.Echo) {
 		RegisterAdminTaskRoutes(group, daoReg, &fsClient, &cpClient)
 		RegisterAdminRepositoriesRoutes(group, daoReg)
 		RegisterAdminNotificationsRoutes(group)
+		RegisterAdminCoverageDemandRoutes(group, daoReg)
 		RegisterFeaturesRoutes(group)
+		RegisterPermissionsRoutes(group)
 		RegisterPublicRepositoriesRoutes(group, daoReg)
 		RegisterPackageGroupRoutes(group, daoReg)
 		RegisterEnvironmentRoutes(group, daoReg)
@@ -97,7 +99,9 @@ func RegisterRoutes(ctx context.Context, engine *echo.Echo) {
 		RegisterModuleStreamsRoutes(group, daoReg)
 		RegisterUserPreferencesRoutes(group, daoReg)
 		RegisterLightwellVulnerabilityRoutes(group, daoReg)
-		RegisterCoverageReportRoutes(group, daoReg)
+		RegisterCoverageReportRoutes(group, daoReg, &taskClient)
+		RegisterWebhookRoutes(group, daoReg)
+		RegisterAuditEventRoutes(group, daoReg)
 
 		// Register package and build routes if tang client is available
 		pulpClient := pulp_client.GetPulpClientWithDomain("")
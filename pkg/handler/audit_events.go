@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	ce "github.com/content-services/content-sources-backend/pkg/errors"
+	"github.com/content-services/content-sources-backend/pkg/rbac"
+	"github.com/labstack/echo/v4"
+)
+
+type AuditEventHandler struct {
+	DaoRegistry dao.DaoRegistry
+}
+
+func RegisterAuditEventRoutes(engine *echo.Group, daoReg *dao.DaoRegistry) {
+	if engine == nil {
+		panic("engine is nil")
+	}
+	if daoReg == nil {
+		panic("daoReg is nil")
+	}
+	h := AuditEventHandler{DaoRegistry: *daoReg}
+
+	addAuditRoute(engine, http.MethodGet, "/audit_events/", h.listAuditEvents, rbac.RbacVerbRead)
+	addAuditRoute(engine, http.MethodGet, "/audit_events/export/", h.exportAuditEvents, rbac.RbacVerbRead)
+}
+
+// ListAuditEvents godoc
+// @Summary      List Audit Events
+// @ID           listAuditEvents
+// @Description  List the requests that created, updated, deleted, uploaded or canceled content of the organization, successful or not, newest first. Events record the body of the request, not the state of the objects before and after it. Events are kept for a year by default.
+// @Tags         audit_events
+// @Param		 offset query int false "Starting point for retrieving a subset of results. Determines how many items to skip from the beginning of the result set. Default value:`0`."
+// @Param		 limit query int false "Number of items to include in response. Use it to control the number of items, particularly when dealing with large datasets. Default value: `100`."
+// @Param		 sort_by query string false "Sort the response data based on specific parameters. Sort criteria can include `created_at`, `username`, and `status_code`."
+// @Param		 object_uuid query string false "List the events about the repository, snapshot, template, task or other object with this UUID."
+// @Param		 username query string false "List the events of this user."
+// @Param		 start_time query string false "List the events at or after this time, in RFC 3339 format, such as `2024-01-02T15:04:05Z`."
+// @Param		 end_time query string false "List the events before this time, in RFC 3339 format."
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} api.AuditEventCollectionResponse
+// @Failure      400 {object} ce.ErrorResponse
+// @Failure      401 {object} ce.ErrorResponse
+// @Failure      500 {object} ce.ErrorResponse
+// @Router       /audit_events/ [get]
+func (h *AuditEventHandler) listAuditEvents(c echo.Context) error {
+	_, orgID := getAccountIdOrgId(c)
+	pageData := ParsePagination(c)
+	filterData, err := parseAuditEventFilters(c)
+	if err != nil {
+		return err
+	}
+
+	auditEvents, total, err := h.DaoRegistry.AuditEvent.List(c.Request().Context(), orgID, pageData, filterData)
+	if err != nil {
+		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error listing audit events", err.Error())
+	}
+	return c.JSON(http.StatusOK, setCollectionResponseMetadata(&auditEvents, c, total))
+}
+
+// ExportAuditEvents godoc
+// @Summary      Export Audit Events
+// @ID           exportAuditEvents
+// @Description  Export the audit events matching the filters as CSV, newest first. At most 10000 events are exported, narrow the time range to export older events.
+// @Tags         audit_events
+// @Param		 object_uuid query string false "Export the events about the repository, snapshot, template, task or other object with this UUID."
+// @Param		 username query string false "Export the events of this user."
+// @Param		 start_time query string false "Export the events at or after this time, in RFC 3339 format, such as `2024-01-02T15:04:05Z`."
+// @Param		 end_time query string false "Export the events before this time, in RFC 3339 format."
+// @Produce      text/csv
+// @Success      200 {string} string
+// @Failure      400 {object} ce.ErrorResponse
+// @Failure      401 {object} ce.ErrorResponse
+// @Failure      500 {object} ce.ErrorResponse
+// @Router       /audit_events/export/ [get]
+func (h *AuditEventHandler) exportAuditEvents(c echo.Context) error {
+	_, orgID := getAccountIdOrgId(c)
+	filterData, err := parseAuditEventFilters(c)
+	if err != nil {
+		return err
+	}
+
+	auditEvents, err := h.DaoRegistry.AuditEvent.Export(c.Request().Context(), orgID, filterData)
+	if err != nil {
+		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error exporting audit events", err.Error())
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"created_at", "username", "account_id", "request_id", "method", "route", "path", "object_uuids", "status_code", "error", "request"})
+	for _, auditEvent := range auditEvents {
+		_ = w.Write([]string{
+			auditEvent.CreatedAt.Format(time.RFC3339),
+			auditEvent.Username,
+			auditEvent.AccountID,
+			auditEvent.RequestID,
+			auditEvent.Method,
+			auditEvent.Route,
+			auditEvent.Path,
+			strings.Join(auditEvent.ObjectUUIDs, " "),
+			strconv.Itoa(auditEvent.StatusCode),
+			auditEvent.Error,
+			string(auditEvent.Request),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return ce.NewErrorResponse(http.StatusInternalServerError, "Error exporting audit events", err.Error())
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="audit_events.csv"`)
+	return c.Blob(http.StatusOK, "text/csv", buf.Bytes())
+}
+
+func parseAuditEventFilters(c echo.Context) (api.AuditEventFilterData, error) {
+	startTime, err := parseTimeQueryParam(c, "start_time")
+	if err != nil {
+		return api.AuditEventFilterData{}, err
+	}
+	endTime, err := parseTimeQueryParam(c, "end_time")
+	if err != nil {
+		return api.AuditEventFilterData{}, err
+	}
+	return api.AuditEventFilterData{
+		ObjectUUID: c.QueryParam("object_uuid"),
+		Username:   c.QueryParam("username"),
+		StartTime:  startTime,
+		EndTime:    endTime,
+	}, nil
+}
+
+// parseTimeQueryParam parses a query parameter in RFC 3339 format, returning nil if it is not set
+func parseTimeQueryParam(c echo.Context, name string) (*time.Time, error) {
+	param := c.QueryParam(name)
+	if param == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, param)
+	if err != nil {
+		return nil, ce.NewErrorResponse(http.StatusBadRequest, "Error parsing "+name, err.Error())
+	}
+	return &t, nil
+}
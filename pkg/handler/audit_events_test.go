@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	"github.com/content-services/content-sources-backend/pkg/middleware"
+	"github.com/content-services/content-sources-backend/pkg/test"
+	test_handler "github.com/content-services/content-sources-backend/pkg/test/handler"
+	"github.com/labstack/echo/v4"
+	"github.com/redhatinsights/platform-go-middlewares/v2/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditEventsSuite struct {
+	suite.Suite
+	reg *dao.MockDaoRegistry
+}
+
+func TestAuditEventsSuite(t *testing.T) {
+	suite.Run(t, new(AuditEventsSuite))
+}
+
+func (suite *AuditEventsSuite) SetupTest() {
+	suite.reg = dao.GetMockDaoRegistry(suite.T())
+}
+
+func (suite *AuditEventsSuite) serveRouter(req *http.Request) (int, *http.Response, []byte, error) {
+	router := echo.New()
+	router.Use(middleware.WrapMiddlewareWithSkipper(identity.EnforceIdentity, middleware.SkipMiddleware))
+	router.HTTPErrorHandler = config.CustomHTTPErrorHandler
+	pathPrefix := router.Group(api.FullRootPath())
+	RegisterAuditEventRoutes(pathPrefix, suite.reg.ToDaoRegistry())
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	response := rr.Result()
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	return response.StatusCode, response, body, err
+}
+
+func (suite *AuditEventsSuite) TestList() {
+	t := suite.T()
+	startTime := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	filterData := api.AuditEventFilterData{ObjectUUID: "object-uuid", Username: "user", StartTime: &startTime}
+	expected := api.AuditEventCollectionResponse{Data: []api.AuditEventResponse{{UUID: "uuid", Method: http.MethodDelete, Route: "/repositories/:uuid"}}}
+	suite.reg.AuditEvent.On("List", test.MockCtx(), test_handler.MockOrgId, api.PaginationData{Limit: 100}, filterData).
+		Return(expected, int64(1), nil)
+
+	path := fmt.Sprintf("%s/audit_events/?object_uuid=object-uuid&username=user&start_time=2024-01-02T15:04:05Z", api.FullRootPath())
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, _, body, err := suite.serveRouter(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	var resp api.AuditEventCollectionResponse
+	assert.NoError(t, json.Unmarshal(body, &resp))
+	assert.Equal(t, expected.Data, resp.Data)
+	assert.Equal(t, int64(1), resp.Meta.Count)
+}
+
+func (suite *AuditEventsSuite) TestListInvalidTime() {
+	t := suite.T()
+
+	path := fmt.Sprintf("%s/audit_events/?end_time=yesterday", api.FullRootPath())
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, _, _, err := suite.serveRouter(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func (suite *AuditEventsSuite) TestExport() {
+	t := suite.T()
+	createdAt := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	auditEvents := []api.AuditEventResponse{{
+		UUID:        "uuid",
+		Username:    "user",
+		Method:      http.MethodPost,
+		Route:       "/repositories/bulk_delete",
+		Path:        "/api/content-sources/v1/repositories/bulk_delete/",
+		ObjectUUIDs: []string{"a", "b"},
+		Request:     json.RawMessage(`{"uuids":["a","b"]}`),
+		StatusCode:  http.StatusNoContent,
+		CreatedAt:   createdAt,
+	}}
+	suite.reg.AuditEvent.On("Export", test.MockCtx(), test_handler.MockOrgId, api.AuditEventFilterData{Username: "user"}).
+		Return(auditEvents, nil)
+
+	path := fmt.Sprintf("%s/audit_events/export/?username=user", api.FullRootPath())
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, response, body, err := suite.serveRouter(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "text/csv", response.Header.Get(echo.HeaderContentType))
+	assert.Contains(t, response.Header.Get(echo.HeaderContentDisposition), "audit_events.csv")
+
+	records, err := csv.NewReader(strings.NewReader(string(body))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "created_at", records[0][0])
+	assert.Equal(t, []string{
+		"2024-01-02T15:04:05Z", "user", "", "", http.MethodPost, "/repositories/bulk_delete",
+		"/api/content-sources/v1/repositories/bulk_delete/", "a b", "204", "", `{"uuids":["a","b"]}`,
+	}, records[1])
+}
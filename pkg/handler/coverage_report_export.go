@@ -0,0 +1,371 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/coverage/matcher"
+	ce "github.com/content-services/content-sources-backend/pkg/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	mimeTypeCycloneDXJSON = "application/vnd.cyclonedx+json"
+	// cycloneDXPropertyPrefix namespaces the coverage properties of the components of CycloneDX exports
+	cycloneDXPropertyPrefix = "lightwell:coverage:"
+)
+
+// ExportCoverageReport godoc
+// @Summary      Export coverage report
+// @ID           exportCoverageReport
+// @Description  Download a completed coverage report with all of its packages. The csv format lists one package per row, with its match status, advisories and the catalog timestamp. The json format also contains the report and its ecosystem summaries. The cyclonedx format is a CycloneDX 1.5 BOM whose components are annotated with their coverage status, listing the advisories affecting them as vulnerabilities (VEX).
+// @Tags         coverage_reports
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.cyclonedx+json
+// @Param        uuid path string true "Coverage report UUID"
+// @Param        format query string false "Format of the export: csv, json or cyclonedx. Default: json"
+// @Success      200 {object} api.CoverageReportExportResponse
+// @Failure      400 {object} ce.ErrorResponse
+// @Failure      404 {object} ce.ErrorResponse
+// @Failure      500 {object} ce.ErrorResponse
+// @Router       /coverage_reports/{uuid}/export [get]
+func (ch *CoverageReportHandler) exportCoverageReport(c echo.Context) error {
+	_, orgID := getAccountIdOrgId(c)
+
+	format := c.QueryParam("format")
+	switch format {
+	case "":
+		format = api.CoverageReportExportFormatJSON
+	case api.CoverageReportExportFormatCSV, api.CoverageReportExportFormatJSON, api.CoverageReportExportFormatCycloneDX:
+	default:
+		return ce.NewErrorResponse(http.StatusBadRequest, "Error parsing format",
+			fmt.Sprintf("format must be one of %s, %s or %s", api.CoverageReportExportFormatCSV, api.CoverageReportExportFormatJSON, api.CoverageReportExportFormatCycloneDX))
+	}
+
+	ctx := c.Request().Context()
+	report, err := ch.DaoRegistry.CoverageReport.FetchExport(ctx, orgID, c.Param("uuid"))
+	if err != nil {
+		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error exporting coverage report", err.Error())
+	}
+
+	// The packages are written as they are loaded, the status can't change once the first page is written
+	filename := "coverage_report_" + report.UUID
+	resp := c.Response()
+	switch format {
+	case api.CoverageReportExportFormatCSV:
+		resp.Header().Set(echo.HeaderContentType, "text/csv")
+		resp.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.csv"`, filename))
+		resp.WriteHeader(http.StatusOK)
+		err = ch.writeCoverageReportCSV(ctx, resp, report)
+	case api.CoverageReportExportFormatCycloneDX:
+		resp.Header().Set(echo.HeaderContentType, mimeTypeCycloneDXJSON)
+		resp.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.cdx.json"`, filename))
+		resp.WriteHeader(http.StatusOK)
+		err = ch.writeCoverageReportCycloneDX(ctx, resp, report)
+	default:
+		resp.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		resp.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.json"`, filename))
+		resp.WriteHeader(http.StatusOK)
+		err = ch.writeCoverageReportJSON(ctx, resp, report)
+	}
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("uuid", report.UUID).Str("format", format).Msg("error writing coverage report export")
+	}
+	return nil
+}
+
+func (ch *CoverageReportHandler) writeCoverageReportCSV(ctx context.Context, resp io.Writer, report api.CoverageReportResponse) error {
+	var catalogSnapshotAt string
+	if report.CatalogSnapshotAt != nil {
+		catalogSnapshotAt = report.CatalogSnapshotAt.Format(time.RFC3339)
+	}
+
+	w := csv.NewWriter(resp)
+	_ = w.Write([]string{"ecosystem", "namespace", "name", "version", "version_constraint", "match_status", "nearest_patch_version", "same_minor_version", "latest_version", "advisory_ids", "highest_severity", "fixed_versions", "catalog_snapshot_at"})
+	return ch.DaoRegistry.CoverageReport.ExportPackages(ctx, report.UUID, func(packages []api.CoverageReportPackageResponse) error {
+		for _, pkg := range packages {
+			var suggested api.CoverageSuggestedVersions
+			if pkg.SuggestedVersions != nil {
+				suggested = *pkg.SuggestedVersions
+			}
+			_ = w.Write([]string{
+				pkg.Ecosystem,
+				pkg.Namespace,
+				pkg.Name,
+				pkg.Version,
+				pkg.VersionConstraint,
+				pkg.MatchStatus,
+				suggested.NearestPatch,
+				suggested.SameMinor,
+				suggested.Latest,
+				strings.Join(pkg.AdvisoryIDs, " "),
+				pkg.HighestSeverity,
+				strings.Join(pkg.FixedVersions, " "),
+				catalogSnapshotAt,
+			})
+		}
+		w.Flush()
+		return w.Error()
+	})
+}
+
+// writeCoverageReportJSON writes the report and its packages in the format of api.CoverageReportExportResponse
+func (ch *CoverageReportHandler) writeCoverageReportJSON(ctx context.Context, resp io.Writer, report api.CoverageReportResponse) error {
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(resp, `{"report":%s,"packages":[`, reportJSON); err != nil {
+		return err
+	}
+	packages := jsonArrayWriter{w: resp}
+	err = ch.DaoRegistry.CoverageReport.ExportPackages(ctx, report.UUID, func(page []api.CoverageReportPackageResponse) error {
+		for _, pkg := range page {
+			if err := packages.write(pkg); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(resp, "]}\n")
+	return err
+}
+
+// jsonArrayWriter writes the elements of a json array as they are added, the brackets are written by the caller
+type jsonArrayWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (a *jsonArrayWriter) write(element any) error {
+	data, err := json.Marshal(element)
+	if err != nil {
+		return err
+	}
+	if a.count > 0 {
+		data = append([]byte{','}, data...)
+	}
+	a.count++
+	_, err = a.w.Write(data)
+	return err
+}
+
+type cycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cycloneDXExportComponent struct {
+	Type       string              `json:"type"`
+	BOMRef     string              `json:"bom-ref"`
+	Group      string              `json:"group,omitempty"`
+	Name       string              `json:"name"`
+	Version    string              `json:"version,omitempty"`
+	PURL       string              `json:"purl,omitempty"`
+	Properties []cycloneDXProperty `json:"properties"`
+}
+
+type cycloneDXAffectedVersion struct {
+	Version string `json:"version"`
+	Status  string `json:"status"`
+}
+
+type cycloneDXAffects struct {
+	Ref      string                     `json:"ref"`
+	Versions []cycloneDXAffectedVersion `json:"versions,omitempty"`
+}
+
+type cycloneDXAnalysis struct {
+	State  string `json:"state"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type cycloneDXVulnerability struct {
+	BOMRef   string             `json:"bom-ref"`
+	ID       string             `json:"id"`
+	Analysis cycloneDXAnalysis  `json:"analysis"`
+	Affects  []cycloneDXAffects `json:"affects"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp  time.Time           `json:"timestamp"`
+	Properties []cycloneDXProperty `json:"properties"`
+}
+
+// cycloneDXExport is the BOM written by writeCoverageReportCycloneDX
+type cycloneDXExport struct {
+	BOMFormat       string                     `json:"bomFormat"`
+	SpecVersion     string                     `json:"specVersion"`
+	SerialNumber    string                     `json:"serialNumber"`
+	Version         int                        `json:"version"`
+	Metadata        cycloneDXMetadata          `json:"metadata"`
+	Components      []cycloneDXExportComponent `json:"components"`
+	Vulnerabilities []cycloneDXVulnerability   `json:"vulnerabilities"`
+}
+
+// writeCoverageReportCycloneDX writes a coverage report as a CycloneDX BOM. The coverage of the report and of each
+// component is recorded as properties, and the advisories affecting the components as vulnerabilities. Components are
+// written as they are loaded, the vulnerabilities are written last as they list every component they affect.
+func (ch *CoverageReportHandler) writeCoverageReportCycloneDX(ctx context.Context, resp io.Writer, report api.CoverageReportResponse) error {
+	metadata, err := json.Marshal(coverageReportCycloneDXMetadata(report))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(resp, `{"bomFormat":"CycloneDX","specVersion":"1.5","serialNumber":"urn:uuid:%s","version":1,"metadata":%s,"components":[`,
+		uuid.NewString(), metadata)
+	if err != nil {
+		return err
+	}
+
+	components := jsonArrayWriter{w: resp}
+	vulnerabilities := []cycloneDXVulnerability{}
+	vulnerabilityIndexes := map[string]int{}
+	err = ch.DaoRegistry.CoverageReport.ExportPackages(ctx, report.UUID, func(packages []api.CoverageReportPackageResponse) error {
+		for _, pkg := range packages {
+			component := coveragePackageCycloneDX(pkg, fmt.Sprintf("component-%d", components.count+1))
+			if err := components.write(component); err != nil {
+				return err
+			}
+
+			for _, advisoryID := range pkg.AdvisoryIDs {
+				index, found := vulnerabilityIndexes[advisoryID]
+				if !found {
+					index = len(vulnerabilities)
+					vulnerabilityIndexes[advisoryID] = index
+					vulnerabilities = append(vulnerabilities, cycloneDXVulnerability{
+						BOMRef: advisoryID,
+						ID:     advisoryID,
+						Analysis: cycloneDXAnalysis{
+							State:  "in_triage",
+							Detail: "The component version is lower than the versions fixing the advisory.",
+						},
+					})
+				}
+				vulnerabilities[index].Affects = append(vulnerabilities[index].Affects, cycloneDXAffects{
+					Ref:      component.BOMRef,
+					Versions: []cycloneDXAffectedVersion{{Version: pkg.Version, Status: "affected"}},
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	vulnerabilitiesJSON, err := json.Marshal(vulnerabilities)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(resp, `],"vulnerabilities":%s}`+"\n", vulnerabilitiesJSON)
+	return err
+}
+
+func coverageReportCycloneDXMetadata(report api.CoverageReportResponse) cycloneDXMetadata {
+	timestamp := report.CreatedAt
+	if report.CompletedAt != nil {
+		timestamp = *report.CompletedAt
+	}
+	metadata := cycloneDXMetadata{
+		Timestamp: timestamp.UTC(),
+		Properties: []cycloneDXProperty{
+			{Name: cycloneDXPropertyPrefix + "report_uuid", Value: report.UUID},
+			{Name: cycloneDXPropertyPrefix + "total", Value: strconv.Itoa(report.Total)},
+			{Name: cycloneDXPropertyPrefix + "exact_matches", Value: strconv.Itoa(report.ExactMatches)},
+			{Name: cycloneDXPropertyPrefix + "partial_matches", Value: strconv.Itoa(report.PartialMatches)},
+			{Name: cycloneDXPropertyPrefix + "unmatched", Value: strconv.Itoa(report.Unmatched)},
+		},
+	}
+	if report.CatalogSnapshotAt != nil {
+		metadata.Properties = append(metadata.Properties, cycloneDXProperty{Name: cycloneDXPropertyPrefix + "catalog_snapshot_at", Value: report.CatalogSnapshotAt.UTC().Format(time.RFC3339)})
+	}
+	for _, summary := range report.EcosystemCoverageSummary {
+		prefix := cycloneDXPropertyPrefix + "ecosystem:" + summary.Ecosystem + ":"
+		metadata.Properties = append(metadata.Properties,
+			cycloneDXProperty{Name: prefix + "total", Value: strconv.Itoa(summary.Total)},
+			cycloneDXProperty{Name: prefix + "exact_matches", Value: strconv.Itoa(summary.ExactMatches)},
+			cycloneDXProperty{Name: prefix + "partial_matches", Value: strconv.Itoa(summary.PartialMatches)},
+			cycloneDXProperty{Name: prefix + "unmatched", Value: strconv.Itoa(summary.Unmatched)},
+		)
+	}
+	return metadata
+}
+
+func coveragePackageCycloneDX(pkg api.CoverageReportPackageResponse, bomRef string) cycloneDXExportComponent {
+	component := cycloneDXExportComponent{
+		Type:    "library",
+		BOMRef:  bomRef,
+		Group:   pkg.Namespace,
+		Name:    pkg.Name,
+		Version: pkg.Version,
+		PURL:    coveragePackagePURL(pkg),
+		Properties: []cycloneDXProperty{
+			{Name: cycloneDXPropertyPrefix + "match_status", Value: pkg.MatchStatus},
+			{Name: cycloneDXPropertyPrefix + "covered", Value: strconv.FormatBool(pkg.Covered)},
+		},
+	}
+	if pkg.VersionConstraint != "" {
+		component.Properties = append(component.Properties, cycloneDXProperty{Name: cycloneDXPropertyPrefix + "version_constraint", Value: pkg.VersionConstraint})
+	}
+	if pkg.SuggestedVersions != nil {
+		for _, suggested := range []cycloneDXProperty{
+			{Name: "nearest_patch_version", Value: pkg.SuggestedVersions.NearestPatch},
+			{Name: "same_minor_version", Value: pkg.SuggestedVersions.SameMinor},
+			{Name: "latest_version", Value: pkg.SuggestedVersions.Latest},
+		} {
+			if suggested.Value != "" {
+				component.Properties = append(component.Properties, cycloneDXProperty{Name: cycloneDXPropertyPrefix + suggested.Name, Value: suggested.Value})
+			}
+		}
+	}
+	if pkg.HighestSeverity != "" {
+		component.Properties = append(component.Properties,
+			cycloneDXProperty{Name: cycloneDXPropertyPrefix + "highest_severity", Value: pkg.HighestSeverity},
+			cycloneDXProperty{Name: cycloneDXPropertyPrefix + "fixed_versions", Value: strings.Join(pkg.FixedVersions, " ")},
+		)
+	}
+	return component
+}
+
+// coveragePackagePURL returns the Package URL of a package, such as pkg:npm/%40babel/core@7.0.0, or an empty string
+// for unknown ecosystems
+func coveragePackagePURL(pkg api.CoverageReportPackageResponse) string {
+	var purl string
+	switch pkg.Ecosystem {
+	case matcher.EcosystemJava:
+		purl = "pkg:maven/"
+		if pkg.Namespace != "" {
+			purl += url.PathEscape(pkg.Namespace) + "/"
+		}
+		purl += url.PathEscape(pkg.Name)
+	case matcher.EcosystemPython:
+		purl = "pkg:pypi/" + url.PathEscape(strings.ToLower(pkg.Name))
+	case matcher.EcosystemNpm:
+		purl = "pkg:npm/"
+		if pkg.Namespace != "" {
+			purl += "%40" + url.PathEscape(strings.TrimPrefix(pkg.Namespace, "@")) + "/"
+		}
+		purl += url.PathEscape(pkg.Name)
+	default:
+		return ""
+	}
+	if pkg.Version != "" {
+		purl += "@" + url.PathEscape(pkg.Version)
+	}
+	return purl
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	ce "github.com/content-services/content-sources-backend/pkg/errors"
+	"github.com/content-services/content-sources-backend/pkg/test"
+	test_handler "github.com/content-services/content-sources-backend/pkg/test/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func coverageReportExport(reportUUID string) api.CoverageReportExportResponse {
+	snapshotAt := time.Date(2026, 10, 1, 6, 0, 0, 0, time.UTC)
+	return api.CoverageReportExportResponse{
+		Report: api.CoverageReportResponse{
+			UUID:              reportUUID,
+			Status:            "completed",
+			CompletedAt:       &snapshotAt,
+			CatalogSnapshotAt: &snapshotAt,
+			Total:             3,
+			ExactMatches:      1,
+			PartialMatches:    1,
+			Unmatched:         1,
+			EcosystemCoverageSummary: []api.EcosystemCoverageSummary{
+				{Ecosystem: "Java", Total: 1, PartialMatches: 1},
+				{Ecosystem: "Python", Total: 1, ExactMatches: 1},
+				{Ecosystem: "npm", Total: 1, Unmatched: 1},
+			},
+		},
+		Packages: []api.CoverageReportPackageResponse{
+			{
+				Ecosystem:         "Java",
+				Namespace:         "org.springframework",
+				Name:              "spring-core",
+				Version:           "5.3.20",
+				Covered:           true,
+				MatchStatus:       "partial",
+				SuggestedVersions: &api.CoverageSuggestedVersions{NearestPatch: "5.3.27", Latest: "6.1.5"},
+			},
+			{
+				Ecosystem:       "Python",
+				Name:            "urllib3",
+				Version:         "2.0.5",
+				Covered:         true,
+				MatchStatus:     "exact",
+				AdvisoryIDs:     []string{"GHSA-v845-jxx5-vc9f"},
+				HighestSeverity: "moderate",
+				FixedVersions:   []string{"2.0.6"},
+			},
+			{Ecosystem: "npm", Namespace: "@babel", Name: "core", Version: "7.0.0", MatchStatus: "none"},
+		},
+	}
+}
+
+// mockCoverageReportExport mocks the export of a report, passing its packages to the writer in pages of two packages
+func (suite *CoverageReportSuite) mockCoverageReportExport(export api.CoverageReportExportResponse) {
+	suite.reg.CoverageReport.On("FetchExport", test.MockCtx(), test_handler.MockOrgId, export.Report.UUID).Return(export.Report, nil)
+	suite.reg.CoverageReport.On("ExportPackages", test.MockCtx(), export.Report.UUID, mock.Anything).
+		Return(func(_ context.Context, _ string, write func([]api.CoverageReportPackageResponse) error) error {
+			for start := 0; start < len(export.Packages); start += 2 {
+				if err := write(export.Packages[start:min(start+2, len(export.Packages))]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+func (suite *CoverageReportSuite) TestExportCoverageReportCSV() {
+	t := suite.T()
+	reportUUID := "550e8400-e29b-41d4-a716-446655440000"
+	suite.mockCoverageReportExport(coverageReportExport(reportUUID))
+
+	path := fmt.Sprintf("%s/coverage_reports/%s/export?format=csv", api.FullRootPath(), reportUUID)
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, body, err := suite.serveCoverageReportRouter(req, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	records, err := csv.NewReader(strings.NewReader(string(body))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	assert.Equal(t, []string{"npm", "@babel", "core", "7.0.0", "", "none", "", "", "", "", "", "", "2026-10-01T06:00:00Z"}, records[3])
+	assert.Equal(t, "match_status", records[0][5])
+	assert.Equal(t, []string{"Java", "org.springframework", "spring-core", "5.3.20", "", "partial", "5.3.27", "", "6.1.5", "", "", "", "2026-10-01T06:00:00Z"}, records[1])
+	assert.Equal(t, []string{"Python", "", "urllib3", "2.0.5", "", "exact", "", "", "", "GHSA-v845-jxx5-vc9f", "moderate", "2.0.6", "2026-10-01T06:00:00Z"}, records[2])
+}
+
+func (suite *CoverageReportSuite) TestExportCoverageReportJSON() {
+	t := suite.T()
+	reportUUID := "550e8400-e29b-41d4-a716-446655440000"
+	expected := coverageReportExport(reportUUID)
+	suite.mockCoverageReportExport(expected)
+
+	path := fmt.Sprintf("%s/coverage_reports/%s/export", api.FullRootPath(), reportUUID)
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, body, err := suite.serveCoverageReportRouter(req, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	var response api.CoverageReportExportResponse
+	require.NoError(t, json.Unmarshal(body, &response))
+	assert.Equal(t, expected.Packages, response.Packages)
+	assert.Equal(t, expected.Report.EcosystemCoverageSummary, response.Report.EcosystemCoverageSummary)
+	assert.True(t, expected.Report.CatalogSnapshotAt.Equal(*response.Report.CatalogSnapshotAt))
+}
+
+func (suite *CoverageReportSuite) TestExportCoverageReportCycloneDX() {
+	t := suite.T()
+	reportUUID := "550e8400-e29b-41d4-a716-446655440000"
+	suite.mockCoverageReportExport(coverageReportExport(reportUUID))
+
+	path := fmt.Sprintf("%s/coverage_reports/%s/export?format=cyclonedx", api.FullRootPath(), reportUUID)
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, body, err := suite.serveCoverageReportRouter(req, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	var bom cycloneDXExport
+	require.NoError(t, json.Unmarshal(body, &bom))
+	assert.Equal(t, "CycloneDX", bom.BOMFormat)
+	assert.Contains(t, bom.Metadata.Properties, cycloneDXProperty{Name: "lightwell:coverage:catalog_snapshot_at", Value: "2026-10-01T06:00:00Z"})
+	assert.Contains(t, bom.Metadata.Properties, cycloneDXProperty{Name: "lightwell:coverage:ecosystem:Java:partial_matches", Value: "1"})
+
+	require.Len(t, bom.Components, 3)
+	assert.Equal(t, []string{"component-1", "component-2", "component-3"}, []string{bom.Components[0].BOMRef, bom.Components[1].BOMRef, bom.Components[2].BOMRef})
+	assert.Equal(t, "pkg:maven/org.springframework/spring-core@5.3.20", bom.Components[0].PURL)
+	assert.Equal(t, []cycloneDXProperty{
+		{Name: "lightwell:coverage:match_status", Value: "partial"},
+		{Name: "lightwell:coverage:covered", Value: "true"},
+		{Name: "lightwell:coverage:nearest_patch_version", Value: "5.3.27"},
+		{Name: "lightwell:coverage:latest_version", Value: "6.1.5"},
+	}, bom.Components[0].Properties)
+	assert.Equal(t, "pkg:pypi/urllib3@2.0.5", bom.Components[1].PURL)
+	assert.Equal(t, "pkg:npm/%40babel/core@7.0.0", bom.Components[2].PURL)
+
+	require.Len(t, bom.Vulnerabilities, 1)
+	assert.Equal(t, "GHSA-v845-jxx5-vc9f", bom.Vulnerabilities[0].ID)
+	assert.Equal(t, []cycloneDXAffects{{
+		Ref:      bom.Components[1].BOMRef,
+		Versions: []cycloneDXAffectedVersion{{Version: "2.0.5", Status: "affected"}},
+	}}, bom.Vulnerabilities[0].Affects)
+}
+
+func (suite *CoverageReportSuite) TestExportCoverageReportInvalidFormat() {
+	t := suite.T()
+
+	path := fmt.Sprintf("%s/coverage_reports/%s/export?format=xlsx", api.FullRootPath(), "550e8400-e29b-41d4-a716-446655440000")
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, _, err := suite.serveCoverageReportRouter(req, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func (suite *CoverageReportSuite) TestExportCoverageReportNotCompleted() {
+	t := suite.T()
+	reportUUID := "550e8400-e29b-41d4-a716-446655440000"
+	suite.reg.CoverageReport.On("FetchExport", test.MockCtx(), test_handler.MockOrgId, reportUUID).
+		Return(api.CoverageReportResponse{}, &ce.DaoError{Message: "Coverage report is not completed", BadValidation: true})
+
+	path := fmt.Sprintf("%s/coverage_reports/%s/export?format=csv", api.FullRootPath(), reportUUID)
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, _, err := suite.serveCoverageReportRouter(req, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestCoveragePackagePURL(t *testing.T) {
+	assert.Equal(t, "pkg:maven/io.netty/netty-codec-http@4.1.108.Final", coveragePackagePURL(api.CoverageReportPackageResponse{Ecosystem: "Java", Namespace: "io.netty", Name: "netty-codec-http", Version: "4.1.108.Final"}))
+	assert.Equal(t, "pkg:pypi/django", coveragePackagePURL(api.CoverageReportPackageResponse{Ecosystem: "Python", Name: "Django"}))
+	assert.Equal(t, "pkg:npm/lodash@4.17.21", coveragePackagePURL(api.CoverageReportPackageResponse{Ecosystem: "npm", Name: "lodash", Version: "4.17.21"}))
+	assert.Empty(t, coveragePackagePURL(api.CoverageReportPackageResponse{Ecosystem: "Go", Name: "cobra"}))
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
 	"io"
@@ -9,11 +10,15 @@ import (
 
 	"github.com/content-services/content-sources-backend/pkg/api"
 	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/coverage/parser"
 	"github.com/content-services/content-sources-backend/pkg/dao"
 	"github.com/content-services/content-sources-backend/pkg/db"
 	ce "github.com/content-services/content-sources-backend/pkg/errors"
 	"github.com/content-services/content-sources-backend/pkg/rbac"
 	"github.com/content-services/content-sources-backend/pkg/seeds"
+	"github.com/content-services/content-sources-backend/pkg/tasks"
+	"github.com/content-services/content-sources-backend/pkg/tasks/client"
+	"github.com/content-services/content-sources-backend/pkg/tasks/queue"
 	"github.com/content-services/content-sources-backend/pkg/utils"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -24,6 +29,7 @@ const maxCoverageUploadSizeBytes = 500 * 1024 * 1024 // 500 MiB
 
 type CoverageReportHandler struct {
 	DaoRegistry dao.DaoRegistry
+	TaskClient  client.TaskClient
 }
 
 func checkLightwellBeaconAndLensAccessible(next echo.HandlerFunc) echo.HandlerFunc {
@@ -35,13 +41,17 @@ func checkLightwellBeaconAndLensAccessible(next echo.HandlerFunc) echo.HandlerFu
 	}
 }
 
-func RegisterCoverageReportRoutes(engine *echo.Group, daoReg *dao.DaoRegistry) {
+func RegisterCoverageReportRoutes(engine *echo.Group, daoReg *dao.DaoRegistry, taskClient *client.TaskClient) {
 	ch := CoverageReportHandler{
 		DaoRegistry: *daoReg,
+		TaskClient:  *taskClient,
 	}
 	addRepoRoute(engine, http.MethodPost, "/coverage_reports/", ch.createCoverageReport, rbac.RbacVerbWrite, checkLightwellBeaconAndLensAccessible)
 	addRepoRoute(engine, http.MethodGet, "/coverage_reports/:uuid", ch.getCoverageReport, rbac.RbacVerbRead, checkLightwellBeaconAndLensAccessible)
 	addRepoRoute(engine, http.MethodGet, "/coverage_reports/:uuid/packages", ch.listCoverageReportPackages, rbac.RbacVerbRead, checkLightwellBeaconAndLensAccessible)
+	addRepoRoute(engine, http.MethodGet, "/coverage_reports/:uuid/compare/:other_uuid", ch.compareCoverageReports, rbac.RbacVerbRead, checkLightwellBeaconAndLensAccessible)
+	addRepoRoute(engine, http.MethodGet, "/coverage_reports/:uuid/export", ch.exportCoverageReport, rbac.RbacVerbRead, checkLightwellBeaconAndLensAccessible)
+	addRepoRoute(engine, http.MethodPost, "/coverage_reports/:uuid/refresh/", ch.refreshCoverageReport, rbac.RbacVerbWrite, checkLightwellBeaconAndLensAccessible)
 }
 
 // CreateCoverageReport godoc
@@ -89,7 +99,16 @@ func (ch *CoverageReportHandler) createCoverageReport(c echo.Context) error {
 	sha256Hex := hex.EncodeToString(hash.Sum(nil))
 	sizeBytes := int64(len(fileBytes))
 
-	reportParams := dao.CreateCoverageReportParams{OrgID: orgID}
+	parsed, err := parser.Parse(fileHeader.Filename, bytes.NewReader(fileBytes))
+	if err != nil {
+		return ce.NewErrorResponse(http.StatusBadRequest, "Error parsing manifest", err.Error())
+	}
+
+	reportParams := dao.CreateCoverageReportParams{
+		OrgID:       orgID,
+		InputFormat: parsed.InputFormat,
+		Warnings:    parsed.Warnings,
+	}
 	if accountID != "" {
 		reportParams.AccountID = utils.Ptr(accountID)
 	}
@@ -146,13 +165,14 @@ func (ch *CoverageReportHandler) getCoverageReport(c echo.Context) error {
 // ListCoverageReportPackages godoc
 // @Summary      List coverage report packages
 // @ID           listCoverageReportPackages
-// @Description  Return paginated packages for a completed coverage report.
+// @Description  Return paginated packages for a completed coverage report. Advisories are matched from their fixed versions only: a covered version is considered affected when an advisory is fixed in a later version and no fix exists at or below it on its own minor line. Advisories do not record the version introducing the vulnerability, so versions released before it may be reported as affected.
 // @Tags         coverage_reports
 // @Produce      json
 // @Param        uuid path string true "Coverage report UUID"
 // @Param        search query string false "Filter by package name"
 // @Param        ecosystem query string false "Filter by ecosystem"
 // @Param        covered query bool false "Filter by coverage status (true = covered, false = not covered)"
+// @Param        vulnerable query bool false "Filter by known advisories affecting the package version (true = covered and vulnerable, false = not vulnerable). Approximated from the fixed versions of the advisories."
 // @Param        offset query int false "Starting point for pagination. Default: 0"
 // @Param        limit query int false "Number of items per page. Default: 100"
 // @Success      200 {object} api.CoverageReportPackageCollectionResponse
@@ -177,3 +197,94 @@ func (ch *CoverageReportHandler) listCoverageReportPackages(c echo.Context) erro
 
 	return c.JSON(http.StatusOK, setCollectionResponseMetadata(&response, c, totalCount))
 }
+
+// CompareCoverageReports godoc
+// @Summary      Compare coverage reports
+// @ID           compareCoverageReports
+// @Description  Return the changes from a completed coverage report to another one, such as the report of the manifest uploaded in the next sprint: packages newly covered or uncovered, version changes, and the change of the counts of each ecosystem.
+// @Tags         coverage_reports
+// @Produce      json
+// @Param        uuid path string true "Coverage report UUID"
+// @Param        other_uuid path string true "UUID of the coverage report to compare to"
+// @Success      200 {object} api.CoverageReportComparisonResponse
+// @Failure      400 {object} ce.ErrorResponse
+// @Failure      404 {object} ce.ErrorResponse
+// @Failure      500 {object} ce.ErrorResponse
+// @Router       /coverage_reports/{uuid}/compare/{other_uuid} [get]
+func (ch *CoverageReportHandler) compareCoverageReports(c echo.Context) error {
+	_, orgID := getAccountIdOrgId(c)
+
+	comparison, err := ch.DaoRegistry.CoverageReport.Compare(c.Request().Context(), orgID, c.Param("uuid"), c.Param("other_uuid"))
+	if err != nil {
+		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error comparing coverage reports", err.Error())
+	}
+
+	return c.JSON(http.StatusOK, comparison)
+}
+
+// RefreshCoverageReport godoc
+// @Summary      Refresh coverage report
+// @ID           refreshCoverageReport
+// @Description  Match the packages of a completed coverage report against the current catalog, without uploading the manifest again. The result is a new pending coverage report linked to the refreshed one, completed by the task of its analysis_task_uuid. Set scheduled_refresh to re-evaluate the new report periodically and be notified when its coverage improves.
+// @Tags         coverage_reports
+// @Accept       json
+// @Produce      json
+// @Param        uuid path string true "Coverage report UUID"
+// @Param        body body api.CoverageReportRefreshRequest false "Refresh options"
+// @Success      201 {object} api.CoverageReportResponse
+// @Failure      400 {object} ce.ErrorResponse
+// @Failure      404 {object} ce.ErrorResponse
+// @Failure      500 {object} ce.ErrorResponse
+// @Router       /coverage_reports/{uuid}/refresh/ [post]
+func (ch *CoverageReportHandler) refreshCoverageReport(c echo.Context) error {
+	_, orgID := getAccountIdOrgId(c)
+	ctx := c.Request().Context()
+
+	req := api.CoverageReportRefreshRequest{}
+	if err := c.Bind(&req); err != nil {
+		return ce.NewErrorResponse(http.StatusBadRequest, "Error binding parameters", err.Error())
+	}
+
+	report, err := ch.DaoRegistry.CoverageReport.Fetch(ctx, orgID, c.Param("uuid"))
+	if err != nil {
+		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error fetching coverage report", err.Error())
+	}
+	if report.Status != config.TaskStatusCompleted {
+		return ce.NewErrorResponse(http.StatusBadRequest, "Error refreshing coverage report", "Coverage report is not completed")
+	}
+
+	refreshed, err := ch.DaoRegistry.CoverageReport.Refresh(ctx, orgID, report.UUID, dao.RefreshCoverageReportParams{
+		ScheduledRefresh: req.ScheduledRefresh,
+	})
+	if err != nil {
+		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error refreshing coverage report", err.Error())
+	}
+
+	taskID, err := ch.enqueueRefreshCoverageReportTask(c, refreshed.UUID)
+	if err != nil {
+		if failErr := ch.DaoRegistry.CoverageReport.InternalOnly_FailAnalysis(ctx, refreshed.UUID, err); failErr != nil {
+			log.Error().Err(failErr).Str("uuid", refreshed.UUID).Msg("failed to mark coverage report refresh as failed")
+		}
+		return ce.NewErrorResponse(http.StatusInternalServerError, "Error enqueueing task", err.Error())
+	}
+	if err := ch.DaoRegistry.CoverageReport.SetAnalysisTask(ctx, orgID, refreshed.UUID, taskID.String()); err != nil {
+		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error setting coverage report task", err.Error())
+	}
+	refreshed.AnalysisTaskUUID = taskID.String()
+
+	return c.JSON(http.StatusCreated, refreshed)
+}
+
+func (ch *CoverageReportHandler) enqueueRefreshCoverageReportTask(c echo.Context, reportUUID string) (uuid.UUID, error) {
+	accountID, orgID := getAccountIdOrgId(c)
+
+	task := queue.Task{
+		Typename:  config.RefreshCoverageReportTask,
+		Payload:   tasks.RefreshCoverageReportPayload{ReportUUID: reportUUID},
+		OrgId:     orgID,
+		AccountId: accountID,
+		RequestID: c.Response().Header().Get(config.HeaderRequestId),
+	}
+
+	return enqueueTask(ch.TaskClient, task)
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -17,8 +18,12 @@ import (
 	ce "github.com/content-services/content-sources-backend/pkg/errors"
 	"github.com/content-services/content-sources-backend/pkg/middleware"
 	"github.com/content-services/content-sources-backend/pkg/seeds"
+	"github.com/content-services/content-sources-backend/pkg/tasks"
+	"github.com/content-services/content-sources-backend/pkg/tasks/client"
+	"github.com/content-services/content-sources-backend/pkg/tasks/queue"
 	"github.com/content-services/content-sources-backend/pkg/test"
 	test_handler "github.com/content-services/content-sources-backend/pkg/test/handler"
+	"github.com/content-services/content-sources-backend/pkg/utils"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/redhatinsights/platform-go-middlewares/v2/identity"
@@ -30,7 +35,8 @@ import (
 
 type CoverageReportSuite struct {
 	suite.Suite
-	reg *dao.MockDaoRegistry
+	reg    *dao.MockDaoRegistry
+	tcMock *client.MockTaskClient
 }
 
 func TestCoverageReportSuite(t *testing.T) {
@@ -39,6 +45,7 @@ func TestCoverageReportSuite(t *testing.T) {
 
 func (suite *CoverageReportSuite) SetupTest() {
 	suite.reg = dao.GetMockDaoRegistry(suite.T())
+	suite.tcMock = client.NewMockTaskClient(suite.T())
 }
 
 func (suite *CoverageReportSuite) serveCoverageReportRouter(req *http.Request, enabled bool, authorized bool) (int, []byte, error) {
@@ -59,7 +66,8 @@ func (suite *CoverageReportSuite) serveCoverageReportRouter(req *http.Request, e
 		config.Get().Features.LightwellBeaconAndLens.Accounts = &[]string{seeds.RandomAccountId()}
 	}
 
-	RegisterCoverageReportRoutes(pathPrefix, suite.reg.ToDaoRegistry())
+	var tc client.TaskClient = suite.tcMock
+	RegisterCoverageReportRoutes(pathPrefix, suite.reg.ToDaoRegistry(), &tc)
 
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
@@ -126,6 +134,52 @@ func (suite *CoverageReportSuite) TestGetCoverageReportNotAccessible() {
 	assert.Contains(t, string(body), "Neither the user nor account is allowed")
 }
 
+func (suite *CoverageReportSuite) TestCompareCoverageReports() {
+	t := suite.T()
+	orgID := test_handler.MockOrgId
+	reportUUID := "550e8400-e29b-41d4-a716-446655440000"
+	otherUUID := "550e8400-e29b-41d4-a716-446655440001"
+
+	expected := api.CoverageReportComparisonResponse{
+		UUID:            reportUUID,
+		OtherUUID:       otherUUID,
+		NewlyCovered:    []api.CoverageReportPackageResponse{{Name: "flask", Version: "3.0.3", Ecosystem: "Python", Covered: true}},
+		NewlyUncovered:  []api.CoverageReportPackageResponse{},
+		VersionChanges:  []api.CoverageReportVersionChange{{Name: "flask", Ecosystem: "Python", Version: "2.0.0", OtherVersion: "3.0.3", OtherCovered: true}},
+		EcosystemDeltas: []api.EcosystemCoverageSummary{{Ecosystem: "Python", ExactMatches: 1, Unmatched: -1}},
+	}
+	suite.reg.CoverageReport.On("Compare", test.MockCtx(), orgID, reportUUID, otherUUID).Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/coverage_reports/%s/compare/%s", api.FullRootPath(), reportUUID, otherUUID), nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, body, err := suite.serveCoverageReportRouter(req, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	var response api.CoverageReportComparisonResponse
+	assert.NoError(t, json.Unmarshal(body, &response))
+	assert.Equal(t, expected, response)
+}
+
+func (suite *CoverageReportSuite) TestCompareCoverageReportsNotCompleted() {
+	t := suite.T()
+	orgID := test_handler.MockOrgId
+	reportUUID := "550e8400-e29b-41d4-a716-446655440000"
+	otherUUID := "550e8400-e29b-41d4-a716-446655440001"
+
+	suite.reg.CoverageReport.On("Compare", test.MockCtx(), orgID, reportUUID, otherUUID).
+		Return(api.CoverageReportComparisonResponse{}, &ce.DaoError{Message: "Coverage report is not completed", BadValidation: true})
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/coverage_reports/%s/compare/%s", api.FullRootPath(), reportUUID, otherUUID), nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, body, err := suite.serveCoverageReportRouter(req, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, string(body), "not completed")
+}
+
 func (suite *CoverageReportSuite) TestListCoverageReportPackages() {
 	t := suite.T()
 	orgID := test_handler.MockOrgId
@@ -219,6 +273,41 @@ func (suite *CoverageReportSuite) TestListCoverageReportPackagesWithFilters() {
 	assert.True(t, response.Data[0].Covered)
 }
 
+func (suite *CoverageReportSuite) TestListCoverageReportPackagesVulnerable() {
+	t := suite.T()
+	orgID := test_handler.MockOrgId
+	reportUUID := "550e8400-e29b-41d4-a716-446655440000"
+
+	expectedResp := api.CoverageReportPackageCollectionResponse{
+		Data: []api.CoverageReportPackageResponse{{
+			Name:            "urllib3",
+			Version:         "2.0.5",
+			Ecosystem:       "Python",
+			Covered:         true,
+			AdvisoryIDs:     []string{"GHSA-v845-jxx5-vc9f"},
+			HighestSeverity: "moderate",
+			FixedVersions:   []string{"2.0.6"},
+		}},
+	}
+
+	suite.reg.CoverageReport.On("ListPackages", test.MockCtx(), orgID, reportUUID,
+		api.PaginationData{Limit: DefaultLimit, Offset: DefaultOffset, SortBy: DefaultSortBy},
+		api.ListCoverageReportPackagesRequest{Vulnerable: utils.Ptr(true)},
+	).Return(expectedResp, int64(1), nil)
+
+	path := fmt.Sprintf("%s/coverage_reports/%s/packages?vulnerable=true", api.FullRootPath(), reportUUID)
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, body, err := suite.serveCoverageReportRouter(req, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	var response api.CoverageReportPackageCollectionResponse
+	assert.NoError(t, json.Unmarshal(body, &response))
+	assert.Equal(t, expectedResp.Data, response.Data)
+}
+
 func (suite *CoverageReportSuite) TestCreateCoverageReport() {
 	t := suite.T()
 	reqBody := &bytes.Buffer{}
@@ -252,6 +341,63 @@ func (suite *CoverageReportSuite) TestCreateCoverageReport() {
 	assert.Equal(t, expectedReport.UUID, response.UUID)
 }
 
+func (suite *CoverageReportSuite) TestCreateCoverageReportWarnings() {
+	t := suite.T()
+	reqBody := &bytes.Buffer{}
+	writer := multipart.NewWriter(reqBody)
+	part, err := writer.CreateFormFile("file", "pom.xml")
+	require.NoError(t, err)
+	_, err = part.Write([]byte(`<project><dependencies>
+		<dependency><groupId>org.example</groupId><artifactId>pinned</artifactId><version>1.0</version></dependency>
+		<dependency><groupId>org.example</groupId><artifactId>managed</artifactId></dependency>
+	</dependencies></project>`))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	warnings := []string{"pom.xml dependency org.example:managed: version is not managed in this file"}
+	expectedReport := api.CoverageReportResponse{
+		UUID:        uuid.NewString(),
+		Status:      config.TaskStatusPending,
+		InputFormat: "pom.xml",
+		Warnings:    warnings,
+	}
+	suite.reg.CoverageReport.On("Create", mock.Anything, mock.MatchedBy(func(params dao.CreateCoverageReportParams) bool {
+		return params.InputFormat == "pom.xml" && assert.ObjectsAreEqual(warnings, params.Warnings)
+	}), mock.Anything).Return(expectedReport, nil)
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s/coverage_reports/", api.FullRootPath()), reqBody)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, body, err := suite.serveCoverageReportRouter(req, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, code)
+
+	var response api.CoverageReportResponse
+	assert.NoError(t, json.Unmarshal(body, &response))
+	assert.Equal(t, warnings, response.Warnings)
+}
+
+func (suite *CoverageReportSuite) TestCreateCoverageReportUnsupportedFormat() {
+	t := suite.T()
+	reqBody := &bytes.Buffer{}
+	writer := multipart.NewWriter(reqBody)
+	part, err := writer.CreateFormFile("file", "notes.txt")
+	require.NoError(t, err)
+	_, err = part.Write([]byte("not a manifest"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s/coverage_reports/", api.FullRootPath()), reqBody)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, body, err := suite.serveCoverageReportRouter(req, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, string(body), "unsupported manifest format")
+}
+
 func (suite *CoverageReportSuite) TestCreateCoverageReportNotAccessible() {
 	t := suite.T()
 	reqBody := &bytes.Buffer{}
@@ -291,3 +437,128 @@ func (suite *CoverageReportSuite) TestCreateCoverageReportEmptyBody() {
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusBadRequest, code)
 }
+
+func (suite *CoverageReportSuite) TestRefreshCoverageReport() {
+	t := suite.T()
+	orgID := test_handler.MockOrgId
+	reportUUID := "550e8400-e29b-41d4-a716-446655440000"
+	taskUUID := uuid.New()
+
+	suite.reg.CoverageReport.On("Fetch", test.MockCtx(), orgID, reportUUID).
+		Return(api.CoverageReportResponse{UUID: reportUUID, Status: config.TaskStatusCompleted}, nil)
+
+	expected := api.CoverageReportResponse{
+		UUID:             "660e8400-e29b-41d4-a716-446655440000",
+		Status:           config.TaskStatusPending,
+		SourceReportUUID: reportUUID,
+		ScheduledRefresh: true,
+	}
+	suite.reg.CoverageReport.On("Refresh", test.MockCtx(), orgID, reportUUID, dao.RefreshCoverageReportParams{ScheduledRefresh: utils.Ptr(true)}).
+		Return(expected, nil)
+	suite.tcMock.On("Enqueue", coverageRefreshTask(expected.UUID)).Return(taskUUID, nil)
+	suite.reg.CoverageReport.On("SetAnalysisTask", test.MockCtx(), orgID, expected.UUID, taskUUID.String()).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s/coverage_reports/%s/refresh/", api.FullRootPath(), reportUUID),
+		bytes.NewReader([]byte(`{"scheduled_refresh": true}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, body, err := suite.serveCoverageReportRouter(req, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, code)
+
+	var response api.CoverageReportResponse
+	require.NoError(t, json.Unmarshal(body, &response))
+	assert.Equal(t, expected.UUID, response.UUID)
+	assert.Equal(t, config.TaskStatusPending, response.Status)
+	assert.Equal(t, reportUUID, response.SourceReportUUID)
+	assert.Equal(t, taskUUID.String(), response.AnalysisTaskUUID)
+	assert.True(t, response.ScheduledRefresh)
+}
+
+func (suite *CoverageReportSuite) TestRefreshCoverageReportWithoutBody() {
+	t := suite.T()
+	orgID := test_handler.MockOrgId
+	reportUUID := "550e8400-e29b-41d4-a716-446655440000"
+	refreshUUID := "660e8400-e29b-41d4-a716-446655440000"
+	taskUUID := uuid.New()
+
+	suite.reg.CoverageReport.On("Fetch", test.MockCtx(), orgID, reportUUID).
+		Return(api.CoverageReportResponse{UUID: reportUUID, Status: config.TaskStatusCompleted}, nil)
+	suite.reg.CoverageReport.On("Refresh", test.MockCtx(), orgID, reportUUID, dao.RefreshCoverageReportParams{}).
+		Return(api.CoverageReportResponse{UUID: refreshUUID, Status: config.TaskStatusPending}, nil)
+	suite.tcMock.On("Enqueue", coverageRefreshTask(refreshUUID)).Return(taskUUID, nil)
+	suite.reg.CoverageReport.On("SetAnalysisTask", test.MockCtx(), orgID, refreshUUID, taskUUID.String()).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s/coverage_reports/%s/refresh/", api.FullRootPath(), reportUUID), nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, _, err := suite.serveCoverageReportRouter(req, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, code)
+}
+
+func (suite *CoverageReportSuite) TestRefreshCoverageReportEnqueueError() {
+	t := suite.T()
+	orgID := test_handler.MockOrgId
+	reportUUID := "550e8400-e29b-41d4-a716-446655440000"
+	refreshUUID := "660e8400-e29b-41d4-a716-446655440000"
+	enqueueErr := errors.New("queue is down")
+
+	suite.reg.CoverageReport.On("Fetch", test.MockCtx(), orgID, reportUUID).
+		Return(api.CoverageReportResponse{UUID: reportUUID, Status: config.TaskStatusCompleted}, nil)
+	suite.reg.CoverageReport.On("Refresh", test.MockCtx(), orgID, reportUUID, dao.RefreshCoverageReportParams{}).
+		Return(api.CoverageReportResponse{UUID: refreshUUID, Status: config.TaskStatusPending}, nil)
+	suite.tcMock.On("Enqueue", coverageRefreshTask(refreshUUID)).Return(uuid.Nil, enqueueErr)
+	suite.reg.CoverageReport.On("InternalOnly_FailAnalysis", test.MockCtx(), refreshUUID, enqueueErr).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s/coverage_reports/%s/refresh/", api.FullRootPath(), reportUUID), nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, body, err := suite.serveCoverageReportRouter(req, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.Contains(t, string(body), "queue is down")
+}
+
+func (suite *CoverageReportSuite) TestRefreshCoverageReportNotCompleted() {
+	t := suite.T()
+	orgID := test_handler.MockOrgId
+	reportUUID := "550e8400-e29b-41d4-a716-446655440000"
+
+	suite.reg.CoverageReport.On("Fetch", test.MockCtx(), orgID, reportUUID).
+		Return(api.CoverageReportResponse{UUID: reportUUID, Status: config.TaskStatusPending}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s/coverage_reports/%s/refresh/", api.FullRootPath(), reportUUID), nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, body, err := suite.serveCoverageReportRouter(req, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, string(body), "not completed")
+}
+
+func (suite *CoverageReportSuite) TestRefreshCoverageReportNotFound() {
+	t := suite.T()
+	orgID := test_handler.MockOrgId
+	reportUUID := "550e8400-e29b-41d4-a716-446655440000"
+
+	suite.reg.CoverageReport.On("Fetch", test.MockCtx(), orgID, reportUUID).
+		Return(api.CoverageReportResponse{}, &ce.DaoError{Message: "Coverage report not found", NotFound: true})
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s/coverage_reports/%s/refresh/", api.FullRootPath(), reportUUID), nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, _, err := suite.serveCoverageReportRouter(req, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func coverageRefreshTask(reportUUID string) queue.Task {
+	return queue.Task{
+		Typename:  config.RefreshCoverageReportTask,
+		Payload:   tasks.RefreshCoverageReportPayload{ReportUUID: reportUUID},
+		OrgId:     test_handler.MockOrgId,
+		AccountId: test_handler.MockAccountNumber,
+	}
+}
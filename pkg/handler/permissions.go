@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/config"
+	ce "github.com/content-services/content-sources-backend/pkg/errors"
+	"github.com/content-services/content-sources-backend/pkg/rbac"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	permissionsBackendRbac   = "rbac"
+	permissionsBackendKessel = "kessel"
+	permissionsBackendFile   = "file"
+)
+
+type PermissionsHandler struct {
+}
+
+func RegisterPermissionsRoutes(engine *echo.Group) {
+	ph := PermissionsHandler{}
+	addUnrestrictedRoute(engine, http.MethodGet, "/permissions/", ph.listPermissions)
+}
+
+// ListPermissions godoc
+// @Summary      List the permissions of the user
+// @ID           listPermissions
+// @Description  Get the permissions of the user for each resource and verb of the service, as checked by RBAC, Kessel or the local policy file. Permissions on single repositories and templates can be requested as well, they include the permissions granted on the whole organization.
+// @Tags         permissions
+// @Param		 repository_uuids query string false "A comma separated list of repository UUIDs to check permissions on."
+// @Param		 template_uuids query string false "A comma separated list of template UUIDs to check permissions on."
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} api.PermissionsResponse
+// @Failure      400 {object} ce.ErrorResponse
+// @Failure      401 {object} ce.ErrorResponse
+// @Failure      500 {object} ce.ErrorResponse
+// @Router       /permissions/ [get]
+func (ph *PermissionsHandler) listPermissions(c echo.Context) error {
+	repoUUIDs := splitCSVQueryParam(c, "repository_uuids")
+	templateUUIDs := splitCSVQueryParam(c, "template_uuids")
+	if len(repoUUIDs)+len(templateUUIDs) > api.PermissionsLimit {
+		limitErrMsg := fmt.Sprintf("Cannot check permissions on more than %d repositories and templates at once.", api.PermissionsLimit)
+		return ce.NewErrorResponse(http.StatusBadRequest, "Error listing permissions", limitErrMsg)
+	}
+
+	ctx := c.Request().Context()
+	client := rbac.ClientFromContext(ctx)
+	response := api.PermissionsResponse{Permissions: map[string]api.ResourcePermissions{}}
+	if client != nil {
+		response.Backend = permissionsBackendRbac
+		if config.Get().Clients.RbacPolicyFile != "" {
+			response.Backend = permissionsBackendFile
+		}
+		if config.FeatureAccessible(ctx, config.Get().Features.Kessel) {
+			response.Backend = permissionsBackendKessel
+		}
+	}
+
+	verbs := rbac.ServicePermissions.Verbs()
+	for resource, resourceVerbs := range verbs {
+		permissions := api.ResourcePermissions{}
+		for _, verb := range resourceVerbs {
+			// Without a client permissions are not checked, so everything is allowed
+			allowed := true
+			if client != nil {
+				var err error
+				allowed, err = client.Allowed(ctx, resource, verb)
+				if err != nil {
+					return ce.NewErrorResponse(http.StatusInternalServerError, "Error checking permissions", err.Error())
+				}
+			}
+			permissions[string(verb)] = allowed
+		}
+		response.Permissions[string(resource)] = permissions
+	}
+	applyWriteFallback(response.Permissions)
+
+	var err error
+	response.Repositories, err = objectPermissions(ctx, verbs, response.Permissions, rbac.ResourceRepositories, repoUUIDs)
+	if err != nil {
+		return ce.NewErrorResponse(http.StatusInternalServerError, "Error checking repository permissions", err.Error())
+	}
+	response.Templates, err = objectPermissions(ctx, verbs, response.Permissions, rbac.ResourceTemplates, templateUUIDs)
+	if err != nil {
+		return ce.NewErrorResponse(http.StatusInternalServerError, "Error checking template permissions", err.Error())
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// objectPermissions returns the permissions of the user on each repository or template, which are those granted
+// on the organization, along with those granted on the object itself
+func objectPermissions(ctx context.Context, verbs map[rbac.Resource][]rbac.Verb, orgPermissions map[string]api.ResourcePermissions, object rbac.Resource, uuids []string) ([]api.ObjectPermissions, error) {
+	var objects []api.ObjectPermissions
+	for _, uuid := range uuids {
+		permissions := map[string]api.ResourcePermissions{}
+		for resource, resourceVerbs := range verbs {
+			if rbac.ObjectResource(resource) != object {
+				continue
+			}
+			resourcePermissions := api.ResourcePermissions{}
+			for _, verb := range resourceVerbs {
+				allowed := orgPermissions[string(resource)][string(verb)]
+				if !allowed {
+					var err error
+					allowed, err = rbac.AllowedObject(ctx, resource, verb, uuid)
+					if err != nil {
+						return nil, err
+					}
+				}
+				resourcePermissions[string(verb)] = allowed
+			}
+			permissions[string(resource)] = resourcePermissions
+		}
+		applyWriteFallback(permissions)
+		objects = append(objects, api.ObjectPermissions{UUID: uuid, Permissions: permissions})
+	}
+	return objects, nil
+}
+
+// applyWriteFallback allows the verbs that are still granted by the write permission, as checked by the rbac middleware
+func applyWriteFallback(permissions map[string]api.ResourcePermissions) {
+	for resource, resourcePermissions := range permissions {
+		for verb, allowed := range resourcePermissions {
+			fallbackResource, fallbackVerb, ok := rbac.WriteFallback(rbac.Resource(resource), rbac.Verb(verb))
+			if !allowed && ok {
+				resourcePermissions[verb] = permissions[string(fallbackResource)][string(fallbackVerb)]
+			}
+		}
+	}
+}
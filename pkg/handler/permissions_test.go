@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/middleware"
+	"github.com/content-services/content-sources-backend/pkg/rbac"
+	test_handler "github.com/content-services/content-sources-backend/pkg/test/handler"
+	"github.com/labstack/echo/v4"
+	"github.com/redhatinsights/platform-go-middlewares/v2/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type PermissionsSuite struct {
+	suite.Suite
+	client       rbac.ClientWrapper
+	objectClient rbac.ObjectClientWrapper
+}
+
+func TestPermissionsSuite(t *testing.T) {
+	suite.Run(t, new(PermissionsSuite))
+}
+
+func (suite *PermissionsSuite) SetupTest() {
+	suite.client = nil
+	suite.objectClient = nil
+	rbac.ServicePermissions.
+		Add(http.MethodGet, "/repositories/", rbac.ResourceRepositories, rbac.RbacVerbRead).
+		Add(http.MethodPost, "/repositories/", rbac.ResourceRepositories, rbac.RbacVerbWrite).
+		Add(http.MethodDelete, "/repositories/:repo_uuid/snapshots/:snapshot_uuid", rbac.ResourceSnapshots, rbac.RbacVerbDelete).
+		Add(http.MethodGet, "/templates/", rbac.ResourceTemplates, rbac.RbacVerbRead)
+}
+
+func (suite *PermissionsSuite) serveRouter(req *http.Request) (int, []byte, error) {
+	router := echo.New()
+	router.Use(middleware.WrapMiddlewareWithSkipper(identity.EnforceIdentity, middleware.SkipMiddleware))
+	router.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			if suite.client != nil {
+				ctx = rbac.WithClient(ctx, suite.client)
+			}
+			if suite.objectClient != nil {
+				ctx = rbac.WithObjectClient(ctx, suite.objectClient)
+			}
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	})
+	router.HTTPErrorHandler = config.CustomHTTPErrorHandler
+	pathPrefix := router.Group(api.FullRootPath())
+	RegisterPermissionsRoutes(pathPrefix)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	response := rr.Result()
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	return response.StatusCode, body, err
+}
+
+func (suite *PermissionsSuite) listPermissions(query string) api.PermissionsResponse {
+	t := suite.T()
+	path := fmt.Sprintf("%s/permissions/%s", api.FullRootPath(), query)
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, body, err := suite.serveRouter(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code, string(body))
+
+	var response api.PermissionsResponse
+	require.NoError(t, json.Unmarshal(body, &response))
+	return response
+}
+
+func (suite *PermissionsSuite) TestListWithoutClient() {
+	t := suite.T()
+
+	response := suite.listPermissions("")
+	assert.Equal(t, "", response.Backend)
+	assert.True(t, response.Permissions["repositories"]["read"])
+	assert.True(t, response.Permissions["repositories"]["write"])
+	assert.True(t, response.Permissions["snapshots"]["delete"])
+	assert.Empty(t, response.Repositories)
+}
+
+func (suite *PermissionsSuite) TestList() {
+	t := suite.T()
+	client := rbac.NewMockClientWrapper(t)
+	client.On("Allowed", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, _ rbac.Resource, verb rbac.Verb) (bool, error) {
+		return verb == rbac.RbacVerbRead, nil
+	})
+	suite.client = client
+
+	response := suite.listPermissions("")
+	assert.Equal(t, "rbac", response.Backend)
+	assert.True(t, response.Permissions["repositories"]["read"])
+	assert.False(t, response.Permissions["repositories"]["write"])
+	assert.False(t, response.Permissions["snapshots"]["delete"])
+	assert.True(t, response.Permissions["templates"]["read"])
+}
+
+func (suite *PermissionsSuite) TestListPolicyFile() {
+	t := suite.T()
+	policyFile := config.Get().Clients.RbacPolicyFile
+	config.Get().Clients.RbacPolicyFile = "policy.yaml"
+	defer func() { config.Get().Clients.RbacPolicyFile = policyFile }()
+
+	client := rbac.NewMockClientWrapper(t)
+	client.On("Allowed", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	suite.client = client
+
+	response := suite.listPermissions("")
+	assert.Equal(t, "file", response.Backend)
+	assert.True(t, response.Permissions["repositories"]["write"])
+}
+
+func (suite *PermissionsSuite) TestListWriteFallback() {
+	t := suite.T()
+	client := rbac.NewMockClientWrapper(t)
+	client.On("Allowed", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, _ rbac.Resource, verb rbac.Verb) (bool, error) {
+		return verb == rbac.RbacVerbRead || verb == rbac.RbacVerbWrite, nil
+	})
+	suite.client = client
+
+	// Write on repositories still allows the verbs that used to require it
+	response := suite.listPermissions("")
+	assert.True(t, response.Permissions["snapshots"]["delete"])
+	assert.True(t, response.Permissions["snapshots"]["update"])
+	assert.True(t, response.Permissions["content"]["delete"])
+	assert.False(t, response.Permissions["repositories"]["upload"])
+
+	clients := config.Get().Clients
+	defer func() { config.Get().Clients = clients }()
+	config.Get().Clients.RbacWriteFallback = false
+	response = suite.listPermissions("")
+	assert.False(t, response.Permissions["snapshots"]["delete"])
+	assert.True(t, response.Permissions["repositories"]["write"])
+}
+
+func (suite *PermissionsSuite) TestListObjects() {
+	t := suite.T()
+	repoUUID := "9f5e2b3c-3f1d-4c3c-8d7e-6d0f1b2c3a4b"
+	templateUUID := "0c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f"
+
+	client := rbac.NewMockClientWrapper(t)
+	client.On("Allowed", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, _ rbac.Resource, verb rbac.Verb) (bool, error) {
+		return verb == rbac.RbacVerbRead, nil
+	})
+	suite.client = client
+	objectClient := rbac.NewMockObjectClientWrapper(t)
+	objectClient.On("AllowedObject", mock.Anything, rbac.ResourceSnapshots, rbac.RbacVerbDelete, repoUUID).Return(true, nil)
+	objectClient.On("AllowedObject", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	suite.objectClient = objectClient
+
+	response := suite.listPermissions(fmt.Sprintf("?repository_uuids=%s&template_uuids=%s", repoUUID, templateUUID))
+	require.Len(t, response.Repositories, 1)
+	repo := response.Repositories[0]
+	assert.Equal(t, repoUUID, repo.UUID)
+	assert.True(t, repo.Permissions["repositories"]["read"])
+	assert.False(t, repo.Permissions["repositories"]["write"])
+	assert.True(t, repo.Permissions["snapshots"]["delete"])
+	assert.NotContains(t, repo.Permissions, "templates")
+
+	require.Len(t, response.Templates, 1)
+	assert.Equal(t, templateUUID, response.Templates[0].UUID)
+	assert.True(t, response.Templates[0].Permissions["templates"]["read"])
+	assert.NotContains(t, response.Templates[0].Permissions, "repositories")
+
+	// Permissions on the organization are not checked again on each object
+	objectClient.AssertNotCalled(t, "AllowedObject", mock.Anything, mock.Anything, rbac.RbacVerbRead, mock.Anything)
+}
+
+func (suite *PermissionsSuite) TestListTooManyObjects() {
+	t := suite.T()
+	uuids := make([]string, api.PermissionsLimit+1)
+	for i := range uuids {
+		uuids[i] = fmt.Sprintf("uuid-%d", i)
+	}
+
+	path := fmt.Sprintf("%s/permissions/?repository_uuids=%s", api.FullRootPath(), strings.Join(uuids, ","))
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, _, err := suite.serveRouter(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
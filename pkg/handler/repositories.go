@@ -56,23 +56,23 @@ func RegisterRepositoryRoutes(engine *echo.Group, daoReg *dao.DaoRegistry,
 		FeatureServiceClient: *fsClient,
 	}
 
-	addRepoRoute(engine, http.MethodGet, "/repositories/", rh.listRepositories, rbac.RbacVerbRead)
+	addRepoListRoute(engine, http.MethodGet, "/repositories/", rh.listRepositories, rbac.RbacVerbRead)
 	addRepoRoute(engine, http.MethodGet, "/repositories/:uuid", rh.fetch, rbac.RbacVerbRead)
 	addRepoRoute(engine, http.MethodPut, "/repositories/:uuid", rh.fullUpdate, rbac.RbacVerbWrite)
 	addRepoRoute(engine, http.MethodPatch, "/repositories/:uuid", rh.partialUpdate, rbac.RbacVerbWrite)
-	addRepoRoute(engine, http.MethodDelete, "/repositories/:uuid", rh.deleteRepository, rbac.RbacVerbWrite)
+	addRepoRoute(engine, http.MethodDelete, "/repositories/:uuid", rh.deleteRepository, rbac.RbacVerbDelete)
 	addRepoRoute(engine, http.MethodPost, "/repositories/:uuid/add_uploads/", rh.addUploads, rbac.RbacVerbUpload)
 	addRepoRoute(engine, http.MethodPost, "/repositories/uploads/", rh.createUpload, rbac.RbacVerbUpload)
 	addRepoRoute(engine, http.MethodPost, "/repositories/uploads/:upload_uuid/upload_chunk/", rh.uploadChunk, rbac.RbacVerbUpload)
-	addRepoRoute(engine, http.MethodPost, "/repositories/bulk_delete/", rh.bulkDeleteRepositories, rbac.RbacVerbWrite)
-	addRepoRoute(engine, http.MethodPost, "/repositories/", rh.createRepository, rbac.RbacVerbWrite)
-	addRepoRoute(engine, http.MethodPost, "/repositories/bulk_create/", rh.bulkCreateRepositories, rbac.RbacVerbWrite)
+	addRepoRoute(engine, http.MethodPost, "/repositories/bulk_delete/", rh.bulkDeleteRepositories, rbac.RbacVerbDelete)
+	addRepoCreateRoute(engine, http.MethodPost, "/repositories/", rh.createRepository, rbac.RbacVerbWrite)
+	addRepoCreateRoute(engine, http.MethodPost, "/repositories/bulk_create/", rh.bulkCreateRepositories, rbac.RbacVerbWrite)
 	addRepoRoute(engine, http.MethodPost, "/repositories/:uuid/snapshot/", rh.createSnapshot, rbac.RbacVerbWrite)
 	addRepoRoute(engine, http.MethodPost, "/repositories/:uuid/introspect/", rh.introspect, rbac.RbacVerbWrite)
 	addRepoRoute(engine, http.MethodGet, "/repository_gpg_key/:uuid", rh.getGpgKeyFile, rbac.RbacVerbRead)
 	addRepoRoute(engine, http.MethodPost, "/repositories/bulk_export/", rh.bulkExportRepositories, rbac.RbacVerbRead)
-	addRepoRoute(engine, http.MethodPost, "/repositories/bulk_import/", rh.bulkImportRepositories, rbac.RbacVerbWrite)
-	addRepoRoute(engine, http.MethodPost, "/repositories/:uuid/rpms/bulk_remove/", rh.bulkRemoveRpms, rbac.RbacVerbWrite)
+	addRepoCreateRoute(engine, http.MethodPost, "/repositories/bulk_import/", rh.bulkImportRepositories, rbac.RbacVerbWrite)
+	addContentRoute(engine, http.MethodPost, "/repositories/:uuid/rpms/bulk_remove/", rh.bulkRemoveRpms, rbac.RbacVerbDelete)
 }
 
 func getAccountIdOrgId(c echo.Context) (string, string) {
@@ -116,6 +116,7 @@ func (rh *RepositoryHandler) listRepositories(c echo.Context) error {
 	c.Logger().Infof("org_id: %s", orgID)
 	pageData := ParsePagination(c)
 	filterData := ParseFilters(c)
+	filterData.AllowedUUIDs = rbac.AllowedObjects(c.Request().Context())
 
 	repos, totalRepos, err := rh.DaoRegistry.RepositoryConfig.List(c.Request().Context(), orgID, pageData, filterData)
 	if err != nil {
@@ -137,6 +138,7 @@ func (rh *RepositoryHandler) listRepositories(c echo.Context) error {
 // @Header       201  {string}  Location "resource URL"
 // @Failure      400 {object} ce.ErrorResponse
 // @Failure      401 {object} ce.ErrorResponse
+// @Failure      403 {object} ce.ErrorResponse
 // @Failure      404 {object} ce.ErrorResponse
 // @Failure      415 {object} ce.ErrorResponse
 // @Failure      500 {object} ce.ErrorResponse
@@ -156,6 +158,9 @@ func (rh *RepositoryHandler) createRepository(c echo.Context) error {
 	if err = rh.CheckSnapshotForRepo(c, newRepository.Snapshot); err != nil {
 		return err
 	}
+	if err = checkWorkspace(c, rbac.ResourceRepositories, newRepository.WorkspaceID); err != nil {
+		return err
+	}
 
 	var response api.RepositoryResponse
 	if response, err = rh.DaoRegistry.RepositoryConfig.Create(c.Request().Context(), newRepository); err != nil {
@@ -185,6 +190,7 @@ func (rh *RepositoryHandler) createRepository(c echo.Context) error {
 // @Header       201  {string}  Location "resource URL"
 // @Failure      400 {object} ce.ErrorResponse
 // @Failure      401 {object} ce.ErrorResponse
+// @Failure      403 {object} ce.ErrorResponse
 // @Failure      404 {object} ce.ErrorResponse
 // @Failure      415 {object} ce.ErrorResponse
 // @Failure      500 {object} ce.ErrorResponse
@@ -208,6 +214,11 @@ func (rh *RepositoryHandler) bulkCreateRepositories(c echo.Context) error {
 	if err := rh.CheckSnapshotForRepos(c, newRepositories); err != nil {
 		return err
 	}
+	for _, newRepository := range newRepositories {
+		if err := checkWorkspace(c, rbac.ResourceRepositories, newRepository.WorkspaceID); err != nil {
+			return err
+		}
+	}
 
 	responses, errs := rh.DaoRegistry.RepositoryConfig.BulkCreate(c.Request().Context(), newRepositories)
 	if len(errs) > 0 {
@@ -463,6 +474,7 @@ func (rh *RepositoryHandler) bulkDeleteRepositories(c echo.Context) error {
 // @Description     Snapshot a repository if not already snapshotting
 // @Tags			repositories
 // @Param  			uuid            path    string                          true   "Repository ID."
+// @Param			Idempotency-Key header  string                          false  "Key identifying the request. Retrying a request with the same key returns the task it already enqueued."
 // @Success			200 {object} api.TaskInfoResponse
 // @Failure      	400 {object} ce.ErrorResponse
 // @Failure      	404 {object} ce.ErrorResponse
@@ -486,6 +498,14 @@ func (rh *RepositoryHandler) createSnapshot(c echo.Context) error {
 		return ce.NewErrorResponse(http.StatusBadRequest, "Cannot snapshot this repository", "Lightwell repositories cannot be snapshotted")
 	}
 
+	retried, err := rh.fetchTaskForRetriedRequest(c, orgID, config.RepositorySnapshotTask, response.RepositoryUUID)
+	if err != nil {
+		return err
+	}
+	if retried != nil {
+		return c.JSON(http.StatusOK, retried)
+	}
+
 	taskIDs, err := rh.DaoRegistry.TaskInfo.FetchActiveTasks(c.Request().Context(), orgID, response.RepositoryUUID, config.RepositorySnapshotTask)
 	if err != nil {
 		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error checking snapshot task", err.Error())
@@ -516,6 +536,7 @@ func (rh *RepositoryHandler) createSnapshot(c echo.Context) error {
 // @Tags			repositories
 // @Param  			uuid            path    string                          true   "Repository ID."
 // @Param			body            body    api.RepositoryIntrospectRequest false  "request body"
+// @Param			Idempotency-Key header  string                          false  "Key identifying the request. Retrying a request with the same key returns the task it already enqueued."
 // @Success			200 {object} api.TaskInfoResponse
 // @Failure      	400 {object} ce.ErrorResponse
 // @Failure      	404 {object} ce.ErrorResponse
@@ -759,6 +780,7 @@ func (rh *RepositoryHandler) uploadChunk(c echo.Context) error {
 // @Accept          json
 // @Param  			uuid            path    string                          true   "Repository ID."
 // @Param			body            body    api.AddUploadsRequest			true  "request body"
+// @Param			Idempotency-Key header  string                          false  "Key identifying the request. Retrying a request with the same key returns the task it already enqueued."
 // @Success			200 {object} api.TaskInfoResponse
 // @Failure      	400 {object} ce.ErrorResponse
 // @Failure      	404 {object} ce.ErrorResponse
@@ -783,6 +805,14 @@ func (rh *RepositoryHandler) addUploads(c echo.Context) error {
 		return ce.NewErrorResponse(http.StatusBadRequest, "Cannot add uploads to this repository", "Can only add them to repositories of type 'upload'")
 	}
 
+	retried, err := rh.fetchTaskForRetriedRequest(c, orgID, config.AddUploadsTask, response.RepositoryUUID)
+	if err != nil {
+		return err
+	}
+	if retried != nil {
+		return c.JSON(http.StatusCreated, retried)
+	}
+
 	activeTaskIDs, err := rh.DaoRegistry.TaskInfo.FetchActiveTasks(c.Request().Context(), orgID, response.RepositoryUUID, config.UpdateLatestSnapshotTask, config.BulkRemoveRpmsTask)
 	if err != nil {
 		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error checking if bulk-remove-rpms or update-latest-snapshot is in progress", err.Error())
@@ -872,6 +902,7 @@ func (rh *RepositoryHandler) bulkExportRepositories(c echo.Context) error {
 // @Header       201  {string}  Location "resource URL"
 // @Failure      400 {object} ce.ErrorResponse
 // @Failure      401 {object} ce.ErrorResponse
+// @Failure      403 {object} ce.ErrorResponse
 // @Failure      404 {object} ce.ErrorResponse
 // @Failure      415 {object} ce.ErrorResponse
 // @Failure      500 {object} ce.ErrorResponse
@@ -895,6 +926,11 @@ func (rh *RepositoryHandler) bulkImportRepositories(c echo.Context) error {
 	if err := rh.CheckSnapshotForRepos(c, reposToImport); err != nil {
 		return err
 	}
+	for _, repoToImport := range reposToImport {
+		if err := checkWorkspace(c, rbac.ResourceRepositories, repoToImport.WorkspaceID); err != nil {
+			return err
+		}
+	}
 
 	responses, errs := rh.DaoRegistry.RepositoryConfig.BulkImport(c.Request().Context(), reposToImport)
 	if len(errs) > 0 {
@@ -1017,6 +1053,7 @@ func (rh *RepositoryHandler) enqueueSnapshotEvent(c echo.Context, response *api.
 			ObjectType: utils.Ptr(config.ObjectTypeRepository),
 			RequestID:  c.Response().Header().Get(config.HeaderRequestId),
 			AccountId:  response.AccountID,
+			DedupKey:   dedupKeyForRequest(c, response.RepositoryUUID),
 		}
 		taskID, err := rh.TaskClient.Enqueue(task)
 		logger := tasks.LogForTask(taskID.String(), task.Typename, task.RequestID)
@@ -1069,6 +1106,7 @@ func (rh *RepositoryHandler) enqueueAddUploadsEvent(c echo.Context, response api
 		ObjectUUID: &response.RepositoryUUID,
 		ObjectType: utils.Ptr(config.ObjectTypeRepository),
 		RequestID:  c.Response().Header().Get(config.HeaderRequestId),
+		DedupKey:   dedupKeyForRequest(c, response.RepositoryUUID),
 	}
 	taskID, err := rh.TaskClient.Enqueue(task)
 	logger := tasks.LogForTask(taskID.String(), task.Typename, task.RequestID)
@@ -1084,6 +1122,27 @@ func (rh *RepositoryHandler) enqueueAddUploadsEvent(c echo.Context, response api
 	return taskID.String()
 }
 
+// fetchTaskForRetriedRequest returns the task of type taskType enqueued for objectUUID by an earlier request
+// with the same Idempotency-Key header, if that task is still pending or running
+func (rh *RepositoryHandler) fetchTaskForRetriedRequest(c echo.Context, orgID string, taskType string, objectUUID string) (*api.TaskInfoResponse, error) {
+	dedupKey := dedupKeyForRequest(c, objectUUID)
+	if dedupKey == "" {
+		return nil, nil
+	}
+	taskID, err := rh.DaoRegistry.TaskInfo.FetchActiveTaskByDedupKey(c.Request().Context(), orgID, taskType, dedupKey)
+	if err != nil {
+		return nil, ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error checking for active tasks", err.Error())
+	}
+	if taskID == "" {
+		return nil, nil
+	}
+	resp, err := rh.DaoRegistry.TaskInfo.Fetch(c.Request().Context(), orgID, taskID)
+	if err != nil {
+		return nil, ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error fetching task info", err.Error())
+	}
+	return &resp, nil
+}
+
 func (rh *RepositoryHandler) enqueueIntrospectEvent(c echo.Context, response api.RepositoryResponse, orgID string) string {
 	var err error
 	task := queue.Task{
@@ -1094,6 +1153,7 @@ func (rh *RepositoryHandler) enqueueIntrospectEvent(c echo.Context, response api
 		ObjectUUID: &response.RepositoryUUID,
 		ObjectType: utils.Ptr(config.ObjectTypeRepository),
 		RequestID:  c.Response().Header().Get(config.HeaderRequestId),
+		DedupKey:   dedupKeyForRequest(c, response.RepositoryUUID),
 	}
 	taskID, err := rh.TaskClient.Enqueue(task)
 	if err != nil {
@@ -1150,6 +1210,7 @@ func (rh *RepositoryHandler) enqueueUpdateLatestSnapshotEvent(c echo.Context, or
 			ObjectType:   utils.Ptr(config.ObjectTypeRepository),
 			RequestID:    c.Response().Header().Get(config.HeaderRequestId),
 			Dependencies: []uuid.UUID{snapshotTaskID},
+			DedupKey:     dedupKeyForRequest(c, response.RepositoryUUID),
 		}
 		taskID, err := rh.TaskClient.Enqueue(task)
 		if err != nil {
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	ce "github.com/content-services/content-sources-backend/pkg/errors"
 	"github.com/content-services/content-sources-backend/pkg/middleware"
 	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/content-services/content-sources-backend/pkg/rbac"
 	"github.com/content-services/content-sources-backend/pkg/tasks"
 	"github.com/content-services/content-sources-backend/pkg/tasks/client"
 	"github.com/content-services/content-sources-backend/pkg/tasks/payloads"
@@ -30,6 +32,8 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/redhatinsights/platform-go-middlewares/v2/identity"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -88,9 +92,10 @@ func createRepoCollection(size, limit, offset int) api.RepositoryCollectionRespo
 	return collection
 }
 
-func (suite *ReposSuite) serveRepositoriesRouter(req *http.Request) (int, []byte, error) {
+func (suite *ReposSuite) serveRepositoriesRouter(req *http.Request, m ...echo.MiddlewareFunc) (int, []byte, error) {
 	router := echo.New()
 	router.Use(middleware.WrapMiddlewareWithSkipper(identity.EnforceIdentity, middleware.SkipMiddleware))
+	router.Use(m...)
 	router.HTTPErrorHandler = config.CustomHTTPErrorHandler
 	pathPrefix := router.Group(api.FullRootPath())
 
@@ -542,6 +547,56 @@ func (suite *ReposSuite) TestCreate() {
 	assert.Equal(t, http.StatusCreated, code)
 }
 
+func (suite *ReposSuite) TestCreateInSubWorkspace() {
+	t := suite.T()
+	features := config.Get().Features
+	defer func() { config.Get().Features = features }()
+	config.Get().Features.Kessel = config.Feature{Enabled: true}
+	config.Get().Features.KesselObjectPermissions = config.Feature{Enabled: true}
+
+	// The user is allowed to create repositories in a sub-workspace only, not in the default workspace
+	workspaceID := "sub-workspace"
+	kesselClient := rbac.NewMockClientWrapper(t)
+	kesselClient.On("Allowed", mock.Anything, rbac.ResourceRepositories, rbac.RbacVerbWrite).Return(false, nil)
+	objectClient := rbac.NewMockObjectClientWrapper(t)
+	objectClient.On("AllowedWorkspace", mock.Anything, rbac.ResourceRepositories, rbac.RbacVerbWrite, workspaceID).Return(true, nil)
+	rbacMiddleware := middleware.NewRbac(middleware.Rbac{
+		PermissionsMap:     rbac.ServicePermissions,
+		RbacClient:         rbac.NewMockClientWrapper(t),
+		KesselClient:       kesselClient,
+		KesselObjectClient: objectClient,
+	})
+
+	expected := api.RepositoryResponse{
+		UUID:           "repoConfigUuid",
+		Name:           "my repo",
+		URL:            "https://example.com",
+		RepositoryUUID: "repoUuid",
+		Origin:         config.OriginExternal,
+	}
+	repo := createRepoRequest("my repo", "https://example.com")
+	repo.WorkspaceID = &workspaceID
+	repo.FillDefaults(&test_handler.MockAccountNumber, &test_handler.MockOrgId)
+	suite.reg.RepositoryConfig.On("Create", test.MockCtx(), repo).Return(expected, nil)
+	mockTaskClientEnqueueIntrospect(suite.tcMock, expected.URL, expected.RepositoryUUID)
+
+	createRepo := func(repo api.RepositoryRequest) int {
+		body, err := json.Marshal(repo)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, api.FullRootPath()+"/repositories/", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+		code, _, err := suite.serveRepositoriesRouter(req, rbacMiddleware)
+		require.NoError(t, err)
+		return code
+	}
+	assert.Equal(t, http.StatusCreated, createRepo(repo))
+
+	// Without a workspace, the repository would be created in the default workspace
+	repo.WorkspaceID = nil
+	assert.Equal(t, http.StatusForbidden, createRepo(repo))
+}
+
 func resetFeatures() {
 	config.Get().Features.Snapshots.Enabled = true
 	config.Get().Features.AdminTasks.Enabled = true
@@ -1617,6 +1672,51 @@ func (suite *ReposSuite) TestCreateSnapshotError() {
 	assert.Equal(t, http.StatusConflict, code)
 }
 
+func (suite *ReposSuite) TestCreateSnapshotRetriedWithIdempotencyKey() {
+	t := suite.T()
+	config.Load()
+	config.Get().Features.Snapshots.Enabled = true
+	config.Get().Features.Snapshots.Accounts = &[]string{test_handler.MockAccountNumber}
+	defer resetFeatures()
+	uuid := "abcadaba"
+	repoUuid := "repoUuid"
+	existingTaskUUID := "existing-task-uuid"
+	repoResp := api.RepositoryResponse{
+		Name:           "my repo",
+		URL:            "https://example.com",
+		UUID:           uuid,
+		RepositoryUUID: repoUuid,
+		Snapshot:       true,
+	}
+	expectedTaskInfo := api.TaskInfoResponse{UUID: existingTaskUUID, OrgId: test_handler.MockOrgId}
+
+	suite.reg.RepositoryConfig.On("Fetch", test.MockCtx(), test_handler.MockOrgId, uuid).Return(repoResp, nil)
+	suite.reg.TaskInfo.On("FetchActiveTaskByDedupKey", test.MockCtx(), test_handler.MockOrgId, config.RepositorySnapshotTask, mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, repoUuid+":")
+	})).Return(existingTaskUUID, nil)
+	suite.reg.TaskInfo.On("Fetch", test.MockCtx(), test_handler.MockOrgId, existingTaskUUID).Return(expectedTaskInfo, nil)
+
+	body, err := json.Marshal("")
+	if err != nil {
+		t.Error("Could not marshal JSON")
+	}
+
+	req := httptest.NewRequest(http.MethodPost, api.FullRootPath()+"/repositories/"+uuid+"/snapshot/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+	req.Header.Set(api.IdempotencyKeyHeader, "my-key")
+
+	code, body, err := suite.serveRepositoriesRouter(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	var actualTaskInfo api.TaskInfoResponse
+	err = json.Unmarshal(body, &actualTaskInfo)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedTaskInfo, actualTaskInfo)
+	suite.tcMock.AssertNotCalled(t, "Enqueue", mock.Anything)
+}
+
 func (suite *ReposSuite) TestCreateSnapshotErrorSnapshottingNotEnabled() {
 	t := suite.T()
 	config.Load()
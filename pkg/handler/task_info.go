@@ -36,6 +36,7 @@ func RegisterTaskInfoRoutes(engine *echo.Group, daoReg *dao.DaoRegistry, taskCli
 	}
 	addRepoRoute(engine, http.MethodGet, "/tasks/", taskInfoHandler.listTasks, rbac.RbacVerbRead)
 	addRepoRoute(engine, http.MethodGet, "/tasks/:uuid", taskInfoHandler.fetch, rbac.RbacVerbRead)
+	addRepoRoute(engine, http.MethodGet, "/tasks/:uuid/graph", taskInfoHandler.fetchGraph, rbac.RbacVerbRead)
 	addRepoRoute(engine, http.MethodPost, "/tasks/:uuid/cancel/", taskInfoHandler.cancel, rbac.RbacVerbWrite)
 }
 
@@ -97,6 +98,31 @@ func (t *TaskInfoHandler) fetch(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+// Get TaskGraphResponse godoc
+// @Summary      Get Task Graph
+// @ID           getTaskGraph
+// @Description  Get a task along with the tasks it is waiting on (upstream) and the tasks waiting on it (downstream). Use it to find which task a pending task is blocked by.
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param  uuid  path  string    true  "Task ID."
+// @Success      200   {object}  api.TaskGraphResponse
+// @Failure      400 {object} ce.ErrorResponse
+// @Failure      401 {object} ce.ErrorResponse
+// @Failure      404 {object} ce.ErrorResponse
+// @Failure      500 {object} ce.ErrorResponse
+// @Router       /tasks/{uuid}/graph [get]
+func (t *TaskInfoHandler) fetchGraph(c echo.Context) error {
+	_, orgID := getAccountIdOrgId(c)
+	id := c.Param("uuid")
+
+	response, err := t.DaoRegistry.TaskInfo.FetchGraph(c.Request().Context(), orgID, id)
+	if err != nil {
+		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error fetching task graph", err.Error())
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
 func (t *TaskInfoHandler) cancel(c echo.Context) error {
 	_, orgID := getAccountIdOrgId(c)
 	id := c.Param("uuid")
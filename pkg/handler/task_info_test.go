@@ -424,3 +424,57 @@ func (suite *TaskInfoSuite) TestFetchNotFound() {
 	code, _, _ := suite.serveTasksRouter(req)
 	assert.Equal(t, http.StatusNotFound, code)
 }
+
+func (suite *TaskInfoSuite) TestFetchGraph() {
+	t := suite.T()
+
+	uuid := "abcadaba"
+	graph := api.TaskGraphResponse{
+		Task: api.TaskInfoResponse{
+			UUID:         uuid,
+			Status:       config.TaskStatusPending,
+			Typename:     config.UpdateLatestSnapshotTask,
+			Dependencies: []string{"parent"},
+			Dependents:   []string{"child"},
+		},
+		Upstream: []api.TaskInfoResponse{
+			{UUID: "parent", Status: config.TaskStatusRunning, Typename: config.RepositorySnapshotTask},
+		},
+		Downstream: []api.TaskInfoResponse{
+			{UUID: "child", Status: config.TaskStatusPending, Typename: config.UpdateTemplateContentTask},
+		},
+	}
+
+	suite.reg.TaskInfo.On("FetchGraph", test.MockCtx(), test_handler.MockOrgId, uuid).Return(graph, nil)
+
+	req := httptest.NewRequest(http.MethodGet, api.FullRootPath()+"/tasks/"+uuid+"/graph", nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, body, err := suite.serveTasksRouter(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	var response api.TaskGraphResponse
+	err = json.Unmarshal(body, &response)
+	assert.Nil(t, err)
+	assert.Equal(t, graph, response)
+}
+
+func (suite *TaskInfoSuite) TestFetchGraphNotFound() {
+	t := suite.T()
+
+	uuid := "abcadaba"
+	daoError := ce.DaoError{
+		NotFound: true,
+		Message:  "Not found",
+	}
+	suite.reg.TaskInfo.On("FetchGraph", test.MockCtx(), test_handler.MockOrgId, uuid).Return(api.TaskGraphResponse{}, &daoError)
+
+	req := httptest.NewRequest(http.MethodGet, api.FullRootPath()+"/tasks/"+uuid+"/graph", nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, _, _ := suite.serveTasksRouter(req)
+	assert.Equal(t, http.StatusNotFound, code)
+}
@@ -42,9 +42,9 @@ func RegisterTemplateRoutes(engine *echo.Group, daoReg *dao.DaoRegistry, taskCli
 		TaskClient:  *taskClient,
 	}
 
-	addTemplateRoute(engine, http.MethodGet, "/templates/", h.listTemplates, rbac.RbacVerbRead)
+	addTemplateListRoute(engine, http.MethodGet, "/templates/", h.listTemplates, rbac.RbacVerbRead)
 	addTemplateRoute(engine, http.MethodGet, "/templates/:uuid", h.fetch, rbac.RbacVerbRead)
-	addTemplateRoute(engine, http.MethodPost, "/templates/", h.createTemplate, rbac.RbacVerbWrite)
+	addTemplateCreateRoute(engine, http.MethodPost, "/templates/", h.createTemplate, rbac.RbacVerbWrite)
 	addTemplateRoute(engine, http.MethodDelete, "/templates/:uuid", h.deleteTemplate, rbac.RbacVerbWrite)
 	addTemplateRoute(engine, http.MethodPut, "/templates/:uuid", h.fullUpdate, rbac.RbacVerbWrite)
 	addTemplateRoute(engine, http.MethodPatch, "/templates/:uuid", h.partialUpdate, rbac.RbacVerbWrite)
@@ -64,6 +64,7 @@ func RegisterTemplateRoutes(engine *echo.Group, daoReg *dao.DaoRegistry, taskCli
 // @Header       201  {string}  Location "resource URL"
 // @Failure      400 {object} ce.ErrorResponse
 // @Failure      401 {object} ce.ErrorResponse
+// @Failure      403 {object} ce.ErrorResponse
 // @Failure      404 {object} ce.ErrorResponse
 // @Failure      415 {object} ce.ErrorResponse
 // @Failure      500 {object} ce.ErrorResponse
@@ -79,6 +80,10 @@ func (th *TemplateHandler) createTemplate(c echo.Context) error {
 	user := getUser(c)
 	newTemplate.User = &user
 
+	if err := checkWorkspace(c, rbac.ResourceTemplates, newTemplate.WorkspaceID); err != nil {
+		return err
+	}
+
 	respTemplate, err := th.DaoRegistry.Template.Create(c.Request().Context(), newTemplate)
 	if err != nil {
 		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error creating template", err.Error())
@@ -145,6 +150,7 @@ func (th *TemplateHandler) listTemplates(c echo.Context) error {
 	_, orgID := getAccountIdOrgId(c)
 	pageData := ParsePagination(c)
 	filterData := ParseTemplateFilters(c)
+	filterData.AllowedUUIDs = rbac.AllowedObjects(c.Request().Context())
 
 	templates, total, err := th.DaoRegistry.Template.List(c.Request().Context(), orgID, false, pageData, filterData)
 	if err != nil {
@@ -162,6 +168,7 @@ func (th *TemplateHandler) listTemplates(c echo.Context) error {
 // @Produce      json
 // @Param        uuid  path  string    true  "Template ID."
 // @Param        body  body     api.TemplateUpdateRequest  true  "request body"
+// @Param        Idempotency-Key  header  string  false  "Key identifying the request. Retrying a request with the same key does not enqueue another template content update."
 // @Success      201  {object}  api.TemplateResponse
 // @Header       201  {string}  Location "resource URL"
 // @Failure      400 {object} ce.ErrorResponse
@@ -183,6 +190,7 @@ func (th *TemplateHandler) fullUpdate(c echo.Context) error {
 // @Produce      json
 // @Param        uuid  path  string    true  "Template ID."
 // @Param        body  body     api.TemplateUpdateRequest  true  "request body"
+// @Param        Idempotency-Key  header  string  false  "Key identifying the request. Retrying a request with the same key does not enqueue another template content update."
 // @Success      201  {object}  api.TemplateResponse
 // @Header       201  {string}  Location "resource URL"
 // @Failure      400 {object} ce.ErrorResponse
@@ -389,6 +397,7 @@ func (th *TemplateHandler) enqueueUpdateTemplateContentEvent(c echo.Context, tem
 		AccountId:  accountID,
 		RequestID:  c.Response().Header().Get(config.HeaderRequestId),
 		Priority:   1,
+		DedupKey:   dedupKeyForRequest(c, template.UUID),
 	}
 	taskID, err := th.TaskClient.Enqueue(task)
 	logger := tasks.LogForTask(taskID.String(), task.Typename, task.RequestID)
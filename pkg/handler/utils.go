@@ -2,6 +2,9 @@ package handler
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -49,6 +52,76 @@ func addTemplateRoute(e *echo.Group, method string, path string, h echo.HandlerF
 	rbac.ServicePermissions.Add(method, path, rbac.ResourceTemplates, verb)
 }
 
+// addUnrestrictedRoute adds a route any user of the organization can access, regardless of their permissions
+func addUnrestrictedRoute(e *echo.Group, method string, path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) {
+	e.Add(method, path, h, m...)
+	rbac.ServicePermissions.AddUnrestricted(method, path)
+}
+
+func addSnapshotRoute(e *echo.Group, method string, path string, h echo.HandlerFunc, verb rbac.Verb, m ...echo.MiddlewareFunc) {
+	e.Add(method, path, h, m...)
+	rbac.ServicePermissions.Add(method, path, rbac.ResourceSnapshots, verb)
+}
+
+func addAuditRoute(e *echo.Group, method string, path string, h echo.HandlerFunc, verb rbac.Verb, m ...echo.MiddlewareFunc) {
+	e.Add(method, path, h, m...)
+	rbac.ServicePermissions.Add(method, path, rbac.ResourceAudit, verb)
+}
+
+func addContentRoute(e *echo.Group, method string, path string, h echo.HandlerFunc, verb rbac.Verb, m ...echo.MiddlewareFunc) {
+	e.Add(method, path, h, m...)
+	rbac.ServicePermissions.Add(method, path, rbac.ResourceContent, verb)
+}
+
+// addRepoListRoute adds a route listing repositories, which users allowed only on some repositories can access
+func addRepoListRoute(e *echo.Group, method string, path string, h echo.HandlerFunc, verb rbac.Verb, m ...echo.MiddlewareFunc) {
+	addRepoRoute(e, method, path, h, verb, m...)
+	rbac.ServicePermissions.AddObjectFilter(method, path)
+}
+
+// addTemplateListRoute adds a route listing templates, which users allowed only on some templates can access
+func addTemplateListRoute(e *echo.Group, method string, path string, h echo.HandlerFunc, verb rbac.Verb, m ...echo.MiddlewareFunc) {
+	addTemplateRoute(e, method, path, h, verb, m...)
+	rbac.ServicePermissions.AddObjectFilter(method, path)
+}
+
+// addRepoCreateRoute adds a route creating repositories, which users allowed only on some workspaces can access.
+// The handler must check the requested workspaces with checkWorkspace.
+func addRepoCreateRoute(e *echo.Group, method string, path string, h echo.HandlerFunc, verb rbac.Verb, m ...echo.MiddlewareFunc) {
+	addRepoRoute(e, method, path, h, verb, m...)
+	rbac.ServicePermissions.AddObjectCreate(method, path)
+}
+
+// addTemplateCreateRoute adds a route creating templates, which users allowed only on some workspaces can access.
+// The handler must check the requested workspaces with checkWorkspace.
+func addTemplateCreateRoute(e *echo.Group, method string, path string, h echo.HandlerFunc, verb rbac.Verb, m ...echo.MiddlewareFunc) {
+	addTemplateRoute(e, method, path, h, verb, m...)
+	rbac.ServicePermissions.AddObjectCreate(method, path)
+}
+
+// checkWorkspace checks that the user can create repositories or templates in the requested workspace, which
+// requires per object permissions. Objects are created in the default workspace when workspaceID is not set.
+func checkWorkspace(c echo.Context, resource rbac.Resource, workspaceID *string) error {
+	ctx := c.Request().Context()
+	if workspaceID == nil {
+		if rbac.DefaultWorkspaceDenied(ctx) {
+			return ce.NewErrorResponse(http.StatusForbidden, "Invalid workspace", fmt.Sprintf("Not allowed to create %s in the default workspace", resource))
+		}
+		return nil
+	}
+	if !rbac.ObjectPermissionsEnabled(ctx) {
+		return ce.NewErrorResponse(http.StatusBadRequest, "Invalid workspace", "workspace_id can only be set when per object permissions are enabled")
+	}
+	allowed, err := rbac.AllowedWorkspace(ctx, resource, rbac.RbacVerbWrite, *workspaceID)
+	if err != nil {
+		return ce.NewErrorResponse(http.StatusInternalServerError, "Error checking workspace permissions", err.Error())
+	}
+	if !allowed {
+		return ce.NewErrorResponse(http.StatusForbidden, "Invalid workspace", fmt.Sprintf("Not allowed to create %s in workspace %s", resource, *workspaceID))
+	}
+	return nil
+}
+
 func preprocessInput(input *api.ContentUnitSearchRequest) {
 	if input == nil {
 		return
@@ -100,6 +173,18 @@ func fetchSnapshotUUIDsForRepos(ctx context.Context, dao *dao.DaoRegistry, orgID
 	return snapshotUUIDs, nil
 }
 
+// dedupKeyForRequest returns the dedup key for a task enqueued on objectUUID, derived from the request's
+// Idempotency-Key header, so that a retried request returns the task enqueued by the original one.
+// Returns an empty string if the header is not set.
+func dedupKeyForRequest(c echo.Context, objectUUID string) string {
+	key := c.Request().Header.Get(api.IdempotencyKeyHeader)
+	if key == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(key))
+	return objectUUID + ":" + hex.EncodeToString(sum[:])
+}
+
 func enqueueTask(tc client.TaskClient, task queue.Task) (uuid.UUID, error) {
 	taskID, err := tc.Enqueue(task)
 	if err != nil {
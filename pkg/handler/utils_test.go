@@ -3,12 +3,16 @@ package handler
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/content-services/content-sources-backend/pkg/api"
 	"github.com/content-services/content-sources-backend/pkg/config"
 	"github.com/content-services/content-sources-backend/pkg/dao"
+	ce "github.com/content-services/content-sources-backend/pkg/errors"
+	"github.com/content-services/content-sources-backend/pkg/rbac"
 	"github.com/content-services/content-sources-backend/pkg/tasks"
 	"github.com/content-services/content-sources-backend/pkg/tasks/client"
 	"github.com/content-services/content-sources-backend/pkg/tasks/payloads"
@@ -16,8 +20,10 @@ import (
 	test_handler "github.com/content-services/content-sources-backend/pkg/test/handler"
 	"github.com/content-services/content-sources-backend/pkg/utils"
 	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -82,6 +88,36 @@ type UtilsSuite struct {
 	reg *dao.MockDaoRegistry
 }
 
+func TestCheckWorkspace(t *testing.T) {
+	workspaceID := "workspace-id"
+	checkStatus := func(objectClient rbac.ObjectClientWrapper, workspaceID *string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		if objectClient != nil {
+			req = req.WithContext(rbac.WithObjectClient(req.Context(), objectClient))
+		}
+		err := checkWorkspace(echo.New().NewContext(req, httptest.NewRecorder()), rbac.ResourceRepositories, workspaceID)
+		if err == nil {
+			return http.StatusOK
+		}
+		var errResp ce.ErrorResponse
+		require.ErrorAs(t, err, &errResp)
+		return errResp.Errors[0].Status
+	}
+
+	// The default workspace is always allowed
+	assert.Equal(t, http.StatusOK, checkStatus(nil, nil))
+
+	// Workspaces require per object permissions
+	assert.Equal(t, http.StatusBadRequest, checkStatus(nil, &workspaceID))
+
+	objectClient := rbac.NewMockObjectClientWrapper(t)
+	objectClient.On("AllowedWorkspace", mock.Anything, rbac.ResourceRepositories, rbac.RbacVerbWrite, workspaceID).Return(true, nil).Once()
+	assert.Equal(t, http.StatusOK, checkStatus(objectClient, &workspaceID))
+
+	objectClient.On("AllowedWorkspace", mock.Anything, rbac.ResourceRepositories, rbac.RbacVerbWrite, workspaceID).Return(false, nil).Once()
+	assert.Equal(t, http.StatusForbidden, checkStatus(objectClient, &workspaceID))
+}
+
 func TestUtilsSuite(t *testing.T) {
 	suite.Run(t, new(UtilsSuite))
 }
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	ce "github.com/content-services/content-sources-backend/pkg/errors"
+	"github.com/content-services/content-sources-backend/pkg/rbac"
+	"github.com/content-services/content-sources-backend/pkg/webhooks"
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookHandler struct {
+	DaoRegistry dao.DaoRegistry
+}
+
+func RegisterWebhookRoutes(engine *echo.Group, daoReg *dao.DaoRegistry) {
+	if engine == nil {
+		panic("engine is nil")
+	}
+	if daoReg == nil {
+		panic("daoReg is nil")
+	}
+	h := WebhookHandler{DaoRegistry: *daoReg}
+
+	addRepoRoute(engine, http.MethodGet, "/webhooks/", h.listWebhooks, rbac.RbacVerbRead)
+	addRepoRoute(engine, http.MethodGet, "/webhooks/:uuid", h.fetchWebhook, rbac.RbacVerbRead)
+	addRepoRoute(engine, http.MethodPost, "/webhooks/", h.createWebhook, rbac.RbacVerbWrite)
+	addRepoRoute(engine, http.MethodPatch, "/webhooks/:uuid", h.updateWebhook, rbac.RbacVerbWrite)
+	addRepoRoute(engine, http.MethodDelete, "/webhooks/:uuid", h.deleteWebhook, rbac.RbacVerbWrite)
+	addRepoRoute(engine, http.MethodGet, "/webhooks/:uuid/deliveries/", h.listWebhookDeliveries, rbac.RbacVerbRead)
+	addRepoRoute(engine, http.MethodPost, "/webhooks/:uuid/test/", h.testWebhook, rbac.RbacVerbWrite)
+}
+
+// ListWebhooks godoc
+// @Summary      List Webhooks
+// @ID           listWebhooks
+// @Description  List the webhooks of the organization.
+// @Tags         webhooks
+// @Param		 offset query int false "Starting point for retrieving a subset of results. Determines how many items to skip from the beginning of the result set. Default value:`0`."
+// @Param		 limit query int false "Number of items to include in response. Use it to control the number of items, particularly when dealing with large datasets. Default value: `100`."
+// @Param		 sort_by query string false "Sort the response data based on specific parameters. Sort criteria can include `name`, `url`, and `created_at`."
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} api.WebhookCollectionResponse
+// @Failure      400 {object} ce.ErrorResponse
+// @Failure      401 {object} ce.ErrorResponse
+// @Failure      500 {object} ce.ErrorResponse
+// @Router       /webhooks/ [get]
+func (h *WebhookHandler) listWebhooks(c echo.Context) error {
+	_, orgID := getAccountIdOrgId(c)
+	pageData := ParsePagination(c)
+
+	webhooks, total, err := h.DaoRegistry.Webhook.List(c.Request().Context(), orgID, pageData)
+	if err != nil {
+		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error listing webhooks", err.Error())
+	}
+	return c.JSON(http.StatusOK, setCollectionResponseMetadata(&webhooks, c, total))
+}
+
+// GetWebhook godoc
+// @Summary      Get Webhook
+// @ID           getWebhook
+// @Description  Get a webhook. Its secret is never returned.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        uuid  path  string  true  "Webhook ID."
+// @Success      200 {object} api.WebhookResponse
+// @Failure      400 {object} ce.ErrorResponse
+// @Failure      401 {object} ce.ErrorResponse
+// @Failure      404 {object} ce.ErrorResponse
+// @Failure      500 {object} ce.ErrorResponse
+// @Router       /webhooks/{uuid} [get]
+func (h *WebhookHandler) fetchWebhook(c echo.Context) error {
+	_, orgID := getAccountIdOrgId(c)
+
+	webhook, err := h.DaoRegistry.Webhook.Fetch(c.Request().Context(), orgID, c.Param("uuid"))
+	if err != nil {
+		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error fetching webhook", err.Error())
+	}
+	return c.JSON(http.StatusOK, webhook)
+}
+
+// CreateWebhook godoc
+// @Summary      Create Webhook
+// @ID           createWebhook
+// @Description  Register an endpoint that receives the subscribed repository and template events. Each event is posted as JSON, signed with the secret using HMAC-SHA256.
+// @Description  The `X-Content-Sources-Signature` header holds `sha256=` followed by the hex encoded HMAC of the `X-Content-Sources-Timestamp` header, a period and the body. Failed deliveries are retried with an exponential backoff.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        body  body     api.WebhookRequest  true  "request body"
+// @Success      201  {object}  api.WebhookResponse
+// @Failure      400 {object} ce.ErrorResponse
+// @Failure      401 {object} ce.ErrorResponse
+// @Failure      415 {object} ce.ErrorResponse
+// @Failure      500 {object} ce.ErrorResponse
+// @Router       /webhooks/ [post]
+func (h *WebhookHandler) createWebhook(c echo.Context) error {
+	var req api.WebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return ce.NewErrorResponse(http.StatusBadRequest, "Error binding params", err.Error())
+	}
+	_, orgID := getAccountIdOrgId(c)
+	req.OrgID = &orgID
+	user := getUser(c)
+	req.User = &user
+
+	webhook, err := h.DaoRegistry.Webhook.Create(c.Request().Context(), req)
+	if err != nil {
+		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error creating webhook", err.Error())
+	}
+	return c.JSON(http.StatusCreated, webhook)
+}
+
+// UpdateWebhook godoc
+// @Summary      Update Webhook
+// @ID           updateWebhook
+// @Description  Update some attributes of a webhook.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        uuid  path  string  true  "Webhook ID."
+// @Param        body  body     api.WebhookUpdateRequest  true  "request body"
+// @Success      200  {object}  api.WebhookResponse
+// @Failure      400 {object} ce.ErrorResponse
+// @Failure      401 {object} ce.ErrorResponse
+// @Failure      404 {object} ce.ErrorResponse
+// @Failure      415 {object} ce.ErrorResponse
+// @Failure      500 {object} ce.ErrorResponse
+// @Router       /webhooks/{uuid} [patch]
+func (h *WebhookHandler) updateWebhook(c echo.Context) error {
+	var req api.WebhookUpdateRequest
+	if err := c.Bind(&req); err != nil {
+		return ce.NewErrorResponse(http.StatusBadRequest, "Error binding parameters", err.Error())
+	}
+	_, orgID := getAccountIdOrgId(c)
+
+	webhook, err := h.DaoRegistry.Webhook.Update(c.Request().Context(), orgID, c.Param("uuid"), req)
+	if err != nil {
+		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error updating webhook", err.Error())
+	}
+	return c.JSON(http.StatusOK, webhook)
+}
+
+// DeleteWebhook godoc
+// @Summary      Delete Webhook
+// @ID           deleteWebhook
+// @Description  Delete a webhook along with its delivery log.
+// @Tags         webhooks
+// @Param        uuid  path  string  true  "Webhook ID."
+// @Success      204 "Webhook was successfully deleted"
+// @Failure      400 {object} ce.ErrorResponse
+// @Failure      401 {object} ce.ErrorResponse
+// @Failure      404 {object} ce.ErrorResponse
+// @Failure      500 {object} ce.ErrorResponse
+// @Router       /webhooks/{uuid} [delete]
+func (h *WebhookHandler) deleteWebhook(c echo.Context) error {
+	_, orgID := getAccountIdOrgId(c)
+
+	if err := h.DaoRegistry.Webhook.Delete(c.Request().Context(), orgID, c.Param("uuid")); err != nil {
+		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error deleting webhook", err.Error())
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListWebhookDeliveries godoc
+// @Summary      List Webhook Deliveries
+// @ID           listWebhookDeliveries
+// @Description  List the events sent, or waiting to be sent, to a webhook, newest first.
+// @Tags         webhooks
+// @Param        uuid  path  string  true  "Webhook ID."
+// @Param		 offset query int false "Starting point for retrieving a subset of results. Determines how many items to skip from the beginning of the result set. Default value:`0`."
+// @Param		 limit query int false "Number of items to include in response. Use it to control the number of items, particularly when dealing with large datasets. Default value: `100`."
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} api.WebhookDeliveryCollectionResponse
+// @Failure      400 {object} ce.ErrorResponse
+// @Failure      401 {object} ce.ErrorResponse
+// @Failure      404 {object} ce.ErrorResponse
+// @Failure      500 {object} ce.ErrorResponse
+// @Router       /webhooks/{uuid}/deliveries/ [get]
+func (h *WebhookHandler) listWebhookDeliveries(c echo.Context) error {
+	_, orgID := getAccountIdOrgId(c)
+	pageData := ParsePagination(c)
+
+	deliveries, total, err := h.DaoRegistry.Webhook.ListDeliveries(c.Request().Context(), orgID, c.Param("uuid"), pageData)
+	if err != nil {
+		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error listing webhook deliveries", err.Error())
+	}
+	return c.JSON(http.StatusOK, setCollectionResponseMetadata(&deliveries, c, total))
+}
+
+// TestWebhook godoc
+// @Summary      Send a test event to a Webhook
+// @ID           testWebhook
+// @Description  Send a `webhook-test` event to the webhook right away, and return the outcome. Test events are not retried.
+// @Tags         webhooks
+// @Param        uuid  path  string  true  "Webhook ID."
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} api.WebhookDeliveryResponse
+// @Failure      400 {object} ce.ErrorResponse
+// @Failure      401 {object} ce.ErrorResponse
+// @Failure      404 {object} ce.ErrorResponse
+// @Failure      500 {object} ce.ErrorResponse
+// @Router       /webhooks/{uuid}/test/ [post]
+func (h *WebhookHandler) testWebhook(c echo.Context) error {
+	_, orgID := getAccountIdOrgId(c)
+
+	delivery, err := h.DaoRegistry.Webhook.CreateTestDelivery(c.Request().Context(), orgID, c.Param("uuid"))
+	if err != nil {
+		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error creating test delivery", err.Error())
+	}
+	response, err := webhooks.NewDeliverer(h.DaoRegistry.Webhook).Deliver(c.Request().Context(), delivery)
+	if err != nil {
+		return ce.NewErrorResponse(ce.HttpCodeForDaoError(err), "Error sending test delivery", err.Error())
+	}
+	return c.JSON(http.StatusOK, response)
+}
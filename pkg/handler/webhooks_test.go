@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	ce "github.com/content-services/content-sources-backend/pkg/errors"
+	"github.com/content-services/content-sources-backend/pkg/middleware"
+	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/content-services/content-sources-backend/pkg/test"
+	test_handler "github.com/content-services/content-sources-backend/pkg/test/handler"
+	"github.com/content-services/content-sources-backend/pkg/utils"
+	"github.com/content-services/content-sources-backend/pkg/webhooks"
+	"github.com/labstack/echo/v4"
+	"github.com/redhatinsights/platform-go-middlewares/v2/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhooksSuite struct {
+	suite.Suite
+	reg *dao.MockDaoRegistry
+}
+
+func TestWebhooksSuite(t *testing.T) {
+	suite.Run(t, new(WebhooksSuite))
+}
+
+func (suite *WebhooksSuite) SetupTest() {
+	suite.reg = dao.GetMockDaoRegistry(suite.T())
+}
+
+func (suite *WebhooksSuite) serveRouter(req *http.Request) (int, []byte, error) {
+	router := echo.New()
+	router.Use(middleware.WrapMiddlewareWithSkipper(identity.EnforceIdentity, middleware.SkipMiddleware))
+	router.HTTPErrorHandler = config.CustomHTTPErrorHandler
+	pathPrefix := router.Group(api.FullRootPath())
+	RegisterWebhookRoutes(pathPrefix, suite.reg.ToDaoRegistry())
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	response := rr.Result()
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	return response.StatusCode, body, err
+}
+
+func (suite *WebhooksSuite) TestList() {
+	t := suite.T()
+	expected := api.WebhookCollectionResponse{Data: []api.WebhookResponse{{UUID: "uuid", Name: "hook"}}}
+	suite.reg.Webhook.On("List", test.MockCtx(), test_handler.MockOrgId, api.PaginationData{Limit: 100, SortBy: "name"}).
+		Return(expected, int64(1), nil)
+
+	path := fmt.Sprintf("%s/webhooks/?sort_by=name", api.FullRootPath())
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, body, err := suite.serveRouter(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	var resp api.WebhookCollectionResponse
+	assert.NoError(t, json.Unmarshal(body, &resp))
+	assert.Equal(t, expected.Data, resp.Data)
+	assert.Equal(t, int64(1), resp.Meta.Count)
+}
+
+func (suite *WebhooksSuite) TestCreate() {
+	t := suite.T()
+	request := api.WebhookRequest{
+		Name:       utils.Ptr("hook"),
+		URL:        utils.Ptr("https://example.com/hook"),
+		Secret:     utils.Ptr("a-very-secret-value"),
+		EventTypes: []string{"template-updated"},
+	}
+	expected := request
+	expected.OrgID = utils.Ptr(test_handler.MockOrgId)
+	expected.User = utils.Ptr("user")
+	suite.reg.Webhook.On("Create", test.MockCtx(), expected).Return(api.WebhookResponse{UUID: "uuid", Name: "hook"}, nil)
+
+	body, err := json.Marshal(request)
+	assert.NoError(t, err)
+	path := fmt.Sprintf("%s/webhooks/", api.FullRootPath())
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, respBody, err := suite.serveRouter(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, code)
+
+	var resp api.WebhookResponse
+	assert.NoError(t, json.Unmarshal(respBody, &resp))
+	assert.Equal(t, "uuid", resp.UUID)
+}
+
+func (suite *WebhooksSuite) TestCreateInvalid() {
+	t := suite.T()
+	suite.reg.Webhook.On("Create", test.MockCtx(), mock.Anything).
+		Return(api.WebhookResponse{}, &ce.DaoError{BadValidation: true, Message: "Invalid event type: not-an-event"})
+
+	path := fmt.Sprintf("%s/webhooks/", api.FullRootPath())
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader([]byte(`{"event_types":["not-an-event"]}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, _, err := suite.serveRouter(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func (suite *WebhooksSuite) TestUpdate() {
+	t := suite.T()
+	request := api.WebhookUpdateRequest{Enabled: utils.Ptr(false)}
+	suite.reg.Webhook.On("Update", test.MockCtx(), test_handler.MockOrgId, "uuid", request).
+		Return(api.WebhookResponse{UUID: "uuid", Enabled: false}, nil)
+
+	path := fmt.Sprintf("%s/webhooks/uuid", api.FullRootPath())
+	req := httptest.NewRequest(http.MethodPatch, path, bytes.NewReader([]byte(`{"enabled":false}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, _, err := suite.serveRouter(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func (suite *WebhooksSuite) TestDelete() {
+	t := suite.T()
+	suite.reg.Webhook.On("Delete", test.MockCtx(), test_handler.MockOrgId, "uuid").Return(nil).Once()
+	suite.reg.Webhook.On("Delete", test.MockCtx(), test_handler.MockOrgId, "uuid").Return(&ce.DaoError{NotFound: true}).Once()
+
+	path := fmt.Sprintf("%s/webhooks/uuid", api.FullRootPath())
+	req := httptest.NewRequest(http.MethodDelete, path, nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, _, err := suite.serveRouter(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, code)
+
+	code, _, err = suite.serveRouter(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func (suite *WebhooksSuite) TestListDeliveries() {
+	t := suite.T()
+	expected := api.WebhookDeliveryCollectionResponse{Data: []api.WebhookDeliveryResponse{{UUID: "delivery", Status: models.WebhookDeliverySucceeded}}}
+	suite.reg.Webhook.On("ListDeliveries", test.MockCtx(), test_handler.MockOrgId, "uuid", api.PaginationData{Limit: 100}).
+		Return(expected, int64(1), nil)
+
+	path := fmt.Sprintf("%s/webhooks/uuid/deliveries/", api.FullRootPath())
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, body, err := suite.serveRouter(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	var resp api.WebhookDeliveryCollectionResponse
+	assert.NoError(t, json.Unmarshal(body, &resp))
+	assert.Equal(t, expected.Data, resp.Data)
+}
+
+func (suite *WebhooksSuite) TestTest() {
+	t := suite.T()
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(webhooks.HeaderSignature)
+	}))
+	defer server.Close()
+
+	delivery := models.WebhookDelivery{
+		Base:      models.Base{UUID: "delivery"},
+		EventType: "webhook-test",
+		Payload:   []byte(`{}`),
+		Webhook:   models.Webhook{URL: server.URL, Secret: "a-very-secret-value", Enabled: true},
+	}
+	expected := api.WebhookDeliveryResponse{UUID: "delivery", Status: models.WebhookDeliverySucceeded}
+	suite.reg.Webhook.On("CreateTestDelivery", test.MockCtx(), test_handler.MockOrgId, "uuid").Return(delivery, nil)
+	suite.reg.Webhook.On("RecordDeliveryAttempt", test.MockCtx(), delivery, utils.Ptr(http.StatusOK), nil).Return(expected, nil)
+
+	path := fmt.Sprintf("%s/webhooks/uuid/test/", api.FullRootPath())
+	req := httptest.NewRequest(http.MethodPost, path, nil)
+	req.Header.Set(api.IdentityHeader, test_handler.EncodedIdentity(t))
+
+	code, body, err := suite.serveRouter(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.NotEmpty(t, signature)
+
+	var resp api.WebhookDeliveryResponse
+	assert.NoError(t, json.Unmarshal(body, &resp))
+	assert.Equal(t, expected, resp)
+}
@@ -106,6 +106,8 @@ func (c *Collector) iterate() {
 		c.metrics.TaskPendingTimeAverageByType.With(prometheus.Labels{"task_type": t}).Set(value)
 	}
 
+	c.iterateTaskBacklog(metricsDao.TaskBacklogByType(ctx))
+
 	templatesUseLatestCount := metricsDao.TemplatesUseLatestCount(ctx)
 	c.metrics.TemplatesUseLatestCount.Set(float64(templatesUseLatestCount))
 	templatesUseDateCount := metricsDao.TemplatesUseDateCount(ctx)
@@ -128,6 +130,43 @@ func (c *Collector) iterate() {
 	}
 }
 
+func (c *Collector) iterateTaskBacklog(backlog []dao.TaskTypeBacklog) {
+	byType := make(map[string]dao.TaskTypeBacklog, len(backlog))
+	for _, b := range backlog {
+		byType[b.Type] = b
+	}
+	for _, t := range config.TaskTypes {
+		b := byType[t]
+		c.metrics.TaskBacklogByType.With(prometheus.Labels{"task_type": t, "state": "pending"}).Set(float64(b.Pending))
+		c.metrics.TaskBacklogByType.With(prometheus.Labels{"task_type": t, "state": "ready"}).Set(float64(b.Ready))
+		c.metrics.TaskBacklogByType.With(prometheus.Labels{"task_type": t, "state": "running"}).Set(float64(b.Running))
+	}
+
+	// Task types not listed in any worker pool, or only in pools without workers, are handled by the shared workers
+	pooled := []string{}
+	for _, pool := range config.Get().Tasking.WorkerPools {
+		if pool.WorkerCount <= 0 {
+			continue
+		}
+		var poolBacklog int64
+		for _, t := range pool.Types {
+			if slices.Contains(pooled, t) {
+				continue
+			}
+			pooled = append(pooled, t)
+			poolBacklog += byType[t].Ready + byType[t].Running
+		}
+		c.metrics.TaskWorkerPoolBacklog.With(prometheus.Labels{"pool": pool.Name}).Set(float64(poolBacklog))
+	}
+	var sharedBacklog int64
+	for _, t := range config.TaskTypes {
+		if !slices.Contains(pooled, t) {
+			sharedBacklog += byType[t].Ready + byType[t].Running
+		}
+	}
+	c.metrics.TaskWorkerPoolBacklog.With(prometheus.Labels{"pool": "shared"}).Set(float64(sharedBacklog))
+}
+
 func (c *Collector) snapshottingFailCheckIterate() {
 	ctx := c.context
 	c.metrics.RHReposSnapshotNotCompletedInLast36HoursCount.Set(float64(c.dao.Metrics.RHReposSnapshotNotCompletedInLast36HoursCount(ctx)))
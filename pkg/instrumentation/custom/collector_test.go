@@ -5,9 +5,12 @@ import (
 	"testing"
 
 	"github.com/content-services/content-sources-backend/pkg/clients/pulp_client"
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/dao"
 	"github.com/content-services/content-sources-backend/pkg/db"
 	"github.com/content-services/content-sources-backend/pkg/instrumentation"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -61,3 +64,24 @@ func TestIterateNoPanic(t *testing.T) {
 		c.iterate()
 	})
 }
+
+func TestIterateTaskBacklogSkipsPoolsWithoutWorkers(t *testing.T) {
+	pools := config.Get().Tasking.WorkerPools
+	defer func() { config.Get().Tasking.WorkerPools = pools }()
+	config.Get().Tasking.WorkerPools = []config.TaskWorkerPool{
+		{Name: "introspection", Types: []string{config.IntrospectTask}, WorkerCount: 2},
+		{Name: "coverage", Types: []string{config.RefreshCoverageReportTask}, WorkerCount: 0},
+	}
+
+	reg := prometheus.NewRegistry()
+	c := &Collector{metrics: instrumentation.NewMetrics(reg)}
+	c.iterateTaskBacklog([]dao.TaskTypeBacklog{
+		{Type: config.IntrospectTask, Ready: 3, Running: 1},
+		{Type: config.RefreshCoverageReportTask, Ready: 5, Running: 2},
+		{Type: config.RepositorySnapshotTask, Ready: 1},
+	})
+
+	assert.Equal(t, float64(4), testutil.ToFloat64(c.metrics.TaskWorkerPoolBacklog.With(prometheus.Labels{"pool": "introspection"})))
+	assert.Equal(t, float64(8), testutil.ToFloat64(c.metrics.TaskWorkerPoolBacklog.With(prometheus.Labels{"pool": "shared"})))
+	assert.Equal(t, 2, testutil.CollectAndCount(&c.metrics.TaskWorkerPoolBacklog))
+}
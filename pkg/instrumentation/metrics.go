@@ -27,6 +27,8 @@ const (
 	TaskStatsLabelAverageWait                      = "task_stats_average_wait"
 	RHReposSnapshotNotCompletedInLast36HoursCount  = "rh_repos_snapshot_not_completed_in_last_36_hour_count"
 	TaskPendingTimeAverageByType                   = "task_pending_time_average_by_type"
+	TaskBacklogByType                              = "task_backlog_by_type"
+	TaskWorkerPoolBacklog                          = "task_worker_pool_backlog"
 	TemplatesCount                                 = "templates_count"
 	TemplatesUseLatestCount                        = "templates_use_latest_count"
 	TemplatesUseDateCount                          = "templates_use_date_count"
@@ -52,6 +54,8 @@ type Metrics struct {
 	OrgTotal                                       prometheus.Gauge
 	RHReposSnapshotNotCompletedInLast36HoursCount  prometheus.Gauge
 	TaskPendingTimeAverageByType                   prometheus.GaugeVec
+	TaskBacklogByType                              prometheus.GaugeVec
+	TaskWorkerPoolBacklog                          prometheus.GaugeVec
 	TemplatesCount                                 prometheus.Gauge
 	TemplatesUseLatestCount                        prometheus.Gauge
 	TemplatesUseDateCount                          prometheus.Gauge
@@ -140,6 +144,16 @@ func NewMetrics(reg *prometheus.Registry) *Metrics {
 			Name:      TaskPendingTimeAverageByType,
 			Help:      "Average pending time of the tasks by their type.",
 		}, []string{"task_type"}),
+		TaskBacklogByType: *promauto.With(reg).NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: NameSpace,
+			Name:      TaskBacklogByType,
+			Help:      "Number of pending, ready (pending and not blocked by dependencies) and running tasks by their type.",
+		}, []string{"task_type", "state"}),
+		TaskWorkerPoolBacklog: *promauto.With(reg).NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: NameSpace,
+			Name:      TaskWorkerPoolBacklog,
+			Help:      "Number of ready and running tasks of the task types handled by each worker pool. Used to scale the workers.",
+		}, []string{"pool"}),
 		TemplatesCount: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
 			Namespace: NameSpace,
 			Name:      TemplatesCount,
@@ -0,0 +1,82 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	"github.com/content-services/content-sources-backend/pkg/db"
+	"github.com/content-services/content-sources-backend/pkg/tasks"
+	"github.com/content-services/content-sources-backend/pkg/tasks/client"
+	"github.com/content-services/content-sources-backend/pkg/tasks/queue"
+	"github.com/content-services/content-sources-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// RefreshCoverageReports enqueues a refresh of the coverage reports with a scheduled refresh against the current
+// catalog, if it changed since they were matched. The refresh task notifies the organizations of the reports now
+// covering more packages, and each refresh replaces the previous refresh of the same report.
+// Usage: go run cmd/jobs/main.go refresh-coverage-reports
+func RefreshCoverageReports(_ []string) {
+	ctx := context.Background()
+
+	pgQueue, err := queue.NewPgQueue(ctx, db.GetUrl())
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to create PgQueue")
+	}
+	defer pgQueue.Close()
+	taskClient := client.NewTaskClient(&pgQueue)
+
+	daoReg := dao.GetDaoRegistry(db.DB)
+	refreshes, err := daoReg.CoverageReport.InternalOnly_ListScheduledRefreshes(ctx)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to list coverage reports to refresh")
+	}
+	if len(refreshes) == 0 {
+		log.Info().Msg("No coverage reports to refresh")
+		return
+	}
+
+	enqueued := enqueueCoverageRefreshes(ctx, daoReg, taskClient, refreshes)
+	log.Info().
+		Int("scheduled_reports", len(refreshes)).
+		Int("enqueued_refreshes", enqueued).
+		Msg("Finished enqueuing coverage report refreshes")
+}
+
+// enqueueCoverageRefreshes creates a pending refresh of each report and enqueues the task matching it, returning the
+// number of refreshes enqueued
+func enqueueCoverageRefreshes(ctx context.Context, daoReg *dao.DaoRegistry, taskClient client.TaskClient, refreshes []dao.ScheduledCoverageRefresh) int {
+	enqueued := 0
+	for _, refresh := range refreshes {
+		logger := log.With().Str("coverage_report_uuid", refresh.UUID).Str("org_id", refresh.OrgID).Logger()
+
+		report, err := daoReg.CoverageReport.Refresh(ctx, refresh.OrgID, refresh.UUID, dao.RefreshCoverageReportParams{Automatic: true})
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to refresh coverage report")
+			continue
+		}
+
+		task := queue.Task{
+			Typename: config.RefreshCoverageReportTask,
+			Payload:  tasks.RefreshCoverageReportPayload{ReportUUID: report.UUID, PreviousCovered: utils.Ptr(refresh.Covered)},
+			OrgId:    refresh.OrgID,
+		}
+		if refresh.AccountID != nil {
+			task.AccountId = *refresh.AccountID
+		}
+		taskID, err := taskClient.Enqueue(task)
+		if err != nil {
+			logger.Error().Err(err).Msg("error enqueuing coverage report refresh task")
+			if failErr := daoReg.CoverageReport.InternalOnly_FailAnalysis(ctx, report.UUID, err); failErr != nil {
+				logger.Error().Err(failErr).Msg("failed to mark coverage report refresh as failed")
+			}
+			continue
+		}
+		if err := daoReg.CoverageReport.SetAnalysisTask(ctx, refresh.OrgID, report.UUID, taskID.String()); err != nil {
+			logger.Error().Err(err).Msg("failed to set coverage report refresh task")
+		}
+		enqueued++
+	}
+	return enqueued
+}
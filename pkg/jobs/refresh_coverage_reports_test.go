@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	"github.com/content-services/content-sources-backend/pkg/tasks"
+	"github.com/content-services/content-sources-backend/pkg/tasks/client"
+	"github.com/content-services/content-sources-backend/pkg/tasks/queue"
+	"github.com/content-services/content-sources-backend/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnqueueCoverageRefreshes(t *testing.T) {
+	ctx := context.Background()
+	mockDao := dao.GetMockDaoRegistry(t)
+	mockTaskClient := client.NewMockTaskClient(t)
+	refreshes := []dao.ScheduledCoverageRefresh{
+		{UUID: "scheduled", OrgID: "org-1", AccountID: utils.Ptr("account-1"), Covered: 2},
+		{UUID: "failing", OrgID: "org-2", Covered: 1},
+		{UUID: "not-enqueued", OrgID: "org-3", Covered: 3},
+	}
+	taskUUID := uuid.New()
+	enqueueErr := errors.New("queue is down")
+
+	mockDao.CoverageReport.On("Refresh", ctx, "org-1", "scheduled", dao.RefreshCoverageReportParams{Automatic: true}).
+		Return(api.CoverageReportResponse{UUID: "scheduled-refresh"}, nil)
+	mockTaskClient.On("Enqueue", queue.Task{
+		Typename:  config.RefreshCoverageReportTask,
+		Payload:   tasks.RefreshCoverageReportPayload{ReportUUID: "scheduled-refresh", PreviousCovered: utils.Ptr(2)},
+		OrgId:     "org-1",
+		AccountId: "account-1",
+	}).Return(taskUUID, nil)
+	mockDao.CoverageReport.On("SetAnalysisTask", ctx, "org-1", "scheduled-refresh", taskUUID.String()).Return(nil)
+
+	mockDao.CoverageReport.On("Refresh", ctx, "org-2", "failing", dao.RefreshCoverageReportParams{Automatic: true}).
+		Return(api.CoverageReportResponse{}, errors.New("database is down"))
+
+	// A refresh that could not be enqueued is marked as failed
+	mockDao.CoverageReport.On("Refresh", ctx, "org-3", "not-enqueued", dao.RefreshCoverageReportParams{Automatic: true}).
+		Return(api.CoverageReportResponse{UUID: "not-enqueued-refresh"}, nil)
+	mockTaskClient.On("Enqueue", queue.Task{
+		Typename: config.RefreshCoverageReportTask,
+		Payload:  tasks.RefreshCoverageReportPayload{ReportUUID: "not-enqueued-refresh", PreviousCovered: utils.Ptr(3)},
+		OrgId:    "org-3",
+	}).Return(uuid.Nil, enqueueErr)
+	mockDao.CoverageReport.On("InternalOnly_FailAnalysis", ctx, "not-enqueued-refresh", enqueueErr).Return(nil)
+
+	enqueued := enqueueCoverageRefreshes(ctx, mockDao.ToDaoRegistry(), mockTaskClient, refreshes)
+	assert.Equal(t, 1, enqueued)
+}
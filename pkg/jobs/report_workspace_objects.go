@@ -0,0 +1,93 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/db"
+	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/content-services/content-sources-backend/pkg/rbac"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+const reportWorkspaceObjectsBatchSize = 100
+
+// ReportWorkspaceObjects reports the existing repositories and templates to kessel, in the workspace they were created
+// in, so per object permissions apply to objects created before they were reported on creation. Reporting an object
+// again is harmless, so the job can be re-run.
+// Usage: go run cmd/jobs/main.go report-workspace-objects
+func ReportWorkspaceObjects(_ []string) {
+	ctx := context.Background()
+
+	if !config.Get().Features.Kessel.Enabled || !config.Get().Features.KesselObjectPermissions.Enabled {
+		log.Info().Msg("Per object permissions are disabled, no workspace objects to report")
+		return
+	}
+
+	err := db.Connect()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to connect to database")
+	}
+	err = rbac.SetupObjectReporter()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to set up kessel object reporter")
+	}
+
+	var repoConfigs []models.RepositoryConfiguration
+	reportedRepos, failedRepos := 0, 0
+	res := db.DB.WithContext(ctx).
+		Select("uuid", "org_id", "workspace_id").
+		Where("org_id NOT IN ?", []string{config.RedHatOrg, config.CommunityOrg, config.LightwellOrg, config.LightwellDemoOrg}).
+		FindInBatches(&repoConfigs, reportWorkspaceObjectsBatchSize, func(tx *gorm.DB, batch int) error {
+			for _, repoConfig := range repoConfigs {
+				if reportWorkspaceObject(ctx, rbac.ResourceRepositories, repoConfig.OrgID, repoConfig.UUID, repoConfig.WorkspaceID) {
+					reportedRepos++
+				} else {
+					failedRepos++
+				}
+			}
+			return nil
+		})
+	if res.Error != nil {
+		log.Fatal().Err(res.Error).Msg("failed to list repositories")
+	}
+
+	var templates []models.Template
+	reportedTemplates, failedTemplates := 0, 0
+	res = db.DB.WithContext(ctx).
+		Select("uuid", "org_id", "workspace_id").
+		FindInBatches(&templates, reportWorkspaceObjectsBatchSize, func(tx *gorm.DB, batch int) error {
+			for _, template := range templates {
+				if reportWorkspaceObject(ctx, rbac.ResourceTemplates, template.OrgID, template.UUID, template.WorkspaceID) {
+					reportedTemplates++
+				} else {
+					failedTemplates++
+				}
+			}
+			return nil
+		})
+	if res.Error != nil {
+		log.Fatal().Err(res.Error).Msg("failed to list templates")
+	}
+
+	log.Info().
+		Int("reported_repositories", reportedRepos).
+		Int("failed_repositories", failedRepos).
+		Int("reported_templates", reportedTemplates).
+		Int("failed_templates", failedTemplates).
+		Msg("Finished reporting workspace objects")
+}
+
+// reportWorkspaceObject reports one object, returning whether it was reported
+func reportWorkspaceObject(ctx context.Context, resource rbac.Resource, orgID string, uuid string, workspaceID *string) bool {
+	var workspace string
+	if workspaceID != nil {
+		workspace = *workspaceID
+	}
+	if err := rbac.ReportObject(ctx, resource, orgID, uuid, workspace); err != nil {
+		log.Error().Err(err).Str("org_id", orgID).Str("uuid", uuid).Msgf("failed to report %s", resource)
+		return false
+	}
+	return true
+}
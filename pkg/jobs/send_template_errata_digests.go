@@ -0,0 +1,91 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	"github.com/content-services/content-sources-backend/pkg/db"
+	"github.com/content-services/content-sources-backend/pkg/event"
+	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/rs/zerolog/log"
+)
+
+type datedTemplate struct {
+	UUID  string
+	OrgID string
+	Name  string
+	Date  time.Time
+}
+
+// SendTemplateErrataDigests counts the errata each template that does not use the latest snapshots is missing, and
+// sends each organization one digest of its templates that are missing errata
+// Usage: go run cmd/jobs/main.go send-template-errata-digests
+func SendTemplateErrataDigests(_ []string) {
+	ctx := context.Background()
+
+	err := config.ConfigureTang()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to configure tang")
+	}
+	config.SetupNotifications()
+	event.SetWebhookDispatcher(dao.GetWebhookDao(db.DB))
+	event.SetOutbox(dao.GetOutboxDao(db.DB))
+
+	var templates []datedTemplate
+	err = db.DB.Model(&models.Template{}).
+		Where("deleted_at IS NULL AND use_latest = ?", false).
+		Order("org_id, name").
+		Select("uuid", "org_id", "name", "date").
+		Find(&templates).Error
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to fetch templates")
+	}
+	log.Info().Int("template_count", len(templates)).Msg("Found templates to count errata for")
+
+	digests := templateErrataDigests(ctx, dao.GetDaoRegistry(db.DB), templates)
+	for _, digest := range digests {
+		event.SendNotification(digest.OrgID, event.TemplateErrataDigest, []event.TemplateErrataDigestPayload{digest})
+	}
+
+	log.Info().
+		Int("digests_sent", len(digests)).
+		Int("total_templates", len(templates)).
+		Msg("Finished sending template errata digests")
+}
+
+// templateErrataDigests counts and stores the errata available to each template, and returns a digest for each
+// organization with templates missing errata. Templates are expected to be ordered by organization.
+func templateErrataDigests(ctx context.Context, daoReg *dao.DaoRegistry, templates []datedTemplate) []event.TemplateErrataDigestPayload {
+	var digests []event.TemplateErrataDigestPayload
+	for _, template := range templates {
+		logger := log.With().Str("template_uuid", template.UUID).Str("org_id", template.OrgID).Logger()
+
+		counts, err := daoReg.Rpm.FetchTemplateAvailableErrataCounts(ctx, template.OrgID, template.UUID)
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to count available errata")
+			continue
+		}
+		err = daoReg.Template.UpdateAvailableErrataCounts(ctx, template.OrgID, template.UUID, counts)
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to store available errata counts")
+		}
+		if counts.Total() == 0 {
+			continue
+		}
+
+		if len(digests) == 0 || digests[len(digests)-1].OrgID != template.OrgID {
+			digests = append(digests, event.TemplateErrataDigestPayload{OrgID: template.OrgID})
+		}
+		digest := &digests[len(digests)-1]
+		digest.Templates = append(digest.Templates, event.MapOutdatedTemplatePayload(api.TemplateResponse{
+			UUID:                  template.UUID,
+			Name:                  template.Name,
+			Date:                  template.Date,
+			AvailableErrataCounts: &counts,
+		}))
+	}
+	return digests
+}
@@ -0,0 +1,55 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateErrataDigests(t *testing.T) {
+	ctx := context.Background()
+	mockDao := dao.GetMockDaoRegistry(t)
+	date := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	templates := []datedTemplate{
+		{UUID: "outdated-1", OrgID: "org-1", Name: "a", Date: date},
+		{UUID: "up-to-date", OrgID: "org-1", Name: "b", Date: date},
+		{UUID: "outdated-2", OrgID: "org-1", Name: "c", Date: date},
+		{UUID: "failing", OrgID: "org-2", Name: "d", Date: date},
+		{UUID: "outdated-3", OrgID: "org-3", Name: "e", Date: date},
+	}
+
+	outdated := api.ErrataCounts{Security: 2, Bugfix: 1}
+	for _, template := range templates {
+		if template.UUID == "failing" {
+			mockDao.Rpm.On("FetchTemplateAvailableErrataCounts", ctx, template.OrgID, template.UUID).
+				Return(api.ErrataCounts{}, errors.New("tang is down"))
+			continue
+		}
+		counts := outdated
+		if template.UUID == "up-to-date" {
+			counts = api.ErrataCounts{}
+		}
+		mockDao.Rpm.On("FetchTemplateAvailableErrataCounts", ctx, template.OrgID, template.UUID).Return(counts, nil)
+		mockDao.Template.On("UpdateAvailableErrataCounts", ctx, template.OrgID, template.UUID, counts).Return(nil)
+	}
+
+	digests := templateErrataDigests(ctx, mockDao.ToDaoRegistry(), templates)
+	require.Len(t, digests, 2)
+
+	assert.Equal(t, "org-1", digests[0].OrgID)
+	require.Len(t, digests[0].Templates, 2)
+	assert.Equal(t, "outdated-1", digests[0].Templates[0].UUID)
+	assert.Equal(t, "outdated-2", digests[0].Templates[1].UUID)
+	assert.Equal(t, outdated, digests[0].Templates[0].AvailableErrataCounts)
+	assert.Equal(t, date.Format(time.RFC3339), digests[0].Templates[0].Date)
+
+	assert.Equal(t, "org-3", digests[1].OrgID)
+	require.Len(t, digests[1].Templates, 1)
+	assert.Equal(t, "outdated-3", digests[1].Templates[0].UUID)
+}
@@ -0,0 +1,41 @@
+package severity
+
+import "strconv"
+
+// Severity labels of Lightwell advisories, ranked by their CVSS score
+const (
+	Critical  = "critical"
+	Important = "important"
+	Moderate  = "moderate"
+	Low       = "low"
+)
+
+// Label converts a numeric CVSS score to a severity label. Missing or invalid scores are low.
+func Label(score string) string {
+	value, err := strconv.ParseFloat(score, 64)
+	if err != nil || value <= 0 {
+		return Low
+	}
+	switch {
+	case value >= 9.0:
+		return Critical
+	case value >= 7.0:
+		return Important
+	case value >= 4.0:
+		return Moderate
+	default:
+		return Low
+	}
+}
+
+// Highest returns the severity label of the highest of a set of CVSS scores
+func Highest(scores []string) string {
+	var maxScore float64
+	for _, score := range scores {
+		value, err := strconv.ParseFloat(score, 64)
+		if err == nil && value > maxScore {
+			maxScore = value
+		}
+	}
+	return Label(strconv.FormatFloat(maxScore, 'f', 1, 64))
+}
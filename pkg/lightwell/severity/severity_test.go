@@ -0,0 +1,21 @@
+package severity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabel(t *testing.T) {
+	assert.Equal(t, Critical, Label("9.8"))
+	assert.Equal(t, Important, Label("7.0"))
+	assert.Equal(t, Moderate, Label("5.3"))
+	assert.Equal(t, Low, Label("2.1"))
+	assert.Equal(t, Low, Label(""))
+}
+
+func TestHighest(t *testing.T) {
+	assert.Equal(t, Critical, Highest([]string{"5.3", "9.8", "invalid"}))
+	assert.Equal(t, Moderate, Highest([]string{"4.0", "2.1"}))
+	assert.Equal(t, Low, Highest(nil))
+}
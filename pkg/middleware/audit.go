@@ -0,0 +1,239 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	path_util "github.com/content-services/content-sources-backend/pkg/handler/utils"
+	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/content-services/content-sources-backend/pkg/rbac"
+	"github.com/labstack/echo/v4"
+	echo_middleware "github.com/labstack/echo/v4/middleware"
+	"github.com/redhatinsights/platform-go-middlewares/v2/identity"
+	"github.com/rs/zerolog"
+)
+
+// AuditBodyLimit is the size of the largest request and response bodies read to record an audit event.
+// Larger request bodies are not recorded.
+const AuditBodyLimit = 64 * 1024
+
+// auditRedacted replaces the values of request attributes that must not be recorded
+const auditRedacted = "REDACTED"
+
+// auditObjectUUIDsKey stores the UUIDs of the objects a handler changed, see AddAuditObjectUUIDs
+const auditObjectUUIDsKey = "audit_object_uuids"
+
+type Audit struct {
+	Skipper        echo_middleware.Skipper
+	PermissionsMap *rbac.PermissionsMap
+	AuditEventDao  dao.AuditEventDao
+}
+
+// NewAudit records an audit event for every request changing the content of an organization, that is every request
+// not mapped to the read verb in the permissions map. Events are recorded whether the request succeeds or not.
+func NewAudit(auditConfig Audit) echo.MiddlewareFunc {
+	if auditConfig.PermissionsMap == nil {
+		panic("PermissionsMap cannot be nil")
+	}
+	if auditConfig.AuditEventDao == nil {
+		panic("AuditEventDao cannot be nil")
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if auditConfig.Skipper != nil && auditConfig.Skipper(c) {
+				return next(c)
+			}
+			method := c.Request().Method
+			if method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions {
+				return next(c)
+			}
+			route := MatchedRoute(c)
+			path := strings.Join(path_util.NewPathWithString(route).RemovePrefixes(), "/")
+			_, verb, err := auditConfig.PermissionsMap.Permission(method, path)
+			if err != nil || verb == rbac.RbacVerbRead {
+				return next(c)
+			}
+
+			requestBody := readAuditRequestBody(c)
+			response := &auditResponseWriter{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = response
+
+			err = next(c)
+
+			c.Response().Writer = response.ResponseWriter
+			auditEvent := newAuditEvent(c, "/"+path, requestBody, response.body.Bytes(), err)
+			if auditEvent.OrgID != "" {
+				ctx := context.WithoutCancel(c.Request().Context())
+				if createErr := auditConfig.AuditEventDao.Create(ctx, auditEvent); createErr != nil {
+					zerolog.Ctx(ctx).Error().Err(createErr).Str("route", auditEvent.Route).Msg("could not record audit event")
+				}
+			}
+			return err
+		}
+	}
+}
+
+func newAuditEvent(c echo.Context, route string, requestBody any, responseBody []byte, err error) models.AuditEvent {
+	ctx := c.Request().Context()
+	id := identity.GetIdentity(ctx).Identity
+	auditEvent := models.AuditEvent{
+		OrgID:       id.Internal.OrgID,
+		AccountID:   id.AccountNumber,
+		Method:      c.Request().Method,
+		Route:       route,
+		Path:        c.Request().URL.Path,
+		ObjectUUIDs: auditObjectUUIDs(c, requestBody, responseBody),
+		StatusCode:  c.Response().Status,
+	}
+	if id.User != nil {
+		auditEvent.Username = id.User.Username
+	} else if id.ServiceAccount != nil {
+		auditEvent.Username = id.ServiceAccount.Username
+	}
+	if requestID, ok := ctx.Value(config.ContextRequestIDKey{}).(string); ok {
+		auditEvent.RequestID = requestID
+	}
+	if requestBody != nil {
+		if request, marshalErr := json.Marshal(requestBody); marshalErr == nil {
+			auditEvent.Request = request
+		}
+	}
+	if err != nil {
+		message := err.Error()
+		auditEvent.Error = &message
+		if status, ok := errorStatus(err); ok {
+			auditEvent.StatusCode = status
+		} else {
+			auditEvent.StatusCode = http.StatusInternalServerError
+		}
+	}
+	return auditEvent
+}
+
+// AddAuditObjectUUIDs records the UUIDs of objects changed by the request in its audit event, for objects neither the
+// request nor the response identify by a uuid attribute, such as the tasks matched by the filters of a bulk action
+func AddAuditObjectUUIDs(c echo.Context, uuids []string) {
+	objectUUIDs, _ := c.Get(auditObjectUUIDsKey).([]string)
+	c.Set(auditObjectUUIDsKey, append(objectUUIDs, uuids...))
+}
+
+// readAuditRequestBody returns the JSON body of the request with secrets redacted, leaving the body readable by the handler
+func readAuditRequestBody(c echo.Context) any {
+	if !strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) || c.Request().Body == nil {
+		return nil
+	}
+	buffered := BufferedReadCloser{bufio.NewReaderSize(c.Request().Body, AuditBodyLimit), c.Request().Body}
+	c.Request().Body = buffered
+
+	body, err := buffered.Peek(AuditBodyLimit)
+	if !errors.Is(err, io.EOF) || len(body) == 0 {
+		return nil
+	}
+	var decoded any
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return nil
+	}
+	return redactAuditBody(decoded)
+}
+
+// redactAuditBody replaces the values of attributes holding secrets, such as the secret of a webhook
+func redactAuditBody(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			lowerKey := strings.ToLower(key)
+			if strings.Contains(lowerKey, "secret") || strings.Contains(lowerKey, "password") || strings.Contains(lowerKey, "token") {
+				v[key] = auditRedacted
+			} else {
+				v[key] = redactAuditBody(item)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactAuditBody(item)
+		}
+	}
+	return value
+}
+
+// auditObjectUUIDs returns the UUIDs in the path parameters, the uuid attributes of the request body, such as
+// uuids or repository_uuids, the uuid attributes of the objects in the response body, such as a created repository,
+// and the UUIDs added by the handler with AddAuditObjectUUIDs
+func auditObjectUUIDs(c echo.Context, requestBody any, responseBody []byte) []string {
+	uuids := []string{}
+	add := func(value any) {
+		switch v := value.(type) {
+		case string:
+			if v != "" && !slices.Contains(uuids, v) {
+				uuids = append(uuids, v)
+			}
+		case []any:
+			for _, item := range v {
+				if s, ok := item.(string); ok && s != "" && !slices.Contains(uuids, s) {
+					uuids = append(uuids, s)
+				}
+			}
+		}
+	}
+	addAttributes := func(body any, isUUID func(key string) bool) {
+		objects, ok := body.([]any)
+		if !ok {
+			objects = []any{body}
+		}
+		for _, object := range objects {
+			if attributes, ok := object.(map[string]any); ok {
+				for key, value := range attributes {
+					if isUUID(key) {
+						add(value)
+					}
+				}
+			}
+		}
+	}
+
+	for i, name := range c.ParamNames() {
+		if strings.Contains(name, "uuid") && i < len(c.ParamValues()) {
+			add(c.ParamValues()[i])
+		}
+	}
+	addAttributes(requestBody, func(key string) bool {
+		return key == "uuid" || key == "uuids" || strings.HasSuffix(key, "_uuid") || strings.HasSuffix(key, "_uuids")
+	})
+	var response any
+	if len(responseBody) > 0 && json.Unmarshal(responseBody, &response) == nil {
+		addAttributes(response, func(key string) bool { return key == "uuid" })
+	}
+	if objectUUIDs, ok := c.Get(auditObjectUUIDsKey).([]string); ok {
+		for _, uuid := range objectUUIDs {
+			add(uuid)
+		}
+	}
+	return uuids
+}
+
+// auditResponseWriter keeps a copy of the beginning of the response body
+type auditResponseWriter struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *auditResponseWriter) Write(b []byte) (int, error) {
+	if remaining := AuditBodyLimit - w.body.Len(); remaining > 0 {
+		w.body.Write(b[:min(len(b), remaining)])
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *auditResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	ce "github.com/content-services/content-sources-backend/pkg/errors"
+	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/content-services/content-sources-backend/pkg/rbac"
+	"github.com/labstack/echo/v4"
+	"github.com/redhatinsights/platform-go-middlewares/v2/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func auditServe(t *testing.T, auditEventDao dao.AuditEventDao, req *http.Request) *httptest.ResponseRecorder {
+	permissions := rbac.NewPermissionsMap().
+		Add(http.MethodGet, "/repositories/", rbac.ResourceRepositories, rbac.RbacVerbRead).
+		Add(http.MethodPost, "/repositories/", rbac.ResourceRepositories, rbac.RbacVerbWrite).
+		Add(http.MethodPost, "/rpms/names/", rbac.ResourceRepositories, rbac.RbacVerbRead).
+		Add(http.MethodDelete, "/repositories/:uuid", rbac.ResourceRepositories, rbac.RbacVerbDelete).
+		Add(http.MethodPost, "/tasks/retry/", rbac.ResourceRepositories, rbac.RbacVerbWrite)
+
+	e := echo.New()
+	e.HTTPErrorHandler = config.CustomHTTPErrorHandler
+	e.Use(AddRequestId)
+	e.Use(echo.WrapMiddleware(identity.EnforceIdentity))
+	e.Use(NewAudit(Audit{PermissionsMap: permissions, AuditEventDao: auditEventDao}))
+
+	g := e.Group("/api/" + config.DefaultAppName + "/v1")
+	g.GET("/repositories/", handleItWorked)
+	g.POST("/rpms/names/", handleItWorked)
+	g.POST("/repositories/", func(c echo.Context) error {
+		var body map[string]any
+		if err := c.Bind(&body); err != nil {
+			return err
+		}
+		return c.JSON(http.StatusCreated, map[string]any{"uuid": "created-uuid", "name": body["name"]})
+	})
+	g.DELETE("/repositories/:uuid", func(c echo.Context) error {
+		return ce.NewErrorResponse(http.StatusNotFound, "Error deleting repository", "not found")
+	})
+	g.POST("/tasks/retry/", func(c echo.Context) error {
+		AddAuditObjectUUIDs(c, []string{"task-1", "task-2"})
+		AddAuditObjectUUIDs(c, []string{"task-2", "task-3"})
+		return c.JSON(http.StatusOK, map[string]any{"task_uuids": []string{"task-1", "task-2", "task-3"}})
+	})
+
+	xrhid := identity.XRHID{Identity: identity.Identity{
+		Type:          "User",
+		OrgID:         "12345",
+		AccountNumber: "11111",
+		Internal:      identity.Internal{OrgID: "12345"},
+		User:          &identity.User{Username: "user"},
+	}}
+	jsonBytes, err := json.Marshal(xrhid)
+	require.NoError(t, err)
+	req.Header.Set(xrhidHeader, base64.StdEncoding.EncodeToString(jsonBytes))
+	req.Header.Set(config.HeaderRequestId, "request-id")
+	rw := httptest.NewRecorder()
+	e.ServeHTTP(rw, req)
+	return rw
+}
+
+func TestAuditCreate(t *testing.T) {
+	auditEventDao := dao.NewMockAuditEventDao(t)
+	var auditEvent models.AuditEvent
+	auditEventDao.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		auditEvent = args.Get(1).(models.AuditEvent)
+	}).Return(nil).Once()
+
+	body := `{"name": "repo", "snapshot": true, "webhook": {"secret": "a-very-secret-value"}, "template_uuids": ["template-uuid"]}`
+	req := httptest.NewRequest(http.MethodPost, URLPrefix+"/v1/repositories/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rw := auditServe(t, auditEventDao, req)
+
+	// The handler still reads the whole body
+	require.Equal(t, http.StatusCreated, rw.Code)
+	assert.Contains(t, rw.Body.String(), `"name":"repo"`)
+
+	assert.Equal(t, "12345", auditEvent.OrgID)
+	assert.Equal(t, "11111", auditEvent.AccountID)
+	assert.Equal(t, "user", auditEvent.Username)
+	assert.Equal(t, "request-id", auditEvent.RequestID)
+	assert.Equal(t, http.MethodPost, auditEvent.Method)
+	assert.Equal(t, "/repositories", auditEvent.Route)
+	assert.Equal(t, URLPrefix+"/v1/repositories/", auditEvent.Path)
+	assert.Equal(t, http.StatusCreated, auditEvent.StatusCode)
+	assert.Nil(t, auditEvent.Error)
+	assert.ElementsMatch(t, []string{"template-uuid", "created-uuid"}, auditEvent.ObjectUUIDs)
+
+	var request map[string]any
+	require.NoError(t, json.Unmarshal(auditEvent.Request, &request))
+	assert.Equal(t, "repo", request["name"])
+	assert.Equal(t, map[string]any{"secret": auditRedacted}, request["webhook"])
+}
+
+func TestAuditError(t *testing.T) {
+	auditEventDao := dao.NewMockAuditEventDao(t)
+	var auditEvent models.AuditEvent
+	auditEventDao.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		auditEvent = args.Get(1).(models.AuditEvent)
+	}).Return(nil).Once()
+
+	req := httptest.NewRequest(http.MethodDelete, URLPrefix+"/v1/repositories/repo-uuid", nil)
+	rw := auditServe(t, auditEventDao, req)
+
+	require.Equal(t, http.StatusNotFound, rw.Code)
+	assert.Equal(t, "/repositories/:uuid", auditEvent.Route)
+	assert.Equal(t, http.StatusNotFound, auditEvent.StatusCode)
+	require.NotNil(t, auditEvent.Error)
+	assert.Contains(t, *auditEvent.Error, "not found")
+	assert.Equal(t, []string{"repo-uuid"}, auditEvent.ObjectUUIDs)
+	assert.Nil(t, auditEvent.Request)
+}
+
+func TestAuditHandlerObjectUUIDs(t *testing.T) {
+	auditEventDao := dao.NewMockAuditEventDao(t)
+	var auditEvent models.AuditEvent
+	auditEventDao.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		auditEvent = args.Get(1).(models.AuditEvent)
+	}).Return(nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, URLPrefix+"/v1/tasks/retry/", strings.NewReader(`{"org_id": "12345"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rw := auditServe(t, auditEventDao, req)
+
+	require.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, []string{"task-1", "task-2", "task-3"}, auditEvent.ObjectUUIDs)
+	assert.JSONEq(t, `{"org_id": "12345"}`, string(auditEvent.Request))
+}
+
+func TestAuditSkipsReads(t *testing.T) {
+	auditEventDao := dao.NewMockAuditEventDao(t)
+
+	rw := auditServe(t, auditEventDao, httptest.NewRequest(http.MethodGet, URLPrefix+"/v1/repositories/", nil))
+	assert.Equal(t, http.StatusOK, rw.Code)
+
+	req := httptest.NewRequest(http.MethodPost, URLPrefix+"/v1/rpms/names/", strings.NewReader(`{"search": "a"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rw = auditServe(t, auditEventDao, req)
+	assert.Equal(t, http.StatusOK, rw.Code)
+
+	auditEventDao.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -16,20 +16,8 @@ func ExtractStatus(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		var err error
 		if err = next(c); err != nil {
-			httpErr := new(ce.ErrorResponse)
-			if errors.As(err, httpErr) {
-				largest := 0
-				for _, respErr := range httpErr.Errors {
-					if respErr.Status > largest {
-						largest = respErr.Status
-					}
-				}
-				c.Response().Status = largest
-			} else {
-				var echoErr *echo.HTTPError
-				if errors.As(err, &echoErr) {
-					c.Response().Status = echoErr.Code
-				}
+			if status, ok := errorStatus(err); ok {
+				c.Response().Status = status
 			}
 			return err
 		}
@@ -37,3 +25,22 @@ func ExtractStatus(next echo.HandlerFunc) echo.HandlerFunc {
 		return nil
 	}
 }
+
+// errorStatus returns the largest status of an error response, or the code of an echo error
+func errorStatus(err error) (int, bool) {
+	httpErr := new(ce.ErrorResponse)
+	if errors.As(err, httpErr) {
+		largest := 0
+		for _, respErr := range httpErr.Errors {
+			if respErr.Status > largest {
+				largest = respErr.Status
+			}
+		}
+		return largest, true
+	}
+	var echoErr *echo.HTTPError
+	if errors.As(err, &echoErr) {
+		return echoErr.Code, true
+	}
+	return 0, false
+}
@@ -22,11 +22,12 @@ const (
 )
 
 type Rbac struct {
-	BaseUrl        string
-	Skipper        echo_middleware.Skipper
-	RbacClient     rbac.ClientWrapper
-	KesselClient   rbac.ClientWrapper
-	PermissionsMap *rbac.PermissionsMap
+	BaseUrl            string
+	Skipper            echo_middleware.Skipper
+	RbacClient         rbac.ClientWrapper
+	KesselClient       rbac.ClientWrapper
+	KesselObjectClient rbac.ObjectClientWrapper
+	PermissionsMap     *rbac.PermissionsMap
 }
 
 func NewRbac(rbacConfig Rbac) echo.MiddlewareFunc {
@@ -63,13 +64,34 @@ func NewRbac(rbacConfig Rbac) echo.MiddlewareFunc {
 				return echo.ErrUnauthorized
 			}
 
-			var allowed bool
+			client := rbacConfig.RbacClient
+			objectsAccessible := false
 			if config.FeatureAccessible(c.Request().Context(), config.Get().Features.Kessel) {
 				logger.Debug().Msg("using kessel")
-				allowed, err = rbacConfig.KesselClient.Allowed(c.Request().Context(), resource, verb)
+				client = rbacConfig.KesselClient
+				if rbacConfig.KesselObjectClient != nil && config.FeatureAccessible(c.Request().Context(), config.Get().Features.KesselObjectPermissions) {
+					objectsAccessible = true
+					c.SetRequest(c.Request().WithContext(rbac.WithObjectClient(c.Request().Context(), rbacConfig.KesselObjectClient)))
+				}
 			} else {
 				logger.Debug().Msg("using rbac")
-				allowed, err = rbacConfig.RbacClient.Allowed(c.Request().Context(), resource, verb)
+			}
+
+			if rbacConfig.PermissionsMap.Unrestricted(method, path) {
+				c.SetRequest(c.Request().WithContext(rbac.WithClient(c.Request().Context(), client)))
+				return next(c)
+			}
+
+			checkAllowed := func(resource rbac.Resource, verb rbac.Verb) (bool, error) {
+				allowed, err := client.Allowed(c.Request().Context(), resource, verb)
+				if objectsAccessible && err == nil && !allowed {
+					allowed, err = objectAllowed(c, rbacConfig.KesselObjectClient, rbacConfig.PermissionsMap, method, path, resource, verb)
+				}
+				return allowed, err
+			}
+			allowed, err := checkAllowed(resource, verb)
+			if fallbackResource, fallbackVerb, ok := rbac.WriteFallback(resource, verb); ok && err == nil && !allowed {
+				allowed, err = checkAllowed(fallbackResource, fallbackVerb)
 			}
 
 			data := identity.GetIdentity(c.Request().Context())
@@ -84,7 +106,43 @@ func NewRbac(rbacConfig Rbac) echo.MiddlewareFunc {
 				return echo.ErrUnauthorized
 			}
 
+			c.SetRequest(c.Request().WithContext(rbac.WithClient(c.Request().Context(), client)))
 			return next(c)
 		}
 	}
 }
+
+// objectAllowed checks the permissions of the user on the repository or template the request is about.
+// Requests listing repositories or templates are allowed if the user is allowed on any of them, and
+// the UUIDs of those are added to the request context to filter the list. Requests creating repositories
+// or templates are allowed, the handler checking the permissions of the user on the requested workspace.
+func objectAllowed(c echo.Context, client rbac.ObjectClientWrapper, permissions *rbac.PermissionsMap, method string, path string, resource rbac.Resource, verb rbac.Verb) (bool, error) {
+	ctx := c.Request().Context()
+	if permissions.CreatesObjects(method, path) {
+		c.SetRequest(c.Request().WithContext(rbac.WithDefaultWorkspaceDenied(ctx)))
+		return true, nil
+	}
+	if uuid := objectUUID(c, path, resource); uuid != "" {
+		return client.AllowedObject(ctx, resource, verb, uuid)
+	}
+	if !permissions.FiltersObjects(method, path) {
+		return false, nil
+	}
+
+	uuids, err := client.AllowedObjects(ctx, resource, verb)
+	if err != nil || len(uuids) == 0 {
+		return false, err
+	}
+	c.SetRequest(c.Request().WithContext(rbac.WithAllowedObjects(ctx, uuids)))
+	return true, nil
+}
+
+// objectUUID returns the UUID of the repository or template in the path of the request, such as
+// repositories/:uuid/snapshots/ or templates/:template_uuid/config.repo
+func objectUUID(c echo.Context, path string, resource rbac.Resource) string {
+	segments := strings.Split(path, "/")
+	if len(segments) < 2 || segments[0] != string(rbac.ObjectResource(resource)) || !strings.HasPrefix(segments[1], ":") {
+		return ""
+	}
+	return c.Param(strings.TrimPrefix(segments[1], ":"))
+}
@@ -16,6 +16,7 @@ import (
 	echo_middleware "github.com/labstack/echo/v4/middleware"
 	"github.com/redhatinsights/platform-go-middlewares/v2/identity"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -335,3 +336,256 @@ func TestRbacMiddleware(t *testing.T) {
 		assert.Equal(t, testCase.Expected.Body, response.Body.String())
 	}
 }
+
+func TestRbacMiddlewareKesselObjects(t *testing.T) {
+	features := config.Get().Features
+	defer func() { config.Get().Features = features }()
+	config.Get().Features.Kessel = config.Feature{Enabled: true}
+
+	testPath := "/api/content-sources/v1"
+	repoUUID := "2f6ee3bc-4d8e-4f1c-a9a9-7ad7b7e1b111"
+	templateUUID := "b3c8a4a8-43b3-4e2e-8d0e-9a8ec4d2f222"
+
+	permissions := rbac.NewPermissionsMap()
+	permissions.Add(http.MethodGet, "/repositories/", rbac.ResourceRepositories, rbac.RbacVerbRead)
+	permissions.AddObjectFilter(http.MethodGet, "/repositories/")
+	permissions.Add(http.MethodDelete, "/repositories/:uuid", rbac.ResourceRepositories, rbac.RbacVerbWrite)
+	permissions.Add(http.MethodGet, "/templates/:template_uuid/config.repo", rbac.ResourceTemplates, rbac.RbacVerbRead)
+	permissions.Add(http.MethodGet, "/features/", rbac.ResourceRepositories, rbac.RbacVerbRead)
+	permissions.Add(http.MethodDelete, "/repositories/:repo_uuid/snapshots/:snapshot_uuid", rbac.ResourceSnapshots, rbac.RbacVerbDelete)
+	permissions.Add(http.MethodPost, "/templates/", rbac.ResourceTemplates, rbac.RbacVerbWrite)
+	permissions.AddObjectCreate(http.MethodPost, "/templates/")
+
+	type TestCase struct {
+		Name           string
+		Method         string
+		Route          string
+		Path           string
+		OrgAllowed     bool
+		ObjectsFeature bool
+		Setup          func(client *rbac.MockObjectClientWrapper)
+		ExpectedCode   int
+		ExpectedBody   string
+	}
+	testCases := []TestCase{
+		{
+			Name:           "Allowed on the organization",
+			Method:         http.MethodGet,
+			Route:          "/repositories/",
+			Path:           "/repositories/",
+			OrgAllowed:     true,
+			ObjectsFeature: true,
+			ExpectedCode:   http.StatusOK,
+			ExpectedBody:   "null\n",
+		},
+		{
+			Name:           "Allowed on the repository",
+			Method:         http.MethodDelete,
+			Route:          "/repositories/:uuid",
+			Path:           "/repositories/" + repoUUID,
+			ObjectsFeature: true,
+			Setup: func(client *rbac.MockObjectClientWrapper) {
+				client.On("AllowedObject", mock.Anything, rbac.ResourceRepositories, rbac.RbacVerbWrite, repoUUID).Return(true, nil)
+			},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "null\n",
+		},
+		{
+			Name:           "Allowed to delete snapshots of the repository",
+			Method:         http.MethodDelete,
+			Route:          "/repositories/:repo_uuid/snapshots/:snapshot_uuid",
+			Path:           "/repositories/" + repoUUID + "/snapshots/" + templateUUID,
+			ObjectsFeature: true,
+			Setup: func(client *rbac.MockObjectClientWrapper) {
+				client.On("AllowedObject", mock.Anything, rbac.ResourceSnapshots, rbac.RbacVerbDelete, repoUUID).Return(true, nil)
+			},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "null\n",
+		},
+		{
+			Name:           "Not allowed on the template",
+			Method:         http.MethodGet,
+			Route:          "/templates/:template_uuid/config.repo",
+			Path:           "/templates/" + templateUUID + "/config.repo",
+			ObjectsFeature: true,
+			Setup: func(client *rbac.MockObjectClientWrapper) {
+				client.On("AllowedObject", mock.Anything, rbac.ResourceTemplates, rbac.RbacVerbRead, templateUUID).Return(false, nil)
+			},
+			ExpectedCode: http.StatusUnauthorized,
+			ExpectedBody: "{\"message\":\"Unauthorized\"}\n",
+		},
+		{
+			Name:           "List filtered to allowed repositories",
+			Method:         http.MethodGet,
+			Route:          "/repositories/",
+			Path:           "/repositories/",
+			ObjectsFeature: true,
+			Setup: func(client *rbac.MockObjectClientWrapper) {
+				client.On("AllowedObjects", mock.Anything, rbac.ResourceRepositories, rbac.RbacVerbRead).Return([]string{repoUUID}, nil)
+			},
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "[\"" + repoUUID + "\"]\n",
+		},
+		{
+			Name:           "List without allowed repositories",
+			Method:         http.MethodGet,
+			Route:          "/repositories/",
+			Path:           "/repositories/",
+			ObjectsFeature: true,
+			Setup: func(client *rbac.MockObjectClientWrapper) {
+				client.On("AllowedObjects", mock.Anything, rbac.ResourceRepositories, rbac.RbacVerbRead).Return([]string{}, nil)
+			},
+			ExpectedCode: http.StatusUnauthorized,
+			ExpectedBody: "{\"message\":\"Unauthorized\"}\n",
+		},
+		{
+			Name:           "Create deferred to the workspace check of the handler",
+			Method:         http.MethodPost,
+			Route:          "/templates/",
+			Path:           "/templates/",
+			ObjectsFeature: true,
+			ExpectedCode:   http.StatusOK,
+			ExpectedBody:   "true\n",
+		},
+		{
+			Name:           "Create allowed on the organization",
+			Method:         http.MethodPost,
+			Route:          "/templates/",
+			Path:           "/templates/",
+			OrgAllowed:     true,
+			ObjectsFeature: true,
+			ExpectedCode:   http.StatusOK,
+			ExpectedBody:   "false\n",
+		},
+		{
+			Name:           "Route without objects",
+			Method:         http.MethodGet,
+			Route:          "/features/",
+			Path:           "/features/",
+			ObjectsFeature: true,
+			ExpectedCode:   http.StatusUnauthorized,
+			ExpectedBody:   "{\"message\":\"Unauthorized\"}\n",
+		},
+		{
+			Name:         "Object permissions disabled",
+			Method:       http.MethodDelete,
+			Route:        "/repositories/:uuid",
+			Path:         "/repositories/" + repoUUID,
+			ExpectedCode: http.StatusUnauthorized,
+			ExpectedBody: "{\"message\":\"Unauthorized\"}\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Log(testCase.Name)
+		config.Get().Features.KesselObjectPermissions = config.Feature{Enabled: testCase.ObjectsFeature}
+
+		mockKesselClient := rbac.NewMockClientWrapper(t)
+		resource, verb, err := permissions.Permission(testCase.Method, testCase.Route)
+		require.NoError(t, err)
+		mockKesselClient.On("Allowed", mock.Anything, resource, verb).Return(testCase.OrgAllowed, nil)
+		mockObjectClient := rbac.NewMockObjectClientWrapper(t)
+		if testCase.Setup != nil {
+			testCase.Setup(mockObjectClient)
+		}
+
+		e := echo.New()
+		e.Use(NewRbac(Rbac{
+			PermissionsMap:     permissions,
+			RbacClient:         rbac.NewMockClientWrapper(t),
+			KesselClient:       mockKesselClient,
+			KesselObjectClient: mockObjectClient,
+		}))
+		handleAllowedObjects := func(c echo.Context) error {
+			return c.JSON(http.StatusOK, rbac.AllowedObjects(c.Request().Context()))
+		}
+		e.GET(testPath+"/repositories/", handleAllowedObjects)
+		e.DELETE(testPath+"/repositories/:uuid", handleAllowedObjects)
+		e.GET(testPath+"/templates/:template_uuid/config.repo", handleAllowedObjects)
+		e.GET(testPath+"/features/", handleAllowedObjects)
+		e.DELETE(testPath+"/repositories/:repo_uuid/snapshots/:snapshot_uuid", handleAllowedObjects)
+		e.POST(testPath+"/templates/", func(c echo.Context) error {
+			return c.JSON(http.StatusOK, rbac.DefaultWorkspaceDenied(c.Request().Context()))
+		})
+
+		req, err := http.NewRequest(testCase.Method, testPath+testCase.Path, nil)
+		require.NoError(t, err)
+		req.Header.Set(xrhidHeader, mockXRhUserIdentity(t, "12345", "12345"))
+		rw := httptest.NewRecorder()
+		e.ServeHTTP(rw, req)
+
+		assert.Equal(t, testCase.ExpectedCode, rw.Code)
+		assert.Equal(t, testCase.ExpectedBody, rw.Body.String())
+	}
+}
+
+func TestRbacMiddlewareWriteFallback(t *testing.T) {
+	clients := config.Get().Clients
+	defer func() { config.Get().Clients = clients }()
+
+	testPath := "/api/content-sources/v1"
+	permissions := rbac.NewPermissionsMap()
+	permissions.Add(http.MethodPatch, "/repositories/:repo_uuid/snapshots/:snapshot_uuid/published", rbac.ResourceSnapshots, rbac.RbacVerbUpdate)
+
+	for _, fallback := range []bool{true, false} {
+		config.Get().Clients.RbacWriteFallback = fallback
+
+		// Roles granting write on repositories, but not the publish permission on snapshots
+		mockRbacClient := rbac.NewMockClientWrapper(t)
+		mockRbacClient.On("Allowed", mock.Anything, rbac.ResourceSnapshots, rbac.RbacVerbUpdate).Return(false, nil)
+		if fallback {
+			mockRbacClient.On("Allowed", mock.Anything, rbac.ResourceRepositories, rbac.RbacVerbWrite).Return(true, nil)
+		}
+
+		e := echo.New()
+		e.Use(NewRbac(Rbac{
+			PermissionsMap: permissions,
+			RbacClient:     mockRbacClient,
+		}))
+		e.PATCH(testPath+"/repositories/:repo_uuid/snapshots/:snapshot_uuid/published", func(c echo.Context) error {
+			return c.NoContent(http.StatusNoContent)
+		})
+
+		req, err := http.NewRequest(http.MethodPatch, testPath+"/repositories/repo-uuid/snapshots/snapshot-uuid/published", nil)
+		require.NoError(t, err)
+		req.Header.Set(xrhidHeader, mockXRhUserIdentity(t, "12345", "12345"))
+		rw := httptest.NewRecorder()
+		e.ServeHTTP(rw, req)
+
+		if fallback {
+			assert.Equal(t, http.StatusNoContent, rw.Code)
+		} else {
+			assert.Equal(t, http.StatusUnauthorized, rw.Code)
+		}
+	}
+}
+
+func TestRbacMiddlewareUnrestricted(t *testing.T) {
+	testPath := "/api/content-sources/v1"
+
+	permissions := rbac.NewPermissionsMap()
+	permissions.AddUnrestricted(http.MethodGet, "/permissions/")
+
+	// The client is not called for unrestricted routes, but is available to the handler
+	mockRbacClient := rbac.NewMockClientWrapper(t)
+	e := echo.New()
+	e.Use(NewRbac(Rbac{
+		PermissionsMap: permissions,
+		RbacClient:     mockRbacClient,
+	}))
+	e.GET(testPath+"/permissions/", func(c echo.Context) error {
+		assert.Equal(t, mockRbacClient, rbac.ClientFromContext(c.Request().Context()))
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	req, err := http.NewRequest(http.MethodGet, testPath+"/permissions/", nil)
+	require.NoError(t, err)
+	rw := httptest.NewRecorder()
+	e.ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusUnauthorized, rw.Code)
+
+	req.Header.Set(xrhidHeader, mockXRhUserIdentity(t, "12345", "12345"))
+	rw = httptest.NewRecorder()
+	e.ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusNoContent, rw.Code)
+}
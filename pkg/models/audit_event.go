@@ -0,0 +1,45 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+const TableNameAuditEvents = "audit_events"
+
+// AuditEvent is a request that changed, or attempted to change, the content of an organization.
+// Only the request is recorded, the state of the objects before and after an update is not.
+type AuditEvent struct {
+	Base
+	OrgID       string `gorm:"not null"`
+	AccountID   string
+	Username    string
+	RequestID   string
+	Method      string          `gorm:"not null"`
+	Route       string          `gorm:"not null"`
+	Path        string          `gorm:"not null"`
+	ObjectUUIDs pq.StringArray  `gorm:"column:object_uuids;type:text[];not null"`
+	Request     json.RawMessage `gorm:"type:jsonb"`
+	StatusCode  int             `gorm:"not null"`
+	Error       *string
+}
+
+func (e *AuditEvent) TableName() string {
+	return TableNameAuditEvents
+}
+
+func (e *AuditEvent) BeforeCreate(tx *gorm.DB) error {
+	if err := e.Base.BeforeCreate(tx); err != nil {
+		return err
+	}
+	if e.ObjectUUIDs == nil {
+		e.ObjectUUIDs = pq.StringArray{}
+	}
+	if e.OrgID == "" {
+		return Error{Message: "Org ID cannot be blank.", Validation: true}
+	}
+	e.Error = trimString(e.Error, 4000)
+	return nil
+}
@@ -18,6 +18,7 @@ const (
 type CoverageDemandSignal struct {
 	UUID        string    `json:"uuid" gorm:"primary_key;column:uuid"`
 	CreatedAt   time.Time `json:"created_at" gorm:"not null"`
+	OrgID       *string   `json:"org_id,omitempty"`
 	Ecosystem   string    `json:"ecosystem" gorm:"not null"`
 	Name        string    `json:"name" gorm:"not null"`
 	Version     string    `json:"version" gorm:"not null"`
@@ -8,6 +8,7 @@ import (
 
 	"github.com/content-services/content-sources-backend/pkg/config"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
@@ -63,6 +64,10 @@ type CoverageReport struct {
 	AnalysisTaskError        *string                   `json:"analysis_task_error,omitempty"`
 	AnalysisTaskUUID         *string                   `json:"analysis_task_uuid,omitempty"`
 	CompletedAt              *time.Time                `json:"completed_at,omitempty"`
+	Warnings                 pq.StringArray            `json:"warnings,omitempty" gorm:"type:text[]"`
+	SourceReportUUID         *string                   `json:"source_report_uuid,omitempty"`
+	ScheduledRefresh         bool                      `json:"scheduled_refresh" gorm:"not null;default:false"`
+	Automatic                bool                      `json:"automatic" gorm:"not null;default:false"` // Created by the scheduled refresh job
 }
 
 func (*CoverageReport) TableName() string {
@@ -21,6 +21,11 @@ type CoverageReportPackage struct {
 	Version            string  `json:"version" gorm:"not null"`
 	Namespace          *string `json:"namespace,omitempty"`
 	MatchStatus        string  `json:"match_status" gorm:"not null"`
+	VersionConstraint  *string `json:"version_constraint,omitempty"`
+	// Catalog versions suggested for partial matches
+	NearestPatchVersion *string `json:"nearest_patch_version,omitempty"`
+	SameMinorVersion    *string `json:"same_minor_version,omitempty"`
+	LatestVersion       *string `json:"latest_version,omitempty"`
 }
 
 func (*CoverageReportPackage) TableName() string {
@@ -53,7 +58,7 @@ func (p *CoverageReportPackage) validate() error {
 	if p.Name == "" {
 		return Error{Message: "Package name cannot be blank.", Validation: true}
 	}
-	if p.Version == "" {
+	if p.Version == "" && (p.VersionConstraint == nil || *p.VersionConstraint == "") {
 		return Error{Message: "Version cannot be blank.", Validation: true}
 	}
 	if p.MatchStatus == "" {
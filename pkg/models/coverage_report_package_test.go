@@ -51,6 +51,33 @@ func (s *CoverageReportPackageSuite) TestCoverageReportPackageCreate() {
 	assert.Equal(s.T(), pkg.MatchStatus, readPkg.MatchStatus)
 }
 
+func (s *CoverageReportPackageSuite) TestCoverageReportPackageVersionConstraint() {
+	tx := s.tx
+
+	report := CoverageReport{
+		OrgID:  "org-1",
+		Status: config.TaskStatusCompleted,
+	}
+	err := tx.Create(&report).Error
+	assert.NoError(s.T(), err)
+
+	pkg := CoverageReportPackage{
+		CoverageReportUUID: report.UUID,
+		Ecosystem:          "Python",
+		Name:               "requests",
+		VersionConstraint:  utils.Ptr(">=2.31,<3"),
+		MatchStatus:        CoverageMatchStatusPartial,
+	}
+	err = tx.Create(&pkg).Error
+	assert.NoError(s.T(), err)
+
+	readPkg := CoverageReportPackage{}
+	err = tx.Where("uuid = ?", pkg.UUID).First(&readPkg).Error
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), readPkg.Version)
+	assert.Equal(s.T(), ">=2.31,<3", *readPkg.VersionConstraint)
+}
+
 func (s *CoverageReportPackageSuite) TestCoverageReportPackageValidations() {
 	t := s.T()
 	tx := s.tx
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+const TableNameOutboxEvents = "event_outbox"
+
+// Status of an outbox event. Sent and failed events are deleted by the outbox cleanup once they are old enough.
+const (
+	OutboxEventPending = "pending" // Waiting for its first or next attempt
+	OutboxEventSent    = "sent"    // Sent through its channel
+	OutboxEventFailed  = "failed"  // Every attempt failed
+)
+
+// OutboxEvent is an event written along with the change it describes, and sent by the outbox relay
+// once the transaction is committed. Its payload is a json array with one entry per object the change is about.
+// Events of a channel sharing an object are sent in id order.
+type OutboxEvent struct {
+	ID            int64           `gorm:"primaryKey;autoIncrement"`
+	OrgID         string          `gorm:"not null"`
+	Channel       string          `gorm:"not null"`
+	EventType     string          `gorm:"not null"`
+	ObjectUUIDs   pq.StringArray  `gorm:"column:object_uuids;type:text[];not null"`
+	Payload       json.RawMessage `gorm:"type:jsonb;not null"`
+	Status        string          `gorm:"not null"`
+	Attempts      int             `gorm:"not null"`
+	Error         *string
+	NextAttemptAt time.Time `gorm:"not null"`
+	CreatedAt     time.Time `gorm:"not null"`
+}
+
+func (e *OutboxEvent) TableName() string {
+	return TableNameOutboxEvents
+}
+
+func (e *OutboxEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ObjectUUIDs == nil {
+		e.ObjectUUIDs = pq.StringArray{}
+	}
+	if e.Status == "" {
+		e.Status = OutboxEventPending
+	}
+	if e.NextAttemptAt.IsZero() {
+		e.NextAttemptAt = time.Now()
+	}
+	return nil
+}
+
+func (e *OutboxEvent) BeforeUpdate(tx *gorm.DB) error {
+	e.Error = trimString(e.Error, 4000)
+	return nil
+}
@@ -38,6 +38,7 @@ type RepositoryConfiguration struct {
 	ExtendedRelease        string         `json:"extended_release" gorm:"default:null"`
 	ExtendedReleaseVersion string         `json:"extended_release_version" gorm:"default:null"`
 	Partner                bool           `json:"partner" gorm:"default:false"`
+	WorkspaceID            *string        `json:"workspace_id" gorm:"default:null"` // Workspace the repository was created in, nil for the default workspace
 }
 
 // When updating a model with gorm, we want to explicitly update any field that is set to
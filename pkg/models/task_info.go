@@ -32,6 +32,7 @@ type TaskInfo struct {
 	NextRetryTime   *time.Time
 	Priority        int
 	CancelAttempted bool
+	DedupKey        *string
 }
 
 type TaskInfoRepositoryConfiguration struct {
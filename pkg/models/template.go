@@ -1,6 +1,9 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -27,6 +30,28 @@ type Template struct {
 	LastUpdateTaskUUID               string                            `json:"last_update_task_uuid" gorm:"default:null"`
 	LastUpdateTask                   *TaskInfo                         `json:"last_update_task" gorm:"foreignKey:last_update_task_uuid"`
 	TemplateRepositoryConfigurations []TemplateRepositoryConfiguration `gorm:"foreignKey:TemplateUUID"`
+	AvailableErrataCounts            *ErrataCounts                     `gorm:"type:jsonb;default:null"`
+	AvailableErrataCountedAt         *time.Time                        `gorm:"default:null"`
+	WorkspaceID                      *string                           `gorm:"default:null"` // Workspace the template was created in, nil for the default workspace
+}
+
+// ErrataCounts are the numbers of errata of each type, stored as JSON
+type ErrataCounts struct {
+	Security    int64 `json:"security"`
+	Bugfix      int64 `json:"bugfix"`
+	Enhancement int64 `json:"enhancement"`
+}
+
+func (ec ErrataCounts) Value() (driver.Value, error) {
+	return json.Marshal(ec)
+}
+
+func (ec *ErrataCounts) Scan(src interface{}) error {
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("type assertion .([]byte) failed")
+	}
+	return json.Unmarshal(source, ec)
 }
 
 // BeforeCreate perform validations and sets UUID of Template
@@ -0,0 +1,161 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+const (
+	TableNameWebhooks          = "webhooks"
+	TableNameWebhookDeliveries = "webhook_deliveries"
+)
+
+// Status of a webhook delivery
+const (
+	WebhookDeliveryPending   = "pending"   // Waiting for its first or next attempt
+	WebhookDeliverySucceeded = "succeeded" // The endpoint responded with a 2xx status
+	WebhookDeliveryFailed    = "failed"    // Every attempt failed
+)
+
+// MinWebhookSecretLength is the shortest secret accepted to sign webhook payloads
+const MinWebhookSecretLength = 16
+
+// Webhook is an endpoint of an organization that receives the events it subscribes to
+type Webhook struct {
+	Base
+	OrgID      string         `gorm:"not null"`
+	Name       string         `gorm:"not null"`
+	URL        string         `gorm:"not null"`
+	Secret     string         `gorm:"serializer:webhook_secret;not null"`
+	EventTypes pq.StringArray `gorm:"type:text[];not null"`
+	Enabled    bool           `gorm:"not null"`
+	CreatedBy  string
+}
+
+func (w *Webhook) TableName() string {
+	return TableNameWebhooks
+}
+
+func (w *Webhook) BeforeCreate(tx *gorm.DB) error {
+	if err := w.Base.BeforeCreate(tx); err != nil {
+		return err
+	}
+	return w.validate()
+}
+
+func (w *Webhook) BeforeUpdate(tx *gorm.DB) error {
+	return w.validate()
+}
+
+func (w *Webhook) validate() error {
+	if w.OrgID == "" {
+		return Error{Message: "Org ID cannot be blank.", Validation: true}
+	}
+	if w.Name == "" {
+		return Error{Message: "Name cannot be blank.", Validation: true}
+	}
+	if err := ValidateWebhookURL(w.URL); err != nil {
+		return Error{Message: err.Error(), Validation: true}
+	}
+	if len(w.Secret) < MinWebhookSecretLength {
+		return Error{Message: fmt.Sprintf("Secret must be at least %d characters long.", MinWebhookSecretLength), Validation: true}
+	}
+	if len(w.EventTypes) == 0 {
+		return Error{Message: "Event types cannot be empty.", Validation: true}
+	}
+	return nil
+}
+
+// ValidateWebhookURL checks that rawURL is an absolute https url, or http if allowed by config.
+// Hosts resolving to internal addresses are only rejected when delivering, after DNS resolution.
+func ValidateWebhookURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid url: %s", rawURL)
+	}
+	if !config.Get().Webhooks.AllowPrivateNetworks {
+		host := u.Hostname()
+		if ip := net.ParseIP(host); (ip != nil && !WebhookIPAllowed(ip)) || host == "localhost" {
+			return fmt.Errorf("url must not target an internal address: %s", rawURL)
+		}
+	}
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		if config.Get().Webhooks.AllowHTTP {
+			return nil
+		}
+	}
+	return fmt.Errorf("url must use https: %s", rawURL)
+}
+
+// webhookBlockedNetworks are the ranges not covered by the net.IP helpers that webhooks must not reach
+var webhookBlockedNetworks = []net.IPNet{
+	{IP: net.IPv4(0, 0, 0, 0), Mask: net.CIDRMask(8, 32)},     // "this" network
+	{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}, // shared address space, used by some metadata services
+	{IP: net.IPv4(192, 0, 0, 0), Mask: net.CIDRMask(24, 32)},  // IETF protocol assignments
+	{IP: net.IPv4(198, 18, 0, 0), Mask: net.CIDRMask(15, 32)}, // benchmarking
+	{IP: net.IPv4(240, 0, 0, 0), Mask: net.CIDRMask(4, 32)},   // reserved
+}
+
+// WebhookIPAllowed returns false for loopback, private, unique-local, link-local (including the cloud metadata
+// address 169.254.169.254), multicast and reserved addresses, which webhooks must not reach
+func WebhookIPAllowed(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, network := range webhookBlockedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// WebhookDelivery is a single event sent, or to be sent, to a webhook
+type WebhookDelivery struct {
+	Base
+	WebhookUUID   string          `gorm:"not null"`
+	OrgID         string          `gorm:"not null"`
+	EventType     string          `gorm:"not null"`
+	Payload       json.RawMessage `gorm:"type:jsonb;not null"`
+	Status        string          `gorm:"not null"`
+	Attempts      int             `gorm:"not null"`
+	ResponseCode  *int
+	Error         *string
+	NextAttemptAt *time.Time
+	DeliveredAt   *time.Time
+	Webhook       Webhook `gorm:"foreignKey:WebhookUUID"`
+}
+
+func (d *WebhookDelivery) TableName() string {
+	return TableNameWebhookDeliveries
+}
+
+// BeforeCreate keeps a uuid set by the caller, as it is part of the payload sent to the webhook
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) error {
+	if d.UUID == "" {
+		if err := d.Base.BeforeCreate(tx); err != nil {
+			return err
+		}
+	}
+	if d.Status == "" {
+		d.Status = WebhookDeliveryPending
+	}
+	d.Error = trimString(d.Error, 4000)
+	return nil
+}
+
+func (d *WebhookDelivery) BeforeUpdate(tx *gorm.DB) error {
+	d.Error = trimString(d.Error, 4000)
+	return nil
+}
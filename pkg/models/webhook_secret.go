@@ -0,0 +1,108 @@
+package models
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"gorm.io/gorm/schema"
+)
+
+// webhookSecretPrefix marks the secrets encrypted with the webhooks.secret_key, others are stored as is
+const webhookSecretPrefix = "enc:v1:"
+
+func init() {
+	schema.RegisterSerializer("webhook_secret", WebhookSecretSerializer{})
+}
+
+// WebhookSecretSerializer encrypts webhook secrets with AES-GCM before they are stored, so they can still be
+// used to sign payloads but are not readable from the database alone. Secrets stored before a key was
+// configured are read as is, and encrypted the next time the webhook is saved.
+type WebhookSecretSerializer struct{}
+
+func (WebhookSecretSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var stored string
+	switch value := dbValue.(type) {
+	case nil:
+		return nil
+	case string:
+		stored = value
+	case []byte:
+		stored = string(value)
+	default:
+		return fmt.Errorf("unsupported webhook secret type %T", dbValue)
+	}
+	secret, err := DecryptWebhookSecret(stored)
+	if err != nil {
+		return err
+	}
+	return field.Set(ctx, dst, secret)
+}
+
+func (WebhookSecretSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	secret, ok := fieldValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("unsupported webhook secret type %T", fieldValue)
+	}
+	return EncryptWebhookSecret(secret)
+}
+
+// EncryptWebhookSecret encrypts the secret with the configured key, or returns it as is without a key
+func EncryptWebhookSecret(secret string) (string, error) {
+	gcm, err := webhookSecretCipher()
+	if err != nil || gcm == nil {
+		return secret, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("could not generate webhook secret nonce: %w", err)
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(secret), nil)
+	return webhookSecretPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptWebhookSecret returns the secret as stored by EncryptWebhookSecret
+func DecryptWebhookSecret(stored string) (string, error) {
+	if !strings.HasPrefix(stored, webhookSecretPrefix) {
+		return stored, nil
+	}
+	gcm, err := webhookSecretCipher()
+	if err != nil {
+		return "", err
+	}
+	if gcm == nil {
+		return "", fmt.Errorf("webhook secret is encrypted but webhooks.secret_key is not set")
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(stored, webhookSecretPrefix))
+	if err != nil || len(sealed) < gcm.NonceSize() {
+		return "", fmt.Errorf("invalid encrypted webhook secret")
+	}
+	secret, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("could not decrypt webhook secret: %w", err)
+	}
+	return string(secret), nil
+}
+
+// webhookSecretCipher returns the cipher of the configured key, or nil if no key is configured
+func webhookSecretCipher() (cipher.AEAD, error) {
+	encodedKey := config.Get().Webhooks.SecretKey
+	if encodedKey == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil || len(key) != 32 {
+		return nil, fmt.Errorf("webhooks.secret_key must be a base64 encoded 32 byte key")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookIPAllowed(t *testing.T) {
+	for _, ip := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.100.100.200", "0.0.0.0", "::1", "fd00:ec2::254", "fe80::1", "::ffff:127.0.0.1"} {
+		assert.False(t, WebhookIPAllowed(net.ParseIP(ip)), ip)
+	}
+	for _, ip := range []string{"8.8.8.8", "2001:4860:4860::8888"} {
+		assert.True(t, WebhookIPAllowed(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestValidateWebhookURLInternal(t *testing.T) {
+	webhooks := config.Get().Webhooks
+	defer func() { config.Get().Webhooks = webhooks }()
+	config.Get().Webhooks.AllowPrivateNetworks = false
+
+	assert.NoError(t, ValidateWebhookURL("https://example.com/hook"))
+	assert.Error(t, ValidateWebhookURL("https://localhost/hook"))
+	assert.Error(t, ValidateWebhookURL("https://169.254.169.254/latest/meta-data"))
+	assert.Error(t, ValidateWebhookURL("https://[::1]:8443/hook"))
+
+	config.Get().Webhooks.AllowPrivateNetworks = true
+	assert.NoError(t, ValidateWebhookURL("https://localhost/hook"))
+}
+
+func TestWebhookSecretEncryption(t *testing.T) {
+	webhooks := config.Get().Webhooks
+	defer func() { config.Get().Webhooks = webhooks }()
+
+	// Without a key secrets are stored as is
+	config.Get().Webhooks.SecretKey = ""
+	stored, err := EncryptWebhookSecret("a-very-secret-value")
+	require.NoError(t, err)
+	assert.Equal(t, "a-very-secret-value", stored)
+
+	config.Get().Webhooks.SecretKey = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+	stored, err = EncryptWebhookSecret("a-very-secret-value")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(stored, webhookSecretPrefix))
+	assert.NotContains(t, stored, "a-very-secret-value")
+
+	secret, err := DecryptWebhookSecret(stored)
+	require.NoError(t, err)
+	assert.Equal(t, "a-very-secret-value", secret)
+
+	// Secrets stored before the key was set are still readable
+	secret, err = DecryptWebhookSecret("a-plain-secret-value")
+	require.NoError(t, err)
+	assert.Equal(t, "a-plain-secret-value", secret)
+
+	config.Get().Webhooks.SecretKey = "YW5vdGhlci1rZXktb2YtMzItYnl0ZXMtYWJjZGVmZ2g="
+	_, err = DecryptWebhookSecret(stored)
+	assert.Error(t, err)
+
+	config.Get().Webhooks.SecretKey = "too-short"
+	_, err = EncryptWebhookSecret("a-very-secret-value")
+	assert.Error(t, err)
+}
@@ -0,0 +1,62 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	"github.com/content-services/content-sources-backend/pkg/event"
+	"github.com/content-services/content-sources-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// relayBatchSize is how many due events are claimed at a time
+const relayBatchSize = 100
+
+// Relay sends the events recorded in the outbox through their channel
+type Relay struct {
+	dao dao.OutboxDao
+}
+
+func NewRelay(outboxDao dao.OutboxDao) *Relay {
+	return &Relay{dao: outboxDao}
+}
+
+// Run sends due events at every poll interval until ctx is done
+func (r *Relay) Run(ctx context.Context) {
+	log.Info().Msg("Starting outbox relay")
+	for {
+		for {
+			count, err := r.RelayDue(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("error relaying outbox events")
+			}
+			if count < relayBatchSize {
+				break
+			}
+		}
+		if err := utils.SleepWithCancel(ctx, config.Get().Outbox.PollInterval); err != nil {
+			log.Info().Msg("outbox relay shutting down")
+			return
+		}
+	}
+}
+
+// RelayDue sends a batch of due events, and returns how many were claimed
+func (r *Relay) RelayDue(ctx context.Context) (int, error) {
+	events, err := r.dao.ClaimDue(ctx, relayBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	for _, outboxEvent := range events {
+		sendErr := event.Deliver(ctx, outboxEvent.Channel, outboxEvent.OrgID, outboxEvent.EventType, outboxEvent.Payload)
+		if sendErr != nil {
+			log.Warn().Err(sendErr).Int64("outbox_event_id", outboxEvent.ID).Str("channel", outboxEvent.Channel).
+				Str("event_type", outboxEvent.EventType).Msg("outbox event failed to send")
+		}
+		if err := r.dao.RecordAttempt(ctx, outboxEvent, sendErr); err != nil {
+			log.Error().Err(err).Int64("outbox_event_id", outboxEvent.ID).Msg("could not record outbox event attempt")
+		}
+	}
+	return len(events), nil
+}
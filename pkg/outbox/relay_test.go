@@ -0,0 +1,84 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	"github.com/content-services/content-sources-backend/pkg/event"
+	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type dispatchedEvent struct {
+	orgID     string
+	eventType string
+	data      any
+}
+
+type testDispatcher struct {
+	dispatched []dispatchedEvent
+	err        error
+}
+
+func (d *testDispatcher) Dispatch(_ context.Context, orgID string, eventType string, data any) error {
+	if d.err != nil {
+		return d.err
+	}
+	d.dispatched = append(d.dispatched, dispatchedEvent{orgID: orgID, eventType: eventType, data: data})
+	return nil
+}
+
+func TestRelayDue(t *testing.T) {
+	dispatcher := &testDispatcher{}
+	event.SetWebhookDispatcher(dispatcher)
+	defer event.SetWebhookDispatcher(nil)
+
+	events := []models.OutboxEvent{
+		{ID: 1, OrgID: "org", Channel: event.ChannelWebhooks, EventType: "template-created", ObjectUUIDs: []string{"template", "other"}, Payload: json.RawMessage(`[{"uuid":"template"},{"uuid":"other"}]`)},
+		{ID: 2, OrgID: "org", Channel: "unknown", EventType: "template-created", ObjectUUIDs: []string{"template"}, Payload: json.RawMessage(`[{}]`)},
+	}
+	outboxDao := dao.NewMockOutboxDao(t)
+	outboxDao.On("ClaimDue", mock.Anything, relayBatchSize).Return(events, nil).Once()
+	outboxDao.On("RecordAttempt", mock.Anything, events[0], nil).Return(nil).Once()
+	outboxDao.On("RecordAttempt", mock.Anything, events[1], mock.Anything).
+		Run(func(args mock.Arguments) {
+			attemptErr, ok := args.Get(2).(error)
+			require.True(t, ok)
+			assert.Equal(t, "unknown event channel: unknown", attemptErr.Error())
+		}).
+		Return(nil).Once()
+
+	count, err := NewRelay(outboxDao).RelayDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	// Webhooks get a call per object of the event
+	require.Len(t, dispatcher.dispatched, 2)
+	assert.Equal(t, "org", dispatcher.dispatched[0].orgID)
+	assert.Equal(t, "template-created", dispatcher.dispatched[0].eventType)
+	assert.Equal(t, json.RawMessage(`{"uuid":"template"}`), dispatcher.dispatched[0].data)
+	assert.Equal(t, json.RawMessage(`{"uuid":"other"}`), dispatcher.dispatched[1].data)
+}
+
+func TestRelayDueFailure(t *testing.T) {
+	dispatcher := &testDispatcher{err: errors.New("db is down")}
+	event.SetWebhookDispatcher(dispatcher)
+	defer event.SetWebhookDispatcher(nil)
+
+	events := []models.OutboxEvent{{ID: 1, OrgID: "org", Channel: event.ChannelWebhooks, EventType: "repository-created", ObjectUUIDs: []string{"repo"}, Payload: json.RawMessage(`[{"uuid":"repo"}]`)}}
+	outboxDao := dao.NewMockOutboxDao(t)
+	outboxDao.On("ClaimDue", mock.Anything, relayBatchSize).Return(events, nil).Once()
+	outboxDao.On("RecordAttempt", mock.Anything, events[0], dispatcher.err).Return(nil).Once()
+
+	count, err := NewRelay(outboxDao).RelayDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	outboxDao.On("ClaimDue", mock.Anything, relayBatchSize).Return(nil, errors.New("claim failed")).Once()
+	_, err = NewRelay(outboxDao).RelayDue(context.Background())
+	assert.Error(t, err)
+}
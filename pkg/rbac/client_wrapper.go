@@ -38,6 +38,34 @@ type ClientWrapper interface {
 	Allowed(ctx context.Context, resource Resource, verb Verb) (bool, error)
 }
 
+type clientKey struct{}
+
+// WithClient returns a copy of ctx holding the client checking the permissions of the request
+func WithClient(ctx context.Context, client ClientWrapper) context.Context {
+	return context.WithValue(ctx, clientKey{}, client)
+}
+
+// ClientFromContext returns the client checking the permissions of the request, or nil if permissions are not checked
+func ClientFromContext(ctx context.Context) ClientWrapper {
+	client, _ := ctx.Value(clientKey{}).(ClientWrapper)
+	return client
+}
+
+// ObjectClientWrapper checks permissions on single repositories and templates, and keeps
+// the permission backend informed of the workspace each of them belongs to
+type ObjectClientWrapper interface {
+	ObjectReporter
+	AllowedObject(ctx context.Context, resource Resource, verb Verb, uuid string) (bool, error)
+	AllowedObjects(ctx context.Context, resource Resource, verb Verb) ([]string, error)
+	AllowedWorkspace(ctx context.Context, resource Resource, verb Verb, workspaceID string) (bool, error)
+}
+
+// ObjectReporter keeps the permission backend informed of the workspace each repository and template belongs to
+type ObjectReporter interface {
+	ReportObject(ctx context.Context, resource Resource, orgID string, uuid string, workspaceID string) error
+	DeleteObject(ctx context.Context, resource Resource, uuid string) error
+}
+
 type ClientWrapperImpl struct {
 	client  rbac.Client
 	timeout time.Duration
@@ -0,0 +1,148 @@
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/RedHatInsights/rbac-client-go"
+	"github.com/fsnotify/fsnotify"
+	"github.com/redhatinsights/platform-go-middlewares/v2/identity"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+// OrgAdminsGroup is the group of organization administrators, members are taken from the identity of the user
+const OrgAdminsGroup = "org_admins"
+
+// FilePolicy grants permissions to users and groups, without calling the RBAC service. It is read from a YAML or
+// JSON file, such as:
+//
+//	groups:
+//	  release-managers: ["alice", "bob"]
+//	rules:
+//	  - groups: ["release-managers"]
+//	    permissions: ["content-sources:*:read", "content-sources:repositories:write"]
+//	  - groups: ["org_admins"]
+//	    permissions: ["content-sources:*:*"]
+type FilePolicy struct {
+	Groups map[string][]string `mapstructure:"groups"` // Usernames of the members of each group
+	Rules  []FilePolicyRule    `mapstructure:"rules"`
+}
+
+// FilePolicyRule grants permissions to the listed users and to the members of the listed groups. Besides the groups of
+// the policy, users are members of org_admins if they administrate their organization, and of each of their associate roles.
+// Group names are case insensitive.
+type FilePolicyRule struct {
+	Users         []string `mapstructure:"users"`
+	Groups        []string `mapstructure:"groups"`
+	Organizations []string `mapstructure:"organizations"` // Restricts the rule to these organizations when set
+	Permissions   []string `mapstructure:"permissions"`   // Permissions in the RBAC format, such as content-sources:repositories:write
+}
+
+// FileClientWrapper is an implementation of the ClientWrapper interface reading permissions from a policy file,
+// which is reloaded when it changes
+type FileClientWrapper struct {
+	mutex  sync.RWMutex
+	policy FilePolicy
+}
+
+// NewFileClientWrapper reads the policy file at path and watches it for changes
+func NewFileClientWrapper(path string) (*FileClientWrapper, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read rbac policy file: %w", err)
+	}
+	policy, err := readFilePolicy(v)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &FileClientWrapper{policy: policy}
+	v.OnConfigChange(func(_ fsnotify.Event) {
+		policy, err := readFilePolicy(v)
+		if err != nil {
+			log.Error().Err(err).Str("path", path).Msg("failed to reload rbac policy file, keeping the previous policy")
+			return
+		}
+		f.setPolicy(policy)
+		log.Info().Str("path", path).Msg("reloaded rbac policy file")
+	})
+	v.WatchConfig()
+	return f, nil
+}
+
+func readFilePolicy(v *viper.Viper) (FilePolicy, error) {
+	var policy FilePolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return FilePolicy{}, fmt.Errorf("failed to parse rbac policy file: %w", err)
+	}
+	for _, rule := range policy.Rules {
+		for _, permission := range rule.Permissions {
+			if len(strings.Split(permission, ":")) != 3 {
+				return FilePolicy{}, fmt.Errorf("invalid permission %q in rbac policy file, expected application:resource:verb", permission)
+			}
+		}
+	}
+	return policy, nil
+}
+
+func (f *FileClientWrapper) setPolicy(policy FilePolicy) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.policy = policy
+}
+
+// Allowed checks if the rules of the policy matching the user grant the given verb on the given resource
+func (f *FileClientWrapper) Allowed(ctx context.Context, resource Resource, verb Verb) (bool, error) {
+	id := identity.GetIdentity(ctx).Identity
+	var username string
+	if id.User != nil {
+		username = id.User.Username
+	} else if id.ServiceAccount != nil {
+		username = id.ServiceAccount.Username
+	}
+	if username == "" {
+		return false, nil
+	}
+
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	groups := f.groups(id, username)
+	var acl rbac.AccessList
+	for _, rule := range f.policy.Rules {
+		if len(rule.Organizations) > 0 && !slices.Contains(rule.Organizations, id.OrgID) {
+			continue
+		}
+		if !slices.Contains(rule.Users, username) && !slices.ContainsFunc(rule.Groups, func(group string) bool {
+			return slices.ContainsFunc(groups, func(userGroup string) bool { return strings.EqualFold(group, userGroup) })
+		}) {
+			continue
+		}
+		for _, permission := range rule.Permissions {
+			acl = append(acl, rbac.Access{Permission: permission})
+		}
+	}
+	return acl.IsAllowed(application, string(resource), string(verb)), nil
+}
+
+// groups returns the groups of the policy the user is a member of, along with the groups taken from the identity
+func (f *FileClientWrapper) groups(id identity.Identity, username string) []string {
+	var groups []string
+	for group, members := range f.policy.Groups {
+		if slices.Contains(members, username) {
+			groups = append(groups, group)
+		}
+	}
+	if id.User != nil && id.User.OrgAdmin {
+		groups = append(groups, OrgAdminsGroup)
+	}
+	if id.Associate != nil {
+		groups = append(groups, id.Associate.Role...)
+	}
+	return groups
+}
@@ -0,0 +1,112 @@
+package rbac
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/redhatinsights/platform-go-middlewares/v2/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicy = `
+groups:
+  Release-Managers: ["alice"]
+rules:
+  - users: ["bob"]
+    permissions: ["content-sources:*:read"]
+  - groups: ["release-managers"]
+    permissions: ["content-sources:repositories:write", "content-sources:snapshots:update"]
+  - groups: ["org_admins", "content-admin"]
+    organizations: ["12345"]
+    permissions: ["content-sources:*:*"]
+`
+
+// writePolicy replaces the policy file at once, so it is never read partially written
+func writePolicy(t *testing.T, path string, policy string) {
+	tmp := path + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, []byte(policy), 0600))
+	require.NoError(t, os.Rename(tmp, path))
+}
+
+func userContext(orgID string, username string, orgAdmin bool) context.Context {
+	return identity.WithIdentity(context.Background(), identity.XRHID{Identity: identity.Identity{
+		OrgID: orgID,
+		User:  &identity.User{Username: username, OrgAdmin: orgAdmin},
+	}})
+}
+
+func TestFileClientWrapper(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	writePolicy(t, path, testPolicy)
+
+	client, err := NewFileClientWrapper(path)
+	require.NoError(t, err)
+
+	type TestCase struct {
+		Name     string
+		Ctx      context.Context
+		Resource Resource
+		Verb     Verb
+		Expected bool
+	}
+	associate := identity.WithIdentity(context.Background(), identity.XRHID{Identity: identity.Identity{
+		OrgID:     "12345",
+		User:      &identity.User{Username: "carol"},
+		Associate: &identity.Associate{Role: []string{"content-admin"}},
+	}})
+	testCases := []TestCase{
+		{Name: "User rule", Ctx: userContext("1", "bob", false), Resource: ResourceTemplates, Verb: RbacVerbRead, Expected: true},
+		{Name: "User rule without the verb", Ctx: userContext("1", "bob", false), Resource: ResourceTemplates, Verb: RbacVerbWrite, Expected: false},
+		{Name: "Policy group", Ctx: userContext("1", "alice", false), Resource: ResourceSnapshots, Verb: RbacVerbUpdate, Expected: true},
+		{Name: "Policy group without the resource", Ctx: userContext("1", "alice", false), Resource: ResourceSnapshots, Verb: RbacVerbDelete, Expected: false},
+		{Name: "Org admin", Ctx: userContext("12345", "dave", true), Resource: ResourceSnapshots, Verb: RbacVerbDelete, Expected: true},
+		{Name: "Org admin of another organization", Ctx: userContext("6789", "dave", true), Resource: ResourceSnapshots, Verb: RbacVerbDelete, Expected: false},
+		{Name: "Associate role", Ctx: associate, Resource: ResourceContent, Verb: RbacVerbDelete, Expected: true},
+		{Name: "Unknown user", Ctx: userContext("12345", "eve", false), Resource: ResourceRepositories, Verb: RbacVerbRead, Expected: false},
+		{Name: "No identity", Ctx: context.Background(), Resource: ResourceRepositories, Verb: RbacVerbRead, Expected: false},
+	}
+	for _, testCase := range testCases {
+		allowed, err := client.Allowed(testCase.Ctx, testCase.Resource, testCase.Verb)
+		assert.NoError(t, err, testCase.Name)
+		assert.Equal(t, testCase.Expected, allowed, testCase.Name)
+	}
+
+	// Changes to the file are picked up
+	writePolicy(t, path, `
+rules:
+  - users: ["eve"]
+    permissions: ["content-sources:repositories:read"]
+`)
+	assert.Eventually(t, func() bool {
+		allowed, err := client.Allowed(userContext("12345", "eve", false), ResourceRepositories, RbacVerbRead)
+		return err == nil && allowed
+	}, 5*time.Second, 50*time.Millisecond)
+	allowed, err := client.Allowed(userContext("1", "bob", false), ResourceTemplates, RbacVerbRead)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+
+	// Invalid changes keep the previous policy
+	writePolicy(t, path, `
+rules:
+  - users: ["bob"]
+    permissions: ["read"]
+`)
+	time.Sleep(500 * time.Millisecond)
+	allowed, err = client.Allowed(userContext("12345", "eve", false), ResourceRepositories, RbacVerbRead)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+func TestNewFileClientWrapperErrors(t *testing.T) {
+	_, err := NewFileClientWrapper(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "policy.json")
+	writePolicy(t, path, `{"rules": [{"users": ["bob"], "permissions": ["repositories:read"]}]}`)
+	_, err = NewFileClientWrapper(path)
+	assert.ErrorContains(t, err, "invalid permission")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/content-services/content-sources-backend/pkg/api"
 	"github.com/content-services/content-sources-backend/pkg/clients/kessel_client"
 	"github.com/redhatinsights/platform-go-middlewares/v2/identity"
 	"github.com/rs/zerolog"
@@ -16,7 +17,7 @@ type KesselClientWrapper struct {
 }
 
 // NewKesselClientWrapper creates a new Kessel RBAC client
-func NewKesselClientWrapper() (ClientWrapper, error) {
+func NewKesselClientWrapper() (*KesselClientWrapper, error) {
 	kesselClient, err := kessel_client.NewKesselClient()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create kessel client: %w", err)
@@ -42,6 +43,7 @@ func (k *KesselClientWrapper) Allowed(ctx context.Context, resource Resource, ve
 	}
 	log.Debug().Msgf("[Kessel] WorkspaceID: %s - OrgID: %s", workspaceID, id.Identity.OrgID)
 
+	resource, verb = kesselSchemaPermission(resource, verb)
 	kesselPermission := mapToKesselPermission(resource, verb)
 
 	if verb == RbacVerbRead {
@@ -51,6 +53,100 @@ func (k *KesselClientWrapper) Allowed(ctx context.Context, resource Resource, ve
 	}
 }
 
+// AllowedObject checks if the user has permission to perform the given verb on a single repository or template
+func (k *KesselClientWrapper) AllowedObject(ctx context.Context, resource Resource, verb Verb, uuid string) (bool, error) {
+	resourceType := kesselResourceType(resource)
+	if resourceType == "" {
+		return false, fmt.Errorf("no kessel resource type for %s", resource)
+	}
+	resource, verb = kesselSchemaPermission(resource, verb)
+	kesselPermission := mapToKesselPermission(resource, verb)
+
+	if verb == RbacVerbRead {
+		return k.kesselClient.CheckResourceRead(ctx, resourceType, uuid, kesselPermission)
+	} else {
+		return k.kesselClient.CheckResourceWrite(ctx, resourceType, uuid, kesselPermission)
+	}
+}
+
+// AllowedObjects returns the UUIDs of the repositories or templates the user has permission to perform the given verb on
+func (k *KesselClientWrapper) AllowedObjects(ctx context.Context, resource Resource, verb Verb) ([]string, error) {
+	resourceType := kesselResourceType(resource)
+	if resourceType == "" {
+		return nil, fmt.Errorf("no kessel resource type for %s", resource)
+	}
+	resource, verb = kesselSchemaPermission(resource, verb)
+	return k.kesselClient.ListResources(ctx, resourceType, mapToKesselPermission(resource, verb))
+}
+
+// AllowedWorkspace checks if the user has permission to perform the given verb on the repositories or templates of a workspace
+func (k *KesselClientWrapper) AllowedWorkspace(ctx context.Context, resource Resource, verb Verb, workspaceID string) (bool, error) {
+	resource, verb = kesselSchemaPermission(resource, verb)
+	kesselPermission := mapToKesselPermission(resource, verb)
+
+	if verb == RbacVerbRead {
+		return k.kesselClient.CheckRead(ctx, workspaceID, kesselPermission)
+	} else {
+		return k.kesselClient.CheckWrite(ctx, workspaceID, kesselPermission)
+	}
+}
+
+// ReportObject registers a repository or template in a workspace, the default workspace of the organization when
+// workspaceID is empty
+func (k *KesselClientWrapper) ReportObject(ctx context.Context, resource Resource, orgID string, uuid string, workspaceID string) error {
+	resourceType := kesselResourceType(resource)
+	if resourceType == "" {
+		return fmt.Errorf("no kessel resource type for %s", resource)
+	}
+
+	if workspaceID == "" {
+		var err error
+		workspaceID, _, err = k.kesselClient.GetDefaultWorkspaceID(ctx, orgID)
+		if err != nil {
+			return fmt.Errorf("failed to get root workspace ID: %w", err)
+		}
+	}
+
+	return k.kesselClient.ReportResource(ctx, kessel_client.Resource{
+		Type:        resourceType,
+		ID:          uuid,
+		WorkspaceID: workspaceID,
+		APIHref:     fmt.Sprintf("%s/%s/%s/", api.FullRootPath(), resource, uuid),
+	})
+}
+
+// DeleteObject unregisters a repository or template
+func (k *KesselClientWrapper) DeleteObject(ctx context.Context, resource Resource, uuid string) error {
+	resourceType := kesselResourceType(resource)
+	if resourceType == "" {
+		return fmt.Errorf("no kessel resource type for %s", resource)
+	}
+	return k.kesselClient.DeleteResource(ctx, resourceType, uuid)
+}
+
+func kesselResourceType(resource Resource) string {
+	switch ObjectResource(resource) {
+	case ResourceRepositories:
+		return kessel_client.ResourceTypeRepository
+	case ResourceTemplates:
+		return kessel_client.ResourceTypeTemplate
+	default:
+		return ""
+	}
+}
+
+// kesselSchemaPermission returns the permission to check on Kessel. The schema has no snapshot, content and audit
+// permissions yet, so their write fallback is checked instead while it is enabled.
+func kesselSchemaPermission(resource Resource, verb Verb) (Resource, Verb) {
+	if resource != ResourceSnapshots && resource != ResourceContent && resource != ResourceAudit {
+		return resource, verb
+	}
+	if fallbackResource, fallbackVerb, ok := WriteFallback(resource, verb); ok {
+		return fallbackResource, fallbackVerb
+	}
+	return resource, verb
+}
+
 // mapToKesselPermission maps rbac v1 verbs to kessel verbs
 func mapToKesselPermission(resource Resource, verb Verb) string {
 	// Skip invalid resources
@@ -67,6 +163,10 @@ func mapToKesselPermission(resource Resource, verb Verb) string {
 		kesselVerb = "edit"
 	case RbacVerbUpload:
 		kesselVerb = "upload"
+	case RbacVerbDelete:
+		kesselVerb = "delete"
+	case RbacVerbUpdate:
+		kesselVerb = "update"
 	default:
 		return ""
 	}
@@ -77,6 +177,12 @@ func mapToKesselPermission(resource Resource, verb Verb) string {
 		kesselResource = "repository"
 	case ResourceTemplates:
 		kesselResource = "template"
+	case ResourceSnapshots:
+		kesselResource = "snapshot"
+	case ResourceContent:
+		kesselResource = "content"
+	case ResourceAudit:
+		kesselResource = "audit"
 	}
 
 	return fmt.Sprintf("content_sources_%s_%s", kesselResource, kesselVerb)
@@ -0,0 +1,49 @@
+package rbac
+
+import (
+	"testing"
+
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapToKesselPermission(t *testing.T) {
+	type TestCase struct {
+		Resource Resource
+		Verb     Verb
+		Expected string
+	}
+	testCases := []TestCase{
+		{Resource: ResourceRepositories, Verb: RbacVerbRead, Expected: "content_sources_repository_view"},
+		{Resource: ResourceRepositories, Verb: RbacVerbWrite, Expected: "content_sources_repository_edit"},
+		{Resource: ResourceRepositories, Verb: RbacVerbUpload, Expected: "content_sources_repository_upload"},
+		{Resource: ResourceRepositories, Verb: RbacVerbDelete, Expected: "content_sources_repository_delete"},
+		{Resource: ResourceTemplates, Verb: RbacVerbWrite, Expected: "content_sources_template_edit"},
+		{Resource: ResourceSnapshots, Verb: RbacVerbDelete, Expected: "content_sources_snapshot_delete"},
+		{Resource: ResourceSnapshots, Verb: RbacVerbUpdate, Expected: "content_sources_snapshot_update"},
+		{Resource: ResourceContent, Verb: RbacVerbDelete, Expected: "content_sources_content_delete"},
+		{Resource: ResourceAny, Verb: RbacVerbRead, Expected: ""},
+		{Resource: ResourceRepositories, Verb: RbacVerbAny, Expected: ""},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.Expected, mapToKesselPermission(testCase.Resource, testCase.Verb), "%s:%s", testCase.Resource, testCase.Verb)
+	}
+}
+
+func TestKesselSchemaPermission(t *testing.T) {
+	clients := config.Get().Clients
+	defer func() { config.Get().Clients = clients }()
+
+	config.Get().Clients.RbacWriteFallback = true
+	resource, verb := kesselSchemaPermission(ResourceSnapshots, RbacVerbUpdate)
+	assert.Equal(t, ResourceRepositories, resource)
+	assert.Equal(t, RbacVerbWrite, verb)
+	resource, verb = kesselSchemaPermission(ResourceRepositories, RbacVerbDelete)
+	assert.Equal(t, ResourceRepositories, resource)
+	assert.Equal(t, RbacVerbDelete, verb)
+
+	config.Get().Clients.RbacWriteFallback = false
+	resource, verb = kesselSchemaPermission(ResourceContent, RbacVerbDelete)
+	assert.Equal(t, ResourceContent, resource)
+	assert.Equal(t, RbacVerbDelete, verb)
+}
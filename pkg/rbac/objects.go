@@ -0,0 +1,108 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/content-services/content-sources-backend/pkg/config"
+)
+
+var objectReporter ObjectReporter
+
+type objectClientKey struct{}
+
+type allowedObjectsKey struct{}
+
+type defaultWorkspaceDeniedKey struct{}
+
+// WithObjectClient returns a copy of ctx checking the permissions of the request on single repositories and templates
+func WithObjectClient(ctx context.Context, client ObjectClientWrapper) context.Context {
+	return context.WithValue(ctx, objectClientKey{}, client)
+}
+
+// WithAllowedObjects returns a copy of ctx restricting the user to the repositories or templates with the given UUIDs
+func WithAllowedObjects(ctx context.Context, uuids []string) context.Context {
+	return context.WithValue(ctx, allowedObjectsKey{}, uuids)
+}
+
+// AllowedObjects returns the UUIDs of the repositories or templates the user is restricted to,
+// or nil if the user is allowed on all of those in the organization
+func AllowedObjects(ctx context.Context) []string {
+	uuids, _ := ctx.Value(allowedObjectsKey{}).([]string)
+	return uuids
+}
+
+// WithDefaultWorkspaceDenied returns a copy of ctx for a user allowed to create repositories or templates in some
+// workspaces only, not in the default workspace of the organization
+func WithDefaultWorkspaceDenied(ctx context.Context) context.Context {
+	return context.WithValue(ctx, defaultWorkspaceDeniedKey{}, true)
+}
+
+// DefaultWorkspaceDenied returns true if the user is not allowed to create repositories or templates in the default
+// workspace of the organization
+func DefaultWorkspaceDenied(ctx context.Context) bool {
+	denied, _ := ctx.Value(defaultWorkspaceDeniedKey{}).(bool)
+	return denied
+}
+
+// AllowedObject checks the permissions of the user on a single repository or template, returning false if per object
+// permissions are not enabled
+func AllowedObject(ctx context.Context, resource Resource, verb Verb, uuid string) (bool, error) {
+	client, ok := ctx.Value(objectClientKey{}).(ObjectClientWrapper)
+	if !ok {
+		return false, nil
+	}
+	return client.AllowedObject(ctx, resource, verb, uuid)
+}
+
+// ObjectPermissionsEnabled returns true if per object permissions are enabled for the request
+func ObjectPermissionsEnabled(ctx context.Context) bool {
+	_, ok := ctx.Value(objectClientKey{}).(ObjectClientWrapper)
+	return ok
+}
+
+// AllowedWorkspace checks the permissions of the user on the repositories or templates of a workspace, returning false
+// if per object permissions are not enabled
+func AllowedWorkspace(ctx context.Context, resource Resource, verb Verb, workspaceID string) (bool, error) {
+	client, ok := ctx.Value(objectClientKey{}).(ObjectClientWrapper)
+	if !ok {
+		return false, nil
+	}
+	return client.AllowedWorkspace(ctx, resource, verb, workspaceID)
+}
+
+// SetObjectReporter sets the reporter of created and deleted repositories and templates. Until it is set, they are not
+// reported.
+func SetObjectReporter(reporter ObjectReporter) {
+	objectReporter = reporter
+}
+
+// SetupObjectReporter reports created and deleted repositories and templates to Kessel, if per object permissions
+// are enabled
+func SetupObjectReporter() error {
+	if !config.Get().Features.Kessel.Enabled || !config.Get().Features.KesselObjectPermissions.Enabled {
+		return nil
+	}
+	client, err := NewKesselClientWrapper()
+	if err != nil {
+		return err
+	}
+	SetObjectReporter(client)
+	return nil
+}
+
+// ReportObject registers a repository or template in a workspace, the default workspace of its organization when
+// workspaceID is empty, if a reporter is set
+func ReportObject(ctx context.Context, resource Resource, orgID string, uuid string, workspaceID string) error {
+	if objectReporter == nil {
+		return nil
+	}
+	return objectReporter.ReportObject(ctx, resource, orgID, uuid, workspaceID)
+}
+
+// DeleteObject unregisters a deleted repository or template, if a reporter is set
+func DeleteObject(ctx context.Context, resource Resource, uuid string) error {
+	if objectReporter == nil {
+		return nil
+	}
+	return objectReporter.DeleteObject(ctx, resource, uuid)
+}
@@ -2,7 +2,10 @@ package rbac
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+
+	"github.com/content-services/content-sources-backend/pkg/config"
 )
 
 // The following constants result from the schema below
@@ -14,11 +17,11 @@ const (
 	RbacVerbRead      Verb = "read"
 	RbacVerbWrite     Verb = "write"
 	RbacVerbUpload    Verb = "upload"
+	RbacVerbDelete    Verb = "delete"
+	RbacVerbUpdate    Verb = "update" // Publishing snapshots
 	RbacVerbUndefined Verb = ""
 	/* Unused Verbs
 	RbacVerbCreate    Verb = "create"
-	RbacVerbUpdate    Verb = "update"
-	RbacVerbDelete    Verb = "delete"
 	RbacVerbLink      Verb = "link"
 	RbacVerbUnlink    Verb = "unlink"
 	RbacVerbOrder     Verb = "order"
@@ -30,13 +33,47 @@ type Resource string
 const (
 	ResourceRepositories Resource = "repositories"
 	ResourceTemplates             = "templates"
+	ResourceSnapshots    Resource = "snapshots" // Snapshots of repositories
+	ResourceContent      Resource = "content"   // Packages and other content of repositories
+	ResourceAudit        Resource = "audit"     // Audit log of the changes to the organization
 	ResourceAny          Resource = "*"
 	ResourceUndefined    Resource = ""
 )
 
+// ObjectResource returns the resource owning the objects of the given resource, repositories for snapshots and content
+func ObjectResource(resource Resource) Resource {
+	switch resource {
+	case ResourceSnapshots, ResourceContent:
+		return ResourceRepositories
+	default:
+		return resource
+	}
+}
+
+// WriteFallback returns the permission that used to be required for the verb, before deleting and publishing
+// snapshots, deleting repositories, removing content and reading the audit log had their own permissions. Users
+// whose roles do not grant the new permissions yet are still allowed with the write permission on repositories,
+// while clients.rbac_write_fallback is enabled. Remove after 2027-01-31, once the roles grant the new permissions.
+func WriteFallback(resource Resource, verb Verb) (Resource, Verb, bool) {
+	if !config.Get().Clients.RbacWriteFallback {
+		return ResourceUndefined, RbacVerbUndefined, false
+	}
+	switch {
+	case verb == RbacVerbDelete || (resource == ResourceSnapshots && verb == RbacVerbUpdate):
+		return ResourceRepositories, RbacVerbWrite, true
+	case resource == ResourceAudit:
+		return ResourceRepositories, RbacVerbWrite, true
+	default:
+		return ResourceUndefined, RbacVerbUndefined, false
+	}
+}
+
 type rbacEntry struct {
-	resource Resource
-	verb     Verb
+	resource       Resource
+	verb           Verb
+	filtersObjects bool
+	createsObjects bool
+	unrestricted   bool
 }
 
 var ServicePermissions *PermissionsMap = NewPermissionsMap()
@@ -74,6 +111,7 @@ func (pm *PermissionsMap) Add(method string, path string, res Resource, verb Ver
 		if permission, ok := paths[path]; ok {
 			permission.resource = res
 			permission.verb = verb
+			paths[path] = permission
 		} else {
 			paths[path] = rbacEntry{
 				resource: res,
@@ -90,3 +128,88 @@ func (pm *PermissionsMap) Add(method string, path string, res Resource, verb Ver
 	}
 	return pm
 }
+
+// AddObjectFilter marks an existing route as listing objects, so users allowed only on some
+// repositories or templates can access it, and only see those
+func (pm *PermissionsMap) AddObjectFilter(method string, path string) *PermissionsMap {
+	path = strings.Trim(path, "/")
+	if paths, ok := (*pm)[method]; ok {
+		if permission, ok := paths[path]; ok {
+			permission.filtersObjects = true
+			paths[path] = permission
+			return pm
+		}
+	}
+	return nil
+}
+
+// AddObjectCreate marks an existing route as creating objects in a workspace, so users allowed only on some
+// workspaces can access it. The handler checks the permissions of the user on the workspace instead.
+func (pm *PermissionsMap) AddObjectCreate(method string, path string) *PermissionsMap {
+	path = strings.Trim(path, "/")
+	if paths, ok := (*pm)[method]; ok {
+		if permission, ok := paths[path]; ok {
+			permission.createsObjects = true
+			paths[path] = permission
+			return pm
+		}
+	}
+	return nil
+}
+
+// CreatesObjects returns true if the route creates objects in a workspace checked by the handler
+func (pm *PermissionsMap) CreatesObjects(method string, path string) bool {
+	path = strings.Trim(path, "/")
+	if paths, ok := (*pm)[method]; ok {
+		return paths[path].createsObjects
+	}
+	return false
+}
+
+// FiltersObjects returns true if the route lists objects, filtering them by the permissions of the user
+func (pm *PermissionsMap) FiltersObjects(method string, path string) bool {
+	path = strings.Trim(path, "/")
+	if paths, ok := (*pm)[method]; ok {
+		return paths[path].filtersObjects
+	}
+	return false
+}
+
+// AddUnrestricted adds a route any user of the organization can access, regardless of their permissions
+func (pm *PermissionsMap) AddUnrestricted(method string, path string) *PermissionsMap {
+	if method == "" || path == "" {
+		return nil
+	}
+	path = strings.Trim(path, "/")
+	if _, ok := (*pm)[method]; !ok {
+		(*pm)[method] = map[string]rbacEntry{}
+	}
+	(*pm)[method][path] = rbacEntry{unrestricted: true}
+	return pm
+}
+
+// Unrestricted returns true if the route can be accessed regardless of the permissions of the user
+func (pm *PermissionsMap) Unrestricted(method string, path string) bool {
+	path = strings.Trim(path, "/")
+	if paths, ok := (*pm)[method]; ok {
+		return paths[path].unrestricted
+	}
+	return false
+}
+
+// Verbs returns the verbs required by the routes of each resource, sorted by name
+func (pm *PermissionsMap) Verbs() map[Resource][]Verb {
+	verbs := map[Resource][]Verb{}
+	for _, paths := range *pm {
+		for _, permission := range paths {
+			if permission.unrestricted || slices.Contains(verbs[permission.resource], permission.verb) {
+				continue
+			}
+			verbs[permission.resource] = append(verbs[permission.resource], permission.verb)
+		}
+	}
+	for resource := range verbs {
+		slices.Sort(verbs[resource])
+	}
+	return verbs
+}
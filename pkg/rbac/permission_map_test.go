@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/content-services/content-sources-backend/pkg/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -186,6 +187,18 @@ func TestPermissionWithServicePermissions(t *testing.T) {
 				Error:    nil,
 			},
 		},
+		{
+			Name: "DELETE */repositories/:repo_uuid/snapshots/:snapshot_uuid",
+			Given: TestCaseGiven{
+				Method: http.MethodDelete,
+				Path:   "/repositories/:repo_uuid/snapshots/:snapshot_uuid",
+			},
+			Expected: TestCaseExpected{
+				Resource: ResourceSnapshots,
+				Verb:     RbacVerbDelete,
+				Error:    nil,
+			},
+		},
 		{
 			Name: "Method no mapped",
 			Given: TestCaseGiven{
@@ -215,7 +228,8 @@ func TestPermissionWithServicePermissions(t *testing.T) {
 		Add(http.MethodGet, "/repositories/", "repositories", "read").
 		Add(http.MethodPost, "/repositories/", "repositories", "write").
 		Add(http.MethodPost, "/repository_parameters/validate/", "repositories", "write").
-		Add(http.MethodPost, "/templates/", "templates", "write")
+		Add(http.MethodPost, "/templates/", "templates", "write").
+		Add(http.MethodDelete, "/repositories/:repo_uuid/snapshots/:snapshot_uuid", "snapshots", "delete")
 
 	for _, testCase := range testCases {
 		t.Log(testCase.Name)
@@ -231,3 +245,84 @@ func TestPermissionWithServicePermissions(t *testing.T) {
 		}
 	}
 }
+
+func TestAddObjectFilter(t *testing.T) {
+	pm := NewPermissionsMap()
+	pm.Add(http.MethodGet, "/repositories/", ResourceRepositories, RbacVerbRead)
+	pm.Add(http.MethodGet, "/repositories/:uuid", ResourceRepositories, RbacVerbRead)
+
+	assert.Nil(t, pm.AddObjectFilter(http.MethodGet, "/templates/"))
+	assert.NotNil(t, pm.AddObjectFilter(http.MethodGet, "/repositories/"))
+
+	assert.True(t, pm.FiltersObjects(http.MethodGet, "repositories"))
+	assert.False(t, pm.FiltersObjects(http.MethodGet, "repositories/:uuid"))
+	assert.False(t, pm.FiltersObjects(http.MethodPost, "repositories"))
+
+	// Updating the permission keeps the filter
+	pm.Add(http.MethodGet, "/repositories/", ResourceRepositories, RbacVerbWrite)
+	assert.True(t, pm.FiltersObjects(http.MethodGet, "repositories"))
+	_, verb, err := pm.Permission(http.MethodGet, "repositories")
+	require.NoError(t, err)
+	assert.Equal(t, RbacVerbWrite, verb)
+}
+
+func TestAddObjectCreate(t *testing.T) {
+	pm := NewPermissionsMap()
+	pm.Add(http.MethodPost, "/repositories/", ResourceRepositories, RbacVerbWrite)
+
+	assert.Nil(t, pm.AddObjectCreate(http.MethodPost, "/templates/"))
+	assert.NotNil(t, pm.AddObjectCreate(http.MethodPost, "/repositories/"))
+
+	assert.True(t, pm.CreatesObjects(http.MethodPost, "repositories"))
+	assert.False(t, pm.CreatesObjects(http.MethodGet, "repositories"))
+	assert.False(t, pm.FiltersObjects(http.MethodPost, "repositories"))
+}
+
+func TestObjectResource(t *testing.T) {
+	assert.Equal(t, ResourceRepositories, ObjectResource(ResourceRepositories))
+	assert.Equal(t, ResourceRepositories, ObjectResource(ResourceSnapshots))
+	assert.Equal(t, ResourceRepositories, ObjectResource(ResourceContent))
+	assert.Equal(t, Resource(ResourceTemplates), ObjectResource(ResourceTemplates))
+}
+
+func TestAddUnrestricted(t *testing.T) {
+	pm := NewPermissionsMap()
+	pm.Add(http.MethodGet, "/repositories/", ResourceRepositories, RbacVerbRead)
+	pm.Add(http.MethodDelete, "/repositories/:uuid", ResourceRepositories, RbacVerbDelete)
+	pm.Add(http.MethodPost, "/repositories/", ResourceRepositories, RbacVerbWrite)
+	pm.Add(http.MethodPost, "/templates/", ResourceTemplates, RbacVerbWrite)
+	pm.Add(http.MethodGet, "/templates/", ResourceTemplates, RbacVerbRead)
+
+	assert.Nil(t, pm.AddUnrestricted("", "/permissions/"))
+	assert.NotNil(t, pm.AddUnrestricted(http.MethodGet, "/permissions/"))
+
+	assert.True(t, pm.Unrestricted(http.MethodGet, "permissions"))
+	assert.False(t, pm.Unrestricted(http.MethodGet, "repositories"))
+	assert.False(t, pm.Unrestricted(http.MethodPut, "permissions"))
+
+	// Unrestricted routes do not require any verb
+	assert.Equal(t, map[Resource][]Verb{
+		ResourceRepositories: {RbacVerbDelete, RbacVerbRead, RbacVerbWrite},
+		ResourceTemplates:    {RbacVerbRead, RbacVerbWrite},
+	}, pm.Verbs())
+}
+
+func TestWriteFallback(t *testing.T) {
+	clients := config.Get().Clients
+	defer func() { config.Get().Clients = clients }()
+	config.Get().Clients.RbacWriteFallback = true
+
+	resource, verb, ok := WriteFallback(ResourceAudit, RbacVerbRead)
+	assert.True(t, ok)
+	assert.Equal(t, ResourceRepositories, resource)
+	assert.Equal(t, RbacVerbWrite, verb)
+
+	_, _, ok = WriteFallback(ResourceSnapshots, RbacVerbDelete)
+	assert.True(t, ok)
+	_, _, ok = WriteFallback(ResourceRepositories, RbacVerbUpload)
+	assert.False(t, ok)
+
+	config.Get().Clients.RbacWriteFallback = false
+	_, _, ok = WriteFallback(ResourceAudit, RbacVerbRead)
+	assert.False(t, ok)
+}
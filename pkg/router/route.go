@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	"github.com/content-services/content-sources-backend/pkg/db"
 	"github.com/content-services/content-sources-backend/pkg/handler"
 	"github.com/content-services/content-sources-backend/pkg/instrumentation"
 	"github.com/content-services/content-sources-backend/pkg/middleware"
@@ -56,30 +58,48 @@ func ConfigureEchoWithMetrics(ctx context.Context, metrics *instrumentation.Metr
 	e.Use(middleware.EnforceOrgId)
 	e.Use(middleware.EnforceConsistentOrgId)
 	e.Use(middleware.CreateMetricsMiddleware(metrics))
+	e.Use(middleware.NewAudit(middleware.Audit{
+		Skipper:        middleware.SkipMiddleware,
+		PermissionsMap: rbac.ServicePermissions,
+		AuditEventDao:  dao.GetAuditEventDao(db.DB),
+	}))
 	if config.Get().Clients.RbacEnabled {
-		rbacBaseUrl := config.Get().Clients.RbacBaseUrl
-		rbacTimeout := time.Duration(int64(config.Get().Clients.RbacTimeout) * int64(time.Second))
-		rbacClient := rbac.NewClientWrapperImpl(rbacBaseUrl, rbacTimeout)
-		log.Info().Msgf("rbacBaseUrl=%s", rbacBaseUrl)
-		log.Info().Msgf("rbacTimeout=%d secs", rbacTimeout/time.Second)
+		var rbacClient rbac.ClientWrapper
+		if policyFile := config.Get().Clients.RbacPolicyFile; policyFile != "" {
+			fileClient, err := rbac.NewFileClientWrapper(policyFile)
+			if err != nil {
+				log.Fatal().Err(err).Msg("could not create rbac policy file client")
+			}
+			rbacClient = fileClient
+			log.Info().Msgf("rbacPolicyFile=%s", policyFile)
+		} else {
+			rbacBaseUrl := config.Get().Clients.RbacBaseUrl
+			rbacTimeout := time.Duration(int64(config.Get().Clients.RbacTimeout) * int64(time.Second))
+			rbacClient = rbac.NewClientWrapperImpl(rbacBaseUrl, rbacTimeout)
+			log.Info().Msgf("rbacBaseUrl=%s", rbacBaseUrl)
+			log.Info().Msgf("rbacTimeout=%d secs", rbacTimeout/time.Second)
+		}
 
 		var kesselClient rbac.ClientWrapper
-		var err error
+		var kesselObjectClient rbac.ObjectClientWrapper
 		if config.Get().Features.Kessel.Enabled {
-			kesselClient, err = rbac.NewKesselClientWrapper()
+			client, err := rbac.NewKesselClientWrapper()
 			if err != nil {
 				log.Fatal().Err(err).Msg("could not create kessel client")
 			}
+			kesselClient = client
+			kesselObjectClient = client
 		}
 
 		e.Use(
 			middleware.NewRbac(
 				middleware.Rbac{
-					BaseUrl:        config.Get().Clients.RbacBaseUrl,
-					Skipper:        middleware.SkipMiddleware,
-					PermissionsMap: rbac.ServicePermissions,
-					RbacClient:     rbacClient,
-					KesselClient:   kesselClient,
+					BaseUrl:            config.Get().Clients.RbacBaseUrl,
+					Skipper:            middleware.SkipMiddleware,
+					PermissionsMap:     rbac.ServicePermissions,
+					RbacClient:         rbacClient,
+					KesselClient:       kesselClient,
+					KesselObjectClient: kesselObjectClient,
 				},
 			),
 		)
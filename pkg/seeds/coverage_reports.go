@@ -65,6 +65,7 @@ func SeedCoverageReport(db *gorm.DB, options CoverageReportSeedOptions) (*models
 	for _, pkg := range packages {
 		if pkg.MatchStatus != models.CoverageMatchStatusExact {
 			demandSignals = append(demandSignals, models.CoverageDemandSignal{
+				OrgID:       &report.OrgID,
 				Ecosystem:   pkg.Ecosystem,
 				Name:        pkg.Name,
 				Version:     pkg.Version,
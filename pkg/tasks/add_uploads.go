@@ -14,6 +14,7 @@ import (
 	"github.com/content-services/content-sources-backend/pkg/config"
 	"github.com/content-services/content-sources-backend/pkg/dao"
 	"github.com/content-services/content-sources-backend/pkg/db"
+	"github.com/content-services/content-sources-backend/pkg/event"
 	"github.com/content-services/content-sources-backend/pkg/models"
 	"github.com/content-services/content-sources-backend/pkg/tasks/queue"
 	"github.com/content-services/content-sources-backend/pkg/utils"
@@ -45,6 +46,7 @@ type AddUploads struct {
 	pulpClient pulp_client.PulpClient
 	queue      *queue.Queue
 	logger     *zerolog.Logger
+	snapshot   *models.Snapshot // Snapshot created by Run, if any content was added
 }
 
 func AddUploadsHandler(ctx context.Context, task *models.TaskInfo, queue *queue.Queue) error {
@@ -87,7 +89,31 @@ func AddUploadsHandler(ctx context.Context, task *models.TaskInfo, queue *queue.
 		queue:      queue,
 		logger:     logger,
 	}
-	return ur.Run()
+	err = ur.Run()
+	if err != nil {
+		return err
+	}
+	ur.sendEvent()
+	return nil
+}
+
+// sendEvent sends the uploads added by Run
+func (ur *AddUploads) sendEvent() {
+	event.SendNotification(ur.orgID, event.UploadCompleted, []event.UploadPayload{ur.uploadPayload()})
+}
+
+func (ur *AddUploads) uploadPayload() event.UploadPayload {
+	payload := event.UploadPayload{
+		RepositoryUUID: ur.repo.UUID,
+		RepositoryName: ur.repo.Name,
+		UploadCount:    len(ur.payload.Uploads) + len(ur.payload.Artifacts),
+	}
+	if ur.snapshot != nil {
+		payload.SnapshotUUID = ur.snapshot.UUID
+		payload.ContentCounts = ur.snapshot.ContentCounts
+		payload.AddedCounts = ur.snapshot.AddedCounts
+	}
+	return payload
 }
 
 func (ur *AddUploads) Run() (err error) {
@@ -149,6 +175,7 @@ func (ur *AddUploads) Run() (err error) {
 	if err != nil {
 		return err
 	}
+	ur.snapshot = helper.snapshot
 
 	err = ur.ImportPackageData(*ur.payload.VersionHref)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/content-services/content-sources-backend/pkg/clients/pulp_client"
 	"github.com/content-services/content-sources-backend/pkg/config"
 	"github.com/content-services/content-sources-backend/pkg/dao"
+	"github.com/content-services/content-sources-backend/pkg/event"
 	"github.com/content-services/content-sources-backend/pkg/models"
 	"github.com/content-services/content-sources-backend/pkg/tasks/queue"
 	"github.com/content-services/content-sources-backend/pkg/utils"
@@ -26,6 +27,7 @@ type AddUploadsSuite struct {
 	MockPulpClient  pulp_client.MockPulpClient
 	MockQueue       queue.MockQueue
 	Queue           queue.Queue
+	mockOutbox      *dao.MockOutboxDao
 }
 
 func TestAddUploadsSuite(t *testing.T) {
@@ -37,6 +39,9 @@ func (s *AddUploadsSuite) SetupTest() {
 	s.MockPulpClient = *pulp_client.NewMockPulpClient(s.T())
 	s.MockQueue = *queue.NewMockQueue(s.T())
 	s.Queue = &s.MockQueue
+	s.mockOutbox = dao.NewMockOutboxDao(s.T())
+	event.SetOutbox(s.mockOutbox)
+	s.T().Cleanup(func() { event.SetOutbox(nil) })
 	config.Get().Clients.Pulp.RepoContentGuards = false
 }
 
@@ -95,6 +100,12 @@ func (s *AddUploadsSuite) TestAddUploadsNoNewVersion() {
 	err := ur.Run()
 	assert.NoError(s.T(), err)
 	assert.Nil(s.T(), ur.payload.VersionHref)
+
+	// No snapshot is created when the uploads were already in the repository
+	s.mockOutbox.On("Queue", mock.Anything, repoConfig.OrgID, event.UploadCompleted, []string{event.ChannelNotifications, event.ChannelWebhooks}, []event.Object{
+		event.UploadPayload{RepositoryUUID: repoConfig.UUID, UploadCount: 1},
+	}).Return(nil).Once()
+	ur.sendEvent()
 }
 
 func (s *AddUploadsSuite) TestAddUploadsWithOrphanVersion() {
@@ -190,6 +201,16 @@ func (s *AddUploadsSuite) TestAddUploadsWithOrphanVersion() {
 	err := ur.Run()
 	assert.NoError(s.T(), err)
 	assert.Equal(s.T(), existingVersionHref, *ur.payload.VersionHref)
+
+	s.mockOutbox.On("Queue", mock.Anything, repoConfig.OrgID, event.UploadCompleted, []string{event.ChannelNotifications, event.ChannelWebhooks}, []event.Object{
+		event.UploadPayload{
+			RepositoryUUID: repoConfig.UUID,
+			UploadCount:    1,
+			ContentCounts:  current,
+			AddedCounts:    added,
+		},
+	}).Return(nil).Once()
+	ur.sendEvent()
 }
 
 func (s *AddUploadsSuite) mockCreateDist(ctx context.Context, pubHref string) (string, string) {
@@ -146,7 +146,9 @@ func (ds *DeleteSnapshots) Run() error {
 			} else {
 				errs = append(errs, fmt.Errorf("failed to delete snapshot %v: %w", snapUUID, err))
 			}
+			continue
 		}
+		sendSnapshotNotification(ds.orgID, event.SnapshotDeleted, event.MapSnapshotPayload(repo, snap))
 	}
 
 	return errors.Join(errs...)
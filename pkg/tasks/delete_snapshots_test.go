@@ -11,6 +11,7 @@ import (
 	"github.com/content-services/content-sources-backend/pkg/clients/pulp_client"
 	"github.com/content-services/content-sources-backend/pkg/config"
 	"github.com/content-services/content-sources-backend/pkg/dao"
+	"github.com/content-services/content-sources-backend/pkg/event"
 	"github.com/content-services/content-sources-backend/pkg/models"
 	"github.com/content-services/content-sources-backend/pkg/tasks/payloads"
 	"github.com/content-services/content-sources-backend/pkg/tasks/queue"
@@ -29,6 +30,7 @@ type DeleteSnapshotsSuite struct {
 	mockPulpClient  pulp_client.MockPulpClient
 	MockQueue       queue.MockQueue
 	Queue           queue.Queue
+	mockOutbox      *dao.MockOutboxDao
 }
 
 func TestDeleteSnapshotSuite(t *testing.T) {
@@ -42,6 +44,9 @@ func (s *DeleteSnapshotsSuite) pulpClient() pulp_client.PulpClient {
 func (s *DeleteSnapshotsSuite) SetupTest() {
 	s.mockDaoRegistry = dao.GetMockDaoRegistry(s.T())
 	s.mockPulpClient = *pulp_client.NewMockPulpClient(s.T())
+	s.mockOutbox = dao.NewMockOutboxDao(s.T())
+	event.SetOutbox(s.mockOutbox)
+	s.T().Cleanup(func() { event.SetOutbox(nil) })
 }
 
 func (s *DeleteSnapshotsSuite) TestDeleteSnapshots() {
@@ -118,6 +123,9 @@ func (s *DeleteSnapshotsSuite) TestDeleteSnapshots() {
 	s.mockPulpClient.On("DeleteRpmDistribution", ctx, snap.DistributionHref).Return(&deleteDistributionHref, nil)
 	s.mockPulpClient.On("PollTask", ctx, mock.Anything).Return(nil, nil)
 	s.mockPulpClient.On("DeleteRpmRepositoryVersion", ctx, snap.VersionHref).Return(utils.Ptr("taskHref"), nil)
+	s.mockOutbox.On("Queue", mock.Anything, orgID, event.SnapshotDeleted, []string{event.ChannelNotifications, event.ChannelWebhooks}, []event.Object{
+		event.SnapshotPayload{UUID: snap.UUID, RepositoryUUID: repo.UUID},
+	}).Return(nil).Once()
 
 	pulpClient := s.pulpClient()
 	task := models.TaskInfo{
@@ -142,6 +150,45 @@ func (s *DeleteSnapshotsSuite) TestDeleteSnapshots() {
 	assert.NoError(t, taskErr)
 }
 
+func (s *DeleteSnapshotsSuite) TestDeleteSnapshotsFailedNotSent() {
+	t := s.T()
+	t.Setenv("CLIENTS_PULP_SERVER", "mock")
+	config.Load()
+	ctx := context.Background()
+
+	orgID := test_handler.MockOrgId
+	repo := api.RepositoryResponse{
+		UUID:  uuid.NewString(),
+		OrgID: orgID,
+	}
+	snapUUID := uuid.NewString()
+	s.mockDaoRegistry.RepositoryConfig.On("Fetch", ctx, orgID, repo.UUID).Return(repo, nil)
+	s.mockDaoRegistry.Snapshot.On("FetchModel", ctx, snapUUID, true).Return(models.Snapshot{}, errors.New("database is down"))
+
+	pulpClient := s.pulpClient()
+	task := models.TaskInfo{
+		Id:        uuid.UUID{},
+		OrgId:     orgID,
+		RequestID: uuid.NewString(),
+		Typename:  config.DeleteSnapshotsTask,
+	}
+	deleteSnapshotsTask := DeleteSnapshots{
+		orgID: orgID,
+		ctx:   ctx,
+		payload: utils.Ptr(payloads.DeleteSnapshotsPayload{
+			RepoUUID:       repo.UUID,
+			SnapshotsUUIDs: []string{snapUUID},
+		}),
+		task:       &task,
+		daoReg:     s.mockDaoRegistry.ToDaoRegistry(),
+		pulpClient: &pulpClient,
+	}
+
+	// No SnapshotDeleted event is queued for a snapshot that could not be deleted
+	taskErr := deleteSnapshotsTask.Run()
+	assert.Error(t, taskErr)
+}
+
 func (s *DeleteSnapshotsSuite) TestDeleteSnapshotDistributionLooksUpByPathWhenHrefEmpty() {
 	t := s.T()
 	ctx := context.Background()
@@ -0,0 +1,534 @@
+package queue
+
+import (
+	"cmp"
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/google/uuid"
+)
+
+const maxErrorLength = 4000 // matches the length the pg queue truncates task errors to
+
+// MemQueue an in-process task queue with the same semantics as PgQueue.
+// Tasks only live as long as the process and are not visible to the task DAOs, which read the tasks table,
+// so it is meant for tests of workers and task handlers that should not need postgres.
+type MemQueue struct {
+	mutex      sync.Mutex
+	tasks      map[uuid.UUID]*memTask
+	order      []*memTask            // tasks in the order they were first enqueued
+	heartbeats map[uuid.UUID]memBeat // keyed by token
+	dequeuers  *dequeuers
+	cancelers  *cancelListeners
+}
+
+type memTask struct {
+	info models.TaskInfo
+	seq  int // tie-breaker for tasks queued at the same instant
+}
+
+type memBeat struct {
+	id        uuid.UUID
+	heartbeat time.Time
+}
+
+// thread-safe list of ListenForCanceledTask callers
+type cancelListeners struct {
+	list  *list.List
+	mutex sync.Mutex
+}
+
+func (c *cancelListeners) pushBack(ch chan uuid.UUID) *list.Element {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.list.PushBack(ch)
+}
+
+func (c *cancelListeners) remove(e *list.Element) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.list.Remove(e)
+}
+
+func (c *cancelListeners) notifyAll(taskID uuid.UUID) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	cur := c.list.Front()
+	for cur != nil {
+		listenerChan, _ := cur.Value.(chan uuid.UUID)
+
+		// notify in a non-blocking way
+		select {
+		case listenerChan <- taskID:
+		default:
+		}
+		cur = cur.Next()
+	}
+}
+
+func NewMemQueue() *MemQueue {
+	return &MemQueue{
+		tasks:      make(map[uuid.UUID]*memTask),
+		heartbeats: make(map[uuid.UUID]memBeat),
+		dequeuers:  newDequeuers(),
+		cancelers:  &cancelListeners{list: list.New()},
+	}
+}
+
+func (q *MemQueue) Enqueue(task *Task) (uuid.UUID, error) {
+	payload, err := json.Marshal(task.Payload)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error enqueuing task: %w", err)
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if task.DedupKey != "" {
+		if existingID := q.activeTaskForDedupKey(task); existingID != uuid.Nil {
+			return existingID, nil
+		}
+	}
+
+	dependencies := make([]string, 0, len(task.Dependencies))
+	for _, d := range task.Dependencies {
+		if _, ok := q.tasks[d]; !ok {
+			return uuid.Nil, fmt.Errorf("error inserting dependency: %w", ErrNotExist)
+		}
+		dependencies = append(dependencies, d.String())
+	}
+
+	now := time.Now()
+	t := &memTask{
+		seq: len(q.order),
+		info: models.TaskInfo{
+			Id:           uuid.New(),
+			Typename:     task.Typename,
+			Payload:      payload,
+			OrgId:        task.OrgId,
+			AccountId:    task.AccountId,
+			ObjectType:   task.ObjectType,
+			Dependencies: dependencies,
+			Queued:       &now,
+			Status:       config.TaskStatusPending,
+			RequestID:    task.RequestID,
+			Priority:     task.Priority,
+		},
+	}
+	if task.ObjectUUID != nil {
+		t.info.ObjectUUID, err = uuid.Parse(*task.ObjectUUID)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("error enqueuing task: %w", err)
+		}
+	}
+	if task.DedupKey != "" {
+		t.info.DedupKey = &task.DedupKey
+	}
+	q.tasks[t.info.Id] = t
+	q.order = append(q.order, t)
+
+	q.dequeuers.notifyAll()
+	return t.info.Id, nil
+}
+
+// activeTaskForDedupKey returns the ID of the most recently queued pending or running task with the
+// same type, org and dedup key as task, or uuid.Nil if there is none
+func (q *MemQueue) activeTaskForDedupKey(task *Task) uuid.UUID {
+	var found *memTask
+	for _, t := range q.order {
+		if t.info.DedupKey == nil || *t.info.DedupKey != task.DedupKey {
+			continue
+		}
+		if t.info.OrgId != task.OrgId || t.info.Typename != task.Typename {
+			continue
+		}
+		if t.info.Status != config.TaskStatusPending && t.info.Status != config.TaskStatusRunning {
+			continue
+		}
+		if found == nil || !t.info.Queued.Before(*found.info.Queued) {
+			found = t
+		}
+	}
+	if found == nil {
+		return uuid.Nil
+	}
+	return found.info.Id
+}
+
+func (q *MemQueue) Dequeue(ctx context.Context, taskTypes []string) (*models.TaskInfo, error) {
+	// add ourselves as a dequeuer
+	c := make(chan struct{}, 1)
+	el := q.dequeuers.pushBack(c)
+	defer q.dequeuers.remove(el)
+
+	for {
+		if ctx.Err() != nil {
+			return nil, ErrContextCanceled
+		}
+		if info := q.dequeueMaybe(taskTypes); info != nil {
+			return info, nil
+		}
+		// no suitable task was found, wait for the next queue update
+		select {
+		case <-c:
+		case <-ctx.Done():
+			return nil, ErrContextCanceled
+		}
+	}
+}
+
+// dequeueMaybe starts the highest priority, longest queued task of one of taskTypes whose dependencies
+// have all finished, returning nil if there is no such task
+func (q *MemQueue) dequeueMaybe(taskTypes []string) *models.TaskInfo {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	var next *memTask
+	for _, t := range q.order {
+		if t.info.Started != nil || t.info.Status == config.TaskStatusCanceled || !slices.Contains(taskTypes, t.info.Typename) {
+			continue
+		}
+		if !q.dependenciesFinished(t) {
+			continue
+		}
+		if next == nil || compareMemTasks(t, next) < 0 {
+			next = t
+		}
+	}
+	if next == nil {
+		return nil
+	}
+
+	now := time.Now()
+	next.info.Token = uuid.New()
+	next.info.Started = &now
+	next.info.Status = config.TaskStatusRunning
+	q.heartbeats[next.info.Token] = memBeat{id: next.info.Id, heartbeat: now}
+
+	return copyTaskInfo(&next.info)
+}
+
+func (q *MemQueue) dependenciesFinished(t *memTask) bool {
+	for _, d := range t.info.Dependencies {
+		dep, ok := q.tasks[uuid.MustParse(d)]
+		if ok && dep.info.Finished == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// compareMemTasks orders tasks by priority descending, then by queued time ascending
+func compareMemTasks(a, b *memTask) int {
+	if c := cmp.Compare(b.info.Priority, a.info.Priority); c != 0 {
+		return c
+	}
+	if c := a.info.Queued.Compare(*b.info.Queued); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.seq, b.seq)
+}
+
+func (q *MemQueue) Status(taskId uuid.UUID) (*models.TaskInfo, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	t, ok := q.tasks[taskId]
+	if !ok {
+		return nil, ErrNotExist
+	}
+	return copyTaskInfo(&t.info), nil
+}
+
+func (q *MemQueue) Finish(taskId uuid.UUID, taskError error) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	err := q.finish(taskId, taskError)
+	if err != nil {
+		return err
+	}
+	q.dequeuers.notifyAll()
+	return nil
+}
+
+func (q *MemQueue) finish(taskId uuid.UUID, taskError error) error {
+	t, ok := q.tasks[taskId]
+	if !ok {
+		return fmt.Errorf("error querying task info: %w", ErrNotExist)
+	}
+	if t.info.Started == nil || t.info.Finished != nil {
+		return ErrNotRunning
+	}
+
+	now := time.Now()
+	t.info.Finished = &now
+	t.info.NextRetryTime = nil
+	q.deleteHeartbeat(taskId)
+
+	if taskError == nil {
+		t.info.Status = config.TaskStatusCompleted
+		t.info.Error = nil
+		return nil
+	}
+
+	t.info.Status = config.TaskStatusFailed
+	t.info.Error = truncateError(strings.ToValidUTF8(taskError.Error(), ""))
+	if !IsFinalAttempt(&t.info) {
+		upperBound := config.Get().Tasking.RetryWaitUpperBound
+		retriesRemaining := float64(MaxTaskRetries - t.info.Retries)
+		timeToWait := time.Second * time.Duration(upperBound.Seconds()/(retriesRemaining+1))
+		nextRetryTime := now.Add(timeToWait)
+		t.info.NextRetryTime = &nextRetryTime
+	}
+
+	for _, dependent := range q.dependents(taskId) {
+		q.cancel(dependent, "parent task failed")
+	}
+	return nil
+}
+
+func (q *MemQueue) Cancel(ctx context.Context, taskId uuid.UUID) error {
+	q.cancelers.notifyAll(taskId)
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	t, ok := q.tasks[taskId]
+	if !ok {
+		return nil
+	}
+	q.deleteHeartbeat(taskId)
+	q.cancel(t, "task canceled")
+	// the flag is set even if the task already finished, to prevent failed tasks from requeueing
+	t.info.CancelAttempted = true
+
+	for _, dependent := range q.dependents(taskId) {
+		q.cancel(dependent, "parent task canceled")
+	}
+
+	q.dequeuers.notifyAll()
+	return nil
+}
+
+// cancel marks an unfinished task as canceled
+func (q *MemQueue) cancel(t *memTask, reason string) {
+	if t.info.Finished != nil {
+		return
+	}
+	t.info.Status = config.TaskStatusCanceled
+	t.info.Error = &reason
+	t.info.CancelAttempted = true
+}
+
+func (q *MemQueue) dependents(taskId uuid.UUID) []*memTask {
+	var dependents []*memTask
+	for _, t := range q.order {
+		if slices.Contains(t.info.Dependencies, taskId.String()) {
+			dependents = append(dependents, t)
+		}
+	}
+	return dependents
+}
+
+func (q *MemQueue) Requeue(taskId uuid.UUID) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	t, ok := q.tasks[taskId]
+	if !ok {
+		return fmt.Errorf("error querying task status: %w", ErrNotExist)
+	}
+	if t.info.CancelAttempted && t.info.Status != config.TaskStatusRunning {
+		return ErrTaskCanceled
+	}
+	if t.info.Started == nil || t.info.Finished != nil {
+		return ErrNotRunning
+	}
+	if t.info.Retries == MaxTaskRetries {
+		err := q.finish(taskId, ErrMaxRetriesExceeded)
+		if err != nil {
+			return fmt.Errorf("error finishing task: %w", err)
+		}
+		q.dequeuers.notifyAll()
+		return ErrMaxRetriesExceeded
+	}
+
+	q.deleteHeartbeat(taskId)
+	q.reset(t)
+
+	q.dequeuers.notifyAll()
+	return nil
+}
+
+func (q *MemQueue) RequeueFailedTasks(taskTypes []string) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	now := time.Now()
+	var toRequeue []*memTask
+	for _, t := range q.order {
+		if t.info.Started == nil || t.info.Finished == nil || t.info.Status != config.TaskStatusFailed ||
+			t.info.Retries >= MaxTaskRetries || t.info.NextRetryTime == nil || t.info.NextRetryTime.After(now) ||
+			!slices.Contains(taskTypes, t.info.Typename) || t.info.CancelAttempted {
+			continue
+		}
+		toRequeue = append(toRequeue, t)
+		toRequeue = append(toRequeue, q.dependents(t.info.Id)...)
+	}
+
+	requeued := make(map[uuid.UUID]bool)
+	for _, t := range toRequeue {
+		if requeued[t.info.Id] {
+			continue
+		}
+		requeued[t.info.Id] = true
+		q.reset(t)
+	}
+
+	q.dequeuers.notifyAll()
+	return nil
+}
+
+// reset returns a task to pending, counting it as a retry
+func (q *MemQueue) reset(t *memTask) {
+	now := time.Now()
+	t.info.Started = nil
+	t.info.Finished = nil
+	t.info.Token = uuid.Nil
+	t.info.Status = config.TaskStatusPending
+	t.info.Retries++
+	t.info.Queued = &now
+}
+
+func (q *MemQueue) Heartbeats(olderThan time.Duration) []uuid.UUID {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	var tokens []uuid.UUID
+	for token, beat := range q.heartbeats {
+		if time.Since(beat.heartbeat) > olderThan {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+func (q *MemQueue) deleteHeartbeat(taskId uuid.UUID) {
+	for token, beat := range q.heartbeats {
+		if beat.id == taskId {
+			delete(q.heartbeats, token)
+		}
+	}
+}
+
+func (q *MemQueue) IdFromToken(token uuid.UUID) (id uuid.UUID, isRunning bool, err error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	t := q.taskForToken(token)
+	if t == nil {
+		return uuid.Nil, false, ErrNotExist
+	}
+	return t.info.Id, t.info.Status == config.TaskStatusRunning, nil
+}
+
+func (q *MemQueue) taskForToken(token uuid.UUID) *memTask {
+	if token == uuid.Nil {
+		return nil
+	}
+	for _, t := range q.order {
+		if t.info.Token == token {
+			return t
+		}
+	}
+	return nil
+}
+
+// Reset the last heartbeat time to time.Now()
+func (q *MemQueue) RefreshHeartbeat(token uuid.UUID) error {
+	if token == uuid.Nil {
+		return nil
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if beat, ok := q.heartbeats[token]; ok {
+		beat.heartbeat = time.Now()
+		q.heartbeats[token] = beat
+		return nil
+	}
+
+	t := q.taskForToken(token)
+	if t == nil {
+		return ErrNotExist
+	}
+	if t.info.Status == config.TaskStatusRunning {
+		return ErrRowsNotAffected
+	}
+	return nil
+}
+
+func (q *MemQueue) UpdatePayload(task *models.TaskInfo, payload interface{}) (*models.TaskInfo, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return task, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if t, ok := q.tasks[task.Id]; ok {
+		t.info.Payload = raw
+	}
+	return task, nil
+}
+
+func (q *MemQueue) ListenForCanceledTask(ctx context.Context) (uuid.UUID, error) {
+	c := make(chan uuid.UUID, 1)
+	el := q.cancelers.pushBack(c)
+	defer q.cancelers.remove(el)
+
+	select {
+	case taskID := <-c:
+		return taskID, nil
+	case <-ctx.Done():
+		return uuid.Nil, ctx.Err()
+	}
+}
+
+// RemoveAllTasks used for tests to clear the queue before running tests
+func (q *MemQueue) RemoveAllTasks() error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.tasks = make(map[uuid.UUID]*memTask)
+	q.order = nil
+	q.heartbeats = make(map[uuid.UUID]memBeat)
+	return nil
+}
+
+func copyTaskInfo(info *models.TaskInfo) *models.TaskInfo {
+	c := *info
+	c.Payload = slices.Clone(info.Payload)
+	c.Dependencies = slices.Clone(info.Dependencies)
+	return &c
+}
+
+func truncateError(msg string) *string {
+	if runes := []rune(msg); len(runes) > maxErrorLength {
+		msg = string(runes[:maxErrorLength])
+	}
+	return &msg
+}
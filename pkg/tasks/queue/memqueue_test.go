@@ -0,0 +1,74 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type MemQueueSuite struct {
+	QueueBehaviorSuite
+}
+
+func (s *MemQueueSuite) SetupTest() {
+	config.RequeueableTasks = append(config.RequeueableTasks, testTaskType)
+
+	s.queue = NewMemQueue()
+}
+
+func TestMemQueueSuite(t *testing.T) {
+	suite.Run(t, &MemQueueSuite{})
+}
+
+func (s *MemQueueSuite) TestListenForCanceledTask() {
+	id, err := s.queue.Enqueue(&testTask)
+	require.NoError(s.T(), err)
+
+	receivedID := make(chan uuid.UUID, 1)
+	go func() {
+		id, err := s.queue.ListenForCanceledTask(context.Background())
+		if err == nil {
+			receivedID <- id
+		}
+	}()
+
+	time.Sleep(time.Millisecond * 100)
+
+	err = s.queue.Cancel(context.Background(), id)
+	require.NoError(s.T(), err)
+
+	assert.Equal(s.T(), id, <-receivedID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = s.queue.ListenForCanceledTask(ctx)
+	assert.ErrorIs(s.T(), err, context.Canceled)
+}
+
+func (s *MemQueueSuite) TestDequeueBlocksUntilEnqueue() {
+	dequeued := make(chan uuid.UUID, 1)
+	go func() {
+		info, err := s.queue.Dequeue(context.Background(), []string{testTaskType})
+		if err == nil {
+			dequeued <- info.Id
+		}
+	}()
+
+	time.Sleep(time.Millisecond * 100)
+
+	id, err := s.queue.Enqueue(&testTask)
+	require.NoError(s.T(), err)
+
+	select {
+	case dequeuedID := <-dequeued:
+		assert.Equal(s.T(), id, dequeuedID)
+	case <-time.After(time.Second * 5):
+		assert.Fail(s.T(), "task was not dequeued after being enqueued")
+	}
+}
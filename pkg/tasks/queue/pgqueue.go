@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"math"
-	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -30,7 +29,16 @@ const (
 	sqlListen   = `LISTEN tasks`
 	sqlUnlisten = `UNLISTEN tasks`
 
-	sqlEnqueue = `INSERT INTO tasks(id, type, payload, queued_at, org_id, object_uuid, object_type, status, request_id, account_id, priority) VALUES ($1, $2, $3, clock_timestamp(), $4, $5, $6, $7, $8, $9, $10)`
+	sqlEnqueue = `INSERT INTO tasks(id, type, payload, queued_at, org_id, object_uuid, object_type, status, request_id, account_id, priority, dedup_key) VALUES ($1, $2, $3, clock_timestamp(), $4, $5, $6, $7, $8, $9, $10, NULLIF($11, ''))`
+	// serializes enqueues of the same dedup key until the transaction ends
+	sqlLockDedupKey = `SELECT pg_advisory_xact_lock(hashtext($1))`
+	sqlQueryDedup   = `
+		SELECT id
+		FROM tasks
+		WHERE org_id = $1 AND type = $2 AND dedup_key = $3
+		  AND status IN ('pending', 'running')
+		ORDER BY queued_at DESC
+		LIMIT 1`
 	sqlDequeue = `
 		UPDATE tasks
 		SET token = $1, started_at = clock_timestamp(), status = 'running'
@@ -322,9 +330,24 @@ func (p *PgQueue) Enqueue(task *Task) (uuid.UUID, error) {
 			err = fmt.Errorf("error rolling back enqueue transaction: %w: %v", errRollback, err)
 		}
 	}()
+
+	if task.DedupKey != "" {
+		existingID, err := p.activeTaskForDedupKey(tx, task)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if existingID != uuid.Nil {
+			err = tx.Commit(context.Background())
+			if err != nil {
+				return uuid.Nil, fmt.Errorf("unable to commit database transaction: %w", err)
+			}
+			return existingID, nil
+		}
+	}
+
 	_, err = tx.Exec(context.Background(), sqlEnqueue,
 		taskID.String(), task.Typename, task.Payload, task.OrgId, task.ObjectUUID, task.ObjectType,
-		config.TaskStatusPending, task.RequestID, task.AccountId, task.Priority)
+		config.TaskStatusPending, task.RequestID, task.AccountId, task.Priority, task.DedupKey)
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("error enqueuing task: %w", err)
 	}
@@ -349,6 +372,24 @@ func (p *PgQueue) Enqueue(task *Task) (uuid.UUID, error) {
 	return taskID, nil
 }
 
+// activeTaskForDedupKey locks the task's dedup key for the rest of the transaction and returns the ID
+// of a pending or running task with the same key, or uuid.Nil if there is none
+func (p *PgQueue) activeTaskForDedupKey(tx Transaction, task *Task) (uuid.UUID, error) {
+	_, err := tx.Exec(context.Background(), sqlLockDedupKey, task.DedupKey)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error locking dedup key: %w", err)
+	}
+
+	var id uuid.UUID
+	err = tx.QueryRow(context.Background(), sqlQueryDedup, task.OrgId, task.Typename, task.DedupKey).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, nil
+	} else if err != nil {
+		return uuid.Nil, fmt.Errorf("error querying tasks by dedup key: %w", err)
+	}
+	return id, nil
+}
+
 func (p *PgQueue) Dequeue(ctx context.Context, taskTypes []string) (*models.TaskInfo, error) {
 	// add ourselves as a dequeuer
 	c := make(chan struct{}, 1)
@@ -529,7 +570,7 @@ func (p *PgQueue) finishWithTx(tx Transaction, taskId uuid.UUID, taskError error
 	}
 
 	var nextRetryTime *time.Time
-	if status == config.TaskStatusFailed && info.Retries < MaxTaskRetries && slices.Contains(config.RequeueableTasks, info.Typename) {
+	if status == config.TaskStatusFailed && !IsFinalAttempt(info) {
 		upperBound := config.Get().Tasking.RetryWaitUpperBound
 		retriesRemaining := float64(MaxTaskRetries - info.Retries)
 		timeToWait := time.Second * time.Duration(upperBound.Seconds()/(retriesRemaining+1))
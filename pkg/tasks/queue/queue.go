@@ -5,14 +5,21 @@ package queue
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
+	"github.com/content-services/content-sources-backend/pkg/config"
 	"github.com/content-services/content-sources-backend/pkg/models"
 	"github.com/google/uuid"
 )
 
 const MaxTaskRetries = 3 // Maximum number of times a task can be retried before failing
 
+// IsFinalAttempt returns whether a failure of the task moves it to failed, rather than scheduling it to be retried
+func IsFinalAttempt(info *models.TaskInfo) bool {
+	return info.Retries >= MaxTaskRetries || !slices.Contains(config.RequeueableTasks, info.Typename)
+}
+
 type Task struct {
 	Typename     string
 	Payload      interface{}
@@ -23,10 +30,13 @@ type Task struct {
 	ObjectType   *string
 	RequestID    string
 	Priority     int
+	// DedupKey is optional.  If set, and a pending or running task of the same type and org has the
+	// same key, Enqueue returns the ID of that task instead of enqueueing a new one.
+	DedupKey string
 }
 
 type Queue interface {
-	// Enqueue Enqueues a job
+	// Enqueue Enqueues a job, or returns the ID of an active job with the same DedupKey
 	Enqueue(task *Task) (uuid.UUID, error)
 	// Dequeue Dequeues a job of a type in taskTypes, blocking until one is available.
 	Dequeue(ctx context.Context, taskTypes []string) (*models.TaskInfo, error)
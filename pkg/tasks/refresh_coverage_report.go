@@ -0,0 +1,124 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/clients/pulp_client"
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	"github.com/content-services/content-sources-backend/pkg/db"
+	"github.com/content-services/content-sources-backend/pkg/event"
+	"github.com/content-services/content-sources-backend/pkg/external_repos"
+	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/content-services/content-sources-backend/pkg/tasks/queue"
+	"github.com/content-services/tang/pkg/tangy"
+	"github.com/rs/zerolog"
+)
+
+type RefreshCoverageReportPayload struct {
+	ReportUUID      string // Pending report created by the refresh
+	PreviousCovered *int   // Packages covered by the refreshed report, set by scheduled refreshes to notify when coverage improves
+}
+
+// RefreshCoverageReportHandler matches the packages of a pending coverage report refresh against the current catalog
+func RefreshCoverageReportHandler(ctx context.Context, task *models.TaskInfo, _ *queue.Queue) error {
+	opts := RefreshCoverageReportPayload{}
+	if err := json.Unmarshal(task.Payload, &opts); err != nil {
+		return fmt.Errorf("payload incorrect type for RefreshCoverageReportPayload")
+	}
+
+	logger := LogForTask(task.Id.String(), task.Typename, task.RequestID)
+	ctxWithLogger := logger.WithContext(ctx)
+
+	t := RefreshCoverageReport{
+		daoReg:     dao.GetDaoRegistry(db.DB),
+		ctx:        ctxWithLogger,
+		orgID:      task.OrgId,
+		accountID:  task.AccountId,
+		payload:    &opts,
+		pulpClient: pulp_client.GetPulpClientWithDomain(""),
+		logger:     logger,
+	}
+	if config.Tang != nil {
+		t.tangClient = *config.Tang
+	}
+	return t.Run()
+}
+
+type RefreshCoverageReport struct {
+	daoReg     *dao.DaoRegistry
+	ctx        context.Context
+	orgID      string
+	accountID  string
+	payload    *RefreshCoverageReportPayload
+	pulpClient pulp_client.PulpClient
+	tangClient tangy.Tangy
+	logger     *zerolog.Logger
+}
+
+// Run matches the report against the catalog, marking it as failed if it can't be
+func (t *RefreshCoverageReport) Run() error {
+	report, err := t.match()
+	if err != nil {
+		if failErr := t.daoReg.CoverageReport.InternalOnly_FailAnalysis(t.ctx, t.payload.ReportUUID, err); failErr != nil {
+			return errors.Join(err, failErr)
+		}
+		return err
+	}
+	t.notifyImprovement(report)
+	return nil
+}
+
+func (t *RefreshCoverageReport) match() (api.CoverageReportResponse, error) {
+	if t.tangClient == nil {
+		return api.CoverageReportResponse{}, errors.New("package catalog is not available")
+	}
+	catalog, err := external_repos.LoadCoverageCatalog(t.ctx, t.daoReg, t.pulpClient, t.tangClient)
+	if err != nil {
+		return api.CoverageReportResponse{}, fmt.Errorf("failed to load the coverage catalog: %w", err)
+	}
+	return t.daoReg.CoverageReport.InternalOnly_MatchRefresh(t.ctx, t.payload.ReportUUID, dao.MatchCoverageRefreshParams{
+		Catalog:           catalog,
+		CatalogSnapshotAt: time.Now(),
+	})
+}
+
+// notifyImprovement notifies the owner of a scheduled refresh covering more packages than the refreshed report
+func (t *RefreshCoverageReport) notifyImprovement(report api.CoverageReportResponse) {
+	payload := t.improvement(report)
+	if payload == nil {
+		return
+	}
+	if !config.Get().Features.LightwellNotifications.Enabled {
+		t.logger.Debug().Msg("Lightwell notifications feature is disabled, skipping notifications")
+		return
+	}
+	events := []event.NotificationEvent{{Metadata: map[string]any{}, Payload: *payload}}
+	event.SendLightwellNotification(t.orgID, event.LightwellEventTypeCoverageImproved, event.SeverityLow, events)
+}
+
+// improvement returns the notification of a scheduled refresh covering more packages than the refreshed report, or
+// nil if coverage did not improve
+func (t *RefreshCoverageReport) improvement(report api.CoverageReportResponse) *event.LightwellCoverageImprovedPayload {
+	if t.payload.PreviousCovered == nil {
+		return nil
+	}
+	covered := report.ExactMatches + report.PartialMatches
+	if covered <= *t.payload.PreviousCovered {
+		return nil
+	}
+	return &event.LightwellCoverageImprovedPayload{
+		ReportLink:       event.LightwellCoverageReportLink(report.UUID),
+		ReportUUID:       report.UUID,
+		SourceReportUUID: report.SourceReportUUID,
+		AccountID:        t.accountID,
+		Total:            report.Total,
+		Covered:          covered,
+		PreviousCovered:  *t.payload.PreviousCovered,
+	}
+}
@@ -0,0 +1,84 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	"github.com/content-services/content-sources-backend/pkg/utils"
+	"github.com/content-services/tang/pkg/tangy"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type RefreshCoverageReportSuite struct {
+	suite.Suite
+	mockDaoRegistry *dao.MockDaoRegistry
+}
+
+func TestRefreshCoverageReportSuite(t *testing.T) {
+	suite.Run(t, new(RefreshCoverageReportSuite))
+}
+
+func (s *RefreshCoverageReportSuite) SetupTest() {
+	s.mockDaoRegistry = dao.GetMockDaoRegistry(s.T())
+}
+
+func (s *RefreshCoverageReportSuite) newTask(tangClient tangy.Tangy, payload RefreshCoverageReportPayload) RefreshCoverageReport {
+	return RefreshCoverageReport{
+		daoReg:     s.mockDaoRegistry.ToDaoRegistry(),
+		ctx:        context.Background(),
+		orgID:      "org-1",
+		accountID:  "account-1",
+		payload:    &payload,
+		tangClient: tangClient,
+		logger:     &log.Logger,
+	}
+}
+
+func (s *RefreshCoverageReportSuite) TestRun() {
+	ctx := context.Background()
+	s.mockDaoRegistry.RepositoryConfig.On("InternalOnly_FetchRepoConfigForOrg", ctx, config.LightwellOrg).Return([]api.RepositoryResponse{}, nil)
+	s.mockDaoRegistry.CoverageReport.On("InternalOnly_MatchRefresh", ctx, "refresh", mock.MatchedBy(func(params dao.MatchCoverageRefreshParams) bool {
+		return len(params.Catalog) == 0 && !params.CatalogSnapshotAt.IsZero()
+	})).Return(api.CoverageReportResponse{UUID: "refresh", Status: config.TaskStatusCompleted}, nil)
+
+	t := s.newTask(tangy.NewMockTangy(s.T()), RefreshCoverageReportPayload{ReportUUID: "refresh"})
+	require.NoError(s.T(), t.Run())
+}
+
+func (s *RefreshCoverageReportSuite) TestRunFailed() {
+	ctx := context.Background()
+	s.mockDaoRegistry.CoverageReport.On("InternalOnly_FailAnalysis", ctx, "refresh", errors.New("package catalog is not available")).Return(nil)
+
+	t := s.newTask(nil, RefreshCoverageReportPayload{ReportUUID: "refresh"})
+	assert.Error(s.T(), t.Run())
+}
+
+func (s *RefreshCoverageReportSuite) TestImprovement() {
+	report := api.CoverageReportResponse{UUID: "refresh", SourceReportUUID: "scheduled", Total: 5, ExactMatches: 2, PartialMatches: 1}
+
+	// Refreshes requested by users are not notified
+	t := s.newTask(nil, RefreshCoverageReportPayload{ReportUUID: "refresh"})
+	assert.Nil(s.T(), t.improvement(report))
+
+	t = s.newTask(nil, RefreshCoverageReportPayload{ReportUUID: "refresh", PreviousCovered: utils.Ptr(3)})
+	assert.Nil(s.T(), t.improvement(report))
+
+	t = s.newTask(nil, RefreshCoverageReportPayload{ReportUUID: "refresh", PreviousCovered: utils.Ptr(2)})
+	improvement := t.improvement(report)
+	require.NotNil(s.T(), improvement)
+	assert.Equal(s.T(), "refresh", improvement.ReportUUID)
+	assert.Equal(s.T(), "scheduled", improvement.SourceReportUUID)
+	assert.Equal(s.T(), "account-1", improvement.AccountID)
+	assert.Equal(s.T(), 5, improvement.Total)
+	assert.Equal(s.T(), 3, improvement.Covered)
+	assert.Equal(s.T(), 2, improvement.PreviousCovered)
+	assert.Contains(s.T(), improvement.ReportLink, "/lightwell/coverage-reports/refresh")
+}
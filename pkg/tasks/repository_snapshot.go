@@ -12,6 +12,7 @@ import (
 	"github.com/content-services/content-sources-backend/pkg/config"
 	"github.com/content-services/content-sources-backend/pkg/dao"
 	"github.com/content-services/content-sources-backend/pkg/db"
+	"github.com/content-services/content-sources-backend/pkg/event"
 	"github.com/content-services/content-sources-backend/pkg/external_repos"
 	"github.com/content-services/content-sources-backend/pkg/models"
 	"github.com/content-services/content-sources-backend/pkg/tasks/payloads"
@@ -48,6 +49,7 @@ func SnapshotHandler(ctx context.Context, task *models.TaskInfo, queue *queue.Qu
 		logger:         logger,
 	}
 	err = sr.Run()
+	sr.sendEvent(err)
 	if err == nil {
 		return daoReg.RepositoryConfig.InternalOnly_ResetFailedSnapshotCount(ctx, sr.repoConfig.UUID)
 	} else {
@@ -62,6 +64,25 @@ func SnapshotHandler(ctx context.Context, task *models.TaskInfo, queue *queue.Qu
 	}
 }
 
+// sendEvent sends the snapshot created by Run, or the error it failed with unless it was canceled or will be retried
+func (sr *SnapshotRepository) sendEvent(err error) {
+	if err == nil {
+		if sr.snapshot != nil {
+			sendSnapshotNotification(sr.orgId, event.SnapshotCreated, event.MapSnapshotPayload(sr.repoConfig, *sr.snapshot))
+		}
+	} else if !errors.Is(err, context.Canceled) && queue.IsFinalAttempt(sr.task) {
+		sendSnapshotNotification(sr.orgId, event.SnapshotFailed, event.MapSnapshotFailurePayload(sr.repoConfig, err))
+	}
+}
+
+// sendSnapshotNotification sends a snapshot event, unless the repository is a Red Hat or community one
+func sendSnapshotNotification(orgID string, eventName event.EventName, payload event.SnapshotPayload) {
+	if orgID == config.RedHatOrg || orgID == config.CommunityOrg {
+		return
+	}
+	event.SendNotification(orgID, eventName, []event.SnapshotPayload{payload})
+}
+
 type SnapshotRepository struct {
 	orgId          string
 	domainName     string
@@ -74,6 +95,7 @@ type SnapshotRepository struct {
 	ctx            context.Context
 	logger         *zerolog.Logger
 	repoConfig     api.RepositoryResponse
+	snapshot       *models.Snapshot // Snapshot created by Run, if the repository changed
 }
 
 // SnapshotRepository creates a snapshot of a given repository config
@@ -162,7 +184,12 @@ func (sr *SnapshotRepository) Run() (err error) {
 		return nil
 	}
 
-	return helper.Run(*versionHref)
+	err = helper.Run(*versionHref)
+	if err != nil {
+		return err
+	}
+	sr.snapshot = helper.snapshot
+	return nil
 }
 
 func (sr *SnapshotRepository) UpdatePayload() error {
@@ -3,12 +3,14 @@ package tasks
 import (
 	"context"
 	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/content-services/content-sources-backend/pkg/api"
 	"github.com/content-services/content-sources-backend/pkg/clients/pulp_client"
 	"github.com/content-services/content-sources-backend/pkg/config"
 	"github.com/content-services/content-sources-backend/pkg/dao"
+	"github.com/content-services/content-sources-backend/pkg/event"
 	"github.com/content-services/content-sources-backend/pkg/models"
 	"github.com/content-services/content-sources-backend/pkg/tasks/payloads"
 	"github.com/content-services/content-sources-backend/pkg/tasks/queue"
@@ -27,6 +29,7 @@ type SnapshotSuite struct {
 	MockPulpClient  pulp_client.MockPulpClient
 	MockQueue       queue.MockQueue
 	Queue           queue.Queue
+	mockOutbox      *dao.MockOutboxDao
 }
 
 func TestSnapshotSuite(t *testing.T) {
@@ -38,6 +41,9 @@ func (s *SnapshotSuite) SetupTest() {
 	s.MockPulpClient = *pulp_client.NewMockPulpClient(s.T())
 	s.MockQueue = *queue.NewMockQueue(s.T())
 	s.Queue = &s.MockQueue
+	s.mockOutbox = dao.NewMockOutboxDao(s.T())
+	event.SetOutbox(s.mockOutbox)
+	s.T().Cleanup(func() { event.SetOutbox(nil) })
 	config.Get().Clients.Pulp.RepoContentGuards = false
 }
 
@@ -134,6 +140,70 @@ func (s *SnapshotSuite) TestSnapshotFull() {
 
 	snapErr := snap.Run()
 	assert.NoError(s.T(), snapErr)
+
+	s.mockOutbox.On("Queue", mock.Anything, repoConfig.OrgID, event.SnapshotCreated, []string{event.ChannelNotifications, event.ChannelWebhooks}, []event.Object{
+		event.SnapshotPayload{
+			RepositoryUUID: repoConfig.UUID,
+			URL:            repoConfig.URL,
+			ContentCounts:  current,
+			AddedCounts:    added,
+			RemovedCounts:  removed,
+		},
+	}).Return(nil).Once()
+	snap.sendEvent(snapErr)
+}
+
+func (s *SnapshotSuite) TestSnapshotFailedEvent() {
+	ctx := context.Background()
+	repoUuid := uuid.New()
+	domainName := "myDomain"
+	repoConfig := api.RepositoryResponse{OrgID: "OrgId", UUID: uuid.NewString(), Name: "my repo", URL: "http://random.example.com/thing"}
+	task := models.TaskInfo{
+		Id:         uuid.UUID{},
+		OrgId:      repoConfig.OrgID,
+		ObjectUUID: repoUuid,
+		ObjectType: utils.Ptr(config.ObjectTypeRepository),
+	}
+
+	s.mockDaoRegistry.RepositoryConfig.On("FetchByRepoUuid", ctx, repoConfig.OrgID, repoUuid.String()).Return(repoConfig, nil)
+	s.MockPulpClient.On("LookupOrCreateDomain", ctx, domainName).Return("", fmt.Errorf("pulp is down")).Once()
+
+	snap := SnapshotRepository{
+		orgId:          repoConfig.OrgID,
+		domainName:     domainName,
+		repositoryUUID: repoUuid,
+		daoReg:         s.mockDaoRegistry.ToDaoRegistry(),
+		pulpClient:     &s.MockPulpClient,
+		payload:        &payloads.SnapshotPayload{},
+		task:           &task,
+		queue:          &s.Queue,
+		ctx:            ctx,
+		logger:         &log.Logger,
+	}
+
+	snapErr := snap.Run()
+	assert.Error(s.T(), snapErr)
+
+	s.mockOutbox.On("Queue", mock.Anything, repoConfig.OrgID, event.SnapshotFailed, []string{event.ChannelNotifications, event.ChannelWebhooks}, []event.Object{
+		event.SnapshotPayload{
+			RepositoryUUID: repoConfig.UUID,
+			RepositoryName: repoConfig.Name,
+			URL:            repoConfig.URL,
+			Error:          "failed to lookup or create domain: pulp is down",
+		},
+	}).Return(nil).Once()
+	snap.sendEvent(snapErr)
+
+	// Canceled snapshots, attempts that will be retried and Red Hat repositories are not sent
+	snap.sendEvent(context.Canceled)
+	requeueableTasks := config.RequeueableTasks
+	config.RequeueableTasks = append(slices.Clone(requeueableTasks), config.RepositorySnapshotTask)
+	defer func() { config.RequeueableTasks = requeueableTasks }()
+	task.Typename = config.RepositorySnapshotTask
+	snap.sendEvent(snapErr)
+	snap.orgId = config.RedHatOrg
+	task.Retries = queue.MaxTaskRetries
+	snap.sendEvent(snapErr)
 }
 
 func (s *SnapshotSuite) TestSnapshotResync() {
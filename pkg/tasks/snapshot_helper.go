@@ -46,6 +46,7 @@ type SnapshotHelper struct {
 	repo       api.RepositoryResponse
 	daoReg     *dao.DaoRegistry
 	domainName string
+	snapshot   *models.Snapshot // Snapshot created by Run
 }
 
 func (sh *SnapshotHelper) Run(versionHref string) error {
@@ -120,6 +121,7 @@ func (sh *SnapshotHelper) Run(versionHref string) error {
 		}
 	}
 
+	sh.snapshot = &snap
 	return nil
 }
 
@@ -3,6 +3,7 @@ package tasks
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"regexp"
@@ -110,25 +111,21 @@ func UpdateTemplateContentHandler(ctx context.Context, task *models.TaskInfo, qu
 		return err
 	}
 
-	// By creating the environment first, we don't block on pulp
-	env, err := t.RunEnvironmentCreate()
-	if err != nil {
-		return err
-	}
-
-	err = t.RunPulp()
-	if err != nil {
-		return err
-	}
+	err = t.Run()
+	t.sendEvents(err)
+	return err
+}
 
-	err = t.RunCandlepin(env)
+// sendEvents sends the template content updated by Run, or the error it failed with unless it was canceled or will be retried
+func (t *UpdateTemplateContent) sendEvents(err error) {
 	if err != nil {
-		return err
+		if !errors.Is(err, context.Canceled) && queue.IsFinalAttempt(t.task) {
+			event.SendTemplateEvent(t.orgId, event.TemplateContentUpdateFailed, []event.TemplateContentEvent{event.MapTemplateContentEvent(t.template, err)})
+		}
+		return
 	}
-
 	event.SendTemplateEvent(t.orgId, event.TemplateUpdated, []event.TemplateEvent{event.MapTemplateResponse(t.template)})
-
-	return nil
+	event.SendTemplateEvent(t.orgId, event.TemplateContentUpdated, []event.TemplateContentEvent{event.MapTemplateContentEvent(t.template, nil)})
 }
 
 type UpdateTemplateContent struct {
@@ -148,6 +145,22 @@ type UpdateTemplateContent struct {
 	logger              *zerolog.Logger
 }
 
+// Run updates the template content in candlepin and pulp
+func (t *UpdateTemplateContent) Run() error {
+	// By creating the environment first, we don't block on pulp
+	env, err := t.RunEnvironmentCreate()
+	if err != nil {
+		return err
+	}
+
+	err = t.RunPulp()
+	if err != nil {
+		return err
+	}
+
+	return t.RunCandlepin(env)
+}
+
 // prepareTriggeredUpdate turns a publication-triggered task into the complete desired repository
 // state for the template. Publication state is intentionally checked at execution time so a
 // publish/unpublish race cannot remove a repository based on stale handler data.
@@ -12,9 +12,12 @@ import (
 	"github.com/content-services/content-sources-backend/pkg/clients/pulp_client"
 	"github.com/content-services/content-sources-backend/pkg/config"
 	"github.com/content-services/content-sources-backend/pkg/dao"
+	"github.com/content-services/content-sources-backend/pkg/event"
 	"github.com/content-services/content-sources-backend/pkg/models"
 	"github.com/content-services/content-sources-backend/pkg/tasks/helpers"
 	"github.com/content-services/content-sources-backend/pkg/tasks/payloads"
+	"github.com/content-services/content-sources-backend/pkg/tasks/queue"
+	"github.com/content-services/content-sources-backend/pkg/utils"
 	zest "github.com/content-services/zest/release/v2026"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -300,6 +303,65 @@ func (s *UpdateTemplateContentSuite) TestRunPulpMakesSnapshotUpdateDecision() {
 	require.NoError(s.T(), task.RunPulp())
 }
 
+func (s *UpdateTemplateContentSuite) TestSendEvents() {
+	ctx := context.Background()
+	pulp := pulp_client.NewMockPulpClient(s.T())
+	outbox := dao.NewMockOutboxDao(s.T())
+	event.SetOutbox(outbox)
+	s.T().Cleanup(func() { event.SetOutbox(nil) })
+
+	orgID := "template-owner"
+	template := api.TemplateResponse{
+		UUID:            uuid.NewString(),
+		Name:            "my template",
+		OrgID:           orgID,
+		Description:     "a template",
+		Arch:            config.X8664,
+		Version:         config.El9,
+		RepositoryUUIDS: []string{uuid.NewString()},
+	}
+	templateEvent := event.TemplateEvent{
+		UUID:            template.UUID,
+		Name:            template.Name,
+		OrgID:           orgID,
+		Description:     utils.Ptr(template.Description),
+		Arch:            template.Arch,
+		Version:         template.Version,
+		RepositoryUUIDS: template.RepositoryUUIDS,
+	}
+	channels := []string{event.ChannelTemplateEvents, event.ChannelWebhooks}
+
+	pulp.On("GetContentPath").Return("", fmt.Errorf("pulp is down")).Once()
+	taskInfo := models.TaskInfo{Typename: config.UpdateTemplateContentTask, Retries: queue.MaxTaskRetries}
+	task := UpdateTemplateContent{
+		task:       &taskInfo,
+		orgId:      orgID,
+		template:   template,
+		daoReg:     dao.GetMockDaoRegistry(s.T()).ToDaoRegistry(),
+		pulpClient: pulp,
+		payload:    &payloads.UpdateTemplateContentPayload{TemplateUUID: template.UUID},
+		ctx:        ctx,
+	}
+	err := task.Run()
+	require.Error(s.T(), err)
+
+	outbox.On("Queue", mock.Anything, orgID, event.TemplateContentUpdateFailed, channels, []event.Object{
+		event.TemplateContentEvent{TemplateEvent: templateEvent, Error: "pulp is down"},
+	}).Return(nil).Once()
+	task.sendEvents(err)
+
+	// Canceled updates and attempts that will be retried are not sent
+	task.sendEvents(context.Canceled)
+	taskInfo.Retries = 0
+	task.sendEvents(err)
+
+	outbox.On("Queue", mock.Anything, orgID, event.TemplateUpdated, channels, []event.Object{templateEvent}).Return(nil).Once()
+	outbox.On("Queue", mock.Anything, orgID, event.TemplateContentUpdated, channels, []event.Object{
+		event.TemplateContentEvent{TemplateEvent: templateEvent},
+	}).Return(nil).Once()
+	task.sendEvents(nil)
+}
+
 func (s *UpdateTemplateContentSuite) TestRunPulpRemovesForeignPartnerAndPreservesBaseRepository() {
 	ctx := context.Background()
 	reg := dao.GetMockDaoRegistry(s.T())
@@ -21,7 +21,7 @@ type TaskWorkerPool interface {
 	// StartWorkerPool starts background processes and workers
 	// Should be run as a go routine.
 	StartWorkerPool(ctx context.Context)
-	// StartWorkers Starts workers up to number numWorkers defined in config.
+	// StartWorkers Starts the workers of each worker pool defined in config, and up to numWorkers workers for the remaining task types.
 	StartWorkers(ctx context.Context)
 	// Stop Gracefully stops all workers
 	Stop()
@@ -141,22 +141,72 @@ func (w *WorkerPool) StartWorkerPool(ctx context.Context) {
 }
 
 func (w *WorkerPool) StartWorkers(ctx context.Context) {
-	workerCount := config.Get().Tasking.WorkerCount
-
-	log.Info().Msgf("Starting %v workers", workerCount)
-	for i := 0; i < workerCount; i++ {
-		wrk := newWorker(workerConfig{
-			queue:     w.queue,
-			workerWg:  w.workerWg,
-			handlers:  w.handlers,
-			taskTypes: w.taskTypes,
-			workerMap: w.workerMap,
-		}, w.metrics)
-
-		w.workers = append(w.workers, &wrk)
-		wrk.workerWg.Add(1)
-		go wrk.start(ctx)
+	tasking := config.Get().Tasking
+
+	for _, group := range workerGroups(w.taskTypes, tasking.WorkerPools, tasking.WorkerCount) {
+		log.Info().Msgf("Starting %v workers in %v pool for task types %v", group.workerCount, group.name, group.taskTypes)
+		for i := 0; i < group.workerCount; i++ {
+			wrk := newWorker(workerConfig{
+				queue:     w.queue,
+				workerWg:  w.workerWg,
+				handlers:  w.handlers,
+				taskTypes: group.taskTypes,
+				workerMap: w.workerMap,
+			}, w.metrics)
+
+			w.workers = append(w.workers, &wrk)
+			wrk.workerWg.Add(1)
+			go wrk.start(ctx)
+		}
+	}
+}
+
+// workerGroup is a set of workers that dequeue the same task types
+type workerGroup struct {
+	name        string
+	taskTypes   []string
+	workerCount int
+}
+
+// workerGroups splits taskTypes between the configured worker pools. A task type belongs to the first pool listing it,
+// and task types not listed by any pool are left to a shared group of workerCount workers.
+func workerGroups(taskTypes []string, pools []config.TaskWorkerPool, workerCount int) []workerGroup {
+	var groups []workerGroup
+	var claimed []string
+
+	for _, pool := range pools {
+		if pool.WorkerCount <= 0 {
+			log.Warn().Msgf("Worker pool %v has no workers, its task types are left to the shared workers", pool.Name)
+			continue
+		}
+		group := workerGroup{name: pool.Name, workerCount: pool.WorkerCount}
+		for _, taskType := range pool.Types {
+			if !contains(taskTypes, taskType) {
+				log.Warn().Msgf("Worker pool %v lists task type %v, which has no registered handler", pool.Name, taskType)
+				continue
+			}
+			if contains(claimed, taskType) {
+				log.Warn().Msgf("Worker pool %v lists task type %v, which already belongs to another pool", pool.Name, taskType)
+				continue
+			}
+			claimed = append(claimed, taskType)
+			group.taskTypes = append(group.taskTypes, taskType)
+		}
+		if len(group.taskTypes) > 0 {
+			groups = append(groups, group)
+		}
+	}
+
+	shared := workerGroup{name: "shared", workerCount: workerCount}
+	for _, taskType := range taskTypes {
+		if !contains(claimed, taskType) {
+			shared.taskTypes = append(shared.taskTypes, taskType)
+		}
+	}
+	if len(shared.taskTypes) > 0 || len(groups) == 0 {
+		groups = append(groups, shared)
 	}
+	return groups
 }
 
 func (w *WorkerPool) RegisterHandler(taskType string, handler TaskHandler) {
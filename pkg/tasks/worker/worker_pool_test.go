@@ -2,11 +2,14 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/content-services/content-sources-backend/pkg/config"
 	"github.com/content-services/content-sources-backend/pkg/models"
 	"github.com/content-services/content-sources-backend/pkg/tasks/queue"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/goleak"
@@ -39,3 +42,125 @@ func (s *WorkerSuite) TestStartStopWorkers() {
 	workerPool.Stop()
 	cancelFunc()
 }
+
+func (s *WorkerSuite) TestProcessTasksWithMemQueue() {
+	defer goleak.VerifyNone(s.T())
+
+	workerCount := config.Get().Tasking.WorkerCount
+	config.Get().Tasking.WorkerCount = 2
+	defer func() { config.Get().Tasking.WorkerCount = workerCount }()
+
+	memQueue := queue.NewMemQueue()
+	workerPool := NewTaskWorkerPool(memQueue, nil)
+
+	handled := make(chan uuid.UUID, 2)
+	workerPool.RegisterHandler("succeed", func(ctx context.Context, task *models.TaskInfo, q *queue.Queue) error {
+		handled <- task.Id
+		return nil
+	})
+	workerPool.RegisterHandler("fail", func(ctx context.Context, task *models.TaskInfo, q *queue.Queue) error {
+		handled <- task.Id
+		return errors.New("task failed")
+	})
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	workerPool.StartWorkers(ctx)
+
+	parentID, err := memQueue.Enqueue(&queue.Task{Typename: "succeed", OrgId: "12345"})
+	s.Require().NoError(err)
+	childID, err := memQueue.Enqueue(&queue.Task{Typename: "fail", OrgId: "12345", Dependencies: []uuid.UUID{parentID}})
+	s.Require().NoError(err)
+
+	// the child task depends on the parent, so it is always handled second
+	s.Equal(parentID, <-handled)
+	s.Equal(childID, <-handled)
+
+	s.Eventually(func() bool {
+		info, err := memQueue.Status(childID)
+		return err == nil && info.Finished != nil
+	}, time.Second*5, time.Millisecond*10)
+
+	info, err := memQueue.Status(parentID)
+	s.Require().NoError(err)
+	s.Equal(config.TaskStatusCompleted, info.Status)
+
+	info, err = memQueue.Status(childID)
+	s.Require().NoError(err)
+	s.Equal(config.TaskStatusFailed, info.Status)
+	s.Require().NotNil(info.Error)
+	s.Equal("task failed", *info.Error)
+
+	cancelFunc()
+	workerPool.Stop()
+}
+
+func (s *WorkerSuite) TestWorkerGroups() {
+	taskTypes := []string{config.RepositorySnapshotTask, config.IntrospectTask, config.UpdateLatestSnapshotTask, config.DeleteTemplatesTask}
+
+	// Without pools every task type is handled by the shared workers
+	groups := workerGroups(taskTypes, nil, 3)
+	s.Equal([]workerGroup{{name: "shared", taskTypes: taskTypes, workerCount: 3}}, groups)
+
+	pools := []config.TaskWorkerPool{
+		{Name: "snapshots", Types: []string{config.RepositorySnapshotTask, config.IntrospectTask, "unknown"}, WorkerCount: 2},
+		{Name: "duplicate", Types: []string{config.RepositorySnapshotTask}, WorkerCount: 1},
+		{Name: "empty", Types: []string{config.DeleteTemplatesTask}, WorkerCount: 0},
+		{Name: "templates", Types: []string{config.UpdateLatestSnapshotTask}, WorkerCount: 1},
+	}
+	groups = workerGroups(taskTypes, pools, 3)
+	s.Equal([]workerGroup{
+		{name: "snapshots", taskTypes: []string{config.RepositorySnapshotTask, config.IntrospectTask}, workerCount: 2},
+		{name: "templates", taskTypes: []string{config.UpdateLatestSnapshotTask}, workerCount: 1},
+		{name: "shared", taskTypes: []string{config.DeleteTemplatesTask}, workerCount: 3},
+	}, groups)
+
+	// No shared workers are started when the pools cover every task type
+	groups = workerGroups(taskTypes[:2], pools, 3)
+	s.Equal([]workerGroup{
+		{name: "snapshots", taskTypes: []string{config.RepositorySnapshotTask, config.IntrospectTask}, workerCount: 2},
+	}, groups)
+}
+
+func (s *WorkerSuite) TestWorkerPoolsAreNotStarved() {
+	defer goleak.VerifyNone(s.T())
+
+	tasking := config.Get().Tasking
+	defer func() { config.Get().Tasking = tasking }()
+	config.Get().Tasking.WorkerCount = 1
+	config.Get().Tasking.WorkerPools = []config.TaskWorkerPool{{Name: "fast", Types: []string{"fast"}, WorkerCount: 1}}
+
+	memQueue := queue.NewMemQueue()
+	workerPool := NewTaskWorkerPool(memQueue, nil)
+
+	release := make(chan struct{})
+	handled := make(chan string, 3)
+	workerPool.RegisterHandler("slow", func(ctx context.Context, task *models.TaskInfo, q *queue.Queue) error {
+		handled <- task.Typename
+		<-release
+		return nil
+	})
+	workerPool.RegisterHandler("fast", func(ctx context.Context, task *models.TaskInfo, q *queue.Queue) error {
+		handled <- task.Typename
+		return nil
+	})
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	workerPool.StartWorkers(ctx)
+
+	_, err := memQueue.Enqueue(&queue.Task{Typename: "slow", OrgId: "12345"})
+	s.Require().NoError(err)
+	s.Equal("slow", <-handled)
+	_, err = memQueue.Enqueue(&queue.Task{Typename: "slow", OrgId: "12345"})
+	s.Require().NoError(err)
+	_, err = memQueue.Enqueue(&queue.Task{Typename: "fast", OrgId: "12345"})
+	s.Require().NoError(err)
+
+	// the only shared worker is busy with the slow task, the fast pool still handles the fast task
+	s.Equal("fast", <-handled)
+
+	close(release)
+	s.Equal("slow", <-handled)
+
+	cancelFunc()
+	workerPool.Stop()
+}
@@ -161,6 +161,21 @@ func MockRbacV1Access(c echo.Context) error {
 			{
 				Permission: "content-sources:templates:write",
 			},
+			{
+				Permission: "content-sources:repositories:delete",
+			},
+			{
+				Permission: "content-sources:snapshots:delete",
+			},
+			{
+				Permission: "content-sources:snapshots:update",
+			},
+			{
+				Permission: "content-sources:content:delete",
+			},
+			{
+				Permission: "content-sources:audit:read",
+			},
 		},
 	}
 	outputRead := RbacAccessResponse{
@@ -0,0 +1,166 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/content-services/content-sources-backend/pkg/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// Headers set on every webhook request
+const (
+	HeaderEvent     = "X-Content-Sources-Event"
+	HeaderDelivery  = "X-Content-Sources-Delivery"
+	HeaderTimestamp = "X-Content-Sources-Timestamp"
+	HeaderSignature = "X-Content-Sources-Signature"
+)
+
+// deliveryBatchSize is how many due deliveries are claimed at a time
+const deliveryBatchSize = 50
+
+// errRequestFailed is recorded instead of the error of the request, which can reveal details of the network of the service
+var errRequestFailed = errors.New("could not send the request to the webhook")
+
+// errAddressNotAllowed is returned when the host of a webhook resolves to an internal address
+var errAddressNotAllowed = errors.New("webhook address is not allowed")
+
+// Sign returns the signature of a webhook request. The timestamp is signed along with the body,
+// so receivers can reject replayed requests.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type Deliverer struct {
+	dao    dao.WebhookDao
+	client *http.Client
+}
+
+func NewDeliverer(webhookDao dao.WebhookDao) *Deliverer {
+	return &Deliverer{
+		dao:    webhookDao,
+		client: newClient(config.Get().Webhooks),
+	}
+}
+
+// newClient returns a client that only connects to public addresses, checked after DNS resolution so hosts
+// cannot be pointed at internal services, and that does not follow redirects
+func newClient(webhooks config.Webhooks) *http.Client {
+	dialer := &net.Dialer{Timeout: webhooks.Timeout}
+	if !webhooks.AllowPrivateNetworks {
+		dialer.Control = func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !models.WebhookIPAllowed(ip) {
+				return errAddressNotAllowed
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would be dialed instead of the webhook, bypassing the address check
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   webhooks.Timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Run sends due deliveries at every poll interval until ctx is done
+func (d *Deliverer) Run(ctx context.Context) {
+	log.Info().Msg("Starting webhook deliverer")
+	for {
+		for {
+			count, err := d.DeliverDue(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("error delivering webhooks")
+			}
+			if count < deliveryBatchSize {
+				break
+			}
+		}
+		if err := utils.SleepWithCancel(ctx, config.Get().Webhooks.PollInterval); err != nil {
+			log.Info().Msg("webhook deliverer shutting down")
+			return
+		}
+	}
+}
+
+// DeliverDue sends a batch of due deliveries, and returns how many were sent
+func (d *Deliverer) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := d.dao.ClaimDueDeliveries(ctx, deliveryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	for _, delivery := range deliveries {
+		if _, err := d.Deliver(ctx, delivery); err != nil {
+			log.Error().Err(err).Str("delivery_uuid", delivery.UUID).Msg("could not record webhook delivery")
+		}
+	}
+	return len(deliveries), nil
+}
+
+// Deliver posts the delivery to its webhook and records the outcome
+func (d *Deliverer) Deliver(ctx context.Context, delivery models.WebhookDelivery) (api.WebhookDeliveryResponse, error) {
+	responseCode, sendErr := d.send(ctx, delivery)
+	if sendErr != nil {
+		log.Warn().Err(sendErr).Str("delivery_uuid", delivery.UUID).Str("webhook_uuid", delivery.WebhookUUID).Msg("webhook delivery attempt failed")
+	}
+	return d.dao.RecordDeliveryAttempt(ctx, delivery, responseCode, sendErr)
+}
+
+func (d *Deliverer) send(ctx context.Context, delivery models.WebhookDelivery) (*int, error) {
+	if !delivery.Webhook.Enabled {
+		return nil, fmt.Errorf("webhook is disabled")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook url")
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", config.DefaultAppName)
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.UUID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		log.Warn().Err(err).Str("delivery_uuid", delivery.UUID).Str("webhook_uuid", delivery.WebhookUUID).Msg("webhook request failed")
+		return nil, errRequestFailed
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return &resp.StatusCode, nil
+}
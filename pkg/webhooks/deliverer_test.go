@@ -0,0 +1,179 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/content-services/content-sources-backend/pkg/api"
+	"github.com/content-services/content-sources-backend/pkg/config"
+	"github.com/content-services/content-sources-backend/pkg/dao"
+	"github.com/content-services/content-sources-backend/pkg/models"
+	"github.com/content-services/content-sources-backend/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	// echo -n '1700000000.{"id":"1"}' | openssl dgst -sha256 -hmac 'a-very-secret-value'
+	signature := Sign("a-very-secret-value", 1700000000, []byte(`{"id":"1"}`))
+	assert.Equal(t, "sha256=0fefa360304a367fd378037c412c35689ad1be2fd12701ed1ef8ffc93d7faeb2", signature)
+
+	assert.NotEqual(t, signature, Sign("another-secret-value", 1700000000, []byte(`{"id":"1"}`)))
+	assert.NotEqual(t, signature, Sign("a-very-secret-value", 1700000001, []byte(`{"id":"1"}`)))
+}
+
+// allowPrivateNetworks lets the test servers, listening on loopback, be reached
+func allowPrivateNetworks(t *testing.T, allowed bool) {
+	webhooks := config.Get().Webhooks
+	t.Cleanup(func() { config.Get().Webhooks = webhooks })
+	config.Get().Webhooks.AllowPrivateNetworks = allowed
+}
+
+func testDelivery(url string) models.WebhookDelivery {
+	payload, _ := json.Marshal(api.WebhookPayload{ID: "delivery-uuid", EventType: "template-updated", OrgID: "org"})
+	return models.WebhookDelivery{
+		Base:        models.Base{UUID: "delivery-uuid"},
+		WebhookUUID: "webhook-uuid",
+		OrgID:       "org",
+		EventType:   "template-updated",
+		Payload:     payload,
+		Status:      models.WebhookDeliveryPending,
+		Webhook: models.Webhook{
+			Base:    models.Base{UUID: "webhook-uuid"},
+			URL:     url,
+			Secret:  "a-very-secret-value",
+			Enabled: true,
+		},
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	allowPrivateNetworks(t, true)
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	delivery := testDelivery(server.URL)
+	webhookDao := dao.NewMockWebhookDao(t)
+	expected := api.WebhookDeliveryResponse{UUID: delivery.UUID, Status: models.WebhookDeliverySucceeded}
+	webhookDao.On("RecordDeliveryAttempt", mock.Anything, delivery, utils.Ptr(http.StatusAccepted), nil).Return(expected, nil)
+
+	response, err := NewDeliverer(webhookDao).Deliver(context.Background(), delivery)
+	require.NoError(t, err)
+	assert.Equal(t, expected, response)
+
+	require.NotNil(t, received)
+	assert.Equal(t, []byte(delivery.Payload), body)
+	assert.Equal(t, "template-updated", received.Header.Get(HeaderEvent))
+	assert.Equal(t, "delivery-uuid", received.Header.Get(HeaderDelivery))
+	timestamp, err := strconv.ParseInt(received.Header.Get(HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, Sign("a-very-secret-value", timestamp, body), received.Header.Get(HeaderSignature))
+}
+
+func TestDeliverFailure(t *testing.T) {
+	allowPrivateNetworks(t, true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	delivery := testDelivery(server.URL)
+	webhookDao := dao.NewMockWebhookDao(t)
+	webhookDao.On("RecordDeliveryAttempt", mock.Anything, delivery, utils.Ptr(http.StatusInternalServerError), mock.Anything).
+		Run(func(args mock.Arguments) {
+			attemptErr, ok := args.Get(3).(error)
+			require.True(t, ok)
+			assert.Equal(t, "webhook responded with status 500", attemptErr.Error())
+		}).
+		Return(api.WebhookDeliveryResponse{}, nil)
+
+	_, err := NewDeliverer(webhookDao).Deliver(context.Background(), delivery)
+	require.NoError(t, err)
+
+	// Disabled webhooks are not called
+	delivery.Webhook.Enabled = false
+	webhookDao.On("RecordDeliveryAttempt", mock.Anything, delivery, (*int)(nil), mock.Anything).Return(api.WebhookDeliveryResponse{}, nil)
+	_, err = NewDeliverer(webhookDao).Deliver(context.Background(), delivery)
+	require.NoError(t, err)
+}
+
+func TestDeliverDue(t *testing.T) {
+	allowPrivateNetworks(t, true)
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	webhookDao := dao.NewMockWebhookDao(t)
+	deliveries := []models.WebhookDelivery{testDelivery(server.URL), testDelivery(server.URL)}
+	webhookDao.On("ClaimDueDeliveries", mock.Anything, deliveryBatchSize).Return(deliveries, nil).Once()
+	webhookDao.On("RecordDeliveryAttempt", mock.Anything, mock.Anything, utils.Ptr(http.StatusOK), nil).Return(api.WebhookDeliveryResponse{}, nil).Twice()
+
+	count, err := NewDeliverer(webhookDao).DeliverDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, 2, calls)
+
+	webhookDao.On("ClaimDueDeliveries", mock.Anything, deliveryBatchSize).Return(nil, errors.New("db is down")).Once()
+	_, err = NewDeliverer(webhookDao).DeliverDue(context.Background())
+	assert.Error(t, err)
+}
+
+func TestDeliverInternalAddress(t *testing.T) {
+	allowPrivateNetworks(t, false)
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	delivery := testDelivery(server.URL)
+	webhookDao := dao.NewMockWebhookDao(t)
+	webhookDao.On("RecordDeliveryAttempt", mock.Anything, delivery, (*int)(nil), mock.Anything).
+		Run(func(args mock.Arguments) {
+			// The dial error is not recorded, as it would reveal details of the network
+			attemptErr, ok := args.Get(3).(error)
+			require.True(t, ok)
+			assert.Equal(t, errRequestFailed, attemptErr)
+		}).
+		Return(api.WebhookDeliveryResponse{}, nil)
+
+	_, err := NewDeliverer(webhookDao).Deliver(context.Background(), delivery)
+	require.NoError(t, err)
+	assert.False(t, called)
+}
+
+func TestDeliverRedirect(t *testing.T) {
+	allowPrivateNetworks(t, true)
+	redirected := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected = true
+	}))
+	defer target.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	delivery := testDelivery(server.URL)
+	webhookDao := dao.NewMockWebhookDao(t)
+	webhookDao.On("RecordDeliveryAttempt", mock.Anything, delivery, utils.Ptr(http.StatusTemporaryRedirect), mock.Anything).Return(api.WebhookDeliveryResponse{}, nil)
+
+	_, err := NewDeliverer(webhookDao).Deliver(context.Background(), delivery)
+	require.NoError(t, err)
+	assert.False(t, redirected)
+}